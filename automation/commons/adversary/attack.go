@@ -0,0 +1,209 @@
+package adversary
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/bloxapp/ssv/protocol/v1/message"
+)
+
+// Attack is the behaviour of an adversary operator
+type Attack interface {
+	// Name returns the name of the attack
+	Name() string
+	// Sign returns the message to sign when the instance asks to sign the given message,
+	// signing a different message results in an invalid signature
+	Sign(msg *message.ConsensusMessage) *message.ConsensusMessage
+	// Rewrite returns the messages to broadcast instead of the given consensus or decided message,
+	// the signer can be used to sign forged messages
+	Rewrite(signer *Signer, msgType message.MsgType, msg *message.SignedMessage) ([]*message.SignedMessage, error)
+}
+
+var forgedPrefix = []byte("forged value")
+
+// ForgedValue returns the value that adversaries use in place of the honest value
+func ForgedValue(height message.Height, round message.Round) []byte {
+	return []byte(fmt.Sprintf("%s height %d round %d", forgedPrefix, height, round))
+}
+
+// IsForged returns true if the given value was created by an adversary
+func IsForged(value []byte) bool {
+	return bytes.HasPrefix(value, forgedPrefix)
+}
+
+// Honest is an attack that doesn't change anything, it can be embedded by attacks that change only some of the behaviour
+type Honest struct{}
+
+// Name returns the name of the attack
+func (Honest) Name() string {
+	return "honest"
+}
+
+// Sign returns the given message
+func (Honest) Sign(msg *message.ConsensusMessage) *message.ConsensusMessage {
+	return msg
+}
+
+// Rewrite returns the given message
+func (Honest) Rewrite(_ *Signer, _ message.MsgType, msg *message.SignedMessage) ([]*message.SignedMessage, error) {
+	return []*message.SignedMessage{msg}, nil
+}
+
+// ConflictingProposals is an attack where the leader broadcasts a proposal for a forged value
+// in addition to the honest proposal
+type ConflictingProposals struct {
+	Honest
+}
+
+// Name returns the name of the attack
+func (ConflictingProposals) Name() string {
+	return "conflicting proposals"
+}
+
+// Rewrite returns a conflicting proposal followed by the honest proposal
+func (ConflictingProposals) Rewrite(signer *Signer, msgType message.MsgType, msg *message.SignedMessage) ([]*message.SignedMessage, error) {
+	if msgType != message.SSVConsensusMsgType || msg.Message.MsgType != message.ProposalMsgType {
+		return []*message.SignedMessage{msg}, nil
+	}
+	proposalData, err := msg.Message.GetProposalData()
+	if err != nil {
+		return nil, err
+	}
+	proposalData.Data = ForgedValue(msg.Message.Height, msg.Message.Round)
+	data, err := proposalData.Encode()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encode proposal data")
+	}
+	conflicting, err := signer.Sign(withData(msg.Message, data), msg.Signers...)
+	if err != nil {
+		return nil, err
+	}
+	return []*message.SignedMessage{conflicting, msg}, nil
+}
+
+// ForgedJustification is an attack where the adversary withholds its proposals in the first round, so a round change
+// is needed, and justifies its round changes with a forged prepare of a value that was never proposed.
+// the forged prepare is signed only by the adversary, while Signers (if set) are declared as its signers
+type ForgedJustification struct {
+	Honest
+	// Signers are the declared signers of the forged prepare, the adversary is the only signer if empty
+	Signers []message.OperatorID
+}
+
+// Name returns the name of the attack
+func (ForgedJustification) Name() string {
+	return "forged justification"
+}
+
+// Rewrite withholds first round proposals and forges the justification of round changes
+func (a ForgedJustification) Rewrite(signer *Signer, msgType message.MsgType, msg *message.SignedMessage) ([]*message.SignedMessage, error) {
+	if msgType != message.SSVConsensusMsgType {
+		return []*message.SignedMessage{msg}, nil
+	}
+	switch msg.Message.MsgType {
+	case message.ProposalMsgType:
+		if msg.Message.Round <= 1 {
+			return nil, nil
+		}
+	case message.RoundChangeMsgType:
+		preparedRound := msg.Message.Round - 1
+		value := ForgedValue(msg.Message.Height, preparedRound)
+		prepareData, err := (&message.PrepareData{Data: value}).Encode()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not encode prepare data")
+		}
+		signers := a.Signers
+		if len(signers) == 0 {
+			signers = msg.Signers
+		}
+		prepare, err := signer.Sign(&message.ConsensusMessage{
+			MsgType:    message.PrepareMsgType,
+			Height:     msg.Message.Height,
+			Round:      preparedRound,
+			Identifier: msg.Message.Identifier,
+			Data:       prepareData,
+		}, signers...)
+		if err != nil {
+			return nil, err
+		}
+		data, err := (&message.RoundChangeData{
+			PreparedValue:            value,
+			Round:                    preparedRound,
+			RoundChangeJustification: []*message.SignedMessage{prepare},
+		}).Encode()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not encode round change data")
+		}
+		roundChange, err := signer.Sign(withData(msg.Message, data), msg.Signers...)
+		if err != nil {
+			return nil, err
+		}
+		return []*message.SignedMessage{roundChange}, nil
+	}
+	return []*message.SignedMessage{msg}, nil
+}
+
+// UnpreparedCommit is an attack where the adversary commits a value that was never proposed nor prepared,
+// right after it prepares the honest value. decided messages are replaced with decided messages of the forged value,
+// that declare the honest signers while signed only by the adversary
+type UnpreparedCommit struct {
+	Honest
+}
+
+// Name returns the name of the attack
+func (UnpreparedCommit) Name() string {
+	return "unprepared commit"
+}
+
+// Rewrite sends commits and decided messages of a forged value
+func (UnpreparedCommit) Rewrite(signer *Signer, msgType message.MsgType, msg *message.SignedMessage) ([]*message.SignedMessage, error) {
+	if msgType == message.SSVConsensusMsgType && msg.Message.MsgType != message.PrepareMsgType &&
+		msg.Message.MsgType != message.CommitMsgType {
+		return []*message.SignedMessage{msg}, nil
+	}
+	commitData, err := (&message.CommitData{Data: ForgedValue(msg.Message.Height, msg.Message.Round)}).Encode()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encode commit data")
+	}
+	commit := withData(msg.Message, commitData)
+	commit.MsgType = message.CommitMsgType
+	forged, err := signer.Sign(commit, msg.Signers...)
+	if err != nil {
+		return nil, err
+	}
+	if msg.Message.MsgType == message.PrepareMsgType {
+		return []*message.SignedMessage{msg, forged}, nil
+	}
+	return []*message.SignedMessage{forged}, nil
+}
+
+// InvalidSignatures is an attack where the adversary signs the messages of the next round,
+// so the signatures of the messages it sends are invalid
+type InvalidSignatures struct {
+	Honest
+}
+
+// Name returns the name of the attack
+func (InvalidSignatures) Name() string {
+	return "invalid signatures"
+}
+
+// Sign returns the given message in the next round
+func (InvalidSignatures) Sign(msg *message.ConsensusMessage) *message.ConsensusMessage {
+	signed := withData(msg, msg.Data)
+	signed.Round++
+	return signed
+}
+
+// withData returns a copy of the given message with the given data
+func withData(msg *message.ConsensusMessage, data []byte) *message.ConsensusMessage {
+	return &message.ConsensusMessage{
+		MsgType:    msg.MsgType,
+		Height:     msg.Height,
+		Round:      msg.Round,
+		Identifier: msg.Identifier,
+		Data:       data,
+	}
+}
@@ -0,0 +1,59 @@
+package adversary
+
+import (
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/network"
+	"github.com/bloxapp/ssv/protocol/v1/message"
+)
+
+// Network wraps the network of an adversary operator, and rewrites the outbound consensus and decided messages
+// according to the attack of the signer.
+// NOTE: messages are broadcasted to the validator's topic, so all the peers receive the same (rewritten) messages
+type Network struct {
+	network.P2PNetwork
+
+	logger *zap.Logger
+	signer *Signer
+}
+
+// NewNetwork wraps the given network
+func NewNetwork(logger *zap.Logger, net network.P2PNetwork, signer *Signer) *Network {
+	return &Network{
+		P2PNetwork: net,
+		logger:     logger.With(zap.String("attack", signer.Attack().Name())),
+		signer:     signer,
+	}
+}
+
+// Broadcast broadcasts the messages that the attack returns instead of the given message
+func (n *Network) Broadcast(msg message.SSVMessage) error {
+	if msg.MsgType != message.SSVConsensusMsgType && msg.MsgType != message.SSVDecidedMsgType {
+		return n.P2PNetwork.Broadcast(msg)
+	}
+	signedMsg := &message.SignedMessage{}
+	if err := signedMsg.Decode(msg.Data); err != nil {
+		return errors.Wrap(err, "could not decode signed message")
+	}
+	msgs, err := n.signer.Attack().Rewrite(n.signer, msg.MsgType, signedMsg)
+	if err != nil {
+		return errors.Wrap(err, "could not rewrite message")
+	}
+	n.logger.Debug("broadcasting rewritten messages", zap.String("type", msg.MsgType.String()),
+		zap.Int("messages", len(msgs)))
+	for _, m := range msgs {
+		data, err := m.Encode()
+		if err != nil {
+			return errors.Wrap(err, "could not encode rewritten message")
+		}
+		if err := n.P2PNetwork.Broadcast(message.SSVMessage{
+			MsgType: msg.MsgType,
+			ID:      msg.ID,
+			Data:    data,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package adversary
+
+import (
+	"encoding/hex"
+	"sync"
+	"sync/atomic"
+
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
+
+	"github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
+	"github.com/bloxapp/ssv/protocol/v1/message"
+)
+
+// Signer is the key manager of an adversary operator.
+// it signs the messages of the instances according to the attack, and can sign forged messages (see Sign)
+type Signer struct {
+	attack Attack
+
+	lock sync.RWMutex
+	keys map[string]*bls.SecretKey
+	// validators maps the validators to their share keys, it is populated once the instances sign messages
+	validators  map[string]*bls.SecretKey
+	forkVersion string
+
+	// forged is the number of forged messages that were signed
+	forged int64
+}
+
+var _ beacon.KeyManager = (*Signer)(nil)
+
+// NewSigner creates a new signer for the given attack
+func NewSigner(attack Attack) *Signer {
+	return &Signer{
+		attack:     attack,
+		keys:       make(map[string]*bls.SecretKey),
+		validators: make(map[string]*bls.SecretKey),
+	}
+}
+
+// Attack returns the attack of the adversary
+func (s *Signer) Attack() Attack {
+	return s.attack
+}
+
+// AddShare saves a share key
+func (s *Signer) AddShare(shareKey *bls.SecretKey) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.keys[shareKey.GetPublicKey().SerializeToHexStr()] = shareKey
+	return nil
+}
+
+// RemoveShare removes a share key
+func (s *Signer) RemoveShare(pubKey string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.keys, pubKey)
+	return nil
+}
+
+// SignIBFTMessage signs the message that the attack returns instead of the given message
+func (s *Signer) SignIBFTMessage(msg *message.ConsensusMessage, pk []byte, forkVersion string) ([]byte, error) {
+	s.lock.Lock()
+	key := s.keys[hex.EncodeToString(pk)]
+	if key != nil {
+		s.validators[hex.EncodeToString(msg.Identifier.GetValidatorPK())] = key
+	}
+	s.forkVersion = forkVersion
+	s.lock.Unlock()
+
+	if key == nil {
+		return nil, errors.Errorf("could not find key for pk: %x", pk)
+	}
+	toSign := s.attack.Sign(msg)
+	if toSign != msg {
+		atomic.AddInt64(&s.forged, 1)
+	}
+	sig, err := toSign.Sign(key, forkVersion)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not sign ibft msg")
+	}
+	return sig.Serialize(), nil
+}
+
+// Forged returns the number of forged messages that were signed
+func (s *Signer) Forged() int64 {
+	return atomic.LoadInt64(&s.forged)
+}
+
+// SignAttestation is not supported, adversaries attack only the consensus
+func (s *Signer) SignAttestation(data *spec.AttestationData, duty *beacon.Duty, pk []byte) (*spec.Attestation, []byte, error) {
+	return nil, nil, errors.New("adversary doesn't sign attestations")
+}
+
+// SignValidatorRegistration is not supported, adversaries attack only the consensus
+func (s *Signer) SignValidatorRegistration(registration *beacon.ValidatorRegistration, pk []byte) ([]byte, []byte, error) {
+	return nil, nil, errors.New("adversary doesn't sign validator registrations")
+}
+
+// Sign signs the given message as-is, with the share of its validator.
+// the given signers are declared as the signers of the message, regardless of the actual signer.
+// NOTE: the share is known only after the instances of the validator signed a message
+func (s *Signer) Sign(msg *message.ConsensusMessage, signers ...message.OperatorID) (*message.SignedMessage, error) {
+	pk := msg.Identifier.GetValidatorPK()
+	s.lock.RLock()
+	key := s.validators[hex.EncodeToString(pk)]
+	forkVersion := s.forkVersion
+	s.lock.RUnlock()
+
+	if key == nil {
+		return nil, errors.Errorf("could not find share of validator: %x", pk)
+	}
+	sig, err := msg.Sign(key, forkVersion)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not sign ibft msg")
+	}
+	atomic.AddInt64(&s.forged, 1)
+	return &message.SignedMessage{
+		Message:   msg,
+		Signature: sig.Serialize(),
+		Signers:   signers,
+	}, nil
+}
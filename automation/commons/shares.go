@@ -10,6 +10,7 @@ import (
 	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
 	"github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
 	"github.com/bloxapp/ssv/protocol/v1/message"
+	"github.com/bloxapp/ssv/protocol/v1/qbft"
 	qbftstorage "github.com/bloxapp/ssv/protocol/v1/qbft/storage"
 	"github.com/bloxapp/ssv/protocol/v1/validator"
 	"github.com/bloxapp/ssv/utils/rsaencryption"
@@ -21,6 +22,20 @@ import (
 
 // CreateShareAndValidators creates a share and the corresponding validators objects
 func CreateShareAndValidators(ctx context.Context, logger *zap.Logger, net *p2pv1.LocalNet, kms []beacon.KeyManager, stores []qbftstorage.QBFTStore) (*beacon.Share, map[uint64]*bls.SecretKey, []validator.IValidator, error) {
+	return createShareAndValidators(ctx, logger, net, kms, nil, stores, nil)
+}
+
+// CreateShareAndValidatorsWithBeacons creates a share and the corresponding validators objects,
+// the validators use the given beacon clients (one per node) to execute duties and sign, and the given qbft configs of the roles
+func CreateShareAndValidatorsWithBeacons(ctx context.Context, logger *zap.Logger, net *p2pv1.LocalNet, beacons []beacon.Beacon, stores []qbftstorage.QBFTStore, instanceConfigs map[message.RoleType]*qbft.InstanceConfig) (*beacon.Share, map[uint64]*bls.SecretKey, []validator.IValidator, error) {
+	kms := make([]beacon.KeyManager, 0, len(beacons))
+	for _, b := range beacons {
+		kms = append(kms, b)
+	}
+	return createShareAndValidators(ctx, logger, net, kms, beacons, stores, instanceConfigs)
+}
+
+func createShareAndValidators(ctx context.Context, logger *zap.Logger, net *p2pv1.LocalNet, kms []beacon.KeyManager, beacons []beacon.Beacon, stores []qbftstorage.QBFTStore, instanceConfigs map[message.RoleType]*qbft.InstanceConfig) (*beacon.Share, map[uint64]*bls.SecretKey, []validator.IValidator, error) {
 	validators := make([]validator.IValidator, 0)
 	operators := make([][]byte, 0)
 	for _, k := range net.NodeKeys {
@@ -41,6 +56,10 @@ func CreateShareAndValidators(ctx context.Context, logger *zap.Logger, net *p2pv
 		if err != nil {
 			return nil, nil, nil, err
 		}
+		var beaconClient beacon.Beacon
+		if beacons != nil {
+			beaconClient = beacons[i]
+		}
 		val := validator.NewValidator(&validator.Options{
 			Context:     ctx,
 			Logger:      logger.With(zap.String("w", fmt.Sprintf("node-%d", i))),
@@ -56,11 +75,12 @@ func CreateShareAndValidators(ctx context.Context, logger *zap.Logger, net *p2pv
 				Operators:    share.Operators,
 			},
 			ForkVersion:                forksprotocol.V0ForkVersion, // TODO need to check v1 too?
-			Beacon:                     nil,
+			Beacon:                     beaconClient,
 			Signer:                     km,
 			SyncRateLimit:              time.Millisecond * 10,
 			SignatureCollectionTimeout: time.Second * 5,
 			ReadMode:                   false,
+			InstanceConfigs:            instanceConfigs,
 		})
 		validators = append(validators, val)
 	}
@@ -56,3 +56,7 @@ func (km *testSigner) SignIBFTMessage(message *message.ConsensusMessage, pk []by
 func (km *testSigner) SignAttestation(data *spec.AttestationData, duty *beacon.Duty, pk []byte) (*spec.Attestation, []byte, error) {
 	return nil, nil, nil
 }
+
+func (km *testSigner) SignValidatorRegistration(registration *beacon.ValidatorRegistration, pk []byte) ([]byte, []byte, error) {
+	return nil, nil, nil
+}
@@ -17,7 +17,13 @@ type Router struct {
 
 // Route processes message and routes it to the right controller
 func (r *Router) Route(message message.SSVMessage) {
-	if err := r.Controllers.ControllerForIdentifier(message.GetIdentifier()).ProcessMsg(&message); err != nil {
+	ctrl := r.Controllers.ControllerForIdentifier(message.GetIdentifier())
+	if ctrl == nil {
+		r.Logger.Warn("could not find controller for message",
+			zap.String("identifier", hex.EncodeToString(message.GetIdentifier())))
+		return
+	}
+	if err := ctrl.ProcessMsg(&message); err != nil {
 		r.Logger.Error("failed to process message",
 			zap.String("identifier", hex.EncodeToString(message.GetIdentifier())))
 	}
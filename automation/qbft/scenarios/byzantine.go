@@ -0,0 +1,249 @@
+package scenarios
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/automation/commons"
+	"github.com/bloxapp/ssv/automation/commons/adversary"
+	"github.com/bloxapp/ssv/automation/qbft/runner"
+	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
+	"github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
+	"github.com/bloxapp/ssv/protocol/v1/message"
+	forksfactory "github.com/bloxapp/ssv/protocol/v1/qbft/controller/forks/factory"
+	ibftinstance "github.com/bloxapp/ssv/protocol/v1/qbft/instance"
+	"github.com/bloxapp/ssv/protocol/v1/validator"
+)
+
+const (
+	// ConflictingProposalsScenario is the scenario name of an adversary that sends conflicting proposals
+	ConflictingProposalsScenario = "ConflictingProposals"
+	// ForgedJustificationScenario is the scenario name of an adversary that forges round change justifications
+	ForgedJustificationScenario = "ForgedJustification"
+	// UnpreparedCommitScenario is the scenario name of an adversary that commits values that were never prepared
+	UnpreparedCommitScenario = "UnpreparedCommit"
+	// InvalidSignaturesScenario is the scenario name of an adversary that sends messages with invalid signatures
+	InvalidSignaturesScenario = "InvalidSignatures"
+)
+
+// ByzantineScenarios is the catalogue of attack scenarios
+var ByzantineScenarios = []string{
+	ConflictingProposalsScenario,
+	ForgedJustificationScenario,
+	UnpreparedCommitScenario,
+	InvalidSignaturesScenario,
+}
+
+const (
+	// adversaryIndex is the index of the adversary operator (operator 1)
+	adversaryIndex = 0
+	// minByzantineHeights is the minimum number of heights that are decided in a byzantine scenario
+	minByzantineHeights = 3
+	// maxByzantineHeights is the maximum number of heights that are decided in a byzantine scenario
+	maxByzantineHeights = 10
+)
+
+// byzantineScenario is a scenario where one of 4 operators runs an attack, while the others are honest.
+// the scenario fails if the honest operators decide different values,
+// or a forged value that is not a valid decision (see allowForged).
+type byzantineScenario struct {
+	logger *zap.Logger
+	name   string
+	attack adversary.Attack
+	signer *adversary.Signer
+	// allowForged is true if the attack might propose forged values in a valid way,
+	// i.e. honest operators are allowed to decide them
+	allowForged bool
+
+	sks        map[uint64]*bls.SecretKey
+	share      *beacon.Share
+	validators []validator.IValidator
+	heights    message.Height
+}
+
+// newByzantineScenario creates a byzantine scenario instance
+func newByzantineScenario(logger *zap.Logger, name string, attack adversary.Attack, allowForged bool) runner.Scenario {
+	return &byzantineScenario{
+		logger:      logger.With(zap.String("attack", attack.Name())),
+		name:        name,
+		attack:      attack,
+		allowForged: allowForged,
+	}
+}
+
+func (r *byzantineScenario) NumOfOperators() int {
+	return 4
+}
+
+func (r *byzantineScenario) NumOfBootnodes() int {
+	return 0
+}
+
+func (r *byzantineScenario) NumOfFullNodes() int {
+	return 0
+}
+
+func (r *byzantineScenario) Name() string {
+	return r.name
+}
+
+func (r *byzantineScenario) PreExecution(ctx *runner.ScenarioContext) error {
+	// the adversary uses its own signer and a network that rewrites its messages
+	r.signer = adversary.NewSigner(r.attack)
+	ctx.KeyManagers[adversaryIndex] = r.signer
+	ctx.LocalNet.Nodes[adversaryIndex] = adversary.NewNetwork(r.logger.With(zap.String("who", "adversary")),
+		ctx.LocalNet.Nodes[adversaryIndex], r.signer)
+
+	share, sks, validators, err := commons.CreateShareAndValidators(ctx.Ctx, r.logger, ctx.LocalNet, ctx.KeyManagers, ctx.Stores)
+	if err != nil {
+		return errors.Wrap(err, "could not create share")
+	}
+	// save all references
+	r.validators = validators
+	r.sks = sks
+	r.share = share
+
+	r.heights, err = r.heightsToRun()
+	if err != nil {
+		return errors.Wrap(err, "could not calculate heights")
+	}
+
+	for i, node := range ctx.LocalNet.Nodes {
+		node.UseMessageRouter(&runner.Router{
+			Logger:      r.logger.With(zap.String("who", fmt.Sprintf("msgRouter-%d", i))),
+			Controllers: r.validators[i].(*validator.Validator).Ibfts(),
+		})
+	}
+
+	return nil
+}
+
+// heightsToRun returns the number of heights to run, so the adversary will lead the first round in at least one of them
+func (r *byzantineScenario) heightsToRun() (message.Height, error) {
+	fork := forksfactory.NewFork(forksprotocol.V0ForkVersion)
+	identifier := fork.Identifier(r.share.PublicKey.Serialize(), message.RoleTypeAttester)
+	for h := message.Height(0); h < maxByzantineHeights; h++ {
+		selector, err := fork.LeaderSelector(identifier, uint64(r.NumOfOperators()), h, nil)
+		if err != nil {
+			return 0, err
+		}
+		if selector.Calculate(1) != adversaryIndex {
+			continue
+		}
+		if h+1 < minByzantineHeights {
+			return minByzantineHeights, nil
+		}
+		return h + 1, nil
+	}
+	return maxByzantineHeights, nil
+}
+
+func (r *byzantineScenario) Execute(_ *runner.ScenarioContext) error {
+	if len(r.sks) == 0 || r.share == nil {
+		return errors.New("pre-execution failed")
+	}
+
+	var wg sync.WaitGroup
+	var startErr error
+	for _, val := range r.validators {
+		wg.Add(1)
+		go func(val validator.IValidator) {
+			defer wg.Done()
+			if err := val.Start(); err != nil {
+				startErr = errors.Wrap(err, "could not start validator")
+			}
+			<-time.After(time.Second * 3)
+		}(val)
+	}
+	wg.Wait()
+
+	if startErr != nil {
+		return startErr
+	}
+
+	r.logger.Info("running heights", zap.Uint64("heights", uint64(r.heights)))
+	for h := message.Height(0); h < r.heights; h++ {
+		for i, val := range r.validators {
+			wg.Add(1)
+			go func(i int, val validator.IValidator, h message.Height) {
+				defer wg.Done()
+				// liveness is not asserted, the honest decisions are checked in post-execution
+				if err := r.startInstance(val, h, []byte(fmt.Sprintf("value of operator %d", i+1))); err != nil {
+					r.logger.Warn("instance failed", zap.Int("node", i), zap.Bool("adversary", i == adversaryIndex),
+						zap.Uint64("height", uint64(h)), zap.Error(err))
+				}
+			}(i, val, h)
+		}
+		wg.Wait()
+	}
+
+	return nil
+}
+
+// startInstance starts an attester instance, the other roles are not relevant for the attacks
+func (r *byzantineScenario) startInstance(val validator.IValidator, h message.Height, value []byte) error {
+	res, err := val.(*validator.Validator).Ibfts().ControllerForIdentifier(r.identifier()).StartInstance(ibftinstance.ControllerStartInstanceOptions{
+		Logger:    r.logger,
+		SeqNumber: h,
+		Value:     value,
+	})
+	if err != nil {
+		return err
+	}
+	if !res.Decided {
+		return errors.New("instance could not decide")
+	}
+	return nil
+}
+
+func (r *byzantineScenario) identifier() message.Identifier {
+	return message.NewIdentifier(r.share.PublicKey.Serialize(), message.RoleTypeAttester)
+}
+
+// PostExecution checks the safety of the honest operators
+func (r *byzantineScenario) PostExecution(ctx *runner.ScenarioContext) error {
+	if r.signer.Forged() == 0 {
+		return errors.New("the adversary didn't send forged messages")
+	}
+	identifier := r.identifier()
+	decided := make(map[message.Height][]byte)
+	for i, store := range ctx.Stores {
+		if i == adversaryIndex {
+			continue
+		}
+		msgs, err := store.GetDecided(identifier, message.Height(0), r.heights-1)
+		if err != nil {
+			return errors.Wrapf(err, "could not get decided messages of node-%d", i)
+		}
+		for _, msg := range msgs {
+			commitData, err := msg.Message.GetCommitData()
+			if err != nil {
+				return errors.Wrapf(err, "could not get commit data of node-%d", i)
+			}
+			value := commitData.Data
+			height := msg.Message.Height
+			if adversary.IsForged(value) && !r.allowForged {
+				return errors.Errorf("safety violation: node-%d decided a forged value in height %d", i, height)
+			}
+			if other, ok := decided[height]; ok && !bytes.Equal(other, value) {
+				return errors.Errorf("safety violation: node-%d decided a different value in height %d (%s, %s)",
+					i, height, string(value), string(other))
+			}
+			decided[height] = value
+		}
+	}
+	if len(decided) == 0 {
+		return errors.New("honest operators didn't decide")
+	}
+	r.logger.Info("honest operators are safe", zap.Int64("forged messages", r.signer.Forged()),
+		zap.Int("decided heights", len(decided)),
+		zap.Uint64("heights", uint64(r.heights)))
+
+	return nil
+}
@@ -0,0 +1,244 @@
+package scenarios
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/eth2-key-manager/core"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/automation/commons"
+	"github.com/bloxapp/ssv/automation/qbft/runner"
+	"github.com/bloxapp/ssv/beacon/goclient"
+	"github.com/bloxapp/ssv/beacon/mockbeacon"
+	"github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
+	"github.com/bloxapp/ssv/protocol/v1/message"
+	"github.com/bloxapp/ssv/protocol/v1/qbft"
+	"github.com/bloxapp/ssv/protocol/v1/validator"
+)
+
+// DutyExecutionScenario is the scenario name of attester duties that are executed through the beacon api
+const DutyExecutionScenario = "DutyExecution"
+
+// attestationTimeout is the time to wait for the attestation of a duty to be submitted
+const attestationTimeout = 15 * time.Second
+
+// dutyStep is a duty that is executed while the given faults are injected into the mock beacon node
+type dutyStep struct {
+	name   string
+	faults map[mockbeacon.Endpoint]mockbeacon.Fault
+	// reorg is true if the block of the duty is reorged once the attestation was submitted
+	reorg bool
+}
+
+// dutyExecutionScenario is a scenario where 4 operators execute attester duties end to end,
+// with beacon clients (goclient) that talk to a mock beacon node over http.
+// each step runs the duty of a past epoch (so there is no waiting for slots), while faults are injected into the beacon node
+type dutyExecutionScenario struct {
+	logger *zap.Logger
+	steps  []dutyStep
+
+	server     *mockbeacon.Server
+	beacons    []beacon.Beacon
+	share      *beacon.Share
+	validators []validator.IValidator
+	index      spec.ValidatorIndex
+	// slots are the slots of the executed duties, by step
+	slots []spec.Slot
+}
+
+// newDutyExecutionScenario creates a duty execution scenario instance
+func newDutyExecutionScenario(logger *zap.Logger) runner.Scenario {
+	return &dutyExecutionScenario{
+		logger: logger,
+		steps: []dutyStep{
+			{name: "regular"},
+			{name: "slow and failing beacon node", faults: map[mockbeacon.Endpoint]mockbeacon.Fault{
+				mockbeacon.EndpointAttestationData: {Delay: time.Second},
+				// the attestation is submitted by all the operators, so a failure of one of them is tolerated
+				mockbeacon.EndpointSubmitAttestations: {Status: http.StatusServiceUnavailable, Count: 1},
+			}},
+			{name: "reorg", reorg: true},
+		},
+	}
+}
+
+func (r *dutyExecutionScenario) NumOfOperators() int {
+	return 4
+}
+
+func (r *dutyExecutionScenario) NumOfBootnodes() int {
+	return 0
+}
+
+func (r *dutyExecutionScenario) NumOfFullNodes() int {
+	return 0
+}
+
+func (r *dutyExecutionScenario) Name() string {
+	return DutyExecutionScenario
+}
+
+func (r *dutyExecutionScenario) PreExecution(ctx *runner.ScenarioContext) error {
+	r.server = mockbeacon.New(mockbeacon.Options{
+		Logger:  r.logger.With(zap.String("who", "mockbeacon")),
+		Network: beacon.NewNetwork(core.PraterNetwork),
+		Addr:    "127.0.0.1:0",
+	})
+	if err := r.server.Start(); err != nil {
+		return errors.Wrap(err, "could not start mock beacon node")
+	}
+
+	for i := range ctx.LocalNet.Nodes {
+		client, err := goclient.New(beacon.Options{
+			Context:        ctx.Ctx,
+			Logger:         r.logger.With(zap.String("who", fmt.Sprintf("beacon-%d", i+1))),
+			Network:        string(core.PraterNetwork),
+			BeaconNodeAddr: r.server.Address(),
+			DB:             ctx.DBs[i],
+		})
+		if err != nil {
+			return errors.Wrap(err, "could not create beacon client")
+		}
+		r.beacons = append(r.beacons, client)
+		ctx.KeyManagers[i] = client
+	}
+
+	// the duties of the steps are in past epochs (so there is no waiting for slots),
+	// the scenario overrides the attester deadline so they are executed
+	network := beacon.NewNetwork(core.PraterNetwork)
+	attesterConfig := qbft.DefaultRoleConsensusParams(message.RoleTypeAttester)
+	attesterConfig.DutyDeadline = time.Duration(uint64(len(r.steps)+1)*network.SlotsPerEpoch()) * network.SlotDurationSec()
+	instanceConfigs := map[message.RoleType]*qbft.InstanceConfig{message.RoleTypeAttester: attesterConfig}
+	share, _, validators, err := commons.CreateShareAndValidatorsWithBeacons(ctx.Ctx, r.logger, ctx.LocalNet, r.beacons, ctx.Stores, instanceConfigs)
+	if err != nil {
+		return errors.Wrap(err, "could not create share")
+	}
+	r.share = share
+	r.validators = validators
+
+	var pk spec.BLSPubKey
+	copy(pk[:], share.PublicKey.Serialize())
+	r.index = r.server.Chain().AddValidator(pk)
+
+	for i, node := range ctx.LocalNet.Nodes {
+		node.UseMessageRouter(&runner.Router{
+			Logger:      r.logger.With(zap.String("who", fmt.Sprintf("msgRouter-%d", i))),
+			Controllers: r.validators[i].(*validator.Validator).Ibfts(),
+		})
+	}
+
+	return nil
+}
+
+func (r *dutyExecutionScenario) Execute(ctx *runner.ScenarioContext) error {
+	if r.share == nil {
+		return errors.New("pre-execution failed")
+	}
+
+	var wg sync.WaitGroup
+	var startErr error
+	for _, val := range r.validators {
+		wg.Add(1)
+		go func(val validator.IValidator) {
+			defer wg.Done()
+			if err := val.Start(); err != nil {
+				startErr = errors.Wrap(err, "could not start validator")
+			}
+			<-time.After(time.Second * 3)
+		}(val)
+	}
+	wg.Wait()
+	if startErr != nil {
+		return startErr
+	}
+
+	// the duties of the steps are in consecutive past epochs, slashing protection allows one attestation per epoch
+	network := beacon.NewNetwork(core.PraterNetwork)
+	firstEpoch := spec.Epoch(network.EstimatedCurrentEpoch()) - spec.Epoch(len(r.steps))
+	for i, step := range r.steps {
+		logger := r.logger.With(zap.String("step", step.name))
+		duties, err := r.beacons[0].GetDuties(firstEpoch+spec.Epoch(i), []spec.ValidatorIndex{r.index})
+		if err != nil {
+			return errors.Wrapf(err, "could not get duties (%s)", step.name)
+		}
+		if len(duties) != 1 {
+			return errors.Errorf("expected a single duty, got %d (%s)", len(duties), step.name)
+		}
+		duty := duties[0]
+		r.slots = append(r.slots, duty.Slot)
+
+		for endpoint, fault := range step.faults {
+			r.server.Inject(endpoint, fault)
+		}
+		logger.Info("executing duty", zap.Uint64("slot", uint64(duty.Slot)))
+		for _, val := range r.validators {
+			wg.Add(1)
+			go func(val validator.IValidator) {
+				defer wg.Done()
+				val.ExecuteDuty(ctx.Ctx, uint64(duty.Slot), duty)
+			}(val)
+		}
+		wg.Wait()
+
+		if err := r.waitForAttestation(duty.Slot); err != nil {
+			return errors.Wrapf(err, "duty was not executed (%s)", step.name)
+		}
+		r.server.ClearFaults()
+		if step.reorg {
+			depth := uint64(r.server.Chain().HeadSlot()-duty.Slot) + 1
+			logger.Info("reorging the block of the duty", zap.Uint64("depth", depth))
+			r.server.Chain().Reorg(depth)
+		}
+	}
+
+	return nil
+}
+
+// waitForAttestation waits until an attestation is submitted in the given slot
+func (r *dutyExecutionScenario) waitForAttestation(slot spec.Slot) error {
+	deadline := time.Now().Add(attestationTimeout)
+	for time.Now().Before(deadline) {
+		if len(r.server.Chain().Attestations(slot)) > 0 {
+			return nil
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	return errors.Errorf("no attestation was submitted in slot %d", slot)
+}
+
+// PostExecution checks that a single valid attestation was kept for each duty, unless its block was reorged
+func (r *dutyExecutionScenario) PostExecution(ctx *runner.ScenarioContext) error {
+	defer func() {
+		_ = r.server.Close()
+	}()
+
+	chain := r.server.Chain()
+	for i, step := range r.steps {
+		attestations := chain.Attestations(r.slots[i])
+		if step.reorg {
+			if len(attestations) != 0 {
+				return errors.Errorf("attestations of orphaned blocks were kept (%s)", step.name)
+			}
+			continue
+		}
+		if len(attestations) != 1 {
+			return errors.Errorf("expected a single attestation, got %d (%s)", len(attestations), step.name)
+		}
+		if root := chain.BlockRoot(r.slots[i]); attestations[0].Data.BeaconBlockRoot != root {
+			return errors.Errorf("attestation votes for a wrong block (%s)", step.name)
+		}
+	}
+	// every operator submits the reconstructed attestation, including those whose submission failed
+	if submissions := r.server.Requests(mockbeacon.EndpointSubmitAttestations); submissions < 2*len(r.steps) {
+		return errors.Errorf("expected the attestations to be submitted by all the operators, got %d submissions", submissions)
+	}
+	r.logger.Info("duties were executed through the beacon api", zap.Int("steps", len(r.steps)),
+		zap.Int("submissions", r.server.Requests(mockbeacon.EndpointSubmitAttestations)))
+
+	return nil
+}
@@ -5,7 +5,9 @@ import (
 
 	"go.uber.org/zap"
 
+	"github.com/bloxapp/ssv/automation/commons/adversary"
 	"github.com/bloxapp/ssv/automation/qbft/runner"
+	"github.com/bloxapp/ssv/protocol/v1/message"
 )
 
 var scenarios = &sync.Map{}
@@ -36,6 +38,18 @@ func NewScenario(name string, logger *zap.Logger) runner.Scenario {
 			s = newSyncFailoverScenario(logger)
 		case FullNodeScenario:
 			s = newFullNodeScenario(logger)
+		case DutyExecutionScenario:
+			s = newDutyExecutionScenario(logger)
+		case ConflictingProposalsScenario:
+			s = newByzantineScenario(logger, name, adversary.ConflictingProposals{}, true)
+		case ForgedJustificationScenario:
+			s = newByzantineScenario(logger, name, adversary.ForgedJustification{
+				Signers: []message.OperatorID{1, 2, 3, 4},
+			}, false)
+		case UnpreparedCommitScenario:
+			s = newByzantineScenario(logger, name, adversary.UnpreparedCommit{}, false)
+		case InvalidSignaturesScenario:
+			s = newByzantineScenario(logger, name, adversary.InvalidSignatures{}, false)
 		default:
 			logger.Panic("could not find scenario")
 		}
@@ -21,3 +21,25 @@ func Test_Automation_QBFTScenarios(t *testing.T) {
 		runner.Start(logger, scenario, scenarios.QBFTScenarioBootstrapper())
 	}
 }
+
+func Test_Automation_ByzantineScenarios(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping byzantine scenarios in short mode")
+	}
+	logger := logex.Build("simulation", zapcore.InfoLevel, nil)
+
+	for _, s := range scenarios.ByzantineScenarios {
+		scenario := scenarios.NewScenario(s, logger)
+		runner.Start(logger, scenario, scenarios.QBFTScenarioBootstrapper())
+	}
+}
+
+func Test_Automation_DutyExecution(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping duty execution scenario in short mode")
+	}
+	logger := logex.Build("simulation", zapcore.InfoLevel, nil)
+
+	scenario := scenarios.NewScenario(scenarios.DutyExecutionScenario, logger)
+	runner.Start(logger, scenario, scenarios.QBFTScenarioBootstrapper())
+}
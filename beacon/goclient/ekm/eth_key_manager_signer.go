@@ -28,6 +28,7 @@ type ethKeyManagerSigner struct {
 	signer       signer.ValidatorSigner
 	storage      *signerStorage
 	signingUtils beacon.SigningUtil
+	network      beaconprotocol.Network
 }
 
 // NewETHKeyManagerSigner returns a new instance of ethKeyManagerSigner
@@ -63,6 +64,7 @@ func NewETHKeyManagerSigner(db basedb.IDb, signingUtils beacon.SigningUtil, netw
 		signer:       beaconSigner,
 		storage:      signerStore,
 		signingUtils: signingUtils,
+		network:      network,
 	}, nil
 }
 
@@ -156,6 +158,34 @@ func (km *ethKeyManagerSigner) SignAttestation(data *spec.AttestationData, duty
 	}, root[:], nil
 }
 
+// SignValidatorRegistration signs the given registration with the builder domain,
+// slashing protection is not relevant for registrations
+func (km *ethKeyManagerSigner) SignValidatorRegistration(registration *beaconprotocol.ValidatorRegistration, pk []byte) ([]byte, []byte, error) {
+	km.walletLock.RLock()
+	defer km.walletLock.RUnlock()
+
+	domain, err := beaconprotocol.ComputeBuilderDomain(km.network.ForkVersion())
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to get builder domain for signing")
+	}
+	root, err := km.signingUtils.ComputeSigningRoot(registration, domain[:])
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to get root for signing")
+	}
+
+	account, err := km.wallet.AccountByPublicKey(hex.EncodeToString(pk))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not get signing account")
+	}
+
+	sig, err := account.ValidationKeySign(root[:])
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not sign validator registration")
+	}
+
+	return sig, root[:], nil
+}
+
 func (km *ethKeyManagerSigner) saveShare(shareKey *bls.SecretKey) error {
 	key, err := core.NewHDKeyFromPrivateKey(shareKey.Serialize(), "")
 	if err != nil {
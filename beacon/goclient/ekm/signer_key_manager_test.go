@@ -124,6 +124,34 @@ func TestSignAttestation(t *testing.T) {
 	})
 }
 
+func TestSignValidatorRegistration(t *testing.T) {
+	km := testKeyManager(t)
+
+	pk := &bls.PublicKey{}
+	require.NoError(t, pk.Deserialize(_byteArray(pk1Str)))
+
+	registration := &beacon2.ValidatorRegistration{
+		GasLimit:  beacon2.DefaultGasLimit,
+		Timestamp: 1606824023,
+	}
+	copy(registration.FeeRecipient[:], _byteArray("0102030405060708090a0b0c0d0e0f1011121314"))
+	copy(registration.Pubkey[:], pk.Serialize())
+
+	sig, root, err := km.SignValidatorRegistration(registration, pk.Serialize())
+	require.NoError(t, err)
+	require.Len(t, root, 32)
+
+	// verify
+	blsSig := &bls.Sign{}
+	require.NoError(t, blsSig.Deserialize(sig))
+	require.True(t, blsSig.VerifyByte(pk, root))
+
+	t.Run("unknown share", func(t *testing.T) {
+		_, _, err := km.SignValidatorRegistration(registration, make([]byte, 48))
+		require.Error(t, err)
+	})
+}
+
 func TestSignIBFTMessage(t *testing.T) {
 	logex.Build("", zapcore.DebugLevel, &logex.EncodingConfig{})
 
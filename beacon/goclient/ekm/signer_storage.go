@@ -1,6 +1,7 @@
 package ekm
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"github.com/bloxapp/eth2-key-manager/core"
@@ -244,3 +245,46 @@ func (s *signerStorage) RetrieveHighestProposal(pubKey []byte) *eth.BeaconBlock
 	}
 	return ret
 }
+
+// SlashingProtectionGuard refuses to replace slashing protection data with older data, e.g. when restoring a db backup
+type SlashingProtectionGuard struct{}
+
+// Applies returns whether the given db key holds slashing protection data
+func (SlashingProtectionGuard) Applies(key []byte) bool {
+	return bytes.Contains(key, []byte(highestAttPrefix)) || bytes.Contains(key, []byte(highestProposalPrefix))
+}
+
+// Check returns an error if the restored slashing protection data is older than the current data or missing
+func (SlashingProtectionGuard) Check(key, current, restored []byte) error {
+	if len(current) == 0 {
+		return nil
+	}
+	if len(restored) == 0 {
+		return errors.Errorf("slashing protection data is missing for key %x", key)
+	}
+	if bytes.Contains(key, []byte(highestAttPrefix)) {
+		cur, res := &eth.AttestationData{}, &eth.AttestationData{}
+		if err := cur.UnmarshalSSZ(current); err != nil {
+			return errors.Wrap(err, "could not decode current highest attestation")
+		}
+		if err := res.UnmarshalSSZ(restored); err != nil {
+			return errors.Wrap(err, "could not decode restored highest attestation")
+		}
+		if res.Source.Epoch < cur.Source.Epoch || res.Target.Epoch < cur.Target.Epoch {
+			return errors.Errorf("restored highest attestation (source %d, target %d) is older than current (source %d, target %d) for key %x",
+				res.Source.Epoch, res.Target.Epoch, cur.Source.Epoch, cur.Target.Epoch, key)
+		}
+		return nil
+	}
+	cur, res := &eth.BeaconBlock{}, &eth.BeaconBlock{}
+	if err := cur.UnmarshalSSZ(current); err != nil {
+		return errors.Wrap(err, "could not decode current highest proposal")
+	}
+	if err := res.UnmarshalSSZ(restored); err != nil {
+		return errors.Wrap(err, "could not decode restored highest proposal")
+	}
+	if res.Slot < cur.Slot {
+		return errors.Errorf("restored highest proposal (slot %d) is older than current (slot %d) for key %x", res.Slot, cur.Slot, key)
+	}
+	return nil
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/bloxapp/ssv/utils/threshold"
 	"github.com/google/uuid"
 	"github.com/herumi/bls-eth-go-binary/bls"
+	types "github.com/prysmaticlabs/eth2-types"
 	eth "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
@@ -396,3 +397,63 @@ func TestSavingHighestAttestation(t *testing.T) {
 		})
 	}
 }
+
+func TestSlashingProtectionGuard(t *testing.T) {
+	attData := func(source, target uint64) []byte {
+		data, err := (&eth.AttestationData{
+			BeaconBlockRoot: make([]byte, 32),
+			Source:          &eth.Checkpoint{Epoch: types.Epoch(source), Root: make([]byte, 32)},
+			Target:          &eth.Checkpoint{Epoch: types.Epoch(target), Root: make([]byte, 32)},
+		}).MarshalSSZ()
+		require.NoError(t, err)
+		return data
+	}
+	proposalData := func(slot uint64) []byte {
+		data, err := (&eth.BeaconBlock{
+			Slot:       types.Slot(slot),
+			ParentRoot: make([]byte, 32),
+			StateRoot:  make([]byte, 32),
+			Body: &eth.BeaconBlockBody{
+				RandaoReveal: make([]byte, 96),
+				Eth1Data:     &eth.Eth1Data{DepositRoot: make([]byte, 32), BlockHash: make([]byte, 32)},
+				Graffiti:     make([]byte, 32),
+			},
+		}).MarshalSSZ()
+		require.NoError(t, err)
+		return data
+	}
+	attKey := []byte("prater" + highestAttPrefix + "abcd")
+	proposalKey := []byte("prater" + highestProposalPrefix + "abcd")
+
+	guard := SlashingProtectionGuard{}
+	require.True(t, guard.Applies(attKey))
+	require.True(t, guard.Applies(proposalKey))
+	require.False(t, guard.Applies([]byte("prater"+walletPrefix)))
+
+	tests := []struct {
+		name     string
+		key      []byte
+		current  []byte
+		restored []byte
+		valid    bool
+	}{
+		{"new attestation data", attKey, nil, attData(1, 2), true},
+		{"same attestation data", attKey, attData(1, 2), attData(1, 2), true},
+		{"newer attestation data", attKey, attData(1, 2), attData(2, 3), true},
+		{"older source epoch", attKey, attData(2, 3), attData(1, 3), false},
+		{"older target epoch", attKey, attData(2, 3), attData(2, 2), false},
+		{"missing attestation data", attKey, attData(2, 3), nil, false},
+		{"newer proposal", proposalKey, proposalData(10), proposalData(11), true},
+		{"older proposal", proposalKey, proposalData(10), proposalData(9), false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := guard.Check(test.key, test.current, test.restored)
+			if test.valid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
@@ -29,6 +29,7 @@ import (
 
 const (
 	healthCheckTimeout = 10 * time.Second
+	requestTimeout     = 5 * time.Second
 )
 
 type beaconNodeStatus int32
@@ -58,11 +59,15 @@ type goClient struct {
 	indicesMapLock sync.Mutex
 	graffiti       []byte
 	keyManager     beaconprotocol.KeyManager
+	beaconAPI      *beaconAPI
 }
 
 // verifies that the client implements HealthCheckAgent
 var _ metrics.HealthCheckAgent = &goClient{}
 
+// verifies that the client implements ComponentHealthAgent
+var _ metrics.ComponentHealthAgent = &goClient{}
+
 // New init new client and go-client instance
 func New(opt beaconprotocol.Options) (beaconprotocol.Beacon, error) {
 	logger := opt.Logger.With(zap.String("component", "goClient"), zap.String("network", opt.Network))
@@ -73,7 +78,7 @@ func New(opt beaconprotocol.Options) (beaconprotocol.Beacon, error) {
 		http.WithAddress(opt.BeaconNodeAddr),
 		// LogLevel supplies the level of logging to carry out.
 		http.WithLogLevel(zerolog.DebugLevel),
-		http.WithTimeout(time.Second*5),
+		http.WithTimeout(requestTimeout),
 	)
 	if err != nil {
 		return nil, errors.WithMessage(err, "failed to create http client")
@@ -82,6 +87,11 @@ func New(opt beaconprotocol.Options) (beaconprotocol.Beacon, error) {
 	logger = logger.With(zap.String("name", httpClient.Name()), zap.String("address", httpClient.Address()))
 	logger.Info("successfully connected to beacon client")
 
+	nodeAPI, err := newBeaconAPI(httpClient.Address(), requestTimeout)
+	if err != nil {
+		return nil, err
+	}
+
 	network := beaconprotocol.NewNetwork(core.NetworkFromString(opt.Network))
 	_client := &goClient{
 		ctx:            opt.Context,
@@ -90,6 +100,7 @@ func New(opt beaconprotocol.Options) (beaconprotocol.Beacon, error) {
 		client:         httpClient,
 		indicesMapLock: sync.Mutex{},
 		graffiti:       opt.Graffiti,
+		beaconAPI:      nodeAPI,
 	}
 
 	_client.keyManager, err = ekm.NewETHKeyManagerSigner(opt.DB, _client, network)
@@ -102,25 +113,36 @@ func New(opt beaconprotocol.Options) (beaconprotocol.Beacon, error) {
 
 // HealthCheck provides health status of beacon node
 func (gc *goClient) HealthCheck() []string {
+	return gc.HealthStatus().Errors
+}
+
+// HealthStatus provides health status of beacon node, including its sync distance
+func (gc *goClient) HealthStatus() metrics.ComponentStatus {
 	if gc.client == nil {
-		return []string{"not connected to beacon node"}
+		return metrics.NewComponentStatus("beacon", []string{"not connected to beacon node"}, nil)
 	}
+	details := map[string]interface{}{}
 	if provider, isProvider := gc.client.(eth2client.NodeSyncingProvider); isProvider {
 		ctx, cancel := context.WithTimeout(gc.ctx, healthCheckTimeout)
 		defer cancel()
 		syncState, err := provider.NodeSyncing(ctx)
 		if err != nil {
 			metricsBeaconNodeStatus.Set(float64(statusUnknown))
-			return []string{"could not get beacon node sync state"}
+			return metrics.NewComponentStatus("beacon", []string{"could not get beacon node sync state"}, nil)
 		}
-		if syncState != nil && syncState.IsSyncing {
-			metricsBeaconNodeStatus.Set(float64(statusSyncing))
-			return []string{fmt.Sprintf("beacon node is currently syncing: head=%d, distance=%d",
-				syncState.HeadSlot, syncState.SyncDistance)}
+		if syncState != nil {
+			details["head_slot"] = uint64(syncState.HeadSlot)
+			details["sync_distance"] = uint64(syncState.SyncDistance)
+			details["is_syncing"] = syncState.IsSyncing
+			if syncState.IsSyncing {
+				metricsBeaconNodeStatus.Set(float64(statusSyncing))
+				return metrics.NewComponentStatus("beacon", []string{fmt.Sprintf("beacon node is currently syncing: head=%d, distance=%d",
+					syncState.HeadSlot, syncState.SyncDistance)}, details)
+			}
 		}
 	}
 	metricsBeaconNodeStatus.Set(float64(statusOK))
-	return []string{}
+	return metrics.NewComponentStatus("beacon", []string{}, details)
 }
 
 func (gc *goClient) GetDuties(epoch spec.Epoch, validatorIndices []spec.ValidatorIndex) ([]*beaconprotocol.Duty, error) {
@@ -0,0 +1,100 @@
+package goclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	api "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+
+	beaconprotocol "github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
+)
+
+const registerValidatorEndpoint = "/eth/v1/validator/register_validator"
+
+// SignValidatorRegistration implements Signer interface
+func (gc *goClient) SignValidatorRegistration(registration *beaconprotocol.ValidatorRegistration, pk []byte) ([]byte, []byte, error) {
+	return gc.keyManager.SignValidatorRegistration(registration, pk)
+}
+
+// SubmitProposalPreparation implements Beacon interface
+func (gc *goClient) SubmitProposalPreparation(feeRecipients map[spec.ValidatorIndex]bellatrix.ExecutionAddress) error {
+	if provider, isProvider := gc.client.(eth2client.ProposalPreparationsSubmitter); isProvider {
+		var preparations []*api.ProposalPreparation
+		for index, recipient := range feeRecipients {
+			preparations = append(preparations, &api.ProposalPreparation{
+				ValidatorIndex: index,
+				FeeRecipient:   recipient,
+			})
+		}
+		return provider.SubmitProposalPreparations(gc.ctx, preparations)
+	}
+	return errors.New("client does not support ProposalPreparationsSubmitter")
+}
+
+// SubmitValidatorRegistration implements Beacon interface,
+// the registration is sent to the beacon node which relays it to the builder network (e.g. mev-boost)
+func (gc *goClient) SubmitValidatorRegistration(registration *beaconprotocol.SignedValidatorRegistration) error {
+	body, err := json.Marshal([]*beaconprotocol.SignedValidatorRegistration{registration})
+	if err != nil {
+		return errors.Wrap(err, "failed to encode validator registration")
+	}
+	if err := gc.beaconAPI.post(gc.ctx, registerValidatorEndpoint, body); err != nil {
+		return errors.Wrap(err, "failed to submit validator registration")
+	}
+	return nil
+}
+
+// beaconAPI sends requests to beacon node endpoints that are not supported by the eth2 client,
+// with the same base address and timeout as the eth2 client
+type beaconAPI struct {
+	base   *url.URL
+	client *http.Client
+}
+
+func newBeaconAPI(addr string, timeout time.Duration) (*beaconAPI, error) {
+	if !strings.HasPrefix(addr, "http") {
+		addr = fmt.Sprintf("http://%s", addr)
+	}
+	base, err := url.Parse(addr)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid beacon node address")
+	}
+	return &beaconAPI{
+		base:   base,
+		client: &http.Client{Timeout: timeout},
+	}, nil
+}
+
+// post sends the given json body to the given endpoint
+func (b *beaconAPI) post(ctx context.Context, endpoint string, body []byte) error {
+	u := *b.base
+	u.Path = strings.TrimSuffix(u.Path, "/") + endpoint
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := b.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+	if res.StatusCode/100 != 2 {
+		data, _ := ioutil.ReadAll(res.Body)
+		return errors.Errorf("status %d: %s", res.StatusCode, string(data))
+	}
+	return nil
+}
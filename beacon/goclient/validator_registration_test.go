@@ -0,0 +1,91 @@
+package goclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	beaconprotocol "github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
+)
+
+func TestSubmitValidatorRegistration(t *testing.T) {
+	registration := &beaconprotocol.SignedValidatorRegistration{
+		Message: &beaconprotocol.ValidatorRegistration{
+			FeeRecipient: [20]byte{1, 2, 3},
+			GasLimit:     beaconprotocol.DefaultGasLimit,
+			Timestamp:    1660000000,
+			Pubkey:       spec.BLSPubKey{4, 5, 6},
+		},
+		Signature: spec.BLSSignature{7, 8, 9},
+	}
+
+	t.Run("submitted", func(t *testing.T) {
+		var received []*beaconprotocol.SignedValidatorRegistration
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodPost, r.Method)
+			require.Equal(t, "/prefix"+registerValidatorEndpoint, r.URL.Path)
+			require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		}))
+		defer server.Close()
+
+		gc := newTestClient(t, server.URL+"/prefix/", requestTimeout)
+		require.NoError(t, gc.SubmitValidatorRegistration(registration))
+		require.Len(t, received, 1)
+		require.Equal(t, registration, received[0])
+	})
+
+	t.Run("address without scheme", func(t *testing.T) {
+		called := false
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+			require.Equal(t, registerValidatorEndpoint, r.URL.Path)
+		}))
+		defer server.Close()
+
+		gc := newTestClient(t, strings.TrimPrefix(server.URL, "http://"), requestTimeout)
+		require.NoError(t, gc.SubmitValidatorRegistration(registration))
+		require.True(t, called)
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "invalid registration", http.StatusBadRequest)
+		}))
+		defer server.Close()
+
+		gc := newTestClient(t, server.URL, requestTimeout)
+		err := gc.SubmitValidatorRegistration(registration)
+		require.EqualError(t, err, "failed to submit validator registration: status 400: invalid registration\n")
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		done := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-done
+		}))
+		defer server.Close()
+		defer close(done)
+
+		gc := newTestClient(t, server.URL, 50*time.Millisecond)
+		err := gc.SubmitValidatorRegistration(registration)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "Client.Timeout exceeded")
+	})
+}
+
+func newTestClient(t *testing.T, addr string, timeout time.Duration) *goClient {
+	nodeAPI, err := newBeaconAPI(addr, timeout)
+	require.NoError(t, err)
+	return &goClient{
+		ctx:       context.Background(),
+		beaconAPI: nodeAPI,
+	}
+}
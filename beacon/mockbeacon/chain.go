@@ -0,0 +1,392 @@
+package mockbeacon
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"sync"
+	"time"
+
+	api "github.com/attestantio/go-eth2-client/api/v1"
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+
+	beaconprotocol "github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
+)
+
+var (
+	// domainBeaconAttester is the DOMAIN_BEACON_ATTESTER domain type
+	domainBeaconAttester = spec.DomainType{0x01, 0x00, 0x00, 0x00}
+	// genesisValidatorsRoot is the (fake) genesis validators root of the mock chain
+	genesisValidatorsRoot = spec.Root(sha256.Sum256([]byte("ssv-mockbeacon")))
+)
+
+const (
+	// validatorBalance is the balance of the validators (32 ETH in gwei)
+	validatorBalance = spec.Gwei(32000000000)
+	// farFutureEpoch is the exit epoch of active validators
+	farFutureEpoch = spec.Epoch(0xffffffffffffffff)
+	// syncTolerance is the distance (in slots) of the head from the clock, above which the node is syncing
+	syncTolerance = 1
+)
+
+// Chain is the state of the mock beacon chain.
+// the head follows the clock of the network unless it was pinned, blocks are derived from their slot and branch,
+// so attestation data is deterministic until a reorg replaces the blocks of the reorged slots.
+// validators are active since genesis, each of them has one attester duty per epoch (a committee of one)
+type Chain struct {
+	lock    sync.RWMutex
+	network beaconprotocol.Network
+
+	// head is the pinned head slot, the head follows the clock if nil
+	head *spec.Slot
+	// branches are the first slots of the reorged branches
+	branches     []spec.Slot
+	validators   []spec.BLSPubKey
+	attestations map[spec.Slot][]*spec.Attestation
+}
+
+// NewChain creates a new chain of the given network
+func NewChain(network beaconprotocol.Network) *Chain {
+	return &Chain{
+		network:      network,
+		attestations: make(map[spec.Slot][]*spec.Attestation),
+	}
+}
+
+// AddValidator activates the given validator, returns its index
+func (c *Chain) AddValidator(pk spec.BLSPubKey) spec.ValidatorIndex {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for i, v := range c.validators {
+		if v == pk {
+			return spec.ValidatorIndex(i)
+		}
+	}
+	c.validators = append(c.validators, pk)
+	return spec.ValidatorIndex(len(c.validators) - 1)
+}
+
+// HeadSlot returns the slot of the head
+func (c *Chain) HeadSlot() spec.Slot {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return c.headSlot()
+}
+
+// SetHead pins the head to the given slot, the chain doesn't progress until it is advanced or follows the clock again
+func (c *Chain) SetHead(slot spec.Slot) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.head = &slot
+}
+
+// Advance pins the head to the given number of slots after the current head
+func (c *Chain) Advance(slots uint64) spec.Slot {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	head := c.headSlot() + spec.Slot(slots)
+	c.head = &head
+	return head
+}
+
+// FollowClock makes the head follow the clock of the network
+func (c *Chain) FollowClock() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.head = nil
+}
+
+// Reorg replaces the blocks of the last depth slots (up to the head), returns the first reorged slot.
+// attestations that were submitted in the reorged slots are dropped, as they vote for orphaned blocks
+func (c *Chain) Reorg(depth uint64) spec.Slot {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	head := c.headSlot()
+	from := spec.Slot(0)
+	if uint64(head)+1 > depth {
+		from = head + 1 - spec.Slot(depth)
+	}
+	c.branches = append(c.branches, from)
+	for slot := range c.attestations {
+		if slot >= from {
+			delete(c.attestations, slot)
+		}
+	}
+	return from
+}
+
+// BlockRoot returns the root of the canonical block in the given slot
+func (c *Chain) BlockRoot(slot spec.Slot) spec.Root {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return c.blockRoot(slot)
+}
+
+// Attestations returns the attestations that were submitted in the given slot
+func (c *Chain) Attestations(slot spec.Slot) []*spec.Attestation {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return append([]*spec.Attestation{}, c.attestations[slot]...)
+}
+
+// Genesis returns the genesis of the chain, which is the genesis of the network
+func (c *Chain) Genesis() *api.Genesis {
+	genesis := &api.Genesis{
+		GenesisTime:           time.Unix(int64(c.network.MinGenesisTime()), 0),
+		GenesisValidatorsRoot: genesisValidatorsRoot,
+	}
+	copy(genesis.GenesisForkVersion[:], c.network.ForkVersion())
+	return genesis
+}
+
+// Fork returns the fork of the chain, there are no forks after genesis
+func (c *Chain) Fork() *spec.Fork {
+	fork := &spec.Fork{}
+	copy(fork.PreviousVersion[:], c.network.ForkVersion())
+	copy(fork.CurrentVersion[:], c.network.ForkVersion())
+	return fork
+}
+
+// SyncState returns the sync state of the chain, i.e. the distance of the head from the clock
+func (c *Chain) SyncState() *api.SyncState {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	head := c.headSlot()
+	state := &api.SyncState{HeadSlot: head}
+	if clock := spec.Slot(c.network.EstimatedCurrentSlot()); clock > head {
+		state.SyncDistance = clock - head
+	}
+	state.IsSyncing = state.SyncDistance > syncTolerance
+	return state
+}
+
+// Validators returns all the validators
+func (c *Chain) Validators() []*api.Validator {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	res := make([]*api.Validator, 0, len(c.validators))
+	for i := range c.validators {
+		res = append(res, c.validator(spec.ValidatorIndex(i)))
+	}
+	return res
+}
+
+// AttesterDuties returns the duties of the given validators in the given epoch
+func (c *Chain) AttesterDuties(epoch spec.Epoch, indices []spec.ValidatorIndex) ([]*api.AttesterDuty, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if current := spec.Epoch(c.network.EstimatedEpochAtSlot(types.Slot(c.headSlot()))); epoch > current+1 {
+		return nil, errors.Errorf("duties of epoch %d are not known yet", epoch)
+	}
+	var duties []*api.AttesterDuty
+	for _, index := range indices {
+		if int(index) >= len(c.validators) {
+			continue
+		}
+		slot, committeeIndex := c.dutySlot(epoch, index)
+		duties = append(duties, &api.AttesterDuty{
+			PubKey:                  c.validators[index],
+			Slot:                    slot,
+			ValidatorIndex:          index,
+			CommitteeIndex:          committeeIndex,
+			CommitteeLength:         1,
+			CommitteesAtSlot:        c.committeesAtSlot(),
+			ValidatorCommitteeIndex: 0,
+		})
+	}
+	return duties, nil
+}
+
+// AttestationData returns the attestation data of the given slot, which votes for the canonical blocks
+func (c *Chain) AttestationData(slot spec.Slot, committeeIndex spec.CommitteeIndex) (*spec.AttestationData, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if head := c.headSlot(); slot > head {
+		return nil, errors.Errorf("slot %d is after the head (%d)", slot, head)
+	}
+	epoch := spec.Epoch(c.network.EstimatedEpochAtSlot(types.Slot(slot)))
+	source := spec.Epoch(0)
+	if epoch > 0 {
+		source = epoch - 1
+	}
+	return &spec.AttestationData{
+		Slot:            slot,
+		Index:           committeeIndex,
+		BeaconBlockRoot: c.blockRoot(slot),
+		Source: &spec.Checkpoint{
+			Epoch: source,
+			Root:  c.blockRoot(c.epochStartSlot(source)),
+		},
+		Target: &spec.Checkpoint{
+			Epoch: epoch,
+			Root:  c.blockRoot(c.epochStartSlot(epoch)),
+		},
+	}, nil
+}
+
+// SubmitAttestation verifies the attestation and keeps it once,
+// the attestation must vote for a canonical block and be signed by the assigned validator
+func (c *Chain) SubmitAttestation(attestation *spec.Attestation) error {
+	if attestation == nil || attestation.Data == nil || attestation.Data.Target == nil {
+		return errors.New("missing attestation data")
+	}
+	data := attestation.Data
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if data.BeaconBlockRoot != c.blockRoot(data.Slot) {
+		return errors.Errorf("attestation votes for an unknown block in slot %d", data.Slot)
+	}
+	index := c.dutyValidator(data.Slot, data.Index)
+	if int(index) >= len(c.validators) {
+		return errors.Errorf("no validator is assigned to committee %d in slot %d", data.Index, data.Slot)
+	}
+	root, err := c.signingRoot(data)
+	if err != nil {
+		return err
+	}
+	pk := &bls.PublicKey{}
+	if err := pk.Deserialize(c.validators[index][:]); err != nil {
+		return errors.Wrap(err, "could not deserialize validator public key")
+	}
+	// the signature is copied as cgo doesn't accept a slice of a struct that holds pointers
+	rawSig := attestation.Signature
+	sig := &bls.Sign{}
+	if err := sig.Deserialize(rawSig[:]); err != nil {
+		return errors.Wrap(err, "could not deserialize attestation signature")
+	}
+	if !sig.VerifyByte(pk, root[:]) {
+		return errors.New("invalid attestation signature")
+	}
+
+	for _, att := range c.attestations[data.Slot] {
+		// all the operators submit the reconstructed attestation
+		if att.Data.Index == data.Index && att.Signature == attestation.Signature {
+			return nil
+		}
+	}
+	c.attestations[data.Slot] = append(c.attestations[data.Slot], attestation)
+	return nil
+}
+
+// chainStatus is the status of the chain, as reported by the control api
+type chainStatus struct {
+	HeadSlot     uint64 `json:"head_slot"`
+	FollowsClock bool   `json:"follows_clock"`
+	Reorgs       int    `json:"reorgs"`
+	Validators   int    `json:"validators"`
+}
+
+func (c *Chain) status() chainStatus {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return chainStatus{
+		HeadSlot:     uint64(c.headSlot()),
+		FollowsClock: c.head == nil,
+		Reorgs:       len(c.branches),
+		Validators:   len(c.validators),
+	}
+}
+
+// Domain returns the attester domain, as computed by the beacon api clients from the genesis and fork of the chain
+func (c *Chain) Domain() (spec.Domain, error) {
+	forkData := &spec.ForkData{GenesisValidatorsRoot: genesisValidatorsRoot}
+	copy(forkData.CurrentVersion[:], c.network.ForkVersion())
+	root, err := forkData.HashTreeRoot()
+	if err != nil {
+		return spec.Domain{}, errors.Wrap(err, "could not compute fork data root")
+	}
+	var domain spec.Domain
+	copy(domain[:], domainBeaconAttester[:])
+	copy(domain[4:], root[:])
+	return domain, nil
+}
+
+// signingRoot returns the root that is signed by the validator of the given data
+func (c *Chain) signingRoot(data *spec.AttestationData) ([32]byte, error) {
+	domain, err := c.Domain()
+	if err != nil {
+		return [32]byte{}, err
+	}
+	objRoot, err := data.HashTreeRoot()
+	if err != nil {
+		return [32]byte{}, errors.Wrap(err, "could not compute attestation data root")
+	}
+	return (&spec.SigningData{ObjectRoot: objRoot, Domain: domain}).HashTreeRoot()
+}
+
+func (c *Chain) headSlot() spec.Slot {
+	if c.head != nil {
+		return *c.head
+	}
+	return spec.Slot(c.network.EstimatedCurrentSlot())
+}
+
+// blockRoot returns the (fake) root of the block in the given slot, in the branch that the slot belongs to
+func (c *Chain) blockRoot(slot spec.Slot) spec.Root {
+	var branch uint64
+	for _, from := range c.branches {
+		if slot >= from {
+			branch++
+		}
+	}
+	data := make([]byte, 16)
+	binary.LittleEndian.PutUint64(data, uint64(slot))
+	binary.LittleEndian.PutUint64(data[8:], branch)
+	return sha256.Sum256(data)
+}
+
+func (c *Chain) validator(index spec.ValidatorIndex) *api.Validator {
+	return &api.Validator{
+		Index:   index,
+		Balance: validatorBalance,
+		Status:  api.ValidatorStateActiveOngoing,
+		Validator: &spec.Validator{
+			PublicKey:             c.validators[index],
+			WithdrawalCredentials: make([]byte, 32),
+			EffectiveBalance:      validatorBalance,
+			ExitEpoch:             farFutureEpoch,
+			WithdrawableEpoch:     farFutureEpoch,
+		},
+	}
+}
+
+// dutySlot returns the slot and committee of the validator in the given epoch
+func (c *Chain) dutySlot(epoch spec.Epoch, index spec.ValidatorIndex) (spec.Slot, spec.CommitteeIndex) {
+	slotsPerEpoch := c.network.SlotsPerEpoch()
+	slot := c.epochStartSlot(epoch) + spec.Slot(uint64(index)%slotsPerEpoch)
+	return slot, spec.CommitteeIndex(uint64(index) / slotsPerEpoch)
+}
+
+// dutyValidator returns the index of the validator that is assigned to the given slot and committee
+func (c *Chain) dutyValidator(slot spec.Slot, committeeIndex spec.CommitteeIndex) spec.ValidatorIndex {
+	slotsPerEpoch := c.network.SlotsPerEpoch()
+	return spec.ValidatorIndex(uint64(committeeIndex)*slotsPerEpoch + uint64(slot)%slotsPerEpoch)
+}
+
+func (c *Chain) committeesAtSlot() uint64 {
+	if len(c.validators) == 0 {
+		return 1
+	}
+	return uint64(len(c.validators)-1)/c.network.SlotsPerEpoch() + 1
+}
+
+func (c *Chain) epochStartSlot(epoch spec.Epoch) spec.Slot {
+	return spec.Slot(uint64(epoch) * c.network.SlotsPerEpoch())
+}
@@ -0,0 +1,385 @@
+package mockbeacon
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	api "github.com/attestantio/go-eth2-client/api/v1"
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/eth2-key-manager/core"
+	"go.uber.org/zap"
+
+	beaconprotocol "github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
+)
+
+// nodeVersion is the version that is reported by the mock beacon node
+const nodeVersion = "ssv-mockbeacon/v1"
+
+// beaconHandler serves the beacon api endpoints
+type beaconHandler struct {
+	logger *zap.Logger
+	chain  *Chain
+}
+
+func (h *beaconHandler) genesis(res http.ResponseWriter, req *http.Request) {
+	writeData(h.logger, res, h.chain.Genesis())
+}
+
+// spec returns the spec values that are used by the clients
+func (h *beaconHandler) spec(res http.ResponseWriter, req *http.Request) {
+	network := h.chain.network
+	writeData(h.logger, res, map[string]string{
+		"CONFIG_NAME":                string(network.Network),
+		"SECONDS_PER_SLOT":           fmt.Sprintf("%d", int64(network.SlotDurationSec().Seconds())),
+		"SLOTS_PER_EPOCH":            fmt.Sprintf("%d", network.SlotsPerEpoch()),
+		"MIN_GENESIS_TIME":           fmt.Sprintf("%d", network.MinGenesisTime()),
+		"GENESIS_FORK_VERSION":       fmt.Sprintf("%#x", network.ForkVersion()),
+		"DOMAIN_BEACON_PROPOSER":     "0x00000000",
+		"DOMAIN_BEACON_ATTESTER":     fmt.Sprintf("%#x", domainBeaconAttester[:]),
+		"DOMAIN_RANDAO":              "0x02000000",
+		"DOMAIN_DEPOSIT":             "0x03000000",
+		"DOMAIN_VOLUNTARY_EXIT":      "0x04000000",
+		"DOMAIN_SELECTION_PROOF":     "0x05000000",
+		"DOMAIN_AGGREGATE_AND_PROOF": "0x06000000",
+	})
+}
+
+func (h *beaconHandler) depositContract(res http.ResponseWriter, req *http.Request) {
+	address, err := hex.DecodeString(strings.TrimPrefix(h.chain.network.DepositContractAddress(), "0x"))
+	if err != nil {
+		writeError(res, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeData(h.logger, res, &api.DepositContract{ChainID: chainID(h.chain.network), Address: address})
+}
+
+func (h *beaconHandler) forkSchedule(res http.ResponseWriter, req *http.Request) {
+	writeData(h.logger, res, []*spec.Fork{h.chain.Fork()})
+}
+
+func (h *beaconHandler) fork(res http.ResponseWriter, req *http.Request) {
+	writeData(h.logger, res, h.chain.Fork())
+}
+
+func (h *beaconHandler) nodeVersion(res http.ResponseWriter, req *http.Request) {
+	writeData(h.logger, res, map[string]string{"version": nodeVersion})
+}
+
+func (h *beaconHandler) syncing(res http.ResponseWriter, req *http.Request) {
+	writeData(h.logger, res, h.chain.SyncState())
+}
+
+// validators returns the validators that match the given ids (?id=<pubkey or index>,...), or all of them
+func (h *beaconHandler) validators(res http.ResponseWriter, req *http.Request) {
+	validators := h.chain.Validators()
+	raw := req.URL.Query().Get("id")
+	if len(raw) == 0 {
+		writeData(h.logger, res, validators)
+		return
+	}
+	ids := make(map[string]bool)
+	for _, id := range strings.Split(raw, ",") {
+		ids[strings.ToLower(id)] = true
+	}
+	filtered := make([]*api.Validator, 0)
+	for _, v := range validators {
+		if ids[fmt.Sprintf("%d", v.Index)] || ids[fmt.Sprintf("%#x", v.Validator.PublicKey)] {
+			filtered = append(filtered, v)
+		}
+	}
+	writeData(h.logger, res, filtered)
+}
+
+// attesterDuties returns the duties of the validators in the body (["<index>",...]) in the epoch of the path
+func (h *beaconHandler) attesterDuties(res http.ResponseWriter, req *http.Request) {
+	epoch, err := strconv.ParseUint(strings.TrimPrefix(req.URL.Path, "/eth/v1/validator/duties/attester/"), 10, 64)
+	if err != nil {
+		writeError(res, http.StatusBadRequest, "invalid epoch")
+		return
+	}
+	var rawIndices []string
+	if err := json.NewDecoder(req.Body).Decode(&rawIndices); err != nil {
+		writeError(res, http.StatusBadRequest, "invalid validator indices")
+		return
+	}
+	indices := make([]spec.ValidatorIndex, 0, len(rawIndices))
+	for _, raw := range rawIndices {
+		index, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			writeError(res, http.StatusBadRequest, "invalid validator index")
+			return
+		}
+		indices = append(indices, spec.ValidatorIndex(index))
+	}
+	duties, err := h.chain.AttesterDuties(spec.Epoch(epoch), indices)
+	if err != nil {
+		writeError(res, http.StatusBadRequest, err.Error())
+		return
+	}
+	if duties == nil {
+		duties = []*api.AttesterDuty{}
+	}
+	writeData(h.logger, res, duties)
+}
+
+// attestationData returns the attestation data of the given slot and committee (?slot=<slot>&committee_index=<index>)
+func (h *beaconHandler) attestationData(res http.ResponseWriter, req *http.Request) {
+	slot, err := strconv.ParseUint(req.URL.Query().Get("slot"), 10, 64)
+	if err != nil {
+		writeError(res, http.StatusBadRequest, "invalid slot")
+		return
+	}
+	committeeIndex, err := strconv.ParseUint(req.URL.Query().Get("committee_index"), 10, 64)
+	if err != nil {
+		writeError(res, http.StatusBadRequest, "invalid committee index")
+		return
+	}
+	data, err := h.chain.AttestationData(spec.Slot(slot), spec.CommitteeIndex(committeeIndex))
+	if err != nil {
+		writeError(res, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeData(h.logger, res, data)
+}
+
+// submitAttestations verifies and keeps the attestations in the body, it fails if one of them is invalid
+func (h *beaconHandler) submitAttestations(res http.ResponseWriter, req *http.Request) {
+	var attestations []*spec.Attestation
+	if err := json.NewDecoder(req.Body).Decode(&attestations); err != nil {
+		writeError(res, http.StatusBadRequest, "invalid attestations")
+		return
+	}
+	var failures []string
+	for i, att := range attestations {
+		if err := h.chain.SubmitAttestation(att); err != nil {
+			h.logger.Debug("invalid attestation", zap.Error(err))
+			failures = append(failures, fmt.Sprintf("attestation %d: %s", i, err.Error()))
+			continue
+		}
+		h.logger.Info("attestation submitted", zap.Uint64("slot", uint64(att.Data.Slot)),
+			zap.Uint64("committeeIndex", uint64(att.Data.Index)))
+	}
+	if len(failures) > 0 {
+		writeError(res, http.StatusBadRequest, strings.Join(failures, ", "))
+		return
+	}
+	res.WriteHeader(http.StatusOK)
+}
+
+// committeeSubscriptions accepts the subscriptions, there are no subnets in the mock chain
+func (h *beaconHandler) committeeSubscriptions(res http.ResponseWriter, req *http.Request) {
+	var subscriptions []*api.BeaconCommitteeSubscription
+	if err := json.NewDecoder(req.Body).Decode(&subscriptions); err != nil {
+		writeError(res, http.StatusBadRequest, "invalid subscriptions")
+		return
+	}
+	res.WriteHeader(http.StatusOK)
+}
+
+// proposerPreparation accepts the fee recipients, there are no proposals in the mock chain
+func (h *beaconHandler) proposerPreparation(res http.ResponseWriter, req *http.Request) {
+	var preparations []*api.ProposalPreparation
+	if err := json.NewDecoder(req.Body).Decode(&preparations); err != nil {
+		writeError(res, http.StatusBadRequest, "invalid preparations")
+		return
+	}
+	res.WriteHeader(http.StatusOK)
+}
+
+// controlHandler serves the endpoints that script the mock beacon node (/mock/...)
+type controlHandler struct {
+	logger *zap.Logger
+	server *Server
+}
+
+// status returns the status of the chain
+func (h *controlHandler) status(res http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		writeError(res, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	h.writeStatus(res)
+}
+
+func (h *controlHandler) writeStatus(res http.ResponseWriter) {
+	writeJSON(h.logger, res, h.server.chain.status())
+}
+
+// head pins the head to a slot (POST ?slot=<slot>), or makes it follow the clock (POST ?follow=true)
+func (h *controlHandler) head(res http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		writeError(res, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	if req.URL.Query().Get("follow") == "true" {
+		h.server.chain.FollowClock()
+		h.logger.Info("head follows the clock")
+		h.writeStatus(res)
+		return
+	}
+	slot, err := strconv.ParseUint(req.URL.Query().Get("slot"), 10, 64)
+	if err != nil {
+		writeError(res, http.StatusBadRequest, "invalid slot")
+		return
+	}
+	h.server.chain.SetHead(spec.Slot(slot))
+	h.logger.Info("head was set", zap.Uint64("slot", slot))
+	h.writeStatus(res)
+}
+
+// advance advances the head by the given number of slots (POST ?slots=<n>, 1 by default)
+func (h *controlHandler) advance(res http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		writeError(res, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	slots := uint64(1)
+	if raw := req.URL.Query().Get("slots"); len(raw) > 0 {
+		var err error
+		if slots, err = strconv.ParseUint(raw, 10, 64); err != nil {
+			writeError(res, http.StatusBadRequest, "invalid slots")
+			return
+		}
+	}
+	head := h.server.chain.Advance(slots)
+	h.logger.Info("head was advanced", zap.Uint64("slot", uint64(head)))
+	h.writeStatus(res)
+}
+
+// reorg replaces the blocks of the last slots (POST ?depth=<n>, 1 by default)
+func (h *controlHandler) reorg(res http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		writeError(res, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	depth := uint64(1)
+	if raw := req.URL.Query().Get("depth"); len(raw) > 0 {
+		var err error
+		if depth, err = strconv.ParseUint(raw, 10, 64); err != nil {
+			writeError(res, http.StatusBadRequest, "invalid depth")
+			return
+		}
+	}
+	from := h.server.chain.Reorg(depth)
+	h.logger.Info("chain was reorged", zap.Uint64("fromSlot", uint64(from)))
+	writeJSON(h.logger, res, map[string]uint64{"from_slot": uint64(from)})
+}
+
+// addValidator activates a validator (POST ?pubkey=<hex>)
+func (h *controlHandler) addValidator(res http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		writeError(res, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	pk, err := parsePubKey(req.URL.Query().Get("pubkey"))
+	if err != nil {
+		writeError(res, http.StatusBadRequest, err.Error())
+		return
+	}
+	index := h.server.chain.AddValidator(pk)
+	h.logger.Info("validator was added", zap.String("pubkey", fmt.Sprintf("%x", pk)), zap.Uint64("index", uint64(index)))
+	writeJSON(h.logger, res, map[string]uint64{"index": uint64(index)})
+}
+
+// attestations returns the attestations of a slot (GET ?slot=<slot>)
+func (h *controlHandler) attestations(res http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		writeError(res, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	slot, err := strconv.ParseUint(req.URL.Query().Get("slot"), 10, 64)
+	if err != nil {
+		writeError(res, http.StatusBadRequest, "invalid slot")
+		return
+	}
+	writeData(h.logger, res, h.server.chain.Attestations(spec.Slot(slot)))
+}
+
+// faults injects a fault (POST ?endpoint=<name>&delay=<duration>&status=<code>&count=<n>) or clears all faults (DELETE)
+func (h *controlHandler) faults(res http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodPost:
+	case http.MethodDelete:
+		h.server.ClearFaults()
+		h.logger.Info("faults were cleared")
+		res.WriteHeader(http.StatusOK)
+		return
+	default:
+		writeError(res, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	q := req.URL.Query()
+	endpoint := Endpoint(q.Get("endpoint"))
+	if len(endpoint) == 0 {
+		writeError(res, http.StatusBadRequest, "missing endpoint")
+		return
+	}
+	fault := Fault{}
+	var err error
+	if raw := q.Get("delay"); len(raw) > 0 {
+		if fault.Delay, err = time.ParseDuration(raw); err != nil {
+			writeError(res, http.StatusBadRequest, "invalid delay")
+			return
+		}
+	}
+	if raw := q.Get("status"); len(raw) > 0 {
+		if fault.Status, err = strconv.Atoi(raw); err != nil {
+			writeError(res, http.StatusBadRequest, "invalid status")
+			return
+		}
+	}
+	if raw := q.Get("count"); len(raw) > 0 {
+		if fault.Count, err = strconv.Atoi(raw); err != nil {
+			writeError(res, http.StatusBadRequest, "invalid count")
+			return
+		}
+	}
+	h.server.Inject(endpoint, fault)
+	h.logger.Info("fault was injected", zap.String("endpoint", string(endpoint)),
+		zap.Duration("delay", fault.Delay), zap.Int("status", fault.Status), zap.Int("count", fault.Count))
+	res.WriteHeader(http.StatusOK)
+}
+
+// parsePubKey parses a hex encoded validator public key
+func parsePubKey(raw string) (spec.BLSPubKey, error) {
+	var pk spec.BLSPubKey
+	b, err := hex.DecodeString(strings.TrimPrefix(raw, "0x"))
+	if err != nil || len(b) != len(pk) {
+		return pk, fmt.Errorf("invalid public key %q", raw)
+	}
+	copy(pk[:], b)
+	return pk, nil
+}
+
+// chainID returns the chain id of the eth1 network of the given network
+func chainID(network beaconprotocol.Network) uint64 {
+	if network.Network == core.MainNetwork {
+		return 1
+	}
+	// the testnets are on goerli
+	return 5
+}
+
+// writeData writes the given data as a beacon api response ({"data": ...})
+func writeData(logger *zap.Logger, res http.ResponseWriter, data interface{}) {
+	writeJSON(logger, res, map[string]interface{}{"data": data})
+}
+
+func writeJSON(logger *zap.Logger, res http.ResponseWriter, v interface{}) {
+	res.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(res).Encode(v); err != nil {
+		logger.Warn("could not write response", zap.Error(err))
+	}
+}
+
+// writeError writes an error in the format of the beacon api
+func writeError(res http.ResponseWriter, status int, message string) {
+	res.Header().Set("Content-Type", "application/json")
+	res.WriteHeader(status)
+	_ = json.NewEncoder(res).Encode(map[string]interface{}{"code": status, "message": message})
+}
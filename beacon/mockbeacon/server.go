@@ -0,0 +1,234 @@
+// Package mockbeacon is a mock beacon node that serves the subset of the Beacon API that is used by the node (see beacon/goclient).
+// the chain is simulated (see Chain) and can be scripted, while endpoints can be slowed down or fail on demand (see Fault),
+// so the node can be tested end to end through its http client.
+package mockbeacon
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	beaconprotocol "github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
+)
+
+// Endpoint is the name of a served endpoint, used to inject faults
+type Endpoint string
+
+// Endpoints that are served by the mock beacon node
+const (
+	EndpointGenesis                Endpoint = "genesis"
+	EndpointSpec                   Endpoint = "spec"
+	EndpointDepositContract        Endpoint = "deposit_contract"
+	EndpointForkSchedule           Endpoint = "fork_schedule"
+	EndpointFork                   Endpoint = "fork"
+	EndpointNodeVersion            Endpoint = "node_version"
+	EndpointSyncing                Endpoint = "syncing"
+	EndpointValidators             Endpoint = "validators"
+	EndpointAttesterDuties         Endpoint = "attester_duties"
+	EndpointAttestationData        Endpoint = "attestation_data"
+	EndpointSubmitAttestations     Endpoint = "submit_attestations"
+	EndpointCommitteeSubscriptions Endpoint = "committee_subscriptions"
+	EndpointProposerPreparation    Endpoint = "proposer_preparation"
+)
+
+// Fault is a misbehaviour that is injected into an endpoint
+type Fault struct {
+	// Delay delays the responses
+	Delay time.Duration
+	// Status is the status code of the injected errors, no errors are injected if zero
+	Status int
+	// Count is the number of requests that are affected, zero means all requests until the faults are cleared
+	Count int
+}
+
+// Options are the options of the mock beacon node
+type Options struct {
+	Logger  *zap.Logger
+	Network beaconprotocol.Network
+	// Addr is the tcp address to listen on (e.g. 127.0.0.1:5052), a free port is picked if the port is 0
+	Addr string
+}
+
+// Server is a mock beacon node
+type Server struct {
+	logger *zap.Logger
+	addr   string
+	chain  *Chain
+	mux    *http.ServeMux
+
+	lock     sync.Mutex
+	faults   map[Endpoint]*Fault
+	requests map[Endpoint]int
+
+	listener net.Listener
+	server   *http.Server
+}
+
+// New creates a new mock beacon node
+func New(opts Options) *Server {
+	s := &Server{
+		logger:   opts.Logger.With(zap.String("component", "mockbeacon")),
+		addr:     opts.Addr,
+		chain:    NewChain(opts.Network),
+		mux:      http.NewServeMux(),
+		faults:   make(map[Endpoint]*Fault),
+		requests: make(map[Endpoint]int),
+	}
+	h := &beaconHandler{logger: s.logger, chain: s.chain}
+	s.mux.HandleFunc("/eth/v1/beacon/genesis", s.route(EndpointGenesis, http.MethodGet, h.genesis))
+	s.mux.HandleFunc("/eth/v1/config/spec", s.route(EndpointSpec, http.MethodGet, h.spec))
+	s.mux.HandleFunc("/eth/v1/config/deposit_contract", s.route(EndpointDepositContract, http.MethodGet, h.depositContract))
+	s.mux.HandleFunc("/eth/v1/config/fork_schedule", s.route(EndpointForkSchedule, http.MethodGet, h.forkSchedule))
+	s.mux.HandleFunc("/eth/v1/node/version", s.route(EndpointNodeVersion, http.MethodGet, h.nodeVersion))
+	s.mux.HandleFunc("/eth/v1/node/syncing", s.route(EndpointSyncing, http.MethodGet, h.syncing))
+	s.mux.HandleFunc("/eth/v1/beacon/states/", s.states(h))
+	s.mux.HandleFunc("/eth/v1/validator/duties/attester/", s.route(EndpointAttesterDuties, http.MethodPost, h.attesterDuties))
+	s.mux.HandleFunc("/eth/v1/validator/attestation_data", s.route(EndpointAttestationData, http.MethodGet, h.attestationData))
+	s.mux.HandleFunc("/eth/v1/beacon/pool/attestations", s.route(EndpointSubmitAttestations, http.MethodPost, h.submitAttestations))
+	s.mux.HandleFunc("/eth/v1/validator/beacon_committee_subscriptions", s.route(EndpointCommitteeSubscriptions, http.MethodPost, h.committeeSubscriptions))
+	s.mux.HandleFunc("/eth/v1/validator/prepare_beacon_proposer", s.route(EndpointProposerPreparation, http.MethodPost, h.proposerPreparation))
+
+	c := &controlHandler{logger: s.logger, server: s}
+	s.mux.HandleFunc("/mock/chain", c.status)
+	s.mux.HandleFunc("/mock/head", c.head)
+	s.mux.HandleFunc("/mock/advance", c.advance)
+	s.mux.HandleFunc("/mock/reorg", c.reorg)
+	s.mux.HandleFunc("/mock/validators", c.addValidator)
+	s.mux.HandleFunc("/mock/attestations", c.attestations)
+	s.mux.HandleFunc("/mock/faults", c.faults)
+	return s
+}
+
+// Start starts to listen to incoming requests
+func (s *Server) Start() error {
+	listener, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return errors.Wrap(err, "could not listen")
+	}
+	s.listener = listener
+	s.server = &http.Server{Handler: s.mux}
+	s.logger.Info("starting mock beacon node", zap.String("addr", s.Address()))
+	go func() {
+		if err := s.server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			s.logger.Error("failed to serve mock beacon node", zap.Error(err))
+		}
+	}()
+	return nil
+}
+
+// Address returns the address that the server listens on
+func (s *Server) Address() string {
+	if s.listener == nil {
+		return s.addr
+	}
+	return s.listener.Addr().String()
+}
+
+// Close stops the server
+func (s *Server) Close() error {
+	if s.server == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	return s.server.Shutdown(ctx)
+}
+
+// Handler returns the http handler of the server
+func (s *Server) Handler() http.Handler {
+	return s.mux
+}
+
+// Chain returns the chain of the mock beacon node
+func (s *Server) Chain() *Chain {
+	return s.chain
+}
+
+// Inject injects the given fault into the endpoint, it replaces an existing fault of the endpoint
+func (s *Server) Inject(endpoint Endpoint, fault Fault) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.faults[endpoint] = &fault
+}
+
+// ClearFaults removes all the injected faults
+func (s *Server) ClearFaults() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.faults = make(map[Endpoint]*Fault)
+}
+
+// Requests returns the number of requests that were made to the given endpoint
+func (s *Server) Requests(endpoint Endpoint) int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.requests[endpoint]
+}
+
+// route wraps the handler of an endpoint with the injected faults
+func (s *Server) route(endpoint Endpoint, method string, handler http.HandlerFunc) http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
+		if req.Method != method {
+			writeError(res, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		fault := s.nextFault(endpoint)
+		if fault.Delay > 0 {
+			select {
+			case <-time.After(fault.Delay):
+			case <-req.Context().Done():
+				return
+			}
+		}
+		if fault.Status != 0 {
+			s.logger.Debug("injecting error", zap.String("endpoint", string(endpoint)), zap.Int("status", fault.Status))
+			writeError(res, fault.Status, "injected error")
+			return
+		}
+		handler(res, req)
+	}
+}
+
+// states routes the state endpoints (/eth/v1/beacon/states/{state_id}/...), there is a single state in the mock chain
+func (s *Server) states(h *beaconHandler) http.HandlerFunc {
+	fork := s.route(EndpointFork, http.MethodGet, h.fork)
+	validators := s.route(EndpointValidators, http.MethodGet, h.validators)
+	return func(res http.ResponseWriter, req *http.Request) {
+		switch {
+		case strings.HasSuffix(req.URL.Path, "/fork"):
+			fork(res, req)
+		case strings.HasSuffix(req.URL.Path, "/validators"):
+			validators(res, req)
+		default:
+			writeError(res, http.StatusNotFound, "not found")
+		}
+	}
+}
+
+// nextFault counts the request and returns the fault that applies to it
+func (s *Server) nextFault(endpoint Endpoint) Fault {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.requests[endpoint]++
+	fault, ok := s.faults[endpoint]
+	if !ok {
+		return Fault{}
+	}
+	if fault.Count > 0 {
+		fault.Count--
+		if fault.Count == 0 {
+			delete(s.faults, endpoint)
+		}
+	}
+	return *fault
+}
@@ -0,0 +1,200 @@
+package mockbeacon
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/eth2-key-manager/core"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/beacon/goclient"
+	beaconprotocol "github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
+	"github.com/bloxapp/ssv/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+func newTestServer(t *testing.T) *Server {
+	s := New(Options{
+		Logger:  zap.L(),
+		Network: beaconprotocol.NewNetwork(core.PraterNetwork),
+		Addr:    "127.0.0.1:0",
+	})
+	require.NoError(t, s.Start())
+	t.Cleanup(func() {
+		_ = s.Close()
+	})
+	return s
+}
+
+func newTestClient(t *testing.T, ctx context.Context, s *Server) beaconprotocol.Beacon {
+	db, err := storage.GetStorageFactory(basedb.Options{
+		Type:   "badger-memory",
+		Logger: zap.L(),
+		Ctx:    ctx,
+	})
+	require.NoError(t, err)
+	t.Cleanup(db.Close)
+	client, err := goclient.New(beaconprotocol.Options{
+		Context:        ctx,
+		Logger:         zap.L(),
+		Network:        string(core.PraterNetwork),
+		BeaconNodeAddr: s.Address(),
+		DB:             db,
+	})
+	require.NoError(t, err)
+	return client
+}
+
+func newValidator(t *testing.T, s *Server) (*bls.SecretKey, spec.ValidatorIndex) {
+	require.NoError(t, bls.Init(bls.BLS12_381))
+	sk := &bls.SecretKey{}
+	sk.SetByCSPRNG()
+	var pk spec.BLSPubKey
+	copy(pk[:], sk.GetPublicKey().Serialize())
+	return sk, s.Chain().AddValidator(pk)
+}
+
+func TestServer_DutyFlow(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s := newTestServer(t)
+	client := newTestClient(t, ctx, s)
+	sk, index := newValidator(t, s)
+	require.NoError(t, client.AddShare(sk))
+
+	var pk spec.BLSPubKey
+	copy(pk[:], sk.GetPublicKey().Serialize())
+	validators, err := client.GetValidatorData([]spec.BLSPubKey{pk})
+	require.NoError(t, err)
+	require.Len(t, validators, 1)
+	require.True(t, validators[index].Status.IsActive())
+
+	// a past epoch, so the attestation data is available without waiting for the slot
+	epoch := spec.Epoch(s.Chain().network.EstimatedCurrentEpoch()) - 1
+	duties, err := client.GetDuties(epoch, []spec.ValidatorIndex{index})
+	require.NoError(t, err)
+	require.Len(t, duties, 1)
+	duty := duties[0]
+	require.Equal(t, index, duty.ValidatorIndex)
+
+	require.NoError(t, client.SubscribeToCommitteeSubnet(nil))
+	require.Equal(t, 1, s.Requests(EndpointCommitteeSubscriptions))
+
+	data, err := client.GetAttestationData(duty.Slot, duty.CommitteeIndex)
+	require.NoError(t, err)
+	require.Equal(t, s.Chain().BlockRoot(duty.Slot), data.BeaconBlockRoot)
+	att, _, err := client.SignAttestation(data, duty, sk.GetPublicKey().Serialize())
+	require.NoError(t, err)
+	require.NoError(t, client.SubmitAttestation(att))
+	require.Len(t, s.Chain().Attestations(duty.Slot), 1)
+
+	// the attestation is dropped once its block is orphaned, and can't be submitted again
+	s.Chain().SetHead(duty.Slot)
+	require.Equal(t, duty.Slot, s.Chain().Reorg(1))
+	require.Len(t, s.Chain().Attestations(duty.Slot), 0)
+	require.Error(t, client.SubmitAttestation(att))
+}
+
+func TestServer_Faults(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s := newTestServer(t)
+	client := newTestClient(t, ctx, s)
+	_, index := newValidator(t, s)
+	epoch := spec.Epoch(s.Chain().network.EstimatedCurrentEpoch())
+
+	s.Inject(EndpointAttesterDuties, Fault{Status: http.StatusServiceUnavailable, Count: 1})
+	_, err := client.GetDuties(epoch, []spec.ValidatorIndex{index})
+	require.Error(t, err)
+	duties, err := client.GetDuties(epoch, []spec.ValidatorIndex{index})
+	require.NoError(t, err)
+	require.Len(t, duties, 1)
+
+	s.Inject(EndpointAttesterDuties, Fault{Delay: 200 * time.Millisecond})
+	for i := 0; i < 2; i++ {
+		start := time.Now()
+		_, err = client.GetDuties(epoch, []spec.ValidatorIndex{index})
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, time.Since(start), 200*time.Millisecond)
+	}
+	s.ClearFaults()
+	require.Equal(t, 4, s.Requests(EndpointAttesterDuties))
+}
+
+func TestChain_Progression(t *testing.T) {
+	network := beaconprotocol.NewNetwork(core.PraterNetwork)
+	c := NewChain(network)
+	clock := spec.Slot(network.EstimatedCurrentSlot())
+	require.GreaterOrEqual(t, c.HeadSlot(), clock)
+	require.False(t, c.SyncState().IsSyncing)
+
+	head := clock - 10
+	c.SetHead(head)
+	require.True(t, c.SyncState().IsSyncing)
+	_, err := c.AttestationData(head+1, 0)
+	require.Error(t, err)
+	require.Equal(t, head+1, c.Advance(1))
+	data, err := c.AttestationData(head+1, 0)
+	require.NoError(t, err)
+
+	// the blocks of the reorged slots are replaced, older blocks are kept
+	before := c.BlockRoot(head)
+	require.Equal(t, head, c.Reorg(2))
+	require.NotEqual(t, before, c.BlockRoot(head))
+	require.Equal(t, data.Source.Root, c.BlockRoot(c.epochStartSlot(data.Source.Epoch)))
+	reorged, err := c.AttestationData(head+1, 0)
+	require.NoError(t, err)
+	require.NotEqual(t, data.BeaconBlockRoot, reorged.BeaconBlockRoot)
+
+	c.FollowClock()
+	require.GreaterOrEqual(t, c.HeadSlot(), clock)
+}
+
+func TestServer_Control(t *testing.T) {
+	s := New(Options{Logger: zap.L(), Network: beaconprotocol.NewNetwork(core.PraterNetwork)})
+
+	do := func(method, target string) (int, map[string]interface{}) {
+		rec := httptest.NewRecorder()
+		s.Handler().ServeHTTP(rec, httptest.NewRequest(method, target, nil))
+		res := make(map[string]interface{})
+		_ = json.Unmarshal(rec.Body.Bytes(), &res)
+		return rec.Code, res
+	}
+
+	code, res := do(http.MethodPost, "/mock/head?slot=100")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, float64(100), res["head_slot"])
+	require.Equal(t, false, res["follows_clock"])
+	_, res = do(http.MethodPost, "/mock/advance?slots=2")
+	require.Equal(t, float64(102), res["head_slot"])
+	_, res = do(http.MethodPost, "/mock/reorg?depth=3")
+	require.Equal(t, float64(100), res["from_slot"])
+
+	code, _ = do(http.MethodPost, "/mock/validators?pubkey=0x1234")
+	require.Equal(t, http.StatusBadRequest, code)
+	_, res = do(http.MethodPost, "/mock/validators?pubkey=0x"+strings.Repeat("ab", 48))
+	require.Equal(t, float64(0), res["index"])
+
+	code, _ = do(http.MethodPost, "/mock/faults?endpoint=genesis&status=500&count=1")
+	require.Equal(t, http.StatusOK, code)
+	code, _ = do(http.MethodGet, "/eth/v1/beacon/genesis")
+	require.Equal(t, http.StatusInternalServerError, code)
+	code, res = do(http.MethodGet, "/eth/v1/beacon/genesis")
+	require.Equal(t, http.StatusOK, code)
+	require.NotNil(t, res["data"])
+
+	_, res = do(http.MethodGet, "/mock/chain")
+	require.Equal(t, float64(1), res["reorgs"])
+	require.Equal(t, float64(1), res["validators"])
+	do(http.MethodPost, "/mock/head?follow=true")
+	_, res = do(http.MethodGet, "/mock/chain")
+	require.Equal(t, true, res["follows_clock"])
+}
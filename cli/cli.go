@@ -7,7 +7,12 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/bloxapp/ssv/cli/bootnode"
+	"github.com/bloxapp/ssv/cli/db"
+	"github.com/bloxapp/ssv/cli/devnet"
+	"github.com/bloxapp/ssv/cli/journal"
+	"github.com/bloxapp/ssv/cli/mockbeacon"
 	"github.com/bloxapp/ssv/cli/operator"
+	"github.com/bloxapp/ssv/cli/registry"
 )
 
 // Logger is the default logger
@@ -35,4 +40,10 @@ func Execute(appName, version string) {
 func init() {
 	RootCmd.AddCommand(bootnode.StartBootNodeCmd)
 	RootCmd.AddCommand(operator.StartNodeCmd)
+	RootCmd.AddCommand(registry.RegistryCmd)
+	RootCmd.AddCommand(db.DBCmd)
+	RootCmd.AddCommand(db.MigrationsCmd)
+	RootCmd.AddCommand(journal.JournalCmd)
+	RootCmd.AddCommand(devnet.DevnetCmd)
+	RootCmd.AddCommand(mockbeacon.MockBeaconCmd)
 }
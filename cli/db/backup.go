@@ -0,0 +1,175 @@
+package db
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/beacon/goclient/ekm"
+	"github.com/bloxapp/ssv/cli/flags"
+	"github.com/bloxapp/ssv/operator/admin"
+	"github.com/bloxapp/ssv/storage"
+	"github.com/bloxapp/ssv/storage/backup"
+)
+
+// BackupCmd is the command to back up the node db
+var BackupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Writes a backup of the node db into a file, either from the configured db (node is stopped) or from a running node",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger := setup(cmd)
+		file, err := flags.GetBackupFileFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get file flag value", zap.Error(err))
+		}
+		include, err := flags.GetBackupCollectionsFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get collections flag value", zap.Error(err))
+		}
+		exclude, err := flags.GetBackupExcludeFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get exclude flag value", zap.Error(err))
+		}
+		nodeURL, err := flags.GetBackupNodeURLFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get node url flag value", zap.Error(err))
+		}
+		collections, err := backup.SelectCollections(backup.ParseList(include), backup.ParseList(exclude))
+		if err != nil {
+			logger.Fatal("invalid collections", zap.Error(err))
+		}
+
+		// writing into a temp file that is renamed once verified
+		tmpFile := file + ".tmp"
+		if len(nodeURL) > 0 {
+			err = downloadBackup(nodeURL, collections, tmpFile)
+		} else {
+			err = writeBackup(collections, tmpFile)
+		}
+		if err != nil {
+			_ = os.Remove(tmpFile)
+			logger.Fatal("failed to write backup", zap.Error(err))
+		}
+		header, summary, err := verifyBackup(tmpFile)
+		if err != nil {
+			_ = os.Remove(tmpFile)
+			logger.Fatal("failed to verify backup", zap.Error(err))
+		}
+		if err := os.Rename(tmpFile, file); err != nil {
+			logger.Fatal("failed to rename backup file", zap.Error(err))
+		}
+		logger.Info("db backup was written", zap.String("file", file), zap.Time("createdAt", header.CreatedAt),
+			zap.Any("items", summary))
+	},
+}
+
+// RestoreCmd is the command to restore the node db from a backup
+var RestoreCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Restores the node db (as configured) from a backup file, the node must be stopped. slashing protection data is never replaced by older data",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger := setup(cmd)
+		file, err := flags.GetBackupFileFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get file flag value", zap.Error(err))
+		}
+		include, err := flags.GetBackupCollectionsFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get collections flag value", zap.Error(err))
+		}
+		cfg.DBOptions.GCInterval = 0
+		db, err := storage.GetStorageFactory(cfg.DBOptions)
+		if err != nil {
+			logger.Fatal("failed to open db", zap.Error(err))
+		}
+		defer db.Close()
+
+		summary, err := backup.Restore(db, func() (io.ReadCloser, error) {
+			return os.Open(filepath.Clean(file))
+		}, backup.RestoreOptions{
+			Collections: backup.ParseList(include),
+			Guards:      []backup.Guard{ekm.SlashingProtectionGuard{}},
+		})
+		if err != nil {
+			logger.Fatal("failed to restore db", zap.Error(err))
+		}
+		logger.Info("db was restored", zap.String("file", file), zap.Any("items", summary))
+	},
+}
+
+// writeBackup writes a backup of the configured db
+func writeBackup(collections []string, file string) error {
+	cfg.DBOptions.GCInterval = 0
+	db, err := storage.GetStorageFactory(cfg.DBOptions)
+	if err != nil {
+		return errors.Wrap(err, "failed to open db")
+	}
+	defer db.Close()
+	f, err := os.OpenFile(filepath.Clean(file), os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := backup.Write(db, f, collections); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// downloadBackup downloads a backup from the admin api of a running node
+func downloadBackup(nodeURL string, collections []string, file string) error {
+	client, baseURL := admin.NewHTTPClient(nodeURL)
+	u, err := url.Parse(baseURL + "/db/backup")
+	if err != nil {
+		return errors.Wrap(err, "invalid node url")
+	}
+	u.RawQuery = url.Values{"collections": []string{strings.Join(collections, ",")}}.Encode()
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return errors.Wrap(err, "could not create backup request")
+	}
+	admin.Authorize(req, cfg.AdminAPIToken)
+	res, err := client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "could not request backup")
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+	if res.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(io.LimitReader(res.Body, 1024))
+		return errors.Errorf("backup request failed (%d): %s", res.StatusCode, strings.TrimSpace(string(body)))
+	}
+	f, err := os.OpenFile(filepath.Clean(file), os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, res.Body); err != nil {
+		_ = f.Close()
+		return errors.Wrap(err, "could not download backup")
+	}
+	return f.Close()
+}
+
+func verifyBackup(file string) (backup.Header, backup.Summary, error) {
+	f, err := os.Open(filepath.Clean(file))
+	if err != nil {
+		return backup.Header{}, nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	header, summary, err := backup.Verify(f)
+	if err != nil {
+		return header, summary, errors.Wrap(err, "invalid backup")
+	}
+	return header, summary, nil
+}
@@ -0,0 +1,142 @@
+package db
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/ilyakaznacheev/cleanenv"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	global_config "github.com/bloxapp/ssv/cli/config"
+	"github.com/bloxapp/ssv/cli/flags"
+	"github.com/bloxapp/ssv/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/utils/commons"
+	"github.com/bloxapp/ssv/utils/logex"
+)
+
+// config is the subset of the node config that is needed to access the db
+type config struct {
+	global_config.GlobalConfig `yaml:"global"`
+	DBOptions                  basedb.Options `yaml:"db"`
+	AdminAPIToken              string         `yaml:"AdminAPIToken" env:"ADMIN_API_TOKEN" env-description:"token of the admin api of a running node"`
+}
+
+var cfg config
+
+var globalArgs global_config.Args
+
+// DBCmd is the parent command of the db maintenance commands
+var DBCmd = &cobra.Command{
+	Use:   "db",
+	Short: "Maintenance commands of the node db",
+}
+
+// ConvertCmd is the command to copy the node db into another storage engine
+var ConvertCmd = &cobra.Command{
+	Use:   "convert",
+	Short: "Copies the node db (as configured) into a new db of another type, e.g. badger-db into bbolt-db",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger := setup(cmd)
+		toType, err := flags.GetDBTypeFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get db type flag value", zap.Error(err))
+		}
+		toPath, err := flags.GetDBPathFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get db path flag value", zap.Error(err))
+		}
+
+		src, err := storage.GetStorageFactory(cfg.DBOptions)
+		if err != nil {
+			logger.Fatal("failed to open source db", zap.Error(err))
+		}
+		defer src.Close()
+		dst, err := storage.GetStorageFactory(basedb.Options{
+			Type:   toType,
+			Path:   toPath,
+			Logger: logger,
+			Ctx:    cmd.Context(),
+		})
+		if err != nil {
+			logger.Fatal("failed to open target db", zap.Error(err))
+		}
+		defer dst.Close()
+
+		n, err := storage.Copy(src, dst)
+		if err != nil {
+			logger.Fatal("failed to convert db", zap.Int("copied", n), zap.Error(err))
+		}
+		logger.Info("db was converted, update the db type and path in the node config to use it",
+			zap.String("type", toType), zap.String("path", toPath), zap.Int("items", n))
+	},
+}
+
+// CompactCmd is the command to reclaim the unused disk space of the node db
+var CompactCmd = &cobra.Command{
+	Use:   "compact",
+	Short: "Compacts the node db (as configured) in order to reclaim unused disk space, the node must be stopped",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger := setup(cmd)
+		// maintenance loop is not needed as the db is compacted explicitly
+		cfg.DBOptions.GCInterval = 0
+
+		db, err := storage.GetStorageFactory(cfg.DBOptions)
+		if err != nil {
+			logger.Fatal("failed to open db", zap.Error(err))
+		}
+		defer db.Close()
+		compactor, ok := db.(basedb.Compactor)
+		if !ok {
+			logger.Fatal("db type does not support compaction", zap.String("type", cfg.DBOptions.Type))
+		}
+		start := time.Now()
+		if err := compactor.Compact(); err != nil {
+			logger.Fatal("failed to compact db", zap.Error(err))
+		}
+		logger.Info("db was compacted", zap.String("type", cfg.DBOptions.Type),
+			zap.Duration("took", time.Since(start)))
+	},
+}
+
+// setup reads the config and builds the logger
+func setup(cmd *cobra.Command) *zap.Logger {
+	if err := cleanenv.ReadConfig(globalArgs.ConfigPath, &cfg); err != nil {
+		log.Fatalf("could not read config %s", err)
+	}
+	commons.SetBuildData(cmd.Root().Short, cmd.Root().Version)
+	loggerLevel, errLogLevel := logex.GetLoggerLevelValue(cfg.LogLevel)
+	logger := logex.Build(commons.GetBuildData(), loggerLevel, &logex.EncodingConfig{
+		Format:       cfg.GlobalConfig.LogFormat,
+		LevelEncoder: logex.LevelEncoder([]byte(cfg.LogLevelFormat)),
+	})
+	if errLogLevel != nil {
+		logger.Warn(fmt.Sprintf("Default log level set to %s", loggerLevel), zap.Error(errLogLevel))
+	}
+
+	cfg.DBOptions.Logger = logger
+	cfg.DBOptions.Ctx = cmd.Context()
+	return logger
+}
+
+func init() {
+	global_config.ProcessArgs(&cfg, &globalArgs, DBCmd)
+	flags.AddDBTypeFlag(ConvertCmd)
+	flags.AddDBPathFlag(ConvertCmd)
+	flags.AddBackupFileFlag(BackupCmd)
+	flags.AddBackupCollectionsFlag(BackupCmd)
+	flags.AddBackupExcludeFlag(BackupCmd)
+	flags.AddBackupNodeURLFlag(BackupCmd)
+	flags.AddBackupFileFlag(RestoreCmd)
+	flags.AddBackupCollectionsFlag(RestoreCmd)
+	DBCmd.AddCommand(ConvertCmd, CompactCmd, BackupCmd, RestoreCmd)
+
+	global_config.ProcessArgs(&cfg, &globalArgs, MigrationsCmd)
+	flags.AddMigrationsDryRunFlag(MigrationsUpCmd)
+	flags.AddMigrationsVersionFlag(MigrationsUpCmd, "Target schema version, latest if not set")
+	flags.AddMigrationsDryRunFlag(MigrationsDownCmd)
+	flags.AddMigrationsVersionFlag(MigrationsDownCmd, "Target schema version, previous version if not set")
+	MigrationsCmd.AddCommand(MigrationsStatusCmd, MigrationsUpCmd, MigrationsDownCmd)
+}
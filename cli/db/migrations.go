@@ -0,0 +1,137 @@
+package db
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/cli/flags"
+	"github.com/bloxapp/ssv/migrations"
+	"github.com/bloxapp/ssv/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+// MigrationsCmd is the parent command of the db migrations commands
+var MigrationsCmd = &cobra.Command{
+	Use:   "migrations",
+	Short: "Reports, applies or reverts the db migrations (schema versions), the node must be stopped",
+}
+
+// MigrationsStatusCmd is the command to report the schema version of the node db
+var MigrationsStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Reports the schema version of the node db (as configured) and the known migrations",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger := setup(cmd)
+		db := openMigrationsDb(logger)
+		defer db.Close()
+
+		status, err := migrations.GetStatus(migrationOptions(logger, db, false))
+		if err != nil {
+			logger.Fatal("failed to get migrations status", zap.Error(err))
+		}
+		for _, m := range status.Migrations {
+			logger.Info("migration", zap.Int("version", m.Version), zap.String("name", m.Name),
+				zap.Bool("applied", m.Applied), zap.Bool("reversible", m.Reversible))
+		}
+		logger.Info("db schema version", zap.Int("version", status.Version), zap.Int("latest", status.Latest))
+	},
+}
+
+// MigrationsUpCmd is the command to apply migrations
+var MigrationsUpCmd = &cobra.Command{
+	Use:   "up",
+	Short: "Applies the migrations up to the given schema version (latest by default)",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger := setup(cmd)
+		dryRun, target, hasTarget := migrationFlags(cmd, logger)
+		db := openMigrationsDb(logger)
+		defer db.Close()
+
+		opts := migrationOptions(logger, db, dryRun)
+		if !hasTarget {
+			status, err := migrations.GetStatus(opts)
+			if err != nil {
+				logger.Fatal("failed to get migrations status", zap.Error(err))
+			}
+			target = status.Latest
+		}
+		report, err := migrations.Up(cmd.Context(), opts, target)
+		if err != nil {
+			logger.Fatal("failed to apply migrations", zap.Error(err))
+		}
+		logReport(logger, report)
+	},
+}
+
+// MigrationsDownCmd is the command to revert migrations
+var MigrationsDownCmd = &cobra.Command{
+	Use:   "down",
+	Short: "Reverts the migrations down to the given schema version (previous version by default)",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger := setup(cmd)
+		dryRun, target, hasTarget := migrationFlags(cmd, logger)
+		db := openMigrationsDb(logger)
+		defer db.Close()
+
+		opts := migrationOptions(logger, db, dryRun)
+		if !hasTarget {
+			status, err := migrations.GetStatus(opts)
+			if err != nil {
+				logger.Fatal("failed to get migrations status", zap.Error(err))
+			}
+			target = status.Version - 1
+		}
+		report, err := migrations.Down(cmd.Context(), opts, target)
+		if err != nil {
+			logger.Fatal("failed to revert migrations", zap.Error(err))
+		}
+		logReport(logger, report)
+	},
+}
+
+func migrationFlags(cmd *cobra.Command, logger *zap.Logger) (bool, int, bool) {
+	dryRun, err := flags.GetMigrationsDryRunFlagValue(cmd)
+	if err != nil {
+		logger.Fatal("failed to get dry-run flag value", zap.Error(err))
+	}
+	target, hasTarget, err := flags.GetMigrationsVersionFlagValue(cmd)
+	if err != nil {
+		logger.Fatal("failed to get version flag value", zap.Error(err))
+	}
+	return dryRun, target, hasTarget
+}
+
+func openMigrationsDb(logger *zap.Logger) basedb.IDb {
+	cfg.DBOptions.GCInterval = 0
+	db, err := storage.GetStorageFactory(cfg.DBOptions)
+	if err != nil {
+		logger.Fatal("failed to open db", zap.Error(err))
+	}
+	return db
+}
+
+func migrationOptions(logger *zap.Logger, db basedb.IDb, dryRun bool) migrations.Options {
+	return migrations.Options{
+		Db:     db,
+		Logger: logger,
+		DbPath: cfg.DBOptions.Path,
+		DryRun: dryRun,
+	}
+}
+
+func logReport(logger *zap.Logger, report *migrations.Report) {
+	for _, m := range report.Migrations {
+		fields := []zap.Field{zap.Int("version", m.Version), zap.String("name", m.Name)}
+		for prefix, n := range m.Affected {
+			fields = append(fields, zap.Int64(fmt.Sprintf("keys(%s)", prefix), n))
+		}
+		logger.Info("migration", fields...)
+	}
+	msg := "db schema version was changed"
+	if report.DryRun {
+		msg = "dry-run, db was not changed"
+	}
+	logger.Info(msg, zap.Int("from", report.FromVersion), zap.Int("to", report.ToVersion))
+}
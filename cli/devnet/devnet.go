@@ -0,0 +1,92 @@
+package devnet
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/cli/flags"
+	"github.com/bloxapp/ssv/operator/devnet"
+	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
+	"github.com/bloxapp/ssv/utils/logex"
+)
+
+// DevnetCmd is the command to run a local devnet of operator nodes
+var DevnetCmd = &cobra.Command{
+	Use:   "devnet",
+	Short: "Runs a local network of operator nodes with a mocked registry contract and beacon node",
+	Run: func(cmd *cobra.Command, args []string) {
+		opts := devnet.Options{}
+		var err error
+		if opts.Dir, err = flags.GetDevnetDirFlagValue(cmd); err != nil {
+			log.Fatalf("failed to get dir flag value %s", err)
+		}
+		operators, err := flags.GetDevnetOperatorsFlagValue(cmd)
+		if err != nil {
+			log.Fatalf("failed to get operators flag value %s", err)
+		}
+		opts.Operators = int(operators)
+		validators, err := flags.GetDevnetValidatorsFlagValue(cmd)
+		if err != nil {
+			log.Fatalf("failed to get validators flag value %s", err)
+		}
+		opts.Validators = int(validators)
+		basePort, err := flags.GetDevnetBasePortFlagValue(cmd)
+		if err != nil {
+			log.Fatalf("failed to get base port flag value %s", err)
+		}
+		opts.BasePort = int(basePort)
+		forkVersion, err := flags.GetDevnetForkVersionFlagValue(cmd)
+		if err != nil {
+			log.Fatalf("failed to get fork version flag value %s", err)
+		}
+		switch opts.ForkVersion = forksprotocol.ForkVersion(forkVersion); opts.ForkVersion {
+		case forksprotocol.V0ForkVersion, forksprotocol.V1ForkVersion, forksprotocol.V2ForkVersion, forksprotocol.V3ForkVersion:
+		default:
+			log.Fatalf("unknown fork version %s", forkVersion)
+		}
+		logLevel, err := flags.GetDevnetLogLevelFlagValue(cmd)
+		if err != nil {
+			log.Fatalf("failed to get log level flag value %s", err)
+		}
+		loggerLevel, errLogLevel := logex.GetLoggerLevelValue(logLevel)
+		opts.Logger = logex.Build(cmd.Parent().Short, loggerLevel, &logex.EncodingConfig{})
+		if errLogLevel != nil {
+			opts.Logger.Warn(fmt.Sprintf("Default log level set to %s", loggerLevel), zap.Error(errLogLevel))
+		}
+
+		ctx, cancel := context.WithCancel(cmd.Context())
+		defer cancel()
+		sigs := make(chan os.Signal, 1)
+		signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+		go func() {
+			<-sigs
+			opts.Logger.Info("stopping devnet")
+			cancel()
+		}()
+
+		d, err := devnet.New(ctx, opts)
+		if err != nil {
+			opts.Logger.Fatal("failed to create devnet", zap.Error(err))
+		}
+		defer d.Close()
+		if err := d.Run(ctx); err != nil {
+			opts.Logger.Error("devnet failed", zap.Error(err))
+		}
+	},
+}
+
+func init() {
+	flags.AddDevnetDirFlag(DevnetCmd)
+	flags.AddDevnetOperatorsFlag(DevnetCmd)
+	flags.AddDevnetValidatorsFlag(DevnetCmd)
+	flags.AddDevnetBasePortFlag(DevnetCmd)
+	flags.AddDevnetForkVersionFlag(DevnetCmd)
+	flags.AddDevnetLogLevelFlag(DevnetCmd)
+}
@@ -0,0 +1,81 @@
+package flags
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/bloxapp/ssv/utils/cliflag"
+)
+
+// Flag names.
+const (
+	dbTypeFlag = "to-type"
+	dbPathFlag = "to-path"
+)
+
+// AddDBTypeFlag adds the target db type flag to the command
+func AddDBTypeFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, dbTypeFlag, "bbolt-db", "Type of the target db", false)
+}
+
+// GetDBTypeFlagValue gets the target db type flag from the command
+func GetDBTypeFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(dbTypeFlag)
+}
+
+// AddDBPathFlag adds the target db path flag to the command
+func AddDBPathFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, dbPathFlag, "", "Path of the target db", true)
+}
+
+// GetDBPathFlagValue gets the target db path flag from the command
+func GetDBPathFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(dbPathFlag)
+}
+
+// Backup flag names.
+const (
+	backupFileFlag        = "file"
+	backupCollectionsFlag = "collections"
+	backupExcludeFlag     = "exclude"
+	backupNodeURLFlag     = "node-url"
+)
+
+// AddBackupFileFlag adds the backup file flag to the command
+func AddBackupFileFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, backupFileFlag, "", "Path of the backup file", true)
+}
+
+// GetBackupFileFlagValue gets the backup file flag from the command
+func GetBackupFileFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(backupFileFlag)
+}
+
+// AddBackupCollectionsFlag adds the collections flag to the command
+func AddBackupCollectionsFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, backupCollectionsFlag, "", "Comma separated collections to select, all if empty", false)
+}
+
+// GetBackupCollectionsFlagValue gets the collections flag from the command
+func GetBackupCollectionsFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(backupCollectionsFlag)
+}
+
+// AddBackupExcludeFlag adds the excluded collections flag to the command
+func AddBackupExcludeFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, backupExcludeFlag, "", "Comma separated collections to exclude, e.g. decided", false)
+}
+
+// GetBackupExcludeFlagValue gets the excluded collections flag from the command
+func GetBackupExcludeFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(backupExcludeFlag)
+}
+
+// AddBackupNodeURLFlag adds the node admin api url flag to the command
+func AddBackupNodeURLFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, backupNodeURLFlag, "", "Admin api url of a running node to take the backup from (e.g. http://127.0.0.1:16000 or unix:/var/run/ssv/admin.sock), the configured db is used if empty", false)
+}
+
+// GetBackupNodeURLFlagValue gets the node admin api url flag from the command
+func GetBackupNodeURLFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(backupNodeURLFlag)
+}
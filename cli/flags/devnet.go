@@ -0,0 +1,77 @@
+package flags
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/bloxapp/ssv/utils/cliflag"
+)
+
+// Devnet flag names.
+const (
+	devnetDirFlag         = "dir"
+	devnetOperatorsFlag   = "operators"
+	devnetValidatorsFlag  = "validators"
+	devnetBasePortFlag    = "base-port"
+	devnetForkVersionFlag = "fork-version"
+	devnetLogLevelFlag    = "log-level"
+)
+
+// AddDevnetDirFlag adds the devnet dir flag to the command
+func AddDevnetDirFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, devnetDirFlag, "./data/devnet", "Dir of the devnet keys, configs and dbs, an existing devnet is resumed", false)
+}
+
+// GetDevnetDirFlagValue gets the devnet dir flag from the command
+func GetDevnetDirFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(devnetDirFlag)
+}
+
+// AddDevnetOperatorsFlag adds the operators count flag to the command
+func AddDevnetOperatorsFlag(c *cobra.Command) {
+	cliflag.AddPersistentIntFlag(c, devnetOperatorsFlag, 4, "Number of operator nodes (at least 4)", false)
+}
+
+// GetDevnetOperatorsFlagValue gets the operators count flag from the command
+func GetDevnetOperatorsFlagValue(c *cobra.Command) (uint64, error) {
+	return c.Flags().GetUint64(devnetOperatorsFlag)
+}
+
+// AddDevnetValidatorsFlag adds the validators count flag to the command
+func AddDevnetValidatorsFlag(c *cobra.Command) {
+	cliflag.AddPersistentIntFlag(c, devnetValidatorsFlag, 1, "Number of validators, shared by all the operators", false)
+}
+
+// GetDevnetValidatorsFlagValue gets the validators count flag from the command
+func GetDevnetValidatorsFlagValue(c *cobra.Command) (uint64, error) {
+	return c.Flags().GetUint64(devnetValidatorsFlag)
+}
+
+// AddDevnetBasePortFlag adds the base port flag to the command
+func AddDevnetBasePortFlag(c *cobra.Command) {
+	cliflag.AddPersistentIntFlag(c, devnetBasePortFlag, 13000, "UDP port of the bootnode, node i listens on base+i (tcp) and base+1000+i (udp)", false)
+}
+
+// GetDevnetBasePortFlagValue gets the base port flag from the command
+func GetDevnetBasePortFlagValue(c *cobra.Command) (uint64, error) {
+	return c.Flags().GetUint64(devnetBasePortFlag)
+}
+
+// AddDevnetForkVersionFlag adds the fork version flag to the command
+func AddDevnetForkVersionFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, devnetForkVersionFlag, "v1", "SSV fork version of the nodes (v0, v1, v2 or v3)", false)
+}
+
+// GetDevnetForkVersionFlagValue gets the fork version flag from the command
+func GetDevnetForkVersionFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(devnetForkVersionFlag)
+}
+
+// AddDevnetLogLevelFlag adds the log level flag to the command
+func AddDevnetLogLevelFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, devnetLogLevelFlag, "info", "Log level of the nodes", false)
+}
+
+// GetDevnetLogLevelFlagValue gets the log level flag from the command
+func GetDevnetLogLevelFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(devnetLogLevelFlag)
+}
@@ -0,0 +1,55 @@
+package flags
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/bloxapp/ssv/utils/cliflag"
+)
+
+// Journal flag names.
+const (
+	journalValidatorFlag = "validator"
+	journalHeightFlag    = "height"
+	journalRoleFlag      = "role"
+	journalJSONFlag      = "json"
+)
+
+// AddJournalValidatorFlag adds the validator public key flag to the command
+func AddJournalValidatorFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, journalValidatorFlag, "", "Public key (hex) of the validator", true)
+}
+
+// GetJournalValidatorFlagValue gets the validator public key flag from the command
+func GetJournalValidatorFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(journalValidatorFlag)
+}
+
+// AddJournalHeightFlag adds the instance height flag to the command
+func AddJournalHeightFlag(c *cobra.Command) {
+	cliflag.AddPersistentIntFlag(c, journalHeightFlag, 0, "Height of the instance", true)
+}
+
+// GetJournalHeightFlagValue gets the instance height flag from the command
+func GetJournalHeightFlagValue(c *cobra.Command) (uint64, error) {
+	return c.Flags().GetUint64(journalHeightFlag)
+}
+
+// AddJournalRoleFlag adds the role flag to the command
+func AddJournalRoleFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, journalRoleFlag, "", "Role of the instance (e.g. ATTESTER), all roles if empty", false)
+}
+
+// GetJournalRoleFlagValue gets the role flag from the command
+func GetJournalRoleFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(journalRoleFlag)
+}
+
+// AddJournalJSONFlag adds the json output flag to the command
+func AddJournalJSONFlag(c *cobra.Command) {
+	cliflag.AddPersistentBoolFlag(c, journalJSONFlag, false, "Prints the events as json instead of a human readable timeline", false)
+}
+
+// GetJournalJSONFlagValue gets the json output flag from the command
+func GetJournalJSONFlagValue(c *cobra.Command) (bool, error) {
+	return c.Flags().GetBool(journalJSONFlag)
+}
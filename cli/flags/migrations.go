@@ -0,0 +1,38 @@
+package flags
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/bloxapp/ssv/utils/cliflag"
+)
+
+// Migrations flag names.
+const (
+	migrationsDryRunFlag  = "dry-run"
+	migrationsVersionFlag = "to-version"
+)
+
+// AddMigrationsDryRunFlag adds the dry-run flag to the command
+func AddMigrationsDryRunFlag(c *cobra.Command) {
+	cliflag.AddPersistentBoolFlag(c, migrationsDryRunFlag, false, "Reports the migrations and the amount of affected keys without changing the db", false)
+}
+
+// GetMigrationsDryRunFlagValue gets the dry-run flag from the command
+func GetMigrationsDryRunFlagValue(c *cobra.Command) (bool, error) {
+	return c.Flags().GetBool(migrationsDryRunFlag)
+}
+
+// AddMigrationsVersionFlag adds the target schema version flag to the command
+func AddMigrationsVersionFlag(c *cobra.Command, description string) {
+	cliflag.AddPersistentIntFlag(c, migrationsVersionFlag, 0, description, false)
+}
+
+// GetMigrationsVersionFlagValue gets the target schema version flag from the command,
+// returns false if the flag was not set
+func GetMigrationsVersionFlagValue(c *cobra.Command) (int, bool, error) {
+	if !c.Flags().Changed(migrationsVersionFlag) {
+		return 0, false, nil
+	}
+	version, err := c.Flags().GetUint64(migrationsVersionFlag)
+	return int(version), true, err
+}
@@ -0,0 +1,61 @@
+package flags
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bloxapp/ssv/utils/cliflag"
+)
+
+// Mock beacon flag names.
+const (
+	mockBeaconAddrFlag       = "addr"
+	mockBeaconNetworkFlag    = "network"
+	mockBeaconValidatorsFlag = "validators"
+	mockBeaconLogLevelFlag   = "log-level"
+)
+
+// AddMockBeaconAddrFlag adds the listen address flag to the command
+func AddMockBeaconAddrFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, mockBeaconAddrFlag, "127.0.0.1:5052", "Address to listen on, configured as BeaconNodeAddr of the nodes", false)
+}
+
+// GetMockBeaconAddrFlagValue gets the listen address flag from the command
+func GetMockBeaconAddrFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(mockBeaconAddrFlag)
+}
+
+// AddMockBeaconNetworkFlag adds the network flag to the command
+func AddMockBeaconNetworkFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, mockBeaconNetworkFlag, "prater", "Beacon network that is simulated (genesis, fork and slots)", false)
+}
+
+// GetMockBeaconNetworkFlagValue gets the network flag from the command
+func GetMockBeaconNetworkFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(mockBeaconNetworkFlag)
+}
+
+// AddMockBeaconValidatorsFlag adds the validators flag to the command
+func AddMockBeaconValidatorsFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, mockBeaconValidatorsFlag, "", "Comma separated public keys (hex) of validators that are active from the start", false)
+}
+
+// GetMockBeaconValidatorsFlagValue gets the validators flag from the command
+func GetMockBeaconValidatorsFlagValue(c *cobra.Command) ([]string, error) {
+	raw, err := c.Flags().GetString(mockBeaconValidatorsFlag)
+	if err != nil || len(raw) == 0 {
+		return nil, err
+	}
+	return strings.Split(raw, ","), nil
+}
+
+// AddMockBeaconLogLevelFlag adds the log level flag to the command
+func AddMockBeaconLogLevelFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, mockBeaconLogLevelFlag, "info", "Log level of the mock beacon node", false)
+}
+
+// GetMockBeaconLogLevelFlagValue gets the log level flag from the command
+func GetMockBeaconLogLevelFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(mockBeaconLogLevelFlag)
+}
@@ -0,0 +1,22 @@
+package flags
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/bloxapp/ssv/utils/cliflag"
+)
+
+// Flag names.
+const (
+	snapshotFileFlag = "file"
+)
+
+// AddSnapshotFileFlag adds the snapshot file flag to the command
+func AddSnapshotFileFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, snapshotFileFlag, "", "Path to the registry snapshot file", true)
+}
+
+// GetSnapshotFileFlagValue gets the snapshot file flag from the command
+func GetSnapshotFileFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(snapshotFileFlag)
+}
@@ -0,0 +1,92 @@
+package journal
+
+import (
+	"encoding/hex"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/ilyakaznacheev/cleanenv"
+	"github.com/spf13/cobra"
+
+	global_config "github.com/bloxapp/ssv/cli/config"
+	"github.com/bloxapp/ssv/cli/flags"
+	"github.com/bloxapp/ssv/protocol/v1/message"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/journal"
+)
+
+// config is the subset of the node config that is needed to read the journal
+type config struct {
+	global_config.GlobalConfig `yaml:"global"`
+	Journal                    journal.Options `yaml:"Journal"`
+}
+
+var cfg config
+
+var globalArgs global_config.Args
+
+// JournalCmd is the parent command of the consensus journal commands
+var JournalCmd = &cobra.Command{
+	Use:   "journal",
+	Short: "Commands of the consensus events journal",
+}
+
+// DumpCmd is the command to print the consensus events of an instance
+var DumpCmd = &cobra.Command{
+	Use:   "dump",
+	Short: "Prints the timeline of consensus events of an instance from the journal (as configured)",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := cleanenv.ReadConfig(globalArgs.ConfigPath, &cfg); err != nil {
+			log.Fatalf("could not read config %s", err)
+		}
+		if !cfg.Journal.Enabled() {
+			log.Fatal("journal dir is not configured")
+		}
+		pk, err := flags.GetJournalValidatorFlagValue(cmd)
+		if err != nil {
+			log.Fatalf("failed to get validator flag value %s", err)
+		}
+		pk = strings.TrimPrefix(pk, "0x")
+		if _, err := hex.DecodeString(pk); err != nil {
+			log.Fatalf("invalid validator public key %s", err)
+		}
+		height, err := flags.GetJournalHeightFlagValue(cmd)
+		if err != nil {
+			log.Fatalf("failed to get height flag value %s", err)
+		}
+		role, err := flags.GetJournalRoleFlagValue(cmd)
+		if err != nil {
+			log.Fatalf("failed to get role flag value %s", err)
+		}
+		asJSON, err := flags.GetJournalJSONFlagValue(cmd)
+		if err != nil {
+			log.Fatalf("failed to get json flag value %s", err)
+		}
+
+		events, err := journal.Read(cfg.Journal.Dir, journal.Filter{
+			PubKey: pk,
+			Role:   role,
+			Height: message.Height(height),
+		})
+		if err != nil {
+			log.Fatalf("failed to read journal %s", err)
+		}
+		if asJSON {
+			err = journal.WriteJSON(os.Stdout, events)
+		} else {
+			err = journal.WriteTimeline(os.Stdout, events)
+		}
+		if err != nil {
+			log.Fatalf("failed to write events %s", err)
+		}
+	},
+}
+
+func init() {
+	global_config.ProcessArgs(&cfg, &globalArgs, JournalCmd)
+	flags.AddJournalValidatorFlag(DumpCmd)
+	flags.AddJournalHeightFlag(DumpCmd)
+	flags.AddJournalRoleFlag(DumpCmd)
+	flags.AddJournalJSONFlag(DumpCmd)
+	JournalCmd.AddCommand(DumpCmd)
+}
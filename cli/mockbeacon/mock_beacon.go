@@ -0,0 +1,89 @@
+package mockbeacon
+
+import (
+	"encoding/hex"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/eth2-key-manager/core"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/beacon/mockbeacon"
+	"github.com/bloxapp/ssv/cli/flags"
+	beaconprotocol "github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
+	"github.com/bloxapp/ssv/utils/logex"
+)
+
+// MockBeaconCmd is the command to run a mock beacon node
+var MockBeaconCmd = &cobra.Command{
+	Use:   "mock-beacon",
+	Short: "Runs a mock beacon node that serves the beacon api endpoints used by the node, for integration testing",
+	Run: func(cmd *cobra.Command, args []string) {
+		logLevel, err := flags.GetMockBeaconLogLevelFlagValue(cmd)
+		if err != nil {
+			log.Fatalf("failed to get log level flag value %s", err)
+		}
+		loggerLevel, errLogLevel := logex.GetLoggerLevelValue(logLevel)
+		logger := logex.Build(cmd.Parent().Short, loggerLevel, &logex.EncodingConfig{})
+		if errLogLevel != nil {
+			logger.Warn(fmt.Sprintf("Default log level set to %s", loggerLevel), zap.Error(errLogLevel))
+		}
+
+		addr, err := flags.GetMockBeaconAddrFlagValue(cmd)
+		if err != nil {
+			log.Fatalf("failed to get addr flag value %s", err)
+		}
+		networkName, err := flags.GetMockBeaconNetworkFlagValue(cmd)
+		if err != nil {
+			log.Fatalf("failed to get network flag value %s", err)
+		}
+		network := core.NetworkFromString(networkName)
+		if len(network) == 0 {
+			log.Fatalf("unknown network %s", networkName)
+		}
+		validators, err := flags.GetMockBeaconValidatorsFlagValue(cmd)
+		if err != nil {
+			log.Fatalf("failed to get validators flag value %s", err)
+		}
+
+		server := mockbeacon.New(mockbeacon.Options{
+			Logger:  logger,
+			Network: beaconprotocol.NewNetwork(network),
+			Addr:    addr,
+		})
+		for _, raw := range validators {
+			pk, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(raw), "0x"))
+			if err != nil || len(pk) != len(spec.BLSPubKey{}) {
+				log.Fatalf("invalid validator public key %s", raw)
+			}
+			var blsPubKey spec.BLSPubKey
+			copy(blsPubKey[:], pk)
+			index := server.Chain().AddValidator(blsPubKey)
+			logger.Info("validator was added", zap.String("pubkey", raw), zap.Uint64("index", uint64(index)))
+		}
+		if err := server.Start(); err != nil {
+			logger.Fatal("failed to start mock beacon node", zap.Error(err))
+		}
+
+		sigs := make(chan os.Signal, 1)
+		signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+		<-sigs
+		logger.Info("stopping mock beacon node")
+		if err := server.Close(); err != nil {
+			logger.Warn("could not stop mock beacon node", zap.Error(err))
+		}
+	},
+}
+
+func init() {
+	flags.AddMockBeaconAddrFlag(MockBeaconCmd)
+	flags.AddMockBeaconNetworkFlag(MockBeaconCmd)
+	flags.AddMockBeaconValidatorsFlag(MockBeaconCmd)
+	flags.AddMockBeaconLogLevelFlag(MockBeaconCmd)
+}
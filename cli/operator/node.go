@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/bloxapp/ssv/exporter/api"
 	"github.com/bloxapp/ssv/exporter/api/decided"
+	"github.com/bloxapp/ssv/exporter/api/registry"
 	"log"
 	"net/http"
 	"time"
@@ -23,13 +24,17 @@ import (
 	ssv_identity "github.com/bloxapp/ssv/identity"
 	"github.com/bloxapp/ssv/migrations"
 	"github.com/bloxapp/ssv/monitoring/metrics"
+	"github.com/bloxapp/ssv/monitoring/tracing"
+	"github.com/bloxapp/ssv/network"
 	forksv0 "github.com/bloxapp/ssv/network/forks/v0"
 	p2pv1 "github.com/bloxapp/ssv/network/p2p"
 	"github.com/bloxapp/ssv/operator"
+	"github.com/bloxapp/ssv/operator/admin"
 	operatorstorage "github.com/bloxapp/ssv/operator/storage"
 	"github.com/bloxapp/ssv/operator/validator"
 	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
 	beaconprotocol "github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/journal"
 	"github.com/bloxapp/ssv/storage"
 	"github.com/bloxapp/ssv/storage/basedb"
 	"github.com/bloxapp/ssv/utils/commons"
@@ -45,16 +50,21 @@ type config struct {
 	ETH1Options                eth1.Options           `yaml:"eth1"`
 	ETH2Options                beaconprotocol.Options `yaml:"eth2"`
 	P2pNetworkConfig           p2pv1.Config           `yaml:"p2p"`
+	Tracing                    tracing.Options        `yaml:"Tracing"`
+	Journal                    journal.Options        `yaml:"Journal"`
 
 	OperatorPrivateKey         string `yaml:"OperatorPrivateKey" env:"OPERATOR_KEY" env-description:"Operator private key, used to decrypt contract events"`
 	GenerateOperatorPrivateKey bool   `yaml:"GenerateOperatorPrivateKey" env:"GENERATE_OPERATOR_KEY" env-description:"Whether to generate operator key if none is passed by config"`
 	MetricsAPIPort             int    `yaml:"MetricsAPIPort" env:"METRICS_API_PORT" env-description:"port of metrics api"`
+	AdminAPIAddr               string `yaml:"AdminAPIAddr" env:"ADMIN_API_ADDR" env-description:"local address of admin api (e.g. 127.0.0.1:16000 or unix:/var/run/ssv/admin.sock), disabled if empty"`
+	AdminAPIToken              string `yaml:"AdminAPIToken" env:"ADMIN_API_TOKEN" env-description:"bearer token of admin api, required if it listens on a tcp address"`
 	EnableProfile              bool   `yaml:"EnableProfile" env:"ENABLE_PROFILE" env-description:"flag that indicates whether go profiling tools are enabled"`
 	NetworkPrivateKey          string `yaml:"NetworkPrivateKey" env:"NETWORK_PRIVATE_KEY" env-description:"private key for network identity"`
 	ClearNetworkKey            bool   `yaml:"ClearNetworkKey" env:"CLEAR_NETWORK_KEY" env-description:"flag that turns on/off network key revocation"`
 
 	ForkV1Epoch uint64 `yaml:"ForkV1Epoch" env:"FORKV1_EPOCH" env-default:"102594" env-description:"Target epoch for fork v1"`
 	ForkV2Epoch uint64 `yaml:"ForkV2Epoch" env:"FORKV2_EPOCH" env-description:"Target epoch for fork v2"`
+	ForkV3Epoch uint64 `yaml:"ForkV3Epoch" env:"FORKV3_EPOCH" env-description:"Target epoch for fork v3"`
 
 	WsAPIPort int  `yaml:"WebSocketAPIPort" env:"WS_API_PORT" env-description:"port of WS API"`
 	WithPing  bool `yaml:"WithPing" env:"WITH_PING" env-description:"Whether to send websocket ping messages'"`
@@ -90,6 +100,13 @@ var StartNodeCmd = &cobra.Command{
 			Logger.Warn(fmt.Sprintf("Default log level set to %s", loggerLevel), zap.Error(errLogLevel))
 		}
 
+		closeTracing, err := tracing.Setup(Logger, cfg.Tracing)
+		if err != nil {
+			Logger.Error("failed to setup tracing", zap.Error(err))
+		} else {
+			defer closeTracing()
+		}
+
 		cfg.DBOptions.Logger = Logger
 		cfg.DBOptions.Ctx = cmd.Context()
 		db, err := storage.GetStorageFactory(cfg.DBOptions)
@@ -117,6 +134,10 @@ var StartNodeCmd = &cobra.Command{
 			Logger.Debug("setting v2 epoch", zap.Uint64("epoch", cfg.ForkV2Epoch))
 			forksprotocol.SetForkEpoch(types.Epoch(cfg.ForkV2Epoch), forksprotocol.V2ForkVersion)
 		}
+		if cfg.ForkV3Epoch > 0 {
+			Logger.Debug("setting v3 epoch", zap.Uint64("epoch", cfg.ForkV3Epoch))
+			forksprotocol.SetForkEpoch(types.Epoch(cfg.ForkV3Epoch), forksprotocol.V3ForkVersion)
+		}
 		ssvForkVersion := forksprotocol.GetCurrentForkVersion(currentEpoch)
 		Logger.Info("using ssv fork version", zap.String("version", string(ssvForkVersion)))
 		// TODO Not refactored yet Start (refactor in exporter as well):
@@ -151,7 +172,7 @@ var StartNodeCmd = &cobra.Command{
 
 		cfg.P2pNetworkConfig.NetworkPrivateKey = netPrivKey
 		cfg.P2pNetworkConfig.Logger = Logger
-		cfg.P2pNetworkConfig.ForkVersion = ssvForkVersion
+		cfg.P2pNetworkConfig.ForkVersion = forksprotocol.NetworkForkVersion(ssvForkVersion)
 		cfg.P2pNetworkConfig.OperatorID = format.OperatorID(operatorPubKey)
 		cfg.P2pNetworkConfig.UserAgent = forksv0.GenUserAgentWithOperatorID(cfg.P2pNetworkConfig.OperatorID)
 		//Logger.Info("xxx", zap.String("ua", cfg.P2pNetworkConfig.UserAgent), zap.String("oid", cfg.P2pNetworkConfig.OperatorID))
@@ -177,6 +198,14 @@ var StartNodeCmd = &cobra.Command{
 		cfg.SSVOptions.ValidatorOptions.Beacon = beaconClient
 		cfg.SSVOptions.ValidatorOptions.CleanRegistryData = cfg.ETH1Options.CleanRegistryData
 		cfg.SSVOptions.ValidatorOptions.KeyManager = beaconClient
+		consensusJournal, err := journal.New(Logger, cfg.Journal)
+		if err != nil {
+			Logger.Fatal("failed to create consensus journal", zap.Error(err))
+		}
+		defer func() {
+			_ = consensusJournal.Close()
+		}()
+		cfg.SSVOptions.ValidatorOptions.Journal = consensusJournal
 
 		cfg.SSVOptions.ValidatorOptions.ShareEncryptionKeyProvider = nodeStorage.GetPrivateKey
 		cfg.SSVOptions.ValidatorOptions.OperatorPubKey = operatorPubKey
@@ -191,15 +220,23 @@ var StartNodeCmd = &cobra.Command{
 				Logger.Fatal("failed to load ABI JSON", zap.Error(err))
 			}
 		}
-		cfg.SSVOptions.Eth1Client, err = goeth.NewEth1Client(goeth.ClientOptions{
+		eth1ClientOpts := goeth.ClientOptions{
 			Ctx:                  cmd.Context(),
 			Logger:               Logger,
 			NodeAddr:             cfg.ETH1Options.ETH1Addr,
+			FallbackAddrs:        cfg.ETH1Options.ETH1FallbackAddrs,
 			ConnectionTimeout:    cfg.ETH1Options.ETH1ConnectionTimeout,
+			FollowDistance:       cfg.ETH1Options.ETH1FollowDistance,
+			PollInterval:         cfg.ETH1Options.ETH1PollInterval,
 			ContractABI:          eth1.ContractABI(cfg.ETH1Options.AbiVersion),
 			RegistryContractAddr: cfg.ETH1Options.RegistryContractAddr,
 			AbiVersion:           cfg.ETH1Options.AbiVersion,
-		})
+		}
+		if cfg.ETH1Options.IsPolling() {
+			cfg.SSVOptions.Eth1Client, err = goeth.NewPollingClient(eth1ClientOpts)
+		} else {
+			cfg.SSVOptions.Eth1Client, err = goeth.NewEth1Client(eth1ClientOpts)
+		}
 		if err != nil {
 			Logger.Fatal("failed to create eth1 client", zap.Error(err))
 		}
@@ -209,6 +246,7 @@ var StartNodeCmd = &cobra.Command{
 			cfg.SSVOptions.WS = ws
 			cfg.SSVOptions.WsAPIPort = cfg.WsAPIPort
 			cfg.SSVOptions.ValidatorOptions.NewDecidedHandler = decided.NewStreamPublisher(Logger, ws)
+			cfg.SSVOptions.ValidatorOptions.RegistryEventHandler = registry.NewStreamPublisher(Logger, ws)
 		}
 
 		validatorCtrl := validator.NewController(cfg.SSVOptions.ValidatorOptions)
@@ -219,6 +257,20 @@ var StartNodeCmd = &cobra.Command{
 		if cfg.MetricsAPIPort > 0 {
 			go startMetricsHandler(cmd.Context(), Logger, cfg.MetricsAPIPort, cfg.EnableProfile)
 		}
+		if len(cfg.AdminAPIAddr) > 0 {
+			peersAdmin, _ := p2pNet.(network.PeersAdmin)
+			adminServer := admin.New(admin.Options{
+				Addr:                cfg.AdminAPIAddr,
+				Token:               cfg.AdminAPIToken,
+				Logger:              Logger,
+				DB:                  db,
+				ValidatorController: validatorCtrl,
+				Peers:               peersAdmin,
+			})
+			if err := adminServer.Start(); err != nil {
+				Logger.Error("failed to start admin api", zap.Error(err))
+			}
+		}
 
 		metrics.WaitUntilHealthy(Logger, cfg.SSVOptions.Eth1Client, "eth1 node")
 		metrics.WaitUntilHealthy(Logger, beaconClient, "beacon node")
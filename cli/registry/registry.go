@@ -0,0 +1,178 @@
+package registry
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/bloxapp/eth2-key-manager/core"
+	"github.com/ilyakaznacheev/cleanenv"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/beacon/goclient/ekm"
+	global_config "github.com/bloxapp/ssv/cli/config"
+	"github.com/bloxapp/ssv/cli/flags"
+	"github.com/bloxapp/ssv/migrations"
+	"github.com/bloxapp/ssv/operator"
+	"github.com/bloxapp/ssv/operator/snapshot"
+	operatorstorage "github.com/bloxapp/ssv/operator/storage"
+	"github.com/bloxapp/ssv/operator/validator"
+	beaconprotocol "github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
+	"github.com/bloxapp/ssv/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/utils/commons"
+	"github.com/bloxapp/ssv/utils/logex"
+)
+
+// config is the subset of the node config that is needed to access the registry data
+type config struct {
+	global_config.GlobalConfig `yaml:"global"`
+	DBOptions                  basedb.Options         `yaml:"db"`
+	SSVOptions                 operator.Options       `yaml:"ssv"`
+	ETH2Options                beaconprotocol.Options `yaml:"eth2"`
+
+	OperatorPrivateKey string `yaml:"OperatorPrivateKey" env:"OPERATOR_KEY" env-description:"Operator private key, used to decrypt contract events"`
+}
+
+var cfg config
+
+var globalArgs global_config.Args
+
+// RegistryCmd is the parent command of the registry snapshot commands
+var RegistryCmd = &cobra.Command{
+	Use:   "registry",
+	Short: "Exports and imports snapshots of the registry data",
+}
+
+// ExportCmd is the command to export a registry snapshot
+var ExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Exports the registry data (operators, shares and sync offset) into a snapshot file",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger, db := setup(cmd)
+		defer db.Close()
+		path, err := flags.GetSnapshotFileFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get snapshot file flag value", zap.Error(err))
+		}
+
+		s, err := snapshot.Export(operatorstorage.NewNodeStorage(db, logger), newCollection(db, logger))
+		if err != nil {
+			logger.Fatal("failed to export registry snapshot", zap.Error(err))
+		}
+		f, err := os.Create(path)
+		if err != nil {
+			logger.Fatal("failed to create snapshot file", zap.Error(err))
+		}
+		defer func() {
+			_ = f.Close()
+		}()
+		if err := s.Write(f); err != nil {
+			logger.Fatal("failed to write snapshot file", zap.Error(err))
+		}
+		logger.Info("registry snapshot was exported", zap.String("file", path),
+			zap.String("syncOffset", s.SyncOffset), zap.Int("operators", len(s.Operators)),
+			zap.Int("shares", len(s.Shares)), zap.String("checksum", s.Checksum))
+	},
+}
+
+// ImportCmd is the command to import a registry snapshot
+var ImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Imports a registry snapshot file, eth1 sync resumes from the snapshot block",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger, db := setup(cmd)
+		defer db.Close()
+		path, err := flags.GetSnapshotFileFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get snapshot file flag value", zap.Error(err))
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			logger.Fatal("failed to open snapshot file", zap.Error(err))
+		}
+		defer func() {
+			_ = f.Close()
+		}()
+		s, err := snapshot.Read(f)
+		if err != nil {
+			logger.Fatal("failed to read snapshot file", zap.Error(err))
+		}
+
+		nodeStorage := operatorstorage.NewNodeStorage(db, logger)
+		if err := nodeStorage.SetupPrivateKey(false, cfg.OperatorPrivateKey); err != nil {
+			logger.Fatal("failed to setup operator private key", zap.Error(err))
+		}
+		operatorPrivateKey, found, err := nodeStorage.GetPrivateKey()
+		if err != nil || !found {
+			logger.Fatal("failed to get operator private key", zap.Error(err))
+		}
+		eth2Network := beaconprotocol.NewNetwork(core.NetworkFromString(cfg.ETH2Options.Network))
+		keyManager, err := ekm.NewETHKeyManagerSigner(db, nil, eth2Network)
+		if err != nil {
+			logger.Fatal("failed to create key manager", zap.Error(err))
+		}
+
+		err = snapshot.Import(s, snapshot.ImportOptions{
+			Logger:             logger,
+			NodeStorage:        nodeStorage,
+			Shares:             newCollection(db, logger),
+			KeyManager:         keyManager,
+			OperatorPrivateKey: operatorPrivateKey,
+			FeeRecipient:       cfg.SSVOptions.ValidatorOptions.FeeRecipient,
+		})
+		if err != nil {
+			logger.Fatal("failed to import registry snapshot", zap.Error(err))
+		}
+	},
+}
+
+// setup reads the config, and opens the db after running migrations,
+// so the imported data won't be cleaned by migrations once the node starts
+func setup(cmd *cobra.Command) (*zap.Logger, basedb.IDb) {
+	if err := cleanenv.ReadConfig(globalArgs.ConfigPath, &cfg); err != nil {
+		log.Fatalf("could not read config %s", err)
+	}
+	commons.SetBuildData(cmd.Root().Short, cmd.Root().Version)
+	loggerLevel, errLogLevel := logex.GetLoggerLevelValue(cfg.LogLevel)
+	logger := logex.Build(commons.GetBuildData(), loggerLevel, &logex.EncodingConfig{
+		Format:       cfg.GlobalConfig.LogFormat,
+		LevelEncoder: logex.LevelEncoder([]byte(cfg.LogLevelFormat)),
+	})
+	if errLogLevel != nil {
+		logger.Warn(fmt.Sprintf("Default log level set to %s", loggerLevel), zap.Error(errLogLevel))
+	}
+
+	cfg.DBOptions.Logger = logger
+	cfg.DBOptions.Ctx = cmd.Context()
+	db, err := storage.GetStorageFactory(cfg.DBOptions)
+	if err != nil {
+		logger.Fatal("failed to create db!", zap.Error(err))
+	}
+	err = migrations.Run(cmd.Context(), migrations.Options{
+		Db:     db,
+		Logger: logger,
+		DbPath: cfg.DBOptions.Path,
+	})
+	if err != nil {
+		logger.Fatal("failed to run migrations", zap.Error(err))
+	}
+	return logger, db
+}
+
+func newCollection(db basedb.IDb, logger *zap.Logger) validator.ICollection {
+	return validator.NewCollection(validator.CollectionOptions{
+		DB:     db,
+		Logger: logger,
+	})
+}
+
+func init() {
+	global_config.ProcessArgs(&cfg, &globalArgs, RegistryCmd)
+	flags.AddSnapshotFileFlag(ExportCmd)
+	flags.AddSnapshotFileFlag(ImportCmd)
+	RegistryCmd.AddCommand(ExportCmd)
+	RegistryCmd.AddCommand(ImportCmd)
+}
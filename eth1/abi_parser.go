@@ -89,6 +89,21 @@ func (ap AbiParser) ParseAccountEnabledEvent(topics []common.Hash) (*abiparser.A
 	return ap.Version.ParseAccountEnabledEvent(topics)
 }
 
+// ParseValidatorUpdatedEvent parses ValidatorUpdatedEvent
+func (ap AbiParser) ParseValidatorUpdatedEvent(data []byte, contractAbi abi.ABI) (*abiparser.ValidatorUpdatedEvent, error) {
+	return ap.Version.ParseValidatorUpdatedEvent(ap.Logger, data, contractAbi)
+}
+
+// ParseOperatorRemovedEvent parses OperatorRemovedEvent
+func (ap AbiParser) ParseOperatorRemovedEvent(data []byte, topics []common.Hash, contractAbi abi.ABI) (*abiparser.OperatorRemovedEvent, error) {
+	return ap.Version.ParseOperatorRemovedEvent(ap.Logger, data, topics, contractAbi)
+}
+
+// ParseOperatorFeeEvent parses the operator fee events
+func (ap AbiParser) ParseOperatorFeeEvent(eventName string, data []byte, topics []common.Hash, contractAbi abi.ABI) (*abiparser.OperatorFeeEvent, error) {
+	return ap.Version.ParseOperatorFeeEvent(ap.Logger, eventName, data, topics, contractAbi)
+}
+
 // AbiVersion serves as the parser client interface
 type AbiVersion interface {
 	ParseOperatorAddedEvent(logger *zap.Logger, data []byte, topics []common.Hash, contractAbi abi.ABI) (*abiparser.OperatorAddedEvent, error)
@@ -96,6 +111,9 @@ type AbiVersion interface {
 	ParseValidatorRemovedEvent(logger *zap.Logger, data []byte, contractAbi abi.ABI) (*abiparser.ValidatorRemovedEvent, error)
 	ParseAccountLiquidatedEvent(topics []common.Hash) (*abiparser.AccountLiquidatedEvent, error)
 	ParseAccountEnabledEvent(topics []common.Hash) (*abiparser.AccountEnabledEvent, error)
+	ParseValidatorUpdatedEvent(logger *zap.Logger, data []byte, contractAbi abi.ABI) (*abiparser.ValidatorUpdatedEvent, error)
+	ParseOperatorRemovedEvent(logger *zap.Logger, data []byte, topics []common.Hash, contractAbi abi.ABI) (*abiparser.OperatorRemovedEvent, error)
+	ParseOperatorFeeEvent(logger *zap.Logger, eventName string, data []byte, topics []common.Hash, contractAbi abi.ABI) (*abiparser.OperatorFeeEvent, error)
 }
 
 // LoadABI enables to load a custom abi json
@@ -6,10 +6,12 @@ import (
 	"github.com/bloxapp/ssv/eth1/abiparser"
 	"github.com/bloxapp/ssv/utils/logex"
 	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"math/big"
 	"strings"
 	"testing"
 )
@@ -199,6 +201,78 @@ func TestParseValidatorAddedEvent(t *testing.T) {
 			require.Equal(t, shares[i], hex.EncodeToString(pk))
 		}
 	})
+
+	t.Run("v2 validator updated", func(t *testing.T) {
+		// ValidatorUpdated has the same data layout as ValidatorAdded
+		vLogValidatorUpdated, contractAbi := unmarshalLog(t, rawValidatorAddedV2, V2)
+		abiParser := NewParser(logex.Build("test", zap.InfoLevel, nil), V2)
+		parsed, err := abiParser.ParseValidatorUpdatedEvent(vLogValidatorUpdated.Data, contractAbi)
+		require.NoError(t, err)
+		require.NotNil(t, parsed)
+		require.Equal(t, "a49871a0b87d674435ac1bb62bb78a27a29bc0901ad7a3b77e564c02644f55da0e72c18e888ca78f8290a8b9c0825dd2", hex.EncodeToString(parsed.PublicKey))
+		require.Equal(t, "0xFeedB14D8b2C76FdF808C29818b06b830E8C2c0e", parsed.OwnerAddress.Hex())
+		require.Len(t, parsed.OperatorIds, 4)
+		require.Len(t, parsed.EncryptedKeys, 4)
+	})
+
+	t.Run("v1 validator updated is not supported", func(t *testing.T) {
+		abiParser := NewParser(logex.Build("test", zap.InfoLevel, nil), V1)
+		parsed, err := abiParser.ParseValidatorUpdatedEvent(nil, abi.ABI{})
+		require.NoError(t, err)
+		require.Nil(t, parsed)
+	})
+}
+
+func TestParseOperatorRemovedEvent(t *testing.T) {
+	contractAbi, err := abi.JSON(strings.NewReader(ContractABI(V2)))
+	require.NoError(t, err)
+	data, err := contractAbi.Events[abiparser.OperatorRemoved].Inputs.NonIndexed().Pack(big.NewInt(7))
+	require.NoError(t, err)
+	owner := common.HexToAddress("0xFeedB14D8b2C76FdF808C29818b06b830E8C2c0e")
+	topics := []common.Hash{contractAbi.Events[abiparser.OperatorRemoved].ID, common.BytesToHash(owner.Bytes())}
+
+	abiParser := NewParser(logex.Build("test", zap.InfoLevel, nil), V2)
+	parsed, err := abiParser.ParseOperatorRemovedEvent(data, topics, contractAbi)
+	require.NoError(t, err)
+	require.NotNil(t, parsed)
+	require.Equal(t, owner, parsed.OwnerAddress)
+	require.Equal(t, int64(7), parsed.OperatorId.Int64())
+
+	_, err = abiParser.ParseOperatorRemovedEvent(data, topics[:1], contractAbi)
+	require.Error(t, err)
+}
+
+func TestParseOperatorFeeEvent(t *testing.T) {
+	contractAbi, err := abi.JSON(strings.NewReader(ContractABI(V2)))
+	require.NoError(t, err)
+	owner := common.HexToAddress("0xFeedB14D8b2C76FdF808C29818b06b830E8C2c0e")
+	abiParser := NewParser(logex.Build("test", zap.InfoLevel, nil), V2)
+
+	for _, name := range []string{abiparser.OperatorFeeSet, abiparser.OperatorFeeApproved} {
+		t.Run(name, func(t *testing.T) {
+			data, err := contractAbi.Events[name].Inputs.NonIndexed().Pack(big.NewInt(3), big.NewInt(100), big.NewInt(1000))
+			require.NoError(t, err)
+			topics := []common.Hash{contractAbi.Events[name].ID, common.BytesToHash(owner.Bytes())}
+			parsed, err := abiParser.ParseOperatorFeeEvent(name, data, topics, contractAbi)
+			require.NoError(t, err)
+			require.NotNil(t, parsed)
+			require.Equal(t, owner, parsed.OwnerAddress)
+			require.Equal(t, int64(3), parsed.OperatorId.Int64())
+			require.Equal(t, int64(100), parsed.BlockNumber.Int64())
+			require.Equal(t, int64(1000), parsed.Fee.Int64())
+		})
+	}
+
+	t.Run(abiparser.OperatorFeeSetCanceled, func(t *testing.T) {
+		data, err := contractAbi.Events[abiparser.OperatorFeeSetCanceled].Inputs.NonIndexed().Pack(big.NewInt(3))
+		require.NoError(t, err)
+		topics := []common.Hash{contractAbi.Events[abiparser.OperatorFeeSetCanceled].ID, common.BytesToHash(owner.Bytes())}
+		parsed, err := abiParser.ParseOperatorFeeEvent(abiparser.OperatorFeeSetCanceled, data, topics, contractAbi)
+		require.NoError(t, err)
+		require.NotNil(t, parsed)
+		require.Equal(t, int64(3), parsed.OperatorId.Int64())
+		require.Nil(t, parsed.Fee)
+	})
 }
 
 func unmarshalLog(t *testing.T, rawOperatorAdded string, abiVersion Version) (*types.Log, abi.ABI) {
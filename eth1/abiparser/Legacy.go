@@ -103,6 +103,21 @@ func (a AdapterLegacy) ParseAccountEnabledEvent(topics []common.Hash) (*AccountE
 	return nil, nil
 }
 
+// ParseValidatorUpdatedEvent event is not supported in legacy format
+func (a AdapterLegacy) ParseValidatorUpdatedEvent(logger *zap.Logger, data []byte, contractAbi abi.ABI) (*ValidatorUpdatedEvent, error) {
+	return nil, nil
+}
+
+// ParseOperatorRemovedEvent event is not supported in legacy format
+func (a AdapterLegacy) ParseOperatorRemovedEvent(logger *zap.Logger, data []byte, topics []common.Hash, contractAbi abi.ABI) (*OperatorRemovedEvent, error) {
+	return nil, nil
+}
+
+// ParseOperatorFeeEvent event is not supported in legacy format
+func (a AdapterLegacy) ParseOperatorFeeEvent(logger *zap.Logger, eventName string, data []byte, topics []common.Hash, contractAbi abi.ABI) (*OperatorFeeEvent, error) {
+	return nil, nil
+}
+
 // AbiLegacy parsing events from legacy abi contract
 type AbiLegacy struct {
 }
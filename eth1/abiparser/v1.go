@@ -83,6 +83,21 @@ func (a AdapterV1) ParseAccountEnabledEvent(topics []common.Hash) (*AccountEnabl
 	return nil, nil
 }
 
+// ParseValidatorUpdatedEvent event is not supported in v1 format
+func (a AdapterV1) ParseValidatorUpdatedEvent(logger *zap.Logger, data []byte, contractAbi abi.ABI) (*ValidatorUpdatedEvent, error) {
+	return nil, nil
+}
+
+// ParseOperatorRemovedEvent event is not supported in v1 format
+func (a AdapterV1) ParseOperatorRemovedEvent(logger *zap.Logger, data []byte, topics []common.Hash, contractAbi abi.ABI) (*OperatorRemovedEvent, error) {
+	return nil, nil
+}
+
+// ParseOperatorFeeEvent event is not supported in v1 format
+func (a AdapterV1) ParseOperatorFeeEvent(logger *zap.Logger, eventName string, data []byte, topics []common.Hash, contractAbi abi.ABI) (*OperatorFeeEvent, error) {
+	return nil, nil
+}
+
 // AbiV1 parsing events from v1 abi contract
 type AbiV1 struct {
 }
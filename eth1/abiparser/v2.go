@@ -16,6 +16,12 @@ const (
 	ValidatorRemoved  = "ValidatorRemoved"
 	AccountLiquidated = "AccountLiquidated"
 	AccountEnabled    = "AccountEnabled"
+	OperatorRemoved   = "OperatorRemoved"
+	ValidatorUpdated  = "ValidatorUpdated"
+	// operator fee events
+	OperatorFeeSet         = "OperatorFeeSet"
+	OperatorFeeSetCanceled = "OperatorFeeSetCanceled"
+	OperatorFeeApproved    = "OperatorFeeApproved"
 )
 
 // ValidatorAddedEvent struct represents event received by the smart contract
@@ -53,6 +59,33 @@ type ValidatorRemovedEvent struct {
 	PublicKey    []byte
 }
 
+// ValidatorUpdatedEvent struct represents event received by the smart contract,
+// the validator shares are replaced with the given operators and keys
+type ValidatorUpdatedEvent struct {
+	PublicKey          []byte
+	OwnerAddress       common.Address
+	OperatorPublicKeys [][]byte
+	OperatorIds        []*big.Int
+	SharesPublicKeys   [][]byte
+	EncryptedKeys      [][]byte
+}
+
+// OperatorRemovedEvent struct represents event received by the smart contract
+type OperatorRemovedEvent struct {
+	OwnerAddress common.Address
+	OperatorId   *big.Int //nolint
+}
+
+// OperatorFeeEvent struct represents the operator fee events received by the smart contract.
+// a new fee is declared by OperatorFeeSet, and becomes the operator fee once OperatorFeeApproved is emitted.
+// BlockNumber and Fee are not set in OperatorFeeSetCanceled events
+type OperatorFeeEvent struct {
+	OwnerAddress common.Address
+	OperatorId   *big.Int //nolint
+	BlockNumber  *big.Int
+	Fee          *big.Int
+}
+
 // AbiV2 parsing events from v2 abi contract
 type AbiV2 struct {
 }
@@ -137,25 +170,52 @@ func (v2 *AbiV2) ParseValidatorAddedEvent(
 			Err: errors.Wrapf(err, "Failed to unpack %s event", ValidatorAdded),
 		}
 	}
+	if err := unpackEncryptedKeys(validatorAddedEvent.EncryptedKeys); err != nil {
+		return nil, err
+	}
+
+	return &validatorAddedEvent, nil
+}
+
+// ParseValidatorUpdatedEvent parses ValidatorUpdatedEvent
+func (v2 *AbiV2) ParseValidatorUpdatedEvent(
+	logger *zap.Logger,
+	data []byte,
+	contractAbi abi.ABI,
+) (*ValidatorUpdatedEvent, error) {
+	var validatorUpdatedEvent ValidatorUpdatedEvent
+	err := contractAbi.UnpackIntoInterface(&validatorUpdatedEvent, ValidatorUpdated, data)
+	if err != nil {
+		return nil, &UnpackError{
+			Err: errors.Wrapf(err, "Failed to unpack %s event", ValidatorUpdated),
+		}
+	}
+	if err := unpackEncryptedKeys(validatorUpdatedEvent.EncryptedKeys); err != nil {
+		return nil, err
+	}
 
+	return &validatorUpdatedEvent, nil
+}
+
+// unpackEncryptedKeys unpacks the abi encoded encrypted share keys in place
+func unpackEncryptedKeys(encryptedKeys [][]byte) error {
 	outAbi, err := getOutAbi()
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to define ABI")
+		return errors.Wrap(err, "failed to define ABI")
 	}
 
-	for i, ek := range validatorAddedEvent.EncryptedKeys {
+	for i, ek := range encryptedKeys {
 		out, err := outAbi.Unpack("method", ek)
 		if err != nil {
-			return nil, &UnpackError{
+			return &UnpackError{
 				Err: errors.Wrap(err, "failed to unpack EncryptedKey"),
 			}
 		}
 		if encryptedSharePrivateKey, ok := out[0].(string); ok {
-			validatorAddedEvent.EncryptedKeys[i] = []byte(encryptedSharePrivateKey)
+			encryptedKeys[i] = []byte(encryptedSharePrivateKey)
 		}
 	}
-
-	return &validatorAddedEvent, nil
+	return nil
 }
 
 // ParseValidatorRemovedEvent parses ValidatorRemovedEvent
@@ -192,3 +252,48 @@ func (v2 *AbiV2) ParseAccountEnabledEvent(topics []common.Hash) (*AccountEnabled
 	accountEnabledEvent.OwnerAddress = common.HexToAddress(topics[1].Hex())
 	return &accountEnabledEvent, nil
 }
+
+// ParseOperatorRemovedEvent parses OperatorRemovedEvent
+func (v2 *AbiV2) ParseOperatorRemovedEvent(
+	logger *zap.Logger,
+	data []byte,
+	topics []common.Hash,
+	contractAbi abi.ABI,
+) (*OperatorRemovedEvent, error) {
+	var operatorRemovedEvent OperatorRemovedEvent
+	err := contractAbi.UnpackIntoInterface(&operatorRemovedEvent, OperatorRemoved, data)
+	if err != nil {
+		return nil, &UnpackError{
+			Err: errors.Wrap(err, "failed to unpack OperatorRemoved event"),
+		}
+	}
+
+	if len(topics) < 2 {
+		return nil, errors.New("operator removed event missing topics. no owner address provided")
+	}
+	operatorRemovedEvent.OwnerAddress = common.HexToAddress(topics[1].Hex())
+	return &operatorRemovedEvent, nil
+}
+
+// ParseOperatorFeeEvent parses the operator fee events (OperatorFeeSet, OperatorFeeSetCanceled and OperatorFeeApproved)
+func (v2 *AbiV2) ParseOperatorFeeEvent(
+	logger *zap.Logger,
+	eventName string,
+	data []byte,
+	topics []common.Hash,
+	contractAbi abi.ABI,
+) (*OperatorFeeEvent, error) {
+	var operatorFeeEvent OperatorFeeEvent
+	err := contractAbi.UnpackIntoInterface(&operatorFeeEvent, eventName, data)
+	if err != nil {
+		return nil, &UnpackError{
+			Err: errors.Wrapf(err, "failed to unpack %s event", eventName),
+		}
+	}
+
+	if len(topics) < 2 {
+		return nil, errors.Errorf("%s event missing topics. no owner address provided", eventName)
+	}
+	operatorFeeEvent.OwnerAddress = common.HexToAddress(topics[1].Hex())
+	return &operatorFeeEvent, nil
+}
@@ -3,6 +3,7 @@ package eth1
 import (
 	"math/big"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/prysmaticlabs/prysm/async/event"
 )
@@ -11,7 +12,7 @@ import (
 
 // Event represents an eth1 event log in the system
 type Event struct {
-	// Log is the raw event log
+	// Log is the raw event log, Log.Removed is set if the log was reverted due to a chain reorganization
 	Log types.Log
 	// Name is the event name used for internal representation.
 	Name string
@@ -32,4 +33,6 @@ type Client interface {
 	EventsFeed() *event.Feed
 	Start() error
 	Sync(fromBlock *big.Int) error
+	// BlockHash returns the hash of the canonical block with the given number
+	BlockHash(blockNumber *big.Int) (common.Hash, error)
 }
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"math/big"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/bloxapp/ssv/eth1"
@@ -15,8 +17,10 @@ import (
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/async/event"
 	"go.uber.org/zap"
@@ -35,6 +39,12 @@ type ClientOptions struct {
 	RegistryContractAddr string
 	ContractABI          string
 	ConnectionTimeout    time.Duration
+	// FollowDistance is the number of confirmations before events are processed
+	FollowDistance uint64
+	// FallbackAddrs are used by the polling client in case NodeAddr fails
+	FallbackAddrs []string
+	// PollInterval is the interval of checking for new blocks in the polling client
+	PollInterval time.Duration
 
 	AbiVersion eth1.Version
 }
@@ -42,13 +52,22 @@ type ClientOptions struct {
 // eth1Client is the internal implementation of Client
 type eth1Client struct {
 	ctx    context.Context
-	conn   *ethclient.Client
 	logger *zap.Logger
 
-	nodeAddr             string
+	// connLock guards the connection and the node address, which are replaced on reconnection or failover
+	connLock sync.RWMutex
+	conn     *ethclient.Client
+	rpc      *rpc.Client
+	nodeAddr string
+
 	registryContractAddr string
 	contractABI          string
 	connectionTimeout    time.Duration
+	followDistance       uint64
+
+	// lastBlock is the last block that its events were processed
+	lastBlock uint64
+	reorgs    reorgTracker
 
 	eventsFeed *event.Feed
 
@@ -58,6 +77,9 @@ type eth1Client struct {
 // verifies that the client implements HealthCheckAgent
 var _ metrics.HealthCheckAgent = &eth1Client{}
 
+// verifies that the client implements ComponentHealthAgent
+var _ metrics.ComponentHealthAgent = &eth1Client{}
+
 // NewEth1Client creates a new instance
 func NewEth1Client(opts ClientOptions) (eth1.Client, error) {
 	logger := opts.Logger.With(zap.String("component", "eth1GoETH"),
@@ -71,6 +93,7 @@ func NewEth1Client(opts ClientOptions) (eth1.Client, error) {
 		registryContractAddr: opts.RegistryContractAddr,
 		contractABI:          opts.ContractABI,
 		connectionTimeout:    opts.ConnectionTimeout,
+		followDistance:       opts.FollowDistance,
 		eventsFeed:           new(event.Feed),
 		abiVersion:           opts.AbiVersion,
 	}
@@ -106,26 +129,60 @@ func (ec *eth1Client) Sync(fromBlock *big.Int) error {
 	return err
 }
 
+// BlockHash returns the hash of the canonical block with the given number
+func (ec *eth1Client) BlockHash(blockNumber *big.Int) (common.Hash, error) {
+	return ec.blockHash(blockNumber.Uint64())
+}
+
+// blockHash fetches the hash of the block from the node rather than computing the hash of the header,
+// as the header might contain fields that are unknown to this version of go-ethereum
+func (ec *eth1Client) blockHash(blockNumber uint64) (common.Hash, error) {
+	var block struct {
+		Hash common.Hash `json:"hash"`
+	}
+	err := ec.rpcClient().CallContext(ec.ctx, &block, "eth_getBlockByNumber", hexutil.EncodeUint64(blockNumber), false)
+	if err != nil {
+		return common.Hash{}, errors.Wrap(err, "failed to get block")
+	}
+	if block.Hash == (common.Hash{}) {
+		return common.Hash{}, errors.Errorf("block %d not found", blockNumber)
+	}
+	return block.Hash, nil
+}
+
 // HealthCheck provides health status of eth1 node
 func (ec *eth1Client) HealthCheck() []string {
-	if ec.conn == nil {
-		return []string{"not connected to eth1 node"}
+	return ec.HealthStatus().Errors
+}
+
+// HealthStatus provides health status of eth1 node, including its head block
+func (ec *eth1Client) HealthStatus() metrics.ComponentStatus {
+	conn := ec.client()
+	if conn == nil {
+		return metrics.NewComponentStatus("eth1", []string{"not connected to eth1 node"}, nil)
 	}
 	ctx, cancel := context.WithTimeout(ec.ctx, healthCheckTimeout)
 	defer cancel()
-	sp, err := ec.conn.SyncProgress(ctx)
+	sp, err := conn.SyncProgress(ctx)
 	if err != nil {
 		reportNodeStatus(statusUnknown)
-		return []string{"could not get eth1 node sync progress"}
+		return metrics.NewComponentStatus("eth1", []string{"could not get eth1 node sync progress"}, nil)
 	}
 	if sp != nil {
 		reportNodeStatus(statusSyncing)
-		return []string{fmt.Sprintf("eth1 node is currently syncing: starting=%d, current=%d, highest=%d",
-			sp.StartingBlock, sp.CurrentBlock, sp.HighestBlock)}
+		return metrics.NewComponentStatus("eth1", []string{fmt.Sprintf("eth1 node is currently syncing: starting=%d, current=%d, highest=%d",
+			sp.StartingBlock, sp.CurrentBlock, sp.HighestBlock)}, map[string]interface{}{
+			"current_block": sp.CurrentBlock,
+			"highest_block": sp.HighestBlock,
+		})
 	}
 	// eth1 node is connected and synced
 	reportNodeStatus(statusOK)
-	return []string{}
+	details := map[string]interface{}{}
+	if head, err := conn.BlockNumber(ctx); err == nil {
+		details["head_block"] = head
+	}
+	return metrics.NewComponentStatus("eth1", []string{}, details)
 }
 
 // connect connects to eth1 client
@@ -134,16 +191,45 @@ func (ec *eth1Client) connect() error {
 	ec.logger.Info("dialing eth1 node...")
 	ctx, cancel := context.WithTimeout(context.Background(), ec.connectionTimeout)
 	defer cancel()
-	conn, err := ethclient.DialContext(ctx, ec.nodeAddr)
+	rpcClient, err := rpc.DialContext(ctx, ec.endpoint())
 	if err != nil {
 		ec.logger.Error("could not connect to the eth1 client", zap.Error(err))
 		return err
 	}
 	ec.logger.Info("successfully connected to eth1 goETH")
-	ec.conn = conn
+	ec.connLock.Lock()
+	prev := ec.rpc
+	ec.rpc = rpcClient
+	ec.conn = ethclient.NewClient(rpcClient)
+	ec.connLock.Unlock()
+	if prev != nil {
+		// closing the previous connection (e.g. after reconnection)
+		prev.Close()
+	}
 	return nil
 }
 
+// client returns the current connection
+func (ec *eth1Client) client() *ethclient.Client {
+	ec.connLock.RLock()
+	defer ec.connLock.RUnlock()
+	return ec.conn
+}
+
+// rpcClient returns the rpc client of the current connection
+func (ec *eth1Client) rpcClient() *rpc.Client {
+	ec.connLock.RLock()
+	defer ec.connLock.RUnlock()
+	return ec.rpc
+}
+
+// endpoint returns the address of the current node
+func (ec *eth1Client) endpoint() string {
+	ec.connLock.RLock()
+	defer ec.connLock.RUnlock()
+	return ec.nodeAddr
+}
+
 // reconnect tries to reconnect multiple times with an exponent interval
 func (ec *eth1Client) reconnect() {
 	limit := 64 * time.Second
@@ -175,15 +261,31 @@ func (ec *eth1Client) fireEvent(log types.Log, name string, data interface{}) {
 	//ec.logger.Debug("events was sent to subscribers", zap.Int("num of subscribers", n))
 }
 
-// streamSmartContractEvents sync events history of the given contract
+// streamSmartContractEvents streams events of the given contract.
+// if a follow distance was configured, events are processed only once their block reached the follow distance
 func (ec *eth1Client) streamSmartContractEvents() error {
-	ec.logger.Debug("streaming smart contract events")
+	ec.logger.Debug("streaming smart contract events", zap.Uint64("followDistance", ec.followDistance))
 
 	contractAbi, err := abi.JSON(strings.NewReader(ec.contractABI))
 	if err != nil {
 		return errors.Wrap(err, "failed to parse ABI interface")
 	}
 
+	if ec.followDistance > 0 {
+		heads := make(chan *types.Header)
+		sub, err := ec.client().SubscribeNewHead(ec.ctx, heads)
+		if err != nil {
+			return errors.Wrap(err, "Failed to subscribe to new heads")
+		}
+		ec.logger.Debug("subscribed to new heads")
+		go func() {
+			if err := ec.listenToHeads(heads, sub, contractAbi); err != nil {
+				ec.reconnect()
+			}
+		}()
+		return nil
+	}
+
 	sub, logs, err := ec.subscribeToLogs()
 	if err != nil {
 		return errors.Wrap(err, "Failed to subscribe to logs")
@@ -204,7 +306,7 @@ func (ec *eth1Client) subscribeToLogs() (ethereum.Subscription, chan types.Log,
 		Addresses: []common.Address{contractAddress},
 	}
 	logs := make(chan types.Log)
-	sub, err := ec.conn.SubscribeFilterLogs(ec.ctx, query, logs)
+	sub, err := ec.client().SubscribeFilterLogs(ec.ctx, query, logs)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "Failed to subscribe to logs")
 	}
@@ -213,7 +315,8 @@ func (ec *eth1Client) subscribeToLogs() (ethereum.Subscription, chan types.Log,
 	return sub, logs, nil
 }
 
-// listenToSubscription listen to new event logs from the contract
+// listenToSubscription listen to new event logs from the contract,
+// removed logs (due to reorgs) are passed on to observers so they could revert the changes
 func (ec *eth1Client) listenToSubscription(logs chan types.Log, sub ethereum.Subscription, contractAbi abi.ABI) error {
 	for {
 		select {
@@ -221,7 +324,13 @@ func (ec *eth1Client) listenToSubscription(logs chan types.Log, sub ethereum.Sub
 			ec.logger.Warn("failed to read logs from subscription", zap.Error(err))
 			return err
 		case vLog := <-logs:
-			ec.logger.Debug("received contract event from stream")
+			if vLog.Removed {
+				ec.logger.Debug("received removed contract event from stream",
+					zap.Uint64("block", vLog.BlockNumber), zap.String("txHash", vLog.TxHash.Hex()))
+				metricReorgedEventsCount.Inc()
+			} else {
+				ec.logger.Debug("received contract event from stream")
+			}
 			err := ec.handleEvent(vLog, contractAbi)
 			if err != nil {
 				ec.logger.Error("Failed to handle event", zap.Error(err))
@@ -231,67 +340,172 @@ func (ec *eth1Client) listenToSubscription(logs chan types.Log, sub ethereum.Sub
 	}
 }
 
-// syncSmartContractsEvents sync events history of the given contract
+// listenToHeads listen to new blocks and process the events of confirmed blocks
+func (ec *eth1Client) listenToHeads(heads chan *types.Header, sub ethereum.Subscription, contractAbi abi.ABI) error {
+	defer sub.Unsubscribe()
+	for {
+		select {
+		case err := <-sub.Err():
+			ec.logger.Warn("failed to read new heads from subscription", zap.Error(err))
+			return err
+		case head := <-heads:
+			if head == nil || head.Number == nil {
+				continue
+			}
+			if err := ec.processConfirmedBlocks(head.Number.Uint64(), contractAbi); err != nil {
+				ec.logger.Warn("failed to process confirmed blocks", zap.Uint64("head", head.Number.Uint64()), zap.Error(err))
+			}
+		}
+	}
+}
+
+// processConfirmedBlocks reverts events of blocks that were dropped by a reorg,
+// and then fetches and handles events of new blocks that reached the follow distance
+func (ec *eth1Client) processConfirmedBlocks(head uint64, contractAbi abi.ABI) error {
+	confirmedBlock := ec.confirmedBlock(head)
+	lastBlock := atomic.LoadUint64(&ec.lastBlock)
+	if lastBlock == 0 {
+		// no sync was done, starting from the current confirmed block
+		hash, err := ec.blockHash(confirmedBlock)
+		if err != nil {
+			return err
+		}
+		ec.reorgs.track(confirmedBlock, hash, nil)
+		atomic.StoreUint64(&ec.lastBlock, confirmedBlock)
+		return nil
+	}
+	if confirmedBlock <= lastBlock {
+		return nil
+	}
+
+	forkBlock, orphaned, reorged, err := ec.reorgs.revert(ec.blockHash)
+	if err != nil {
+		return err
+	}
+	if reorged {
+		ec.logger.Warn("detected eth1 reorg deeper than follow distance, reverting events of orphaned blocks",
+			zap.Uint64("forkBlock", forkBlock), zap.Int("orphanedEvents", len(orphaned)))
+		metricReorgedEventsCount.Add(float64(len(orphaned)))
+		for _, vLog := range orphaned {
+			if err := ec.handleEvent(vLog, contractAbi); err != nil {
+				ec.logger.Error("Failed to revert event", zap.Error(err))
+			}
+		}
+		lastBlock = forkBlock
+		atomic.StoreUint64(&ec.lastBlock, lastBlock)
+	}
+
+	toBlock := confirmedBlock
+	if toBlock-lastBlock > blocksInBatch {
+		// the rest of the blocks will be processed on the next head
+		toBlock = lastBlock + blocksInBatch
+	}
+	// the hash is fetched before the logs, so a reorg that happens in between will be detected on the next head
+	hash, err := ec.blockHash(toBlock)
+	if err != nil {
+		return err
+	}
+	logs, _, processedBlock, err := ec.fetchAndProcessBatch(lastBlock+1, toBlock, contractAbi)
+	if err != nil {
+		return err
+	}
+	if processedBlock != toBlock {
+		// batch was reduced, hash must match the last processed block
+		if hash, err = ec.blockHash(processedBlock); err != nil {
+			return err
+		}
+	}
+	ec.reorgs.track(processedBlock, hash, logs)
+	atomic.StoreUint64(&ec.lastBlock, processedBlock)
+	return nil
+}
+
+// confirmedBlock returns the last block that reached the follow distance
+func (ec *eth1Client) confirmedBlock(head uint64) uint64 {
+	if head < ec.followDistance {
+		return 0
+	}
+	return head - ec.followDistance
+}
+
+// syncProgress keeps track of the events that were emitted during a sync,
+// so a failed sync could be resumed without emitting the same events again
+type syncProgress struct {
+	// next is the first block that its events were not emitted yet
+	next     uint64
+	logs     []types.Log
+	nSuccess int
+}
+
+// syncSmartContractsEvents sync events history of the given contract, up to the last confirmed block
 func (ec *eth1Client) syncSmartContractsEvents(fromBlock *big.Int) error {
-	ec.logger.Debug("syncing smart contract events", zap.Uint64("fromBlock", fromBlock.Uint64()))
+	return ec.resumeSync(&syncProgress{next: fromBlock.Uint64()})
+}
+
+// resumeSync syncs events history from the next block of the given progress, up to the last confirmed block.
+// the progress is updated after each batch, so in case of an error it could be resumed (e.g. with another endpoint)
+func (ec *eth1Client) resumeSync(progress *syncProgress) error {
+	ec.logger.Debug("syncing smart contract events", zap.Uint64("fromBlock", progress.next))
 
 	contractAbi, err := abi.JSON(strings.NewReader(ec.contractABI))
 	if err != nil {
 		return errors.Wrap(err, "failed to parse ABI interface")
 	}
-	currentBlock, err := ec.conn.BlockNumber(ec.ctx)
+	currentBlock, err := ec.client().BlockNumber(ec.ctx)
 	if err != nil {
 		return errors.Wrap(err, "failed to get current block")
 	}
-	var logs []types.Log
-	var nSuccess int
-	for {
-		var toBlock *big.Int
-		if currentBlock-fromBlock.Uint64() > blocksInBatch {
-			toBlock = big.NewInt(int64(fromBlock.Uint64() + blocksInBatch))
-		} else { // no more batches are required -> setting toBlock to nil
-			toBlock = nil
+	confirmedBlock := ec.confirmedBlock(currentBlock)
+	for progress.next <= confirmedBlock {
+		toBlock := confirmedBlock
+		if toBlock-progress.next > blocksInBatch {
+			toBlock = progress.next + blocksInBatch
 		}
-		_logs, _nSuccess, err := ec.fetchAndProcessEvents(fromBlock, toBlock, contractAbi)
+		logs, nSuccess, to, err := ec.fetchAndProcessBatch(progress.next, toBlock, contractAbi)
 		if err != nil {
-			// in case request exceeded limit, try again with less blocks
-			// will stop after log(blocksInBatch) tries
-			if !strings.Contains(err.Error(), "websocket: read limit exceeded") {
-				return errors.Wrap(err, "failed to get events")
-			}
-			currentBatchSize := int64(blocksInBatch)
-		retryLoop:
-			for currentBatchSize > 1 {
-				currentBatchSize /= 2
-				ec.logger.Debug("using a lower batch size", zap.Int64("currentBatchSize", currentBatchSize))
-				toBlock = big.NewInt(int64(fromBlock.Uint64()) + currentBatchSize)
-				_logs, _nSuccess, err = ec.fetchAndProcessEvents(fromBlock, toBlock, contractAbi)
-				if err != nil {
-					if !strings.Contains(err.Error(), "websocket: read limit exceeded") {
-						return errors.Wrap(err, "failed to get events")
-					}
-					// limit exceeded
-					continue retryLoop
-				}
-				// done
-				break retryLoop
-			}
-		}
-		nSuccess += _nSuccess
-		logs = append(logs, _logs...)
-		if toBlock == nil { // finished
-			break
+			return err
 		}
-		fromBlock = toBlock
+		progress.nSuccess += nSuccess
+		progress.logs = append(progress.logs, logs...)
+		progress.next = to + 1
 	}
-	ec.logger.Debug("finished syncing registry contract",
-		zap.Int("total events", len(logs)), zap.Int("total success", nSuccess))
+	if progress.next > 0 {
+		atomic.StoreUint64(&ec.lastBlock, progress.next-1)
+	}
+	ec.logger.Debug("finished syncing registry contract", zap.Uint64("confirmedBlock", confirmedBlock),
+		zap.Int("total events", len(progress.logs)), zap.Int("total success", progress.nSuccess))
 	// publishing SyncEndedEvent so other components could track the sync
-	ec.fireEvent(types.Log{}, "SyncEndedEvent", eth1.SyncEndedEvent{Logs: logs, Success: nSuccess == len(logs)})
+	ec.fireEvent(types.Log{}, "SyncEndedEvent", eth1.SyncEndedEvent{Logs: progress.logs, Success: progress.nSuccess == len(progress.logs)})
 
 	return nil
 }
 
+// fetchAndProcessBatch fetches and processes the events of the given blocks range.
+// in case the request exceeded the read limit, it tries again with less blocks (will stop after log(batch size) tries).
+// returns the last block that was processed
+func (ec *eth1Client) fetchAndProcessBatch(fromBlock, toBlock uint64, contractAbi abi.ABI) ([]types.Log, int, uint64, error) {
+	for {
+		logs, nSuccess, err := ec.fetchAndProcessEvents(new(big.Int).SetUint64(fromBlock), new(big.Int).SetUint64(toBlock), contractAbi)
+		if err == nil {
+			return logs, nSuccess, toBlock, nil
+		}
+		if !isLimitExceeded(err) || toBlock == fromBlock {
+			return nil, 0, 0, errors.Wrap(err, "failed to get events")
+		}
+		toBlock = fromBlock + (toBlock-fromBlock)/2
+		ec.logger.Debug("using a lower batch size", zap.Uint64("currentBatchSize", toBlock-fromBlock))
+	}
+}
+
+// isLimitExceeded returns true if the error was caused by a response that is too large,
+// either by the websocket read limit or by the results limit of http providers
+func isLimitExceeded(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "read limit exceeded") ||
+		strings.Contains(msg, "query returned more than") ||
+		strings.Contains(msg, "block range")
+}
+
 func (ec *eth1Client) fetchAndProcessEvents(fromBlock, toBlock *big.Int, contractAbi abi.ABI) ([]types.Log, int, error) {
 	logger := ec.logger.With(zap.Int64("fromBlock", fromBlock.Int64()))
 	contractAddress := common.HexToAddress(ec.registryContractAddr)
@@ -304,7 +518,7 @@ func (ec *eth1Client) fetchAndProcessEvents(fromBlock, toBlock *big.Int, contrac
 		logger = logger.With(zap.Int64("toBlock", toBlock.Int64()))
 	}
 	logger.Debug("fetching event logs")
-	logs, err := ec.conn.FilterLogs(ec.ctx, query)
+	logs, err := ec.client().FilterLogs(ec.ctx, query)
 	if err != nil {
 		return nil, 0, errors.Wrap(err, "failed to get event logs")
 	}
@@ -381,6 +595,33 @@ func (ec *eth1Client) handleEvent(vLog types.Log, contractAbi abi.ABI) error {
 			return errors.Wrap(err, "failed to parse AccountEnabled event")
 		}
 		ec.fireEvent(vLog, eventName, *parsed)
+	case abiparser.ValidatorUpdated:
+		parsed, err := abiParser.ParseValidatorUpdatedEvent(vLog.Data, contractAbi)
+		reportSyncEvent(eventName, err)
+		if err != nil {
+			return errors.Wrap(err, "failed to parse ValidatorUpdated event")
+		}
+		if parsed != nil {
+			ec.fireEvent(vLog, eventName, *parsed)
+		}
+	case abiparser.OperatorRemoved:
+		parsed, err := abiParser.ParseOperatorRemovedEvent(vLog.Data, vLog.Topics, contractAbi)
+		reportSyncEvent(eventName, err)
+		if err != nil {
+			return errors.Wrap(err, "failed to parse OperatorRemoved event")
+		}
+		if parsed != nil {
+			ec.fireEvent(vLog, eventName, *parsed)
+		}
+	case abiparser.OperatorFeeSet, abiparser.OperatorFeeSetCanceled, abiparser.OperatorFeeApproved:
+		parsed, err := abiParser.ParseOperatorFeeEvent(eventName, vLog.Data, vLog.Topics, contractAbi)
+		reportSyncEvent(eventName, err)
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse %s event", eventName)
+		}
+		if parsed != nil {
+			ec.fireEvent(vLog, eventName, *parsed)
+		}
 
 	default:
 		ec.logger.Debug("unknown contract event was received", zap.String("hash", vLog.TxHash.Hex()), zap.String("eventName", eventName))
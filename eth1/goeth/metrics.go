@@ -17,6 +17,10 @@ var (
 		Name: "ssv:eth1:sync:count:failed",
 		Help: "Count failed eth1 sync events",
 	}, []string{"etype"})
+	metricReorgedEventsCount = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "ssv:eth1:reorged_events:count",
+		Help: "Count eth1 events that were removed due to a reorg",
+	})
 	metricsEth1NodeStatus = promauto.NewGauge(prometheus.GaugeOpts{
 		Name: "ssv:eth1:node_status",
 		Help: "Status of the connected eth1 node",
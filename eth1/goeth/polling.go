@@ -0,0 +1,205 @@
+package goeth
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/async/event"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/eth1"
+	"github.com/bloxapp/ssv/monitoring/metrics"
+)
+
+const (
+	defaultPollInterval      = 12 * time.Second
+	defaultConnectionTimeout = 10 * time.Second
+	maxFailoverBackoff       = 64 * time.Second
+)
+
+// pollingClient is an eth1 client that polls the contract logs (eth_getLogs) over HTTP
+// rather than subscribing to them, and fails over between the given endpoints
+type pollingClient struct {
+	*eth1Client
+
+	endpoints []string
+	// endpointIdx is guarded by connLock
+	endpointIdx  int
+	failures     int
+	pollInterval time.Duration
+}
+
+// verifies that the client implements HealthCheckAgent
+var _ metrics.HealthCheckAgent = &pollingClient{}
+
+// NewPollingClient creates a new instance of a polling client,
+// opts.NodeAddr is the primary endpoint and opts.FallbackAddrs are used for failover
+func NewPollingClient(opts ClientOptions) (eth1.Client, error) {
+	logger := opts.Logger.With(zap.String("component", "eth1Polling"),
+		zap.String("address", opts.RegistryContractAddr))
+	endpoints := append([]string{opts.NodeAddr}, opts.FallbackAddrs...)
+	logger.Info("eth1 addresses", zap.Strings("addresses", endpoints))
+
+	pollInterval := opts.PollInterval
+	if pollInterval == 0 {
+		pollInterval = defaultPollInterval
+	}
+	connectionTimeout := opts.ConnectionTimeout
+	if connectionTimeout == 0 {
+		connectionTimeout = defaultConnectionTimeout
+	}
+	pc := pollingClient{
+		eth1Client: &eth1Client{
+			ctx:                  opts.Ctx,
+			logger:               logger,
+			nodeAddr:             opts.NodeAddr,
+			registryContractAddr: opts.RegistryContractAddr,
+			contractABI:          opts.ContractABI,
+			connectionTimeout:    connectionTimeout,
+			followDistance:       opts.FollowDistance,
+			eventsFeed:           new(event.Feed),
+			abiVersion:           opts.AbiVersion,
+		},
+		endpoints:    endpoints,
+		pollInterval: pollInterval,
+	}
+
+	var err error
+	for range endpoints {
+		if err = pc.connect(); err == nil {
+			break
+		}
+		pc.nextEndpoint()
+	}
+	if err != nil {
+		logger.Error("failed to connect to the Ethereum client", zap.Error(err))
+		return nil, err
+	}
+
+	return &pc, nil
+}
+
+// EventsFeed returns the contract events feed
+func (pc *pollingClient) EventsFeed() *event.Feed {
+	return pc.eventsFeed
+}
+
+// Start polls new blocks and processes the events of the confirmed blocks
+func (pc *pollingClient) Start() error {
+	contractAbi, err := abi.JSON(strings.NewReader(pc.contractABI))
+	if err != nil {
+		pc.logger.Error("Failed to init operator contract address subject", zap.Error(err))
+		return errors.Wrap(err, "failed to parse ABI interface")
+	}
+	go pc.poll(contractAbi)
+	return nil
+}
+
+// Sync reads events history, in case of an error it tries again with the other endpoints.
+// the sync is resumed from the first block that its events were not emitted yet, so events are emitted only once
+func (pc *pollingClient) Sync(fromBlock *big.Int) error {
+	progress := &syncProgress{next: fromBlock.Uint64()}
+	err := pc.withFailover(func() error {
+		return pc.resumeSync(progress)
+	})
+	if err != nil {
+		pc.logger.Error("Failed to sync contract events", zap.Error(err))
+	}
+	return err
+}
+
+// BlockHash returns the hash of the canonical block with the given number
+func (pc *pollingClient) BlockHash(blockNumber *big.Int) (common.Hash, error) {
+	var hash common.Hash
+	err := pc.withFailover(func() error {
+		var err error
+		hash, err = pc.blockHash(blockNumber.Uint64())
+		return err
+	})
+	return hash, err
+}
+
+// poll checks for new blocks every poll interval
+func (pc *pollingClient) poll(contractAbi abi.ABI) {
+	pc.logger.Debug("polling smart contract events",
+		zap.Duration("pollInterval", pc.pollInterval), zap.Uint64("followDistance", pc.followDistance))
+	ticker := time.NewTicker(pc.pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-pc.ctx.Done():
+			return
+		case <-ticker.C:
+			if err := pc.pollOnce(contractAbi); err != nil {
+				pc.logger.Warn("failed to poll contract events", zap.String("endpoint", pc.endpoint()), zap.Error(err))
+				pc.failover()
+				continue
+			}
+			pc.failures = 0
+		}
+	}
+}
+
+// pollOnce processes the blocks that were confirmed since the last poll
+func (pc *pollingClient) pollOnce(contractAbi abi.ABI) error {
+	ctx, cancel := context.WithTimeout(pc.ctx, pc.connectionTimeout)
+	defer cancel()
+	head, err := pc.client().BlockNumber(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get current block")
+	}
+	return pc.processConfirmedBlocks(head, contractAbi)
+}
+
+// withFailover runs the given function with each of the endpoints until it succeeds,
+// returns the last error if all the endpoints failed
+func (pc *pollingClient) withFailover(f func() error) error {
+	var err error
+	for i := range pc.endpoints {
+		if i > 0 {
+			pc.nextEndpoint()
+			if err = pc.connect(); err != nil {
+				continue
+			}
+		}
+		if err = f(); err == nil {
+			return nil
+		}
+		pc.logger.Warn("eth1 endpoint failed", zap.String("endpoint", pc.endpoint()), zap.Error(err))
+	}
+	return err
+}
+
+// failover switches to the next endpoint, once all the endpoints failed it backs off exponentially
+func (pc *pollingClient) failover() {
+	pc.failures++
+	if rounds := pc.failures / len(pc.endpoints); rounds > 0 && pc.failures%len(pc.endpoints) == 0 {
+		backoff := pc.pollInterval << uint(rounds)
+		if backoff > maxFailoverBackoff || backoff <= 0 {
+			backoff = maxFailoverBackoff
+		}
+		pc.logger.Warn("all eth1 endpoints failed, backing off", zap.Duration("backoff", backoff))
+		select {
+		case <-pc.ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+	}
+	pc.nextEndpoint()
+	if err := pc.connect(); err != nil {
+		pc.logger.Warn("could not connect to eth1 endpoint", zap.String("endpoint", pc.endpoint()), zap.Error(err))
+	}
+}
+
+// nextEndpoint moves to the next endpoint, the caller is responsible for connecting to it
+func (pc *pollingClient) nextEndpoint() {
+	pc.connLock.Lock()
+	defer pc.connLock.Unlock()
+	pc.endpointIdx = (pc.endpointIdx + 1) % len(pc.endpoints)
+	pc.nodeAddr = pc.endpoints[pc.endpointIdx]
+}
@@ -0,0 +1,242 @@
+package goeth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/eth1"
+	"github.com/bloxapp/ssv/eth1/abiparser"
+)
+
+// mockRPCServer is a minimal json-rpc server that serves the registry contract logs
+type mockRPCServer struct {
+	lock sync.Mutex
+	head uint64
+	// logs holds the blocks that contain an OperatorAdded log
+	logs []uint64
+	// failLogsFrom fails requests of logs from the given block and on, zero means no failures
+	failLogsFrom uint64
+}
+
+func (s *mockRPCServer) setHead(head uint64, logs ...uint64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.head = head
+	s.logs = append(s.logs, logs...)
+}
+
+func (s *mockRPCServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ID     json.RawMessage   `json:"id"`
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var result interface{}
+	switch req.Method {
+	case "eth_blockNumber":
+		result = hexutil.Uint64(s.head)
+	case "eth_getBlockByNumber":
+		var number hexutil.Uint64
+		_ = json.Unmarshal(req.Params[0], &number)
+		result = map[string]string{"hash": fmt.Sprintf("0x%064x", uint64(number))}
+	case "eth_getLogs":
+		var filter struct {
+			FromBlock hexutil.Uint64 `json:"fromBlock"`
+			ToBlock   hexutil.Uint64 `json:"toBlock"`
+		}
+		_ = json.Unmarshal(req.Params[0], &filter)
+		if s.failLogsFrom > 0 && uint64(filter.FromBlock) >= s.failLogsFrom {
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+		logs := make([]map[string]interface{}, 0)
+		for _, block := range s.logs {
+			if block < uint64(filter.FromBlock) || block > uint64(filter.ToBlock) {
+				continue
+			}
+			var l map[string]interface{}
+			_ = json.Unmarshal([]byte(rawOperatorAdded), &l)
+			l["blockNumber"] = hexutil.Uint64(block).String()
+			l["blockHash"] = fmt.Sprintf("0x%064x", block)
+			logs = append(logs, l)
+		}
+		result = logs
+	default:
+		http.Error(w, "unknown method", http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": result})
+}
+
+func TestPollingClient(t *testing.T) {
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "rate limited", http.StatusTooManyRequests)
+	}))
+	defer failing.Close()
+	rpcServer := &mockRPCServer{}
+	rpcServer.setHead(0x49f600, 0x49f59c)
+	healthy := httptest.NewServer(rpcServer)
+	defer healthy.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client, err := NewPollingClient(ClientOptions{
+		Ctx:                  ctx,
+		Logger:               zap.L(),
+		NodeAddr:             failing.URL,
+		FallbackAddrs:        []string{healthy.URL},
+		RegistryContractAddr: "0x9573c41f0ed8b72f3bd6a9ba6e3e15426a0aa65b",
+		ContractABI:          eth1.ContractABI(eth1.Legacy),
+		AbiVersion:           eth1.Legacy,
+		FollowDistance:       8,
+		PollInterval:         10 * time.Millisecond,
+	})
+	require.NoError(t, err)
+
+	cn := make(chan *eth1.Event, 16)
+	sub := client.EventsFeed().Subscribe(cn)
+	defer sub.Unsubscribe()
+
+	t.Run("sync with failover", func(t *testing.T) {
+		require.NoError(t, client.Sync(big.NewInt(0x49f500)))
+		e := <-cn
+		require.Equal(t, abiparser.OperatorAdded, e.Name)
+		require.EqualValues(t, 0x49f59c, e.Log.BlockNumber)
+		e = <-cn
+		syncEnded, ok := e.Data.(eth1.SyncEndedEvent)
+		require.True(t, ok)
+		require.True(t, syncEnded.Success)
+		require.Len(t, syncEnded.Logs, 1)
+	})
+
+	t.Run("poll confirmed blocks", func(t *testing.T) {
+		require.NoError(t, client.Start())
+		// the new log is not processed until it reaches the follow distance
+		rpcServer.setHead(0x49f605, 0x49f602)
+		select {
+		case e := <-cn:
+			t.Fatalf("unexpected event in block %d", e.Log.BlockNumber)
+		case <-time.After(100 * time.Millisecond):
+		}
+		rpcServer.setHead(0x49f60a)
+		select {
+		case e := <-cn:
+			require.Equal(t, abiparser.OperatorAdded, e.Name)
+			require.EqualValues(t, 0x49f602, e.Log.BlockNumber)
+			require.False(t, e.Log.Removed)
+		case <-time.After(2 * time.Second):
+			t.Fatal("event was not polled")
+		}
+	})
+
+	t.Run("block hash", func(t *testing.T) {
+		hash, err := client.BlockHash(big.NewInt(10))
+		require.NoError(t, err)
+		require.Equal(t, fmt.Sprintf("0x%064x", 10), hash.Hex())
+	})
+}
+
+func TestPollingClient_SyncResume(t *testing.T) {
+	// the first endpoint fails in the middle of the sync, after the events of the first batch were emitted
+	failing := &mockRPCServer{failLogsFrom: blocksInBatch + 1}
+	failing.setHead(2*blocksInBatch+10, 10, blocksInBatch+10)
+	failingServer := httptest.NewServer(failing)
+	defer failingServer.Close()
+	healthy := &mockRPCServer{}
+	healthy.setHead(2*blocksInBatch+10, 10, blocksInBatch+10)
+	healthyServer := httptest.NewServer(healthy)
+	defer healthyServer.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client, err := NewPollingClient(ClientOptions{
+		Ctx:                  ctx,
+		Logger:               zap.L(),
+		NodeAddr:             failingServer.URL,
+		FallbackAddrs:        []string{healthyServer.URL},
+		RegistryContractAddr: "0x9573c41f0ed8b72f3bd6a9ba6e3e15426a0aa65b",
+		ContractABI:          eth1.ContractABI(eth1.Legacy),
+		AbiVersion:           eth1.Legacy,
+		FollowDistance:       8,
+	})
+	require.NoError(t, err)
+
+	cn := make(chan *eth1.Event, 16)
+	sub := client.EventsFeed().Subscribe(cn)
+	defer sub.Unsubscribe()
+
+	require.NoError(t, client.Sync(big.NewInt(0)))
+	// each event is emitted once
+	for _, block := range []uint64{10, blocksInBatch + 10} {
+		e := <-cn
+		require.Equal(t, abiparser.OperatorAdded, e.Name)
+		require.Equal(t, block, e.Log.BlockNumber)
+	}
+	e := <-cn
+	syncEnded, ok := e.Data.(eth1.SyncEndedEvent)
+	require.True(t, ok)
+	require.True(t, syncEnded.Success)
+	require.Len(t, syncEnded.Logs, 2)
+	require.Empty(t, cn)
+}
+
+func TestPollingClient_ConcurrentFailover(t *testing.T) {
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "rate limited", http.StatusTooManyRequests)
+	}))
+	defer failing.Close()
+	rpcServer := &mockRPCServer{}
+	rpcServer.setHead(100)
+	healthy := httptest.NewServer(rpcServer)
+	defer healthy.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client, err := NewPollingClient(ClientOptions{
+		Ctx:                  ctx,
+		Logger:               zap.L(),
+		NodeAddr:             failing.URL,
+		FallbackAddrs:        []string{healthy.URL, failing.URL},
+		RegistryContractAddr: "0x9573c41f0ed8b72f3bd6a9ba6e3e15426a0aa65b",
+		ContractABI:          eth1.ContractABI(eth1.Legacy),
+		AbiVersion:           eth1.Legacy,
+		PollInterval:         time.Millisecond,
+	})
+	require.NoError(t, err)
+	// polling fails over between the endpoints while syncing and fetching block hashes,
+	// the connection must not be replaced while it is used (checked by the race detector)
+	require.NoError(t, client.Start())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_ = client.Sync(big.NewInt(90))
+		}()
+		go func() {
+			defer wg.Done()
+			_, _ = client.BlockHash(big.NewInt(10))
+		}()
+	}
+	wg.Wait()
+}
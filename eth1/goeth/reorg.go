@@ -0,0 +1,95 @@
+package goeth
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+)
+
+// maxCheckpoints is the amount of processed blocks that are tracked for reorgs
+const maxCheckpoints = 32
+
+// blockCheckpoint is a processed block (number and hash)
+type blockCheckpoint struct {
+	number uint64
+	hash   common.Hash
+}
+
+// blockHashGetter returns the hash of the canonical block with the given number
+type blockHashGetter func(number uint64) (common.Hash, error)
+
+// reorgTracker keeps track of recently processed blocks and the logs that were handled in them,
+// so the logs of blocks that were dropped by a reorg (deeper than the follow distance) could be reverted
+type reorgTracker struct {
+	checkpoints []blockCheckpoint
+	logs        []types.Log
+}
+
+// track adds a processed block and the logs that were handled up to that block
+func (rt *reorgTracker) track(number uint64, hash common.Hash, logs []types.Log) {
+	rt.checkpoints = append(rt.checkpoints, blockCheckpoint{number: number, hash: hash})
+	rt.logs = append(rt.logs, logs...)
+	if len(rt.checkpoints) <= maxCheckpoints {
+		return
+	}
+	rt.checkpoints = rt.checkpoints[len(rt.checkpoints)-maxCheckpoints:]
+	// logs of blocks at or below the oldest checkpoint won't be reverted
+	oldest := rt.checkpoints[0].number
+	i := 0
+	for i < len(rt.logs) && rt.logs[i].BlockNumber <= oldest {
+		i++
+	}
+	rt.logs = rt.logs[i:]
+}
+
+// lastBlock returns the last tracked block
+func (rt *reorgTracker) lastBlock() (blockCheckpoint, bool) {
+	if len(rt.checkpoints) == 0 {
+		return blockCheckpoint{}, false
+	}
+	return rt.checkpoints[len(rt.checkpoints)-1], true
+}
+
+// revert checks the tracked blocks against the canonical chain.
+// in case of a reorg, it returns the last block that is still canonical and the orphaned logs (newest first),
+// the orphaned blocks and logs are removed from the tracker.
+func (rt *reorgTracker) revert(getHash blockHashGetter) (uint64, []types.Log, bool, error) {
+	forkIndex := -1
+	for i := len(rt.checkpoints) - 1; i >= 0; i-- {
+		cp := rt.checkpoints[i]
+		hash, err := getHash(cp.number)
+		if err != nil {
+			return 0, nil, false, errors.Wrap(err, "could not get block hash")
+		}
+		if hash == cp.hash {
+			forkIndex = i
+			break
+		}
+	}
+	if forkIndex == len(rt.checkpoints)-1 {
+		// no reorg
+		return 0, nil, false, nil
+	}
+	var forkBlock uint64
+	if forkIndex >= 0 {
+		forkBlock = rt.checkpoints[forkIndex].number
+	} else if oldest := rt.checkpoints[0].number; oldest > 0 {
+		// the reorg is deeper than the tracked blocks, reverting everything we know about
+		forkBlock = oldest - 1
+	}
+	rt.checkpoints = rt.checkpoints[:forkIndex+1]
+
+	i := len(rt.logs)
+	for i > 0 && rt.logs[i-1].BlockNumber > forkBlock {
+		i--
+	}
+	orphaned := make([]types.Log, 0, len(rt.logs)-i)
+	for j := len(rt.logs) - 1; j >= i; j-- {
+		l := rt.logs[j]
+		l.Removed = true
+		orphaned = append(orphaned, l)
+	}
+	rt.logs = rt.logs[:i]
+
+	return forkBlock, orphaned, true, nil
+}
@@ -0,0 +1,74 @@
+package goeth
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReorgTracker(t *testing.T) {
+	canonical := map[uint64]common.Hash{}
+	getHash := func(number uint64) (common.Hash, error) {
+		hash, ok := canonical[number]
+		if !ok {
+			return common.Hash{}, errors.New("not found")
+		}
+		return hash, nil
+	}
+	rt := &reorgTracker{}
+	for i := uint64(1); i <= 5; i++ {
+		canonical[i*10] = common.BytesToHash([]byte{byte(i)})
+		rt.track(i*10, canonical[i*10], []types.Log{{BlockNumber: i*10 - 1, Index: 0}, {BlockNumber: i * 10, Index: 1}})
+	}
+
+	t.Run("no reorg", func(t *testing.T) {
+		_, orphaned, reorged, err := rt.revert(getHash)
+		require.NoError(t, err)
+		require.False(t, reorged)
+		require.Len(t, orphaned, 0)
+		last, ok := rt.lastBlock()
+		require.True(t, ok)
+		require.EqualValues(t, 50, last.number)
+	})
+
+	t.Run("reorg", func(t *testing.T) {
+		canonical[40] = common.HexToHash("0x40")
+		canonical[50] = common.HexToHash("0x50")
+		forkBlock, orphaned, reorged, err := rt.revert(getHash)
+		require.NoError(t, err)
+		require.True(t, reorged)
+		require.EqualValues(t, 30, forkBlock)
+		require.Len(t, orphaned, 4)
+		// newest first
+		require.EqualValues(t, 50, orphaned[0].BlockNumber)
+		require.EqualValues(t, 39, orphaned[3].BlockNumber)
+		for _, l := range orphaned {
+			require.True(t, l.Removed)
+		}
+		last, ok := rt.lastBlock()
+		require.True(t, ok)
+		require.EqualValues(t, 30, last.number)
+		require.Len(t, rt.logs, 6)
+	})
+
+	t.Run("hash error", func(t *testing.T) {
+		delete(canonical, 30)
+		_, _, _, err := rt.revert(getHash)
+		require.Error(t, err)
+	})
+}
+
+func TestReorgTracker_MaxCheckpoints(t *testing.T) {
+	rt := &reorgTracker{}
+	for i := uint64(1); i <= maxCheckpoints+10; i++ {
+		rt.track(i, common.Hash{}, []types.Log{{BlockNumber: i}})
+	}
+	require.Len(t, rt.checkpoints, maxCheckpoints)
+	require.EqualValues(t, 11, rt.checkpoints[0].number)
+	// logs at or below the oldest checkpoint are dropped
+	require.Len(t, rt.logs, maxCheckpoints-1)
+	require.EqualValues(t, 12, rt.logs[0].BlockNumber)
+}
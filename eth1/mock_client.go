@@ -5,6 +5,7 @@
 package eth1
 
 import (
+	common "github.com/ethereum/go-ethereum/common"
 	gomock "github.com/golang/mock/gomock"
 	event "github.com/prysmaticlabs/prysm/async/event"
 	big "math/big"
@@ -75,3 +76,18 @@ func (mr *MockClientMockRecorder) Sync(fromBlock interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sync", reflect.TypeOf((*MockClient)(nil).Sync), fromBlock)
 }
+
+// BlockHash mocks base method
+func (m *MockClient) BlockHash(blockNumber *big.Int) (common.Hash, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockHash", blockNumber)
+	ret0, _ := ret[0].(common.Hash)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BlockHash indicates an expected call of BlockHash
+func (mr *MockClientMockRecorder) BlockHash(blockNumber interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockHash", reflect.TypeOf((*MockClient)(nil).BlockHash), blockNumber)
+}
@@ -5,6 +5,7 @@
 package eth1
 
 import (
+	common "github.com/ethereum/go-ethereum/common"
 	gomock "github.com/golang/mock/gomock"
 	reflect "reflect"
 )
@@ -61,3 +62,33 @@ func (mr *MockSyncOffsetStorageMockRecorder) GetSyncOffset() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSyncOffset", reflect.TypeOf((*MockSyncOffsetStorage)(nil).GetSyncOffset))
 }
+
+// SaveSyncBlockHash mocks base method
+func (m *MockSyncOffsetStorage) SaveSyncBlockHash(hash common.Hash) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSyncBlockHash", hash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveSyncBlockHash indicates an expected call of SaveSyncBlockHash
+func (mr *MockSyncOffsetStorageMockRecorder) SaveSyncBlockHash(hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSyncBlockHash", reflect.TypeOf((*MockSyncOffsetStorage)(nil).SaveSyncBlockHash), hash)
+}
+
+// GetSyncBlockHash mocks base method
+func (m *MockSyncOffsetStorage) GetSyncBlockHash() (common.Hash, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSyncBlockHash")
+	ret0, _ := ret[0].(common.Hash)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetSyncBlockHash indicates an expected call of GetSyncBlockHash
+func (mr *MockSyncOffsetStorageMockRecorder) GetSyncBlockHash() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSyncBlockHash", reflect.TypeOf((*MockSyncOffsetStorage)(nil).GetSyncBlockHash))
+}
@@ -5,6 +5,7 @@ import (
 	"math/big"
 	"sync"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 )
@@ -20,15 +21,25 @@ const (
 // SyncOffset is the type of variable used for passing around the offset
 type SyncOffset = big.Int
 
+// ErrReorgDetected is returned when the persisted sync offset is no longer part of the canonical chain
+var ErrReorgDetected = errors.New("eth1 reorg detected")
+
 // SyncEventHandler handles a given event
 type SyncEventHandler func(Event) error
 
+// RegistryCleaner removes the registry data that was derived from eth1 events, including the sync offset
+type RegistryCleaner func() error
+
 // SyncOffsetStorage represents the interface for compatible storage
 type SyncOffsetStorage interface {
 	// SaveSyncOffset saves the offset (block number)
 	SaveSyncOffset(offset *SyncOffset) error
 	// GetSyncOffset returns the sync offset
 	GetSyncOffset() (*SyncOffset, bool, error)
+	// SaveSyncBlockHash saves the hash of the block at the sync offset
+	SaveSyncBlockHash(hash common.Hash) error
+	// GetSyncBlockHash returns the hash of the block at the sync offset
+	GetSyncBlockHash() (common.Hash, bool, error)
 }
 
 // DefaultSyncOffset returns the default value (block number of the first event from the contract)
@@ -76,8 +87,19 @@ func SyncEth1Events(logger *zap.Logger, client Client, storage SyncOffsetStorage
 			}
 		}
 	}()
+	// stop ends the events consumer when the sync fails, as SyncEndedEvent won't arrive
+	stop := func() {
+		sub.Unsubscribe()
+		close(cn)
+		syncWg.Wait()
+	}
 	syncOffset = determineSyncOffset(logger, storage, syncOffset)
+	if err := verifySyncOffset(logger, client, storage, syncOffset); err != nil {
+		stop()
+		return err
+	}
 	if err := client.Sync(syncOffset); err != nil {
+		stop()
 		return errors.Wrap(err, "failed to sync contract events")
 	}
 	// waiting for eth1 sync to finish
@@ -91,23 +113,68 @@ func SyncEth1Events(logger *zap.Logger, client Client, storage SyncOffsetStorage
 	return upgradeSyncOffset(logger, storage, syncOffset, syncEndedEvent)
 }
 
+// SyncEth1EventsOrResync syncs past events (see SyncEth1Events).
+// if the persisted sync offset is not part of the canonical chain, events that were already handled might have been dropped by a reorg,
+// in that case the registry data is cleaned and all events are synced again from the given offset
+func SyncEth1EventsOrResync(logger *zap.Logger, client Client, storage SyncOffsetStorage, syncOffset *SyncOffset,
+	handler SyncEventHandler, clean RegistryCleaner) error {
+	err := SyncEth1Events(logger, client, storage, syncOffset, handler)
+	if !errors.Is(err, ErrReorgDetected) {
+		return err
+	}
+	logger.Warn("registry data might have been affected by an eth1 reorg, cleaning registry data and re-syncing", zap.Error(err))
+	if err := clean(); err != nil {
+		return errors.Wrap(err, "could not clean registry data")
+	}
+	return SyncEth1Events(logger, client, storage, syncOffset, handler)
+}
+
 // upgradeSyncOffset updates the sync offset after a sync
 func upgradeSyncOffset(logger *zap.Logger, storage SyncOffsetStorage, syncOffset *SyncOffset, syncEndedEvent SyncEndedEvent) error {
 	nResults := len(syncEndedEvent.Logs)
 	if nResults > 0 {
 		if !syncEndedEvent.Success {
 			logger.Warn("could not parse all events from eth1")
-		} else if rawOffset := syncEndedEvent.Logs[nResults-1].BlockNumber; rawOffset > syncOffset.Uint64() {
-			logger.Debug("upgrading sync offset", zap.Uint64("syncOffset", rawOffset))
-			syncOffset.SetUint64(rawOffset)
+		} else if lastLog := syncEndedEvent.Logs[nResults-1]; lastLog.BlockNumber > syncOffset.Uint64() {
+			logger.Debug("upgrading sync offset", zap.Uint64("syncOffset", lastLog.BlockNumber),
+				zap.String("blockHash", lastLog.BlockHash.Hex()))
+			syncOffset.SetUint64(lastLog.BlockNumber)
 			if err := storage.SaveSyncOffset(syncOffset); err != nil {
 				return errors.Wrap(err, "could not upgrade sync offset")
 			}
+			if err := storage.SaveSyncBlockHash(lastLog.BlockHash); err != nil {
+				return errors.Wrap(err, "could not save sync block hash")
+			}
 		}
 	}
 	return nil
 }
 
+// verifySyncOffset makes sure that the block of the persisted sync offset is still part of the canonical chain,
+// otherwise events that were already handled might have been dropped by a reorg that happened while the node was down
+func verifySyncOffset(logger *zap.Logger, client Client, storage SyncOffsetStorage, syncOffset *SyncOffset) error {
+	hash, found, err := storage.GetSyncBlockHash()
+	if err != nil {
+		return errors.Wrap(err, "could not get sync block hash")
+	}
+	if !found {
+		// block hash was not persisted by older versions
+		return nil
+	}
+	canonicalHash, err := client.BlockHash(syncOffset)
+	if err != nil {
+		return errors.Wrap(err, "could not get canonical block hash")
+	}
+	if canonicalHash != hash {
+		logger.Error("sync offset block is not part of the canonical chain",
+			zap.Uint64("syncOffset", syncOffset.Uint64()),
+			zap.String("blockHash", hash.Hex()),
+			zap.String("canonicalBlockHash", canonicalHash.Hex()))
+		return errors.Wrapf(ErrReorgDetected, "block %d", syncOffset.Uint64())
+	}
+	return nil
+}
+
 // determineSyncOffset decides what is the value of sync offset by using one of (by priority):
 //   1. last saved sync offset
 //   2. provided value (from config)
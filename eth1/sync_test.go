@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/golang/mock/gomock"
 	"github.com/prysmaticlabs/prysm/async/event"
@@ -26,7 +27,7 @@ func TestSyncEth1(t *testing.T) {
 	go func() {
 		// wait 5 ms and start to push events
 		time.Sleep(5 * time.Millisecond)
-		logs := []types.Log{{BlockNumber: rawOffset - 1}, {BlockNumber: rawOffset}}
+		logs := []types.Log{{BlockNumber: rawOffset - 1}, {BlockNumber: rawOffset, BlockHash: common.HexToHash("0x1")}}
 		eventsFeed.Send(&Event{Data: struct{}{}, Log: logs[0]})
 		eventsFeed.Send(&Event{Data: struct{}{}, Log: logs[1]})
 		eventsFeed.Send(&Event{Data: SyncEndedEvent{Logs: logs, Success: true}})
@@ -37,6 +38,103 @@ func TestSyncEth1(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, syncOffset)
 	require.Equal(t, syncOffset.Uint64(), rawOffset)
+	hash, found, err := storage.GetSyncBlockHash()
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, common.HexToHash("0x1"), hash)
+}
+
+func TestSyncEth1Reorg(t *testing.T) {
+	logger := zap.L()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storage := syncStorageMock(ctrl)
+	require.NoError(t, storage.SaveSyncOffset(DefaultSyncOffset()))
+	require.NoError(t, storage.SaveSyncBlockHash(common.HexToHash("0x1")))
+
+	t.Run("canonical block", func(t *testing.T) {
+		eth1Client, eventsFeed := eth1ClientMock(ctrl, nil)
+		eth1Client.EXPECT().BlockHash(gomock.Any()).DoAndReturn(func(blockNumber *big.Int) (common.Hash, error) {
+			require.Equal(t, DefaultSyncOffset().Uint64(), blockNumber.Uint64())
+			return common.HexToHash("0x1"), nil
+		})
+		go func() {
+			<-time.After(time.Millisecond * 10)
+			eventsFeed.Send(&Event{Data: SyncEndedEvent{Success: true}})
+		}()
+		require.NoError(t, SyncEth1Events(logger, eth1Client, storage, nil, nil))
+	})
+
+	t.Run("reorged block", func(t *testing.T) {
+		eth1Client := NewMockClient(ctrl)
+		eventsFeed := new(event.Feed)
+		eth1Client.EXPECT().EventsFeed().Return(eventsFeed)
+		eth1Client.EXPECT().BlockHash(gomock.Any()).Return(common.HexToHash("0x2"), nil)
+		err := SyncEth1Events(logger, eth1Client, storage, nil, nil)
+		require.True(t, errors.Is(err, ErrReorgDetected))
+		// the events consumer was stopped
+		require.Zero(t, eventsFeed.Send(&Event{Data: SyncEndedEvent{}}))
+	})
+}
+
+func TestSyncEth1EventsOrResync(t *testing.T) {
+	logger := zap.L()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storage := syncStorageMock(ctrl)
+	require.NoError(t, storage.SaveSyncOffset(DefaultSyncOffset()))
+	require.NoError(t, storage.SaveSyncBlockHash(common.HexToHash("0x1")))
+
+	eventsFeed := new(event.Feed)
+	eth1Client := NewMockClient(ctrl)
+	eth1Client.EXPECT().EventsFeed().Return(eventsFeed).Times(2)
+	eth1Client.EXPECT().BlockHash(gomock.Any()).Return(common.HexToHash("0x2"), nil)
+	resyncFrom := DefaultSyncOffset().Uint64() - 100
+	eth1Client.EXPECT().Sync(gomock.Any()).DoAndReturn(func(fromBlock *big.Int) error {
+		// all events are synced again from the given offset
+		require.Equal(t, resyncFrom, fromBlock.Uint64())
+		logs := []types.Log{{BlockNumber: fromBlock.Uint64() + 1, BlockHash: common.HexToHash("0x3")}}
+		eventsFeed.Send(&Event{Data: struct{}{}, Log: logs[0]})
+		eventsFeed.Send(&Event{Data: SyncEndedEvent{Logs: logs, Success: true}})
+		return nil
+	})
+
+	cleaned := 0
+	clean := func() error {
+		cleaned++
+		return storage.SaveSyncOffset(new(SyncOffset))
+	}
+	handled := 0
+	handler := func(Event) error {
+		handled++
+		return nil
+	}
+	require.NoError(t, SyncEth1EventsOrResync(logger, eth1Client, storage, new(SyncOffset).SetUint64(resyncFrom), handler, clean))
+	require.Equal(t, 1, cleaned)
+	require.Equal(t, 1, handled)
+	syncOffset, found, err := storage.GetSyncOffset()
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, resyncFrom+1, syncOffset.Uint64())
+	hash, found, err := storage.GetSyncBlockHash()
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, common.HexToHash("0x3"), hash)
+
+	t.Run("clean error", func(t *testing.T) {
+		require.NoError(t, storage.SaveSyncBlockHash(common.HexToHash("0x1")))
+		eth1Client := NewMockClient(ctrl)
+		eth1Client.EXPECT().EventsFeed().Return(new(event.Feed))
+		eth1Client.EXPECT().BlockHash(gomock.Any()).Return(common.HexToHash("0x2"), nil)
+		err := SyncEth1EventsOrResync(logger, eth1Client, storage, nil, nil, func() error {
+			return errors.New("test")
+		})
+		require.EqualError(t, err, "could not clean registry data: test")
+	})
 }
 
 func TestSyncEth1Error(t *testing.T) {
@@ -133,6 +231,7 @@ func eth1ClientMock(ctrl *gomock.Controller, err error) (*MockClient, *event.Fee
 
 func syncStorageMock(ctrl *gomock.Controller) *MockSyncOffsetStorage {
 	syncOffsetStorage := make([]byte, 0)
+	var syncBlockHash *common.Hash
 
 	storage := NewMockSyncOffsetStorage(ctrl)
 	storage.EXPECT().SaveSyncOffset(gomock.Any()).DoAndReturn(func(offset *SyncOffset) error {
@@ -147,5 +246,16 @@ func syncStorageMock(ctrl *gomock.Controller) *MockSyncOffsetStorage {
 		offset.SetBytes(syncOffsetStorage)
 		return offset, true, nil
 	}).AnyTimes()
+	storage.EXPECT().SaveSyncBlockHash(gomock.Any()).DoAndReturn(func(hash common.Hash) error {
+		syncBlockHash = &hash
+		return nil
+	}).AnyTimes()
+	storage.EXPECT().GetSyncBlockHash().DoAndReturn(func() (common.Hash, bool, error) {
+		// the block hash is cleaned together with the sync offset
+		if syncBlockHash == nil || len(syncOffsetStorage) == 0 {
+			return common.Hash{}, false, nil
+		}
+		return *syncBlockHash, true, nil
+	}).AnyTimes()
 	return storage
 }
@@ -62,6 +62,18 @@ func DecidedAPIData(msgs ...*message.SignedMessage) (interface{}, error) {
 	return data, nil
 }
 
+// RegistryEventData is the data of operator / validator stream messages,
+// which are sent once a registry contract event was handled
+type RegistryEventData struct {
+	// Event is the name of the contract event
+	Event        string   `json:"event"`
+	OwnerAddress string   `json:"ownerAddress,omitempty"`
+	OperatorID   uint64   `json:"operatorId,omitempty"`
+	OperatorIDs  []uint64 `json:"operatorIds,omitempty"`
+	// Fee is the decimal representation of the operator fee
+	Fee string `json:"fee,omitempty"`
+}
+
 // MessageFilter is a criteria for query in request messages and projection in responses
 type MessageFilter struct {
 	// From is the starting index of the desired data
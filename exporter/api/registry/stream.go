@@ -0,0 +1,96 @@
+package registry
+
+import (
+	"encoding/hex"
+	"math/big"
+
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/eth1"
+	"github.com/bloxapp/ssv/eth1/abiparser"
+	"github.com/bloxapp/ssv/exporter/api"
+	"github.com/bloxapp/ssv/operator/validator"
+)
+
+// NewStreamPublisher handles registry contract events that were applied by the node,
+// it forwards operator and validator changes to websocket stream
+func NewStreamPublisher(logger *zap.Logger, ws api.WebSocketServer) validator.RegistryEventHandler {
+	logger = logger.With(zap.String("who", "RegistryEventHandler"))
+	feed := ws.BroadcastFeed()
+	return func(e eth1.Event) {
+		msg, ok := toAPIMsg(e)
+		if !ok {
+			return
+		}
+		logger.Debug("broadcast registry event stream", zap.String("event", e.Name),
+			zap.Uint64("blockNumber", e.Log.BlockNumber))
+		feed.Send(msg)
+	}
+}
+
+// toAPIMsg converts the given event into a stream message, returns false for events that are not published
+func toAPIMsg(e eth1.Event) (api.Message, bool) {
+	data := api.RegistryEventData{Event: e.Name}
+	switch ev := e.Data.(type) {
+	case abiparser.OperatorAddedEvent:
+		data.OwnerAddress = ev.OwnerAddress.Hex()
+		data.OperatorID = toUint64(ev.Id)
+		data.Fee = toDecimal(ev.Fee)
+		return api.Message{
+			Type:   api.TypeOperator,
+			Filter: api.MessageFilter{PublicKey: string(ev.PublicKey)},
+			Data:   data,
+		}, true
+	case abiparser.OperatorRemovedEvent:
+		data.OwnerAddress = ev.OwnerAddress.Hex()
+		data.OperatorID = toUint64(ev.OperatorId)
+		return api.Message{Type: api.TypeOperator, Data: data}, true
+	case abiparser.OperatorFeeEvent:
+		data.OwnerAddress = ev.OwnerAddress.Hex()
+		data.OperatorID = toUint64(ev.OperatorId)
+		data.Fee = toDecimal(ev.Fee)
+		return api.Message{Type: api.TypeOperator, Data: data}, true
+	case abiparser.ValidatorAddedEvent:
+		data.OwnerAddress = ev.OwnerAddress.Hex()
+		data.OperatorIDs = toUint64s(ev.OperatorIds)
+		return validatorMsg(ev.PublicKey, data), true
+	case abiparser.ValidatorUpdatedEvent:
+		data.OwnerAddress = ev.OwnerAddress.Hex()
+		data.OperatorIDs = toUint64s(ev.OperatorIds)
+		return validatorMsg(ev.PublicKey, data), true
+	case abiparser.ValidatorRemovedEvent:
+		data.OwnerAddress = ev.OwnerAddress.Hex()
+		return validatorMsg(ev.PublicKey, data), true
+	}
+	return api.Message{}, false
+}
+
+func validatorMsg(pk []byte, data api.RegistryEventData) api.Message {
+	return api.Message{
+		Type:   api.TypeValidator,
+		Filter: api.MessageFilter{PublicKey: hex.EncodeToString(pk)},
+		Data:   data,
+	}
+}
+
+func toUint64(n *big.Int) uint64 {
+	if n == nil {
+		return 0
+	}
+	return n.Uint64()
+}
+
+func toUint64s(ns []*big.Int) []uint64 {
+	var res []uint64
+	for _, n := range ns {
+		res = append(res, toUint64(n))
+	}
+	return res
+}
+
+func toDecimal(n *big.Int) string {
+	if n == nil {
+		return ""
+	}
+	return n.String()
+}
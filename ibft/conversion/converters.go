@@ -100,7 +100,7 @@ func ToV1Message(msgV0 *network.Message) (*message.SSVMessage, error) {
 			return nil, err
 		}
 		msg.Data = data
-		if len(msg.ID) == 0 {
+		if len(msg.ID) == 0 && signed.Message != nil {
 			msg.ID = signed.Message.Identifier
 		}
 	}
@@ -111,7 +111,7 @@ func ToV1Message(msgV0 *network.Message) (*message.SSVMessage, error) {
 func toSignedPostConsensusMessageV1(sm *proto.SignedMessage) *message.SignedPostConsensusMessage {
 	signed := new(message.SignedPostConsensusMessage)
 	consensus := &message.PostConsensusMessage{
-		Height:          message.Height(sm.Message.SeqNumber),
+		Height:          message.Height(sm.GetMessage().GetSeqNumber()),
 		DutySignature:   sm.GetSignature(),
 		DutySigningRoot: nil,
 	}
@@ -260,6 +260,9 @@ func ToV0Message(msg *message.SSVMessage) (*network.Message, error) {
 		if err := signedMsg.Decode(msg.GetData()); err != nil {
 			return nil, errors.Wrap(err, "could not get post consensus Message from network Message")
 		}
+		if signedMsg.Message == nil {
+			return nil, errors.New("could not convert post consensus signed message without a message")
+		}
 		v0Msg.SignedMessage = toSignedMessagePostConsensusV0(signedMsg, identifierV0)
 	case message.SSVSyncMsgType:
 		v0Msg.Type = network.NetworkMsg_SyncType
@@ -271,7 +274,7 @@ func ToV0Message(msg *message.SSVMessage) (*network.Message, error) {
 			syncMsg.Status = message.StatusSuccess
 		}
 		v0Msg.SyncMessage = new(network.SyncMessage)
-		if len(syncMsg.Params.Height) > 0 {
+		if syncMsg.Params != nil && len(syncMsg.Params.Height) > 0 {
 			v0Msg.SyncMessage.Params = make([]uint64, 1)
 			v0Msg.SyncMessage.Params[0] = uint64(syncMsg.Params.Height[0])
 			if len(syncMsg.Params.Height) > 1 {
@@ -311,6 +314,9 @@ func ToV0Message(msg *message.SSVMessage) (*network.Message, error) {
 
 // ToSignedMessageV0 converts a signed message from v1 to v0
 func ToSignedMessageV0(signedMsg *message.SignedMessage, identifierV0 []byte) (*proto.SignedMessage, error) {
+	if signedMsg == nil || signedMsg.Message == nil {
+		return nil, errors.New("could not convert signed message without a consensus message")
+	}
 	signedMsgV0 := &proto.SignedMessage{}
 	signedMsgV0.Message = &proto.Message{
 		Round:     uint64(signedMsg.Message.Round),
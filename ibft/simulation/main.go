@@ -110,6 +110,10 @@ func (km *testSigner) SignAttestation(data *spec.AttestationData, duty *beacon.D
 	return nil, nil, nil
 }
 
+func (km *testSigner) SignValidatorRegistration(registration *beacon.ValidatorRegistration, pk []byte) ([]byte, []byte, error) {
+	return nil, nil, nil
+}
+
 func db() qbftstorage.QBFTStore {
 	db, err := storage.GetStorageFactory(basedb.Options{
 		Type:   "badger-memory",
@@ -6,6 +6,8 @@ import (
 	"encoding/json"
 	forksv0 "github.com/bloxapp/ssv/ibft/storage/forks/v0"
 	"log"
+	"math"
+	"sort"
 	"sync"
 
 	"github.com/bloxapp/ssv/ibft/conversion"
@@ -27,7 +29,7 @@ import (
 
 const (
 	highestKey         = "highest"
-	decidedKey         = "decided"
+	decidedKey         = "decided/" // followed by a big endian height, see heightKey
 	currentKey         = "current"
 	lastChangeRoundKey = "last_change_round"
 )
@@ -122,47 +124,134 @@ func (i *ibftStorage) SaveLastDecided(signedMsgs ...*message.SignedMessage) erro
 	return nil
 }
 
+// GetDecided returns the decided messages of the given identifier in the range [from, to], sorted by height.
+// it reads current fork items, and uses v0 items for the heights that were not found
 func (i *ibftStorage) GetDecided(identifier message.Identifier, from message.Height, to message.Height) ([]*message.SignedMessage, error) {
 	i.forkLock.RLock()
 	defer i.forkLock.RUnlock()
 
-	identifierV0 := []byte(format.IdentifierFormat(identifier.GetValidatorPK(), identifier.GetRoleType().String()))
 	msgs := make([]*message.SignedMessage, 0)
+	if from > to {
+		return msgs, nil
+	}
+	rangeOpts := decidedRange(from, to)
+	err := i.db.Iterate(i.decidedPrefix(identifier), rangeOpts, func(obj basedb.Obj) error {
+		msg := message.SignedMessage{}
+		if err := json.Unmarshal(obj.Value, &msg); err != nil {
+			return errors.Wrap(err, "could not unmarshal signed message v1")
+		}
+		msgs = append(msgs, &msg)
+		return nil
+	})
+	if err != nil {
+		return msgs, err
+	}
+	if uint64(len(msgs)) > uint64(to-from) {
+		return msgs, nil
+	}
 
-	for seq := from; seq <= to; seq++ {
-		// use the v1 identifier, if not found use the v0. this is to support old msg types when sync history
-		val, found, err := i.get(decidedKey, identifier, uInt64ToByteSlice(uint64(seq)))
-		if err != nil {
-			return msgs, err
+	// some heights are missing, trying v0 items in order to support old msg types when sync history
+	found := make(map[message.Height]bool, len(msgs))
+	for _, msg := range msgs {
+		found[msg.Message.Height] = true
+	}
+	identifierV0 := []byte(format.IdentifierFormat(identifier.GetValidatorPK(), identifier.GetRoleType().String()))
+	n := len(msgs)
+	err = i.db.Iterate(i.decidedPrefix(identifierV0), rangeOpts, func(obj basedb.Obj) error {
+		if found[message.Height(binary.BigEndian.Uint64(obj.Key))] {
+			return nil
 		}
-		if found {
-			msg := message.SignedMessage{}
-			if err := json.Unmarshal(val, &msg); err != nil {
-				return msgs, errors.Wrap(err, "could not unmarshal signed message v1")
-			}
-			msgs = append(msgs, &msg)
-			continue
+		ret := proto.SignedMessage{}
+		if err := json.Unmarshal(obj.Value, &ret); err != nil {
+			return errors.Wrap(err, "could not unmarshal signed message v0")
+		}
+		msg, err := conversion.ToSignedMessageV1(&ret)
+		if err != nil {
+			return err
 		}
+		msgs = append(msgs, msg)
+		return nil
+	})
+	if len(msgs) > n {
+		sort.Slice(msgs, func(a, b int) bool {
+			return msgs[a].Message.Height < msgs[b].Message.Height
+		})
+	}
+	return msgs, err
+}
+
+// GetLowestDecided returns the lowest decided message of the given identifier, of both current fork and v0 items
+func (i *ibftStorage) GetLowestDecided(identifier message.Identifier) (*message.SignedMessage, error) {
+	i.forkLock.RLock()
+	defer i.forkLock.RUnlock()
 
-		// v1 not found, try with v0 identifier
-		val, found, err = i.get(decidedKey, identifierV0, uInt64ToByteSlice(uint64(seq)))
+	var lowest *message.SignedMessage
+	lowestOpts := basedb.RangeOptions{Limit: 1}
+	err := i.db.Iterate(i.decidedPrefix(identifier), lowestOpts, func(obj basedb.Obj) error {
+		msg := message.SignedMessage{}
+		if err := json.Unmarshal(obj.Value, &msg); err != nil {
+			return errors.Wrap(err, "could not unmarshal signed message v1")
+		}
+		lowest = &msg
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	identifierV0 := []byte(format.IdentifierFormat(identifier.GetValidatorPK(), identifier.GetRoleType().String()))
+	err = i.db.Iterate(i.decidedPrefix(identifierV0), lowestOpts, func(obj basedb.Obj) error {
+		if lowest != nil && lowest.Message.Height <= message.Height(binary.BigEndian.Uint64(obj.Key)) {
+			return nil
+		}
+		ret := proto.SignedMessage{}
+		if err := json.Unmarshal(obj.Value, &ret); err != nil {
+			return errors.Wrap(err, "could not unmarshal signed message v0")
+		}
+		msg, err := conversion.ToSignedMessageV1(&ret)
 		if err != nil {
-			return msgs, err
+			return err
 		}
-		if found {
-			ret := proto.SignedMessage{}
-			if err := json.Unmarshal(val, &ret); err != nil {
-				return msgs, errors.Wrap(err, "could not unmarshal signed message v0")
-			}
-			msg, err := conversion.ToSignedMessageV1(&ret)
+		lowest = msg
+		return nil
+	})
+	return lowest, err
+}
+
+// PruneDecided deletes the decided messages of the given identifier in the range [from, to], of both current fork and v0 items
+func (i *ibftStorage) PruneDecided(identifier message.Identifier, from message.Height, to message.Height) (int, error) {
+	i.forkLock.RLock()
+	defer i.forkLock.RUnlock()
+
+	if from > to {
+		return 0, nil
+	}
+	identifierV0 := []byte(format.IdentifierFormat(identifier.GetValidatorPK(), identifier.GetRoleType().String()))
+	rangeOpts := decidedRange(from, to)
+	rangeOpts.KeysOnly = true
+	count := 0
+	err := i.db.Update(func(txn basedb.Txn) error {
+		for _, prefix := range [][]byte{i.decidedPrefix(identifier), i.decidedPrefix(identifierV0)} {
+			var keys [][]byte
+			err := txn.Iterate(prefix, rangeOpts, func(obj basedb.Obj) error {
+				keys = append(keys, obj.Key)
+				return nil
+			})
 			if err != nil {
-				return msgs, err
+				return err
+			}
+			for _, k := range keys {
+				if err := txn.Delete(prefix, k); err != nil {
+					return err
+				}
 			}
-			msgs = append(msgs, msg)
+			count += len(keys)
 		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
 	}
-
-	return msgs, nil
+	return count, nil
 }
 
 func (i *ibftStorage) SaveDecided(signedMsg ...*message.SignedMessage) error {
@@ -171,7 +260,7 @@ func (i *ibftStorage) SaveDecided(signedMsg ...*message.SignedMessage) error {
 
 	return i.db.SetMany(i.prefix, len(signedMsg), func(j int) (basedb.Obj, error) {
 		msg := signedMsg[j]
-		k := i.key(decidedKey, uInt64ToByteSlice(uint64(msg.Message.Height)))
+		k := i.key(decidedKey, heightKey(msg.Message.Height))
 		value, err := i.fork.EncodeSignedMsg(msg)
 		if err != nil {
 			return basedb.Obj{}, err
@@ -270,6 +359,23 @@ func (i *ibftStorage) delete(id string, pk []byte, keyParams ...[]byte) error {
 	return i.db.Delete(prefix, key)
 }
 
+// decidedPrefix returns the prefix of the decided messages of the given identifier
+func (i *ibftStorage) decidedPrefix(identifier []byte) []byte {
+	prefix := make([]byte, 0, len(i.prefix)+len(identifier)+len(decidedKey))
+	prefix = append(prefix, i.prefix...)
+	prefix = append(prefix, identifier...)
+	return append(prefix, decidedKey...)
+}
+
+// decidedRange returns the range options of the given (inclusive) heights
+func decidedRange(from, to message.Height) basedb.RangeOptions {
+	opts := basedb.RangeOptions{From: heightKey(from)}
+	if to < math.MaxInt64 {
+		opts.To = heightKey(to + 1)
+	}
+	return opts
+}
+
 func (i *ibftStorage) key(id string, params ...[]byte) []byte {
 	ret := []byte(id)
 	for _, p := range params {
@@ -278,9 +384,10 @@ func (i *ibftStorage) key(id string, params ...[]byte) []byte {
 	return ret
 }
 
-func uInt64ToByteSlice(n uint64) []byte {
+// heightKey encodes the given height in big endian, so decided messages are sorted by height
+func heightKey(h message.Height) []byte {
 	b := make([]byte, 8)
-	binary.LittleEndian.PutUint64(b, n)
+	binary.BigEndian.PutUint64(b, uint64(h))
 	return b
 }
 
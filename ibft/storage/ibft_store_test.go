@@ -55,6 +55,128 @@ func TestSaveAndFetchLastState(t *testing.T) {
 	require.Equal(t, []byte("input"), savedState.GetInputValue())
 }
 
+func TestGetDecided(t *testing.T) {
+	db, err := ssvstorage.GetStorageFactory(basedb.Options{
+		Type:   "badger-memory",
+		Logger: logex.GetLogger(),
+		Path:   "",
+	})
+	require.NoError(t, err)
+	defer db.Close()
+
+	identifier := message.NewIdentifier([]byte("pk"), message.RoleTypeAttester)
+	decided := func(height message.Height, round message.Round) *message.SignedMessage {
+		commitData, err := (&message.CommitData{Data: []byte("value")}).Encode()
+		require.NoError(t, err)
+		return &message.SignedMessage{
+			Signature: []byte("sig"),
+			Signers:   []message.OperatorID{1, 2, 3},
+			Message: &message.ConsensusMessage{
+				MsgType:    message.CommitMsgType,
+				Height:     height,
+				Round:      round,
+				Identifier: identifier,
+				Data:       commitData,
+			},
+		}
+	}
+
+	// v0 items are saved with round 1, v1 items with round 2
+	storeV0 := New(db, logex.GetLogger(), "test", forksprotocol.V0ForkVersion)
+	for h := message.Height(1); h <= 5; h++ {
+		require.NoError(t, storeV0.SaveDecided(decided(h, 1)))
+	}
+	store := New(db, logex.GetLogger(), "test", forksprotocol.V1ForkVersion)
+	for _, h := range []message.Height{3, 4, 5, 7, 8, 300} {
+		require.NoError(t, store.SaveDecided(decided(h, 2)))
+	}
+
+	tests := []struct {
+		name     string
+		from, to message.Height
+		heights  []message.Height
+		rounds   []message.Round
+	}{
+		{"v1 items", 3, 5, []message.Height{3, 4, 5}, []message.Round{2, 2, 2}},
+		{"v0 and v1 items", 0, 10, []message.Height{1, 2, 3, 4, 5, 7, 8}, []message.Round{1, 1, 2, 2, 2, 2, 2}},
+		{"missing height", 6, 6, nil, nil},
+		{"height above 255", 8, 300, []message.Height{8, 300}, []message.Round{2, 2}},
+		{"invalid range", 5, 3, nil, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			msgs, err := store.GetDecided(identifier, test.from, test.to)
+			require.NoError(t, err)
+			require.Len(t, msgs, len(test.heights))
+			for i, msg := range msgs {
+				require.Equal(t, test.heights[i], msg.Message.Height)
+				require.Equal(t, test.rounds[i], msg.Message.Round)
+			}
+		})
+	}
+}
+
+func TestPruneDecided(t *testing.T) {
+	db, err := ssvstorage.GetStorageFactory(basedb.Options{
+		Type:   "badger-memory",
+		Logger: logex.GetLogger(),
+		Path:   "",
+	})
+	require.NoError(t, err)
+	defer db.Close()
+
+	identifier := message.NewIdentifier([]byte("pk"), message.RoleTypeAttester)
+	decided := func(height message.Height) *message.SignedMessage {
+		commitData, err := (&message.CommitData{Data: []byte("value")}).Encode()
+		require.NoError(t, err)
+		return &message.SignedMessage{
+			Signature: []byte("sig"),
+			Signers:   []message.OperatorID{1, 2, 3},
+			Message: &message.ConsensusMessage{
+				MsgType:    message.CommitMsgType,
+				Height:     height,
+				Identifier: identifier,
+				Data:       commitData,
+			},
+		}
+	}
+
+	storeV0 := New(db, logex.GetLogger(), "test", forksprotocol.V0ForkVersion)
+	store := New(db, logex.GetLogger(), "test", forksprotocol.V1ForkVersion)
+
+	lowest, err := store.GetLowestDecided(identifier)
+	require.NoError(t, err)
+	require.Nil(t, lowest)
+
+	for h := message.Height(2); h <= 4; h++ {
+		require.NoError(t, storeV0.SaveDecided(decided(h)))
+	}
+	for h := message.Height(3); h <= 10; h++ {
+		require.NoError(t, store.SaveDecided(decided(h)))
+	}
+
+	lowest, err = store.GetLowestDecided(identifier)
+	require.NoError(t, err)
+	require.NotNil(t, lowest)
+	require.Equal(t, message.Height(2), lowest.Message.Height)
+
+	// v0 items (2-4) and v1 items (3-5)
+	n, err := store.PruneDecided(identifier, 0, 5)
+	require.NoError(t, err)
+	require.Equal(t, 6, n)
+
+	lowest, err = store.GetLowestDecided(identifier)
+	require.NoError(t, err)
+	require.Equal(t, message.Height(6), lowest.Message.Height)
+	msgs, err := store.GetDecided(identifier, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, msgs, 5)
+
+	n, err = store.PruneDecided(identifier, 8, 7)
+	require.NoError(t, err)
+	require.Equal(t, 0, n)
+}
+
 func newTestIbftStorage(logger *zap.Logger, prefix string, forkVersion forksprotocol.ForkVersion) (qbftstorage.QBFTStore, error) {
 	db, err := ssvstorage.GetStorageFactory(basedb.Options{
 		Type:   "badger-memory",
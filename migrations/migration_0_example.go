@@ -6,35 +6,43 @@ import (
 	"github.com/bloxapp/ssv/storage/basedb"
 )
 
+var (
+	exampleOwnerAddressKey       = []byte("owner-address")
+	exampleOperatorsPublicKeyKey = []byte("operators-public-keys")
+)
+
 // This migration is an Example migration
 var migrationExample1 = Migration{
 	Name: "migration_0_example",
-	Run: func(ctx context.Context, opt Options, key []byte) error {
+	Up: func(ctx context.Context, opt Options) error {
 		// Example to clean registry data for specific storage
 		if err := opt.nodeStorage().CleanRegistryData(); err != nil {
 			return err
 		}
 
-		// Using SetMany, the following 3 updates either all happen and are committed,
+		// Using SetMany, the following 2 updates either all happen and are committed,
 		// or they all rollback.
-		// If we used Set 3 times independently, the migration could abort in the middle
+		// If we used Set 2 times independently, the migration could abort in the middle
 		// with only some of the Set's committed, resulting in a corrupted database.
 		sets := []basedb.Obj{
 			{
-				Key:   []byte("owner-address"),
+				Key:   exampleOwnerAddressKey,
 				Value: []byte("123"),
 			},
 			{
-				Key:   []byte("operators-public-keys"),
+				Key:   exampleOperatorsPublicKeyKey,
 				Value: []byte("abc"),
 			},
-			{
-				Key:   key,
-				Value: migrationCompleted,
-			},
 		}
-		return opt.Db.SetMany(migrationsPrefix, 3, func(i int) (basedb.Obj, error) {
+		return opt.Db.SetMany(migrationsPrefix, 2, func(i int) (basedb.Obj, error) {
 			return sets[i], nil
 		})
 	},
+	DownTxn: func(ctx context.Context, opt Options, txn basedb.Txn) error {
+		if err := txn.Delete(migrationsPrefix, exampleOwnerAddressKey); err != nil {
+			return err
+		}
+		return txn.Delete(migrationsPrefix, exampleOperatorsPublicKeyKey)
+	},
+	Prefixes: [][]byte{operatorSyncOffsetPrefix, operatorsKeyPrefix},
 }
@@ -8,30 +8,33 @@ import (
 	"github.com/pkg/errors"
 )
 
+var (
+	exampleTestPrefix = []byte("test_prefix/")
+	exampleTestKey    = []byte("test_key")
+)
+
 // This migration is an Example of atomic
 // View/Update transactions usage
 var migrationExample2 = Migration{
 	Name: "migration_1_example",
-	Run: func(ctx context.Context, opt Options, key []byte) error {
-		return opt.Db.Update(func(txn basedb.Txn) error {
-			var (
-				testPrefix = []byte("test_prefix/")
-				testKey    = []byte("test_key")
-				testValue  = []byte("test_value")
-			)
-			err := txn.Set(testPrefix, testKey, testValue)
-			if err != nil {
-				return err
-			}
-			obj, found, err := txn.Get(testPrefix, testKey)
-			if err != nil {
-				return err
-			}
-			if !found {
-				return errors.Errorf("the key %s is not found", string(obj.Key))
-			}
-			fmt.Printf("the key %s is found. value = %s", string(obj.Key), string(obj.Key))
-			return txn.Set(migrationsPrefix, key, migrationCompleted)
-		})
+	UpTxn: func(ctx context.Context, opt Options, txn basedb.Txn) error {
+		testValue := []byte("test_value")
+		err := txn.Set(exampleTestPrefix, exampleTestKey, testValue)
+		if err != nil {
+			return err
+		}
+		obj, found, err := txn.Get(exampleTestPrefix, exampleTestKey)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return errors.Errorf("the key %s is not found", string(obj.Key))
+		}
+		fmt.Printf("the key %s is found. value = %s", string(obj.Key), string(obj.Key))
+		return nil
+	},
+	DownTxn: func(ctx context.Context, opt Options, txn basedb.Txn) error {
+		return txn.Delete(exampleTestPrefix, exampleTestKey)
 	},
+	Prefixes: [][]byte{exampleTestPrefix},
 }
@@ -8,12 +8,14 @@ import (
 // This migration is responsible to delete all (exporter, operator) registry data
 var migrationCleanOperatorNodeRegistryData = Migration{
 	Name: "migration_3_clean_operator_node_registry_data",
-	Run: func(ctx context.Context, opt Options, key []byte) error {
+	Up: func(ctx context.Context, opt Options) error {
 		storage := opt.nodeStorage()
 		err := storage.CleanRegistryData()
 		if err != nil {
 			return errors.Wrap(err, "could not clean registry data")
 		}
-		return opt.Db.Set(migrationsPrefix, key, migrationCompleted)
+		return nil
 	},
+	Down:     downNoop,
+	Prefixes: [][]byte{operatorSyncOffsetPrefix, operatorsKeyPrefix},
 }
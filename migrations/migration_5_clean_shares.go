@@ -6,7 +6,7 @@ import (
 
 var migrationCleanValidatorRegistryData = Migration{
 	Name: "migration_5_clean_validator_registry_data",
-	Run: func(ctx context.Context, opt Options, key []byte) error {
+	Up: func(ctx context.Context, opt Options) error {
 		stores := opt.getRegistryStores()
 		for _, store := range stores {
 			err := store.CleanRegistryData()
@@ -14,6 +14,8 @@ var migrationCleanValidatorRegistryData = Migration{
 				return err
 			}
 		}
-		return opt.Db.Set(migrationsPrefix, key, migrationCompleted)
+		return nil
 	},
+	Down:     downNoop,
+	Prefixes: registryPrefixes,
 }
@@ -0,0 +1,153 @@
+package migrations
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/protocol/v1/message"
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+const sortableKeysBatchSize = 1000
+
+var (
+	// legacyDecidedKey was followed by a little endian height
+	legacyDecidedKey = []byte("decided")
+	// decidedKey is followed by a big endian height
+	decidedKey = []byte("decided/")
+
+	// operatorsKeyPrefix is the prefix of operators in the node storage,
+	// legacy keys were followed by a decimal index while current keys are followed by a big endian index
+	operatorsKeyPrefix = []byte("operator-operators/")
+
+	sortableDecidedPrefix = []byte(message.RoleTypeAttester.String())
+)
+
+// This migration re-keys decided messages and operators data with big endian heights / indices,
+// so they are sorted and could be iterated by range.
+// items are re-keyed in batches, legacy keys are recognized by their format so the migration can be resumed
+var migrationSortableStorageKeys = Migration{
+	Name: "migration_6_sortable_storage_keys",
+	Up: func(ctx context.Context, opt Options) error {
+		n, err := rekey(opt.Db, sortableDecidedPrefix, legacyDecidedToSortable)
+		if err != nil {
+			return errors.Wrap(err, "could not re-key decided messages")
+		}
+		opt.Logger.Debug("decided messages were re-keyed", zap.Int("count", n))
+
+		n, err = rekey(opt.Db, operatorsKeyPrefix, legacyOperatorToSortable)
+		if err != nil {
+			return errors.Wrap(err, "could not re-key operators")
+		}
+		opt.Logger.Debug("operators were re-keyed", zap.Int("count", n))
+		return nil
+	},
+	Down: func(ctx context.Context, opt Options) error {
+		n, err := rekey(opt.Db, sortableDecidedPrefix, sortableDecidedToLegacy)
+		if err != nil {
+			return errors.Wrap(err, "could not re-key decided messages")
+		}
+		opt.Logger.Debug("decided messages were re-keyed", zap.Int("count", n))
+
+		n, err = rekey(opt.Db, operatorsKeyPrefix, sortableOperatorToLegacy)
+		if err != nil {
+			return errors.Wrap(err, "could not re-key operators")
+		}
+		opt.Logger.Debug("operators were re-keyed", zap.Int("count", n))
+		return nil
+	},
+	Prefixes: [][]byte{sortableDecidedPrefix, operatorsKeyPrefix},
+}
+
+// legacyDecidedToSortable converts "<identifier>decided<LE height>" into "<identifier>decided/<BE height>"
+func legacyDecidedToSortable(k []byte) ([]byte, bool) {
+	n := len(k)
+	if n < len(legacyDecidedKey)+8 || !bytes.Equal(k[n-8-len(legacyDecidedKey):n-8], legacyDecidedKey) {
+		return nil, false
+	}
+	newKey := make([]byte, n+1)
+	copy(newKey, k[:n-8-len(legacyDecidedKey)])
+	copy(newKey[n-8-len(legacyDecidedKey):], decidedKey)
+	binary.BigEndian.PutUint64(newKey[n-7:], binary.LittleEndian.Uint64(k[n-8:]))
+	return newKey, true
+}
+
+// sortableDecidedToLegacy converts "<identifier>decided/<BE height>" into "<identifier>decided<LE height>"
+func sortableDecidedToLegacy(k []byte) ([]byte, bool) {
+	n := len(k)
+	if n < len(decidedKey)+8 || !bytes.Equal(k[n-8-len(decidedKey):n-8], decidedKey) {
+		return nil, false
+	}
+	newKey := make([]byte, n-1)
+	copy(newKey, k[:n-8-len(decidedKey)])
+	copy(newKey[n-8-len(decidedKey):], legacyDecidedKey)
+	binary.LittleEndian.PutUint64(newKey[n-9:], binary.BigEndian.Uint64(k[n-8:]))
+	return newKey, true
+}
+
+// legacyOperatorToSortable converts a decimal operator index into big endian
+func legacyOperatorToSortable(k []byte) ([]byte, bool) {
+	index, err := strconv.ParseUint(string(k), 10, 64)
+	if err != nil {
+		return nil, false
+	}
+	newKey := make([]byte, 8)
+	binary.BigEndian.PutUint64(newKey, index)
+	return newKey, true
+}
+
+// sortableOperatorToLegacy converts a big endian operator index into decimal
+func sortableOperatorToLegacy(k []byte) ([]byte, bool) {
+	if len(k) != 8 {
+		return nil, false
+	}
+	if _, legacy := legacyOperatorToSortable(k); legacy {
+		return nil, false
+	}
+	return []byte(strconv.FormatUint(binary.BigEndian.Uint64(k), 10)), true
+}
+
+// rekey moves the items of the given prefix whose keys are converted by the given function
+func rekey(db basedb.IDb, prefix []byte, convert func([]byte) ([]byte, bool)) (int, error) {
+	var batch []basedb.Obj
+	count := 0
+	flush := func() error {
+		err := db.Update(func(txn basedb.Txn) error {
+			for _, obj := range batch {
+				newKey, _ := convert(obj.Key)
+				if err := txn.Set(prefix, newKey, obj.Value); err != nil {
+					return err
+				}
+				if err := txn.Delete(prefix, obj.Key); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		count += len(batch)
+		batch = batch[:0]
+		return err
+	}
+	err := db.GetAll(prefix, func(i int, obj basedb.Obj) error {
+		if _, ok := convert(obj.Key); !ok {
+			return nil
+		}
+		batch = append(batch, obj)
+		if len(batch) < sortableKeysBatchSize {
+			return nil
+		}
+		return flush()
+	})
+	if err != nil {
+		return count, err
+	}
+	if len(batch) > 0 {
+		return count, flush()
+	}
+	return count, nil
+}
@@ -3,6 +3,7 @@ package migrations
 import (
 	"bytes"
 	"context"
+	"encoding/binary"
 
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
@@ -16,6 +17,13 @@ import (
 var (
 	migrationsPrefix   = []byte("migrations/")
 	migrationCompleted = []byte("migrationCompleted")
+	// schemaVersionKey holds the schema version of the db, which is the amount of applied migrations
+	schemaVersionKey = []byte("schemaVersion")
+
+	// operatorSyncOffsetPrefix and sharesPrefix are (together with operatorsKeyPrefix) the prefixes of registry data
+	operatorSyncOffsetPrefix = []byte("operator-syncOffset")
+	sharesPrefix             = []byte("share-")
+	registryPrefixes         = [][]byte{operatorSyncOffsetPrefix, operatorsKeyPrefix, sharesPrefix}
 
 	defaultMigrations = Migrations{
 		migrationExample1,
@@ -24,25 +32,61 @@ var (
 		migrationCleanOperatorNodeRegistryData,
 		migrationCleanExporterRegistryData,
 		migrationCleanValidatorRegistryData,
+		migrationSortableStorageKeys,
 	}
 )
 
 // Run executes the default migrations.
 func Run(ctx context.Context, opt Options) error {
-	return defaultMigrations.Run(ctx, opt)
+	_, err := defaultMigrations.Up(ctx, opt, len(defaultMigrations))
+	return err
+}
+
+// Up applies the default migrations up to the given schema version.
+func Up(ctx context.Context, opt Options, version int) (*Report, error) {
+	return defaultMigrations.Up(ctx, opt, version)
 }
 
-// MigrationFunc is a function that performs a migration.
-type MigrationFunc func(ctx context.Context, opt Options, key []byte) error
+// Down reverts the default migrations down to the given schema version.
+func Down(ctx context.Context, opt Options, version int) (*Report, error) {
+	return defaultMigrations.Down(ctx, opt, version)
+}
+
+// GetStatus returns the status of the default migrations.
+func GetStatus(opt Options) (*Status, error) {
+	return defaultMigrations.Status(opt)
+}
 
-// Migration is a named MigrationFunc.
+// MigrationFunc is a function that performs (or reverts) a migration.
+// it's expected to be idempotent, as it's executed again if it was interrupted.
+type MigrationFunc func(ctx context.Context, opt Options) error
+
+// TxnMigrationFunc is a function that performs (or reverts) a migration within a transaction,
+// which also updates the schema version.
+type TxnMigrationFunc func(ctx context.Context, opt Options, txn basedb.Txn) error
+
+// Migration is a named migration, either Up or UpTxn must be set.
 type Migration struct {
 	Name string
-	Run  MigrationFunc
+	// Up applies the migration, it's used for migrations that can't be done in a single transaction
+	Up MigrationFunc
+	// UpTxn applies the migration in a single transaction
+	UpTxn TxnMigrationFunc
+	// Down (or DownTxn) reverts the migration, migrations without them are irreversible
+	Down    MigrationFunc
+	DownTxn TxnMigrationFunc
+	// Prefixes are the db prefixes that are affected by the migration, their keys are counted in dry-run mode
+	Prefixes [][]byte
+}
+
+// Reversible returns whether the migration can be reverted
+func (m Migration) Reversible() bool {
+	return m.Down != nil || m.DownTxn != nil
 }
 
 // Migrations is a slice of named migrations, meant to be executed
 // from first to last (order is significant).
+// the schema version of the db is the amount of applied migrations.
 type Migrations []Migration
 
 // Options are configurations for migrations
@@ -50,6 +94,8 @@ type Options struct {
 	Db     basedb.IDb
 	Logger *zap.Logger
 	DbPath string
+	// DryRun reports the migrations that would be applied or reverted without changing the db
+	DryRun bool
 }
 
 func (o *Options) getRegistryStores() []eth1.RegistryStore {
@@ -66,32 +112,249 @@ func (o Options) nodeStorage() operatorstorage.Storage {
 	return operatorstorage.NewNodeStorage(o.Db, o.Logger)
 }
 
+// Report describes the migrations that were applied or reverted (or would be, in dry-run mode)
+type Report struct {
+	FromVersion int
+	ToVersion   int
+	DryRun      bool
+	Migrations  []MigrationReport
+}
+
+// MigrationReport describes a single migration in a report
+type MigrationReport struct {
+	Name string
+	// Version is the schema version of the migration, i.e. once it's applied
+	Version int
+	// Affected is the amount of keys per affected prefix
+	Affected map[string]int64
+}
+
+// Status describes the schema version of the db and the known migrations
+type Status struct {
+	Version    int
+	Latest     int
+	Migrations []MigrationStatus
+}
+
+// MigrationStatus describes a single migration in a status
+type MigrationStatus struct {
+	Name       string
+	Version    int
+	Applied    bool
+	Reversible bool
+}
+
+// Status returns the schema version of the db and the status of each migration.
+func (m Migrations) Status(opt Options) (*Status, error) {
+	version, err := m.version(opt.Db)
+	if err != nil {
+		return nil, err
+	}
+	status := &Status{Version: version, Latest: len(m)}
+	for i, migration := range m {
+		status.Migrations = append(status.Migrations, MigrationStatus{
+			Name:       migration.Name,
+			Version:    i + 1,
+			Applied:    i < version,
+			Reversible: migration.Reversible(),
+		})
+	}
+	return status, nil
+}
+
 // Run executes the migrations.
 func (m Migrations) Run(ctx context.Context, opt Options) error {
-	opt.Logger.Info("Running migrations:")
-	count := 0
-	for _, migration := range m {
-		// Skip the migration if it's already completed.
-		obj, _, err := opt.Db.Get(migrationsPrefix, []byte(migration.Name))
+	_, err := m.Up(ctx, opt, len(m))
+	return err
+}
+
+// Up applies the migrations up to the given schema version.
+func (m Migrations) Up(ctx context.Context, opt Options, target int) (*Report, error) {
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+	version, err := m.version(opt.Db)
+	if err != nil {
+		return nil, err
+	}
+	if target < version || target > len(m) {
+		return nil, errors.Errorf("invalid target version %d, current version is %d and latest is %d", target, version, len(m))
+	}
+	opt.Logger.Info("Running migrations:", zap.Int("version", version), zap.Int("target", target),
+		zap.Bool("dryRun", opt.DryRun))
+	report := &Report{FromVersion: version, ToVersion: target, DryRun: opt.DryRun}
+	for i := version; i < target; i++ {
+		migration := m[i]
+		affected, err := countAffected(opt.Db, migration.Prefixes)
 		if err != nil {
-			return err
+			return report, errors.Wrapf(err, "could not count keys affected by migration %q", migration.Name)
 		}
-		if bytes.Equal(obj.Value, migrationCompleted) {
-			opt.Logger.Debug("migration already applied, skipping", zap.String("name", migration.Name))
+		report.Migrations = append(report.Migrations, MigrationReport{Name: migration.Name, Version: i + 1, Affected: affected})
+		if opt.DryRun {
+			opt.Logger.Info("migration would be applied", zap.String("name", migration.Name), zap.Any("affected", affected))
 			continue
 		}
+		if err := migration.up(ctx, opt, i+1); err != nil {
+			return report, errors.Wrapf(err, "migration %q failed", migration.Name)
+		}
+		opt.Logger.Info("migration applied successfully", zap.String("name", migration.Name), zap.Int("version", i+1))
+	}
+	if version == target {
+		opt.Logger.Info("No migrations to apply.")
+	}
+	return report, nil
+}
 
-		// Execute the migration.
-		err = migration.Run(ctx, opt, []byte(migration.Name))
+// Down reverts the migrations down to the given schema version.
+// nothing is reverted if any of the migrations is irreversible.
+func (m Migrations) Down(ctx context.Context, opt Options, target int) (*Report, error) {
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+	version, err := m.version(opt.Db)
+	if err != nil {
+		return nil, err
+	}
+	if target < 0 || target > version {
+		return nil, errors.Errorf("invalid target version %d, current version is %d", target, version)
+	}
+	for i := version - 1; i >= target; i-- {
+		if !m[i].Reversible() {
+			return nil, errors.Errorf("migration %q is irreversible", m[i].Name)
+		}
+	}
+	opt.Logger.Info("Reverting migrations:", zap.Int("version", version), zap.Int("target", target),
+		zap.Bool("dryRun", opt.DryRun))
+	report := &Report{FromVersion: version, ToVersion: target, DryRun: opt.DryRun}
+	for i := version - 1; i >= target; i-- {
+		migration := m[i]
+		affected, err := countAffected(opt.Db, migration.Prefixes)
 		if err != nil {
-			return errors.Wrapf(err, "migration %q failed", migration.Name)
+			return report, errors.Wrapf(err, "could not count keys affected by migration %q", migration.Name)
+		}
+		report.Migrations = append(report.Migrations, MigrationReport{Name: migration.Name, Version: i + 1, Affected: affected})
+		if opt.DryRun {
+			opt.Logger.Info("migration would be reverted", zap.String("name", migration.Name), zap.Any("affected", affected))
+			continue
 		}
-		count++
-		opt.Logger.Info("migration applied successfully", zap.String("name", migration.Name))
+		if err := migration.down(ctx, opt, i); err != nil {
+			return report, errors.Wrapf(err, "could not revert migration %q", migration.Name)
+		}
+		opt.Logger.Info("migration reverted successfully", zap.String("name", migration.Name), zap.Int("version", i))
 	}
-	if count == 0 {
-		opt.Logger.Info("No migrations to apply.")
+	return report, nil
+}
+
+func (m Migration) up(ctx context.Context, opt Options, version int) error {
+	if m.UpTxn != nil {
+		return opt.Db.Update(func(txn basedb.Txn) error {
+			if err := m.UpTxn(ctx, opt, txn); err != nil {
+				return err
+			}
+			return setVersion(txn, m.Name, version, true)
+		})
+	}
+	if err := m.Up(ctx, opt); err != nil {
+		return err
+	}
+	return opt.Db.Update(func(txn basedb.Txn) error {
+		return setVersion(txn, m.Name, version, true)
+	})
+}
+
+func (m Migration) down(ctx context.Context, opt Options, version int) error {
+	if m.DownTxn != nil {
+		return opt.Db.Update(func(txn basedb.Txn) error {
+			if err := m.DownTxn(ctx, opt, txn); err != nil {
+				return err
+			}
+			return setVersion(txn, m.Name, version, false)
+		})
+	}
+	if err := m.Down(ctx, opt); err != nil {
+		return err
+	}
+	return opt.Db.Update(func(txn basedb.Txn) error {
+		return setVersion(txn, m.Name, version, false)
+	})
+}
+
+func (m Migrations) validate() error {
+	names := make(map[string]bool, len(m))
+	for _, migration := range m {
+		if names[migration.Name] {
+			return errors.Errorf("duplicated migration %q", migration.Name)
+		}
+		names[migration.Name] = true
+		if (migration.Up == nil) == (migration.UpTxn == nil) {
+			return errors.Errorf("migration %q must have either Up or UpTxn", migration.Name)
+		}
+		if migration.Down != nil && migration.DownTxn != nil {
+			return errors.Errorf("migration %q must not have both Down and DownTxn", migration.Name)
+		}
+	}
+	return nil
+}
+
+// version returns the schema version of the db.
+// dbs that were migrated before versioning was introduced have only the completed migrations,
+// in which case the version is the amount of leading completed migrations
+func (m Migrations) version(db basedb.IDb) (int, error) {
+	obj, found, err := db.Get(migrationsPrefix, schemaVersionKey)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not read schema version")
+	}
+	if found {
+		if len(obj.Value) != 8 {
+			return 0, errors.New("invalid schema version")
+		}
+		version := int(binary.BigEndian.Uint64(obj.Value))
+		if version > len(m) {
+			return version, errors.Errorf("db schema version %d is newer than the latest known version %d", version, len(m))
+		}
+		return version, nil
+	}
+	version := 0
+	for _, migration := range m {
+		obj, _, err := db.Get(migrationsPrefix, []byte(migration.Name))
+		if err != nil {
+			return 0, err
+		}
+		if !bytes.Equal(obj.Value, migrationCompleted) {
+			break
+		}
+		version++
 	}
+	return version, nil
+}
+
+// setVersion sets the schema version, the completed mark of the migration is kept as well
+// so older versions of the node (which don't know the schema version) could run with the db
+func setVersion(txn basedb.Txn, name string, version int, completed bool) error {
+	v := make([]byte, 8)
+	binary.BigEndian.PutUint64(v, uint64(version))
+	if err := txn.Set(migrationsPrefix, schemaVersionKey, v); err != nil {
+		return err
+	}
+	if completed {
+		return txn.Set(migrationsPrefix, []byte(name), migrationCompleted)
+	}
+	return txn.Delete(migrationsPrefix, []byte(name))
+}
+
+func countAffected(db basedb.IDb, prefixes [][]byte) (map[string]int64, error) {
+	affected := make(map[string]int64, len(prefixes))
+	for _, prefix := range prefixes {
+		n, err := db.CountByCollection(prefix)
+		if err != nil {
+			return nil, err
+		}
+		affected[string(prefix)] = n
+	}
+	return affected, nil
+}
 
+// downNoop reverts migrations that only cleaned data which is synced again by the node, e.g. registry data
+func downNoop(ctx context.Context, opt Options) error {
 	return nil
 }
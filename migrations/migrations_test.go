@@ -2,11 +2,18 @@ package migrations
 
 import (
 	"context"
+	"encoding/binary"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path"
+	"strconv"
 	"testing"
 
+	ibftstorage "github.com/bloxapp/ssv/ibft/storage"
+	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
+	"github.com/bloxapp/ssv/protocol/v1/message"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
 	"github.com/bloxapp/ssv/storage/basedb"
 	"github.com/bloxapp/ssv/storage/kv"
 	"github.com/pkg/errors"
@@ -43,9 +50,9 @@ func Test_RunNotMigratingTwice(t *testing.T) {
 	migrations := Migrations{
 		{
 			Name: "not_migrating_twice",
-			Run: func(ctx context.Context, opt Options, key []byte) error {
+			Up: func(ctx context.Context, opt Options) error {
 				count++
-				return opt.Db.Set(migrationsPrefix, key, migrationCompleted)
+				return nil
 			},
 		},
 	}
@@ -139,17 +146,248 @@ func Test_DeprecatedMigrationFakeApplied(t *testing.T) {
 	require.True(t, found)
 }
 
+func Test_SortableStorageKeys(t *testing.T) {
+	ctx := context.Background()
+	opt, err := setupOptions(ctx, t)
+	require.NoError(t, err)
+
+	// legacy decided messages
+	identifier := message.NewIdentifier([]byte("pk"), message.RoleTypeAttester)
+	decidedPrefix := append([]byte(message.RoleTypeAttester.String()), identifier...)
+	heights := []message.Height{1, 2, 256, 300}
+	for _, h := range heights {
+		msg := &message.SignedMessage{Message: &message.ConsensusMessage{Height: h, Identifier: identifier}}
+		value, err := msg.Encode()
+		require.NoError(t, err)
+		key := make([]byte, 8)
+		binary.LittleEndian.PutUint64(key, uint64(h))
+		require.NoError(t, opt.Db.Set(decidedPrefix, append([]byte("decided"), key...), value))
+	}
+	// legacy operators
+	for i := uint64(1); i <= 12; i++ {
+		value, err := json.Marshal(registrystorage.OperatorData{Index: i, Name: fmt.Sprintf("operator-%d", i)})
+		require.NoError(t, err)
+		require.NoError(t, opt.Db.Set(operatorsKeyPrefix, []byte(strconv.FormatUint(i, 10)), value))
+	}
+
+	require.NoError(t, Migrations{migrationSortableStorageKeys}.Run(ctx, opt))
+
+	decided, err := ibftstorage.New(opt.Db, opt.Logger, message.RoleTypeAttester.String(), forksprotocol.V1ForkVersion).
+		GetDecided(identifier, 2, 1000)
+	require.NoError(t, err)
+	require.Len(t, decided, 3)
+	for i, msg := range decided {
+		require.Equal(t, heights[i+1], msg.Message.Height)
+	}
+
+	operators, err := opt.nodeStorage().ListOperators(10, 11)
+	require.NoError(t, err)
+	require.Len(t, operators, 2)
+	require.Equal(t, "operator-10", operators[0].Name)
+	require.Equal(t, "operator-11", operators[1].Name)
+
+	// legacy keys were removed
+	n, err := opt.Db.CountByCollection(decidedPrefix)
+	require.NoError(t, err)
+	require.Equal(t, int64(len(heights)), n)
+	n, err = opt.Db.CountByCollection(operatorsKeyPrefix)
+	require.NoError(t, err)
+	require.Equal(t, int64(12), n)
+
+	// reverting into legacy keys
+	_, err = Migrations{migrationSortableStorageKeys}.Down(ctx, opt, 0)
+	require.NoError(t, err)
+	for _, h := range heights {
+		key := make([]byte, 8)
+		binary.LittleEndian.PutUint64(key, uint64(h))
+		obj, found, err := opt.Db.Get(decidedPrefix, append([]byte("decided"), key...))
+		require.NoError(t, err)
+		require.True(t, found)
+		msg := &message.SignedMessage{}
+		require.NoError(t, msg.Decode(obj.Value))
+		require.Equal(t, h, msg.Message.Height)
+	}
+	obj, found, err := opt.Db.Get(operatorsKeyPrefix, []byte("11"))
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Contains(t, string(obj.Value), "operator-11")
+	n, err = opt.Db.CountByCollection(decidedPrefix)
+	require.NoError(t, err)
+	require.Equal(t, int64(len(heights)), n)
+	n, err = opt.Db.CountByCollection(operatorsKeyPrefix)
+	require.NoError(t, err)
+	require.Equal(t, int64(12), n)
+}
+
+func Test_SchemaVersion(t *testing.T) {
+	ctx := context.Background()
+	opt, err := setupOptions(ctx, t)
+	require.NoError(t, err)
+	migrations := Migrations{
+		fakeMigration("first", nil),
+		fakeMigration("second", nil),
+		fakeMigration("third", nil),
+	}
+
+	status, err := migrations.Status(opt)
+	require.NoError(t, err)
+	require.Equal(t, 0, status.Version)
+	require.Equal(t, 3, status.Latest)
+
+	report, err := migrations.Up(ctx, opt, 2)
+	require.NoError(t, err)
+	require.Equal(t, 0, report.FromVersion)
+	require.Equal(t, 2, report.ToVersion)
+	require.Len(t, report.Migrations, 2)
+	status, err = migrations.Status(opt)
+	require.NoError(t, err)
+	require.Equal(t, 2, status.Version)
+	require.True(t, status.Migrations[1].Applied)
+	require.False(t, status.Migrations[2].Applied)
+
+	require.NoError(t, migrations.Run(ctx, opt))
+	n, err := opt.Db.CountByCollection([]byte("test/"))
+	require.NoError(t, err)
+	require.Equal(t, int64(3), n)
+
+	// target is older than current
+	_, err = migrations.Up(ctx, opt, 1)
+	require.EqualError(t, err, "invalid target version 1, current version is 3 and latest is 3")
+
+	// db is newer than the known migrations
+	_, err = migrations[:2].Status(opt)
+	require.EqualError(t, err, "db schema version 3 is newer than the latest known version 2")
+	require.Error(t, migrations[:2].Run(ctx, opt))
+}
+
+func Test_SchemaVersionOfLegacyDb(t *testing.T) {
+	ctx := context.Background()
+	opt, err := setupOptions(ctx, t)
+	require.NoError(t, err)
+	// completed migrations before versioning was introduced
+	require.NoError(t, opt.Db.Set(migrationsPrefix, []byte("first"), migrationCompleted))
+	require.NoError(t, opt.Db.Set(migrationsPrefix, []byte("second"), migrationCompleted))
+
+	var count int
+	migrations := Migrations{
+		fakeMigration("first", errors.New("should not run")),
+		fakeMigration("second", errors.New("should not run")),
+		{Name: "third", Up: func(ctx context.Context, opt Options) error {
+			count++
+			return nil
+		}},
+	}
+	status, err := migrations.Status(opt)
+	require.NoError(t, err)
+	require.Equal(t, 2, status.Version)
+
+	require.NoError(t, migrations.Run(ctx, opt))
+	require.Equal(t, 1, count)
+	obj, found, err := opt.Db.Get(migrationsPrefix, schemaVersionKey)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, uint64(3), binary.BigEndian.Uint64(obj.Value))
+}
+
+func Test_DryRun(t *testing.T) {
+	ctx := context.Background()
+	opt, err := setupOptions(ctx, t)
+	require.NoError(t, err)
+	require.NoError(t, opt.Db.Set([]byte("test/"), []byte("existing"), []byte("value")))
+	migrations := Migrations{
+		fakeMigration("first", nil),
+		fakeMigration("second", nil),
+	}
+
+	opt.DryRun = true
+	report, err := migrations.Up(ctx, opt, 2)
+	require.NoError(t, err)
+	require.True(t, report.DryRun)
+	require.Len(t, report.Migrations, 2)
+	require.Equal(t, map[string]int64{"test/": 1}, report.Migrations[0].Affected)
+	status, err := migrations.Status(opt)
+	require.NoError(t, err)
+	require.Equal(t, 0, status.Version)
+	n, err := opt.Db.CountByCollection([]byte("test/"))
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+
+	opt.DryRun = false
+	require.NoError(t, migrations.Run(ctx, opt))
+	opt.DryRun = true
+	report, err = migrations.Down(ctx, opt, 0)
+	require.NoError(t, err)
+	require.Len(t, report.Migrations, 2)
+	require.Equal(t, "second", report.Migrations[0].Name)
+	require.Equal(t, map[string]int64{"test/": 3}, report.Migrations[0].Affected)
+	status, err = migrations.Status(opt)
+	require.NoError(t, err)
+	require.Equal(t, 2, status.Version)
+}
+
+func Test_Down(t *testing.T) {
+	ctx := context.Background()
+	opt, err := setupOptions(ctx, t)
+	require.NoError(t, err)
+	irreversible := fakeMigration("irreversible", nil)
+	irreversible.DownTxn = nil
+	migrations := Migrations{
+		irreversible,
+		fakeMigration("first", nil),
+		fakeMigration("second", nil),
+	}
+	require.NoError(t, migrations.Run(ctx, opt))
+
+	_, err = migrations.Down(ctx, opt, 0)
+	require.EqualError(t, err, "migration \"irreversible\" is irreversible")
+	status, err := migrations.Status(opt)
+	require.NoError(t, err)
+	require.Equal(t, 3, status.Version)
+
+	report, err := migrations.Down(ctx, opt, 1)
+	require.NoError(t, err)
+	require.Equal(t, 3, report.FromVersion)
+	require.Equal(t, 1, report.ToVersion)
+	status, err = migrations.Status(opt)
+	require.NoError(t, err)
+	require.Equal(t, 1, status.Version)
+	for _, name := range []string{"first", "second"} {
+		_, found, err := opt.Db.Get([]byte("test/"), []byte(name))
+		require.NoError(t, err)
+		require.False(t, found)
+		// completed marks are removed as well, for older versions of the node
+		_, found, err = opt.Db.Get(migrationsPrefix, []byte(name))
+		require.NoError(t, err)
+		require.False(t, found)
+	}
+
+	// migrations are applied again
+	require.NoError(t, migrations.Run(ctx, opt))
+	n, err := opt.Db.CountByCollection([]byte("test/"))
+	require.NoError(t, err)
+	require.Equal(t, int64(3), n)
+}
+
+func Test_DefaultMigrations(t *testing.T) {
+	require.NoError(t, defaultMigrations.validate())
+	for _, migration := range defaultMigrations {
+		require.True(t, migration.Reversible(), migration.Name)
+	}
+}
+
 func fakeMigration(name string, returnErr error) Migration {
 	return Migration{
 		Name: name,
-		Run: func(ctx context.Context, opt Options, key []byte) error {
-			return opt.Db.Update(func(txn basedb.Txn) error {
-				err := txn.Set(migrationsPrefix, key, migrationCompleted)
-				if err != nil {
-					return err
-				}
-				return returnErr
-			})
+		UpTxn: func(ctx context.Context, opt Options, txn basedb.Txn) error {
+			err := txn.Set([]byte("test/"), []byte(name), []byte(name))
+			if err != nil {
+				return err
+			}
+			return returnErr
+		},
+		DownTxn: func(ctx context.Context, opt Options, txn basedb.Txn) error {
+			return txn.Delete([]byte("test/"), []byte(name))
 		},
+		Prefixes: [][]byte{[]byte("test/")},
 	}
 }
@@ -18,7 +18,7 @@ import (
 
 // Handler handles incoming metrics requests
 type Handler interface {
-	// Start starts an http server, listening to /metrics requests
+	// Start starts an http server, listening to /metrics and health (/health, /livez, /readyz, /health/details) requests
 	Start(mux *http.ServeMux, addr string) error
 }
 
@@ -99,6 +99,45 @@ func (mh *metricsHandler) Start(mux *http.ServeMux, addr string) error {
 		}
 	})
 
+	// liveness: the process is up and serving requests
+	mux.HandleFunc("/livez", func(res http.ResponseWriter, req *http.Request) {
+		if _, err := fmt.Fprintln(res, "ok"); err != nil {
+			http.Error(res, err.Error(), http.StatusInternalServerError)
+		}
+	})
+
+	// readiness: the node was started and all its components are healthy
+	mux.HandleFunc("/readyz", func(res http.ResponseWriter, req *http.Request) {
+		report := mh.healthReport()
+		if !report.Ready {
+			errs := report.Errors()
+			if len(errs) == 0 {
+				errs = []string{"node is not started yet"}
+			}
+			if raw, err := json.Marshal(map[string][]string{"errors": errs}); err != nil {
+				http.Error(res, err.Error(), http.StatusInternalServerError)
+			} else {
+				http.Error(res, string(raw), http.StatusServiceUnavailable)
+			}
+			return
+		}
+		if _, err := fmt.Fprintln(res, "ok"); err != nil {
+			http.Error(res, err.Error(), http.StatusInternalServerError)
+		}
+	})
+
+	mux.HandleFunc("/health/details", func(res http.ResponseWriter, req *http.Request) {
+		raw, err := json.Marshal(mh.healthReport())
+		if err != nil {
+			http.Error(res, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		res.Header().Set("Content-Type", "application/json")
+		if _, err := res.Write(raw); err != nil {
+			mh.logger.Debug("could not write health details", zap.Error(err))
+		}
+	})
+
 	go func() {
 		if err := http.ListenAndServe(addr, mux); err != nil {
 			mh.logger.Error("failed to start metrics http end-point", zap.Error(err))
@@ -108,6 +147,15 @@ func (mh *metricsHandler) Start(mux *http.ServeMux, addr string) error {
 	return nil
 }
 
+// healthReport returns the detailed health of the node,
+// health checkers that are not a HealthReporter are reported as a single component
+func (mh *metricsHandler) healthReport() HealthReport {
+	if reporter, ok := mh.healthChecker.(HealthReporter); ok {
+		return reporter.HealthReport()
+	}
+	return NewHealthReport(true, NewComponentStatus("node", mh.healthChecker.HealthCheck(), nil))
+}
+
 func (mh *metricsHandler) configureProfiling() {
 	runtime.SetBlockProfileRate(1000)
 	runtime.SetMutexProfileFraction(1)
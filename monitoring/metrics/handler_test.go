@@ -0,0 +1,68 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type mockReporter struct {
+	report HealthReport
+}
+
+func (mr *mockReporter) HealthCheck() []string {
+	return mr.report.Errors()
+}
+
+func (mr *mockReporter) HealthReport() HealthReport {
+	return mr.report
+}
+
+func TestHandler_Health(t *testing.T) {
+	reporter := &mockReporter{}
+	mux := http.NewServeMux()
+	require.NoError(t, NewMetricsHandler(context.Background(), zap.L(), false, reporter).Start(mux, "127.0.0.1:0"))
+
+	get := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	t.Run("not started", func(t *testing.T) {
+		reporter.report = NewHealthReport(false, NewComponentStatus("beacon", nil, nil))
+		require.Equal(t, http.StatusOK, get("/livez").Code)
+		rec := get("/readyz")
+		require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		require.Contains(t, rec.Body.String(), "node is not started yet")
+	})
+
+	t.Run("unhealthy component", func(t *testing.T) {
+		reporter.report = NewHealthReport(true, NewComponentStatus("beacon", nil, nil),
+			NewComponentStatus("p2p", []string{"no connected peers"}, map[string]interface{}{"connected_peers": 0}))
+		require.Equal(t, http.StatusOK, get("/livez").Code)
+		rec := get("/readyz")
+		require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		require.Contains(t, rec.Body.String(), "p2p: no connected peers")
+
+		rec = get("/health/details")
+		require.Equal(t, http.StatusOK, rec.Code)
+		var report HealthReport
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+		require.False(t, report.Ready)
+		require.Len(t, report.Components, 2)
+		require.False(t, report.Components[1].Healthy)
+		require.Equal(t, float64(0), report.Components[1].Details["connected_peers"])
+	})
+
+	t.Run("ready", func(t *testing.T) {
+		reporter.report = NewHealthReport(true, NewComponentStatus("beacon", nil, nil))
+		require.Equal(t, http.StatusOK, get("/readyz").Code)
+		require.Equal(t, http.StatusOK, get("/health").Code)
+	})
+}
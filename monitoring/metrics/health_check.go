@@ -41,3 +41,78 @@ func WaitUntilHealthy(logger *zap.Logger, component interface{}, name string) {
 	}
 	logger.Debug(name + " is healthy")
 }
+
+// ComponentStatus is the health status of a single component of the node
+type ComponentStatus struct {
+	Name    string                 `json:"name"`
+	Healthy bool                   `json:"healthy"`
+	Errors  []string               `json:"errors,omitempty"`
+	Details map[string]interface{} `json:"details,omitempty"`
+}
+
+// NewComponentStatus creates a status of the given component, which is healthy if there are no errors
+func NewComponentStatus(name string, errs []string, details map[string]interface{}) ComponentStatus {
+	return ComponentStatus{
+		Name:    name,
+		Healthy: len(errs) == 0,
+		Errors:  errs,
+		Details: details,
+	}
+}
+
+// ComponentHealthAgent is implemented by components that report a detailed health status
+type ComponentHealthAgent interface {
+	HealthStatus() ComponentStatus
+}
+
+// ComponentHealth returns the status of the given component, components that implement only HealthCheckAgent
+// are reported without details
+func ComponentHealth(name string, component interface{}) (ComponentStatus, bool) {
+	switch agent := component.(type) {
+	case ComponentHealthAgent:
+		status := agent.HealthStatus()
+		status.Name = name
+		return status, true
+	case HealthCheckAgent:
+		return NewComponentStatus(name, agent.HealthCheck(), nil), true
+	default:
+		return ComponentStatus{}, false
+	}
+}
+
+// HealthReport is the detailed health status of the node
+type HealthReport struct {
+	// Healthy is true if all components are healthy
+	Healthy bool `json:"healthy"`
+	// Ready is true once the node was started and all components are healthy
+	Ready      bool              `json:"ready"`
+	Components []ComponentStatus `json:"components"`
+}
+
+// NewHealthReport creates a report of the given components
+func NewHealthReport(started bool, components ...ComponentStatus) HealthReport {
+	report := HealthReport{Healthy: true, Components: components}
+	for _, c := range components {
+		if !c.Healthy {
+			report.Healthy = false
+		}
+	}
+	report.Ready = started && report.Healthy
+	return report
+}
+
+// Errors returns the errors of all components, prefixed with the component name
+func (r HealthReport) Errors() []string {
+	var errs []string
+	for _, c := range r.Components {
+		for _, err := range c.Errors {
+			errs = append(errs, c.Name+": "+err)
+		}
+	}
+	return errs
+}
+
+// HealthReporter reports the detailed health status of the node
+type HealthReporter interface {
+	HealthReport() HealthReport
+}
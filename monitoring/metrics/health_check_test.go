@@ -21,3 +21,46 @@ type mockAgent struct {
 func (ma *mockAgent) HealthCheck() []string {
 	return ma.errs[:]
 }
+
+func TestComponentHealth(t *testing.T) {
+	status, ok := ComponentHealth("a", &mockAgent{errs: []string{"dummy error"}})
+	require.True(t, ok)
+	require.Equal(t, "a", status.Name)
+	require.False(t, status.Healthy)
+
+	status, ok = ComponentHealth("b", &mockComponent{status: NewComponentStatus("x", nil, map[string]interface{}{"k": 1})})
+	require.True(t, ok)
+	require.Equal(t, "b", status.Name)
+	require.True(t, status.Healthy)
+	require.Equal(t, 1, status.Details["k"])
+
+	_, ok = ComponentHealth("c", struct{}{})
+	require.False(t, ok)
+}
+
+func TestNewHealthReport(t *testing.T) {
+	healthy := NewComponentStatus("a", nil, nil)
+	unhealthy := NewComponentStatus("b", []string{"dummy error"}, nil)
+
+	report := NewHealthReport(true, healthy)
+	require.True(t, report.Healthy)
+	require.True(t, report.Ready)
+	require.Empty(t, report.Errors())
+
+	report = NewHealthReport(false, healthy)
+	require.True(t, report.Healthy)
+	require.False(t, report.Ready)
+
+	report = NewHealthReport(true, healthy, unhealthy)
+	require.False(t, report.Healthy)
+	require.False(t, report.Ready)
+	require.Equal(t, []string{"b: dummy error"}, report.Errors())
+}
+
+type mockComponent struct {
+	status ComponentStatus
+}
+
+func (mc *mockComponent) HealthStatus() ComponentStatus {
+	return mc.status
+}
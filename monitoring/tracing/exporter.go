@@ -0,0 +1,326 @@
+package tracing
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.opencensus.io/trace"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultFlushInterval = 5 * time.Second
+	defaultBatchSize     = 512
+	// defaultQueueSize is the max amount of pending spans, spans are dropped once the queue is full
+	defaultQueueSize   = 4096
+	defaultHTTPTimeout = 10 * time.Second
+	scopeName          = "github.com/bloxapp/ssv"
+)
+
+type exporterOptions struct {
+	URL           string
+	ServiceName   string
+	FlushInterval time.Duration
+	BatchSize     int
+	QueueSize     int
+}
+
+// exporter exports spans to an OTLP/HTTP collector (JSON encoding), spans are sent in batches
+type exporter struct {
+	logger *zap.Logger
+	opts   exporterOptions
+	client *http.Client
+
+	lock    sync.Mutex
+	pending []*trace.SpanData
+	dropped int
+
+	flushC chan struct{}
+	stopC  chan struct{}
+	doneC  chan struct{}
+	once   sync.Once
+}
+
+func newExporter(logger *zap.Logger, opts exporterOptions) *exporter {
+	if opts.FlushInterval == 0 {
+		opts.FlushInterval = defaultFlushInterval
+	}
+	if opts.BatchSize == 0 {
+		opts.BatchSize = defaultBatchSize
+	}
+	if opts.QueueSize == 0 {
+		opts.QueueSize = defaultQueueSize
+	}
+	exp := &exporter{
+		logger: logger.With(zap.String("who", "tracingExporter")),
+		opts:   opts,
+		client: &http.Client{Timeout: defaultHTTPTimeout},
+		flushC: make(chan struct{}, 1),
+		stopC:  make(chan struct{}),
+		doneC:  make(chan struct{}),
+	}
+	go exp.loop()
+	return exp
+}
+
+// ExportSpan implements trace.Exporter
+func (exp *exporter) ExportSpan(sd *trace.SpanData) {
+	exp.lock.Lock()
+	defer exp.lock.Unlock()
+
+	if len(exp.pending) >= exp.opts.QueueSize {
+		exp.dropped++
+		return
+	}
+	exp.pending = append(exp.pending, sd)
+	if len(exp.pending) >= exp.opts.BatchSize {
+		select {
+		case exp.flushC <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Close stops the exporter, after sending the pending spans
+func (exp *exporter) Close() {
+	exp.once.Do(func() {
+		close(exp.stopC)
+		<-exp.doneC
+	})
+}
+
+func (exp *exporter) loop() {
+	defer close(exp.doneC)
+	ticker := time.NewTicker(exp.opts.FlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-exp.flushC:
+		case <-exp.stopC:
+			exp.flush()
+			return
+		}
+		exp.flush()
+	}
+}
+
+// flush sends all the pending spans
+func (exp *exporter) flush() {
+	exp.lock.Lock()
+	spans := exp.pending
+	dropped := exp.dropped
+	exp.pending = nil
+	exp.dropped = 0
+	exp.lock.Unlock()
+
+	if dropped > 0 {
+		exp.logger.Warn("spans were dropped as the queue is full", zap.Int("dropped", dropped))
+	}
+	for len(spans) > 0 {
+		n := len(spans)
+		if n > exp.opts.BatchSize {
+			n = exp.opts.BatchSize
+		}
+		if err := exp.send(spans[:n]); err != nil {
+			exp.logger.Debug("could not export spans", zap.Int("count", n), zap.Error(err))
+		}
+		spans = spans[n:]
+	}
+}
+
+func (exp *exporter) send(spans []*trace.SpanData) error {
+	raw, err := json.Marshal(encodeSpans(exp.opts.ServiceName, spans))
+	if err != nil {
+		return errors.Wrap(err, "could not encode spans")
+	}
+	res, err := exp.client.Post(exp.opts.URL, "application/json", bytes.NewReader(raw))
+	if err != nil {
+		return errors.Wrap(err, "could not send spans")
+	}
+	defer func() {
+		_, _ = io.Copy(ioutil.Discard, res.Body)
+		_ = res.Body.Close()
+	}()
+	if res.StatusCode/100 != 2 {
+		return errors.Errorf("collector returned status %d", res.StatusCode)
+	}
+	return nil
+}
+
+// the following types are the OTLP/JSON representation of spans,
+// see https://github.com/open-telemetry/opentelemetry-proto/blob/main/docs/specification.md#json-protobuf-encoding
+
+type otlpTraces struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              int            `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Events            []otlpEvent    `json:"events,omitempty"`
+	Links             []otlpLink     `json:"links,omitempty"`
+	Status            otlpStatus     `json:"status"`
+}
+
+type otlpEvent struct {
+	TimeUnixNano string         `json:"timeUnixNano"`
+	Name         string         `json:"name"`
+	Attributes   []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpLink struct {
+	TraceID    string         `json:"traceId"`
+	SpanID     string         `json:"spanId"`
+	Attributes []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+// OTLP span kinds and status codes
+const (
+	otlpSpanKindInternal = 1
+	otlpSpanKindServer   = 2
+	otlpSpanKindClient   = 3
+
+	otlpStatusUnset = 0
+	otlpStatusError = 2
+)
+
+func encodeSpans(serviceName string, spans []*trace.SpanData) otlpTraces {
+	encoded := make([]otlpSpan, 0, len(spans))
+	for _, sd := range spans {
+		encoded = append(encoded, encodeSpan(sd))
+	}
+	return otlpTraces{ResourceSpans: []otlpResourceSpans{{
+		Resource: otlpResource{Attributes: encodeAttributes(map[string]interface{}{
+			"service.name": serviceName,
+		})},
+		ScopeSpans: []otlpScopeSpans{{
+			Scope: otlpScope{Name: scopeName},
+			Spans: encoded,
+		}},
+	}}}
+}
+
+func encodeSpan(sd *trace.SpanData) otlpSpan {
+	s := otlpSpan{
+		TraceID:           hex.EncodeToString(sd.TraceID[:]),
+		SpanID:            hex.EncodeToString(sd.SpanID[:]),
+		Name:              sd.Name,
+		Kind:              otlpSpanKindInternal,
+		StartTimeUnixNano: unixNano(sd.StartTime),
+		EndTimeUnixNano:   unixNano(sd.EndTime),
+		Attributes:        encodeAttributes(sd.Attributes),
+		Status:            otlpStatus{Code: otlpStatusUnset},
+	}
+	if sd.ParentSpanID != (trace.SpanID{}) {
+		s.ParentSpanID = hex.EncodeToString(sd.ParentSpanID[:])
+	}
+	switch sd.SpanKind {
+	case trace.SpanKindServer:
+		s.Kind = otlpSpanKindServer
+	case trace.SpanKindClient:
+		s.Kind = otlpSpanKindClient
+	}
+	if sd.Code != trace.StatusCodeOK {
+		s.Status = otlpStatus{Code: otlpStatusError, Message: sd.Message}
+	}
+	for _, a := range sd.Annotations {
+		s.Events = append(s.Events, otlpEvent{
+			TimeUnixNano: unixNano(a.Time),
+			Name:         a.Message,
+			Attributes:   encodeAttributes(a.Attributes),
+		})
+	}
+	for _, l := range sd.Links {
+		s.Links = append(s.Links, otlpLink{
+			TraceID:    hex.EncodeToString(l.TraceID[:]),
+			SpanID:     hex.EncodeToString(l.SpanID[:]),
+			Attributes: encodeAttributes(l.Attributes),
+		})
+	}
+	return s
+}
+
+func encodeAttributes(attrs map[string]interface{}) []otlpKeyValue {
+	if len(attrs) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(attrs))
+	for k := range attrs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	kvs := make([]otlpKeyValue, 0, len(attrs))
+	for _, k := range keys {
+		var val otlpValue
+		switch v := attrs[k].(type) {
+		case string:
+			val.StringValue = &v
+		case bool:
+			val.BoolValue = &v
+		case int64:
+			s := strconv.FormatInt(v, 10)
+			val.IntValue = &s
+		case float64:
+			val.DoubleValue = &v
+		default:
+			continue
+		}
+		kvs = append(kvs, otlpKeyValue{Key: k, Value: val})
+	}
+	return kvs
+}
+
+func unixNano(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
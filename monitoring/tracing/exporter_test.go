@@ -0,0 +1,118 @@
+package tracing
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/trace"
+	"go.uber.org/zap"
+)
+
+func TestExporter(t *testing.T) {
+	var lock sync.Mutex
+	var received []otlpTraces
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1/traces", r.URL.Path)
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		var traces otlpTraces
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&traces))
+		lock.Lock()
+		received = append(received, traces)
+		lock.Unlock()
+	}))
+	defer server.Close()
+
+	exp := newExporter(zap.L(), exporterOptions{
+		URL:           server.URL + "/v1/traces",
+		ServiceName:   "test-node",
+		FlushInterval: time.Hour,
+		BatchSize:     2,
+	})
+	start := time.Unix(100, 0)
+	parent := &trace.SpanData{
+		SpanContext: trace.SpanContext{TraceID: trace.TraceID{1}, SpanID: trace.SpanID{1}},
+		Name:        "parent",
+		StartTime:   start,
+		EndTime:     start.Add(time.Second),
+		Attributes:  map[string]interface{}{"role": "ATTESTER", "slot": int64(10)},
+	}
+	child := &trace.SpanData{
+		SpanContext:  trace.SpanContext{TraceID: trace.TraceID{1}, SpanID: trace.SpanID{2}},
+		ParentSpanID: trace.SpanID{1},
+		Name:         "child",
+		SpanKind:     trace.SpanKindClient,
+		StartTime:    start,
+		EndTime:      start.Add(time.Millisecond),
+		Status:       trace.Status{Code: trace.StatusCodeUnknown, Message: "failed"},
+		Annotations:  []trace.Annotation{{Time: start, Message: "signature collected"}},
+	}
+	last := &trace.SpanData{
+		SpanContext: trace.SpanContext{TraceID: trace.TraceID{2}, SpanID: trace.SpanID{3}},
+		Name:        "last",
+	}
+	// the first two spans are flushed as a batch, the last one once the exporter is closed
+	exp.ExportSpan(parent)
+	exp.ExportSpan(child)
+	require.Eventually(t, func() bool {
+		lock.Lock()
+		defer lock.Unlock()
+		return len(received) == 1
+	}, time.Second*5, time.Millisecond*10)
+	exp.ExportSpan(last)
+	exp.Close()
+
+	lock.Lock()
+	defer lock.Unlock()
+	require.Len(t, received, 2)
+	require.Len(t, received[0].ResourceSpans, 1)
+	rs := received[0].ResourceSpans[0]
+	require.Equal(t, "service.name", rs.Resource.Attributes[0].Key)
+	require.Equal(t, "test-node", *rs.Resource.Attributes[0].Value.StringValue)
+	spans := rs.ScopeSpans[0].Spans
+	require.Len(t, spans, 2)
+
+	require.Equal(t, "parent", spans[0].Name)
+	require.Equal(t, "01000000000000000000000000000000", spans[0].TraceID)
+	require.Equal(t, "0100000000000000", spans[0].SpanID)
+	require.Empty(t, spans[0].ParentSpanID)
+	require.Equal(t, "100000000000", spans[0].StartTimeUnixNano)
+	require.Equal(t, "101000000000", spans[0].EndTimeUnixNano)
+	require.Equal(t, otlpSpanKindInternal, spans[0].Kind)
+	require.Equal(t, otlpStatusUnset, spans[0].Status.Code)
+	require.Len(t, spans[0].Attributes, 2)
+	require.Equal(t, "role", spans[0].Attributes[0].Key)
+	require.Equal(t, "ATTESTER", *spans[0].Attributes[0].Value.StringValue)
+	require.Equal(t, "slot", spans[0].Attributes[1].Key)
+	require.Equal(t, "10", *spans[0].Attributes[1].Value.IntValue)
+
+	require.Equal(t, "child", spans[1].Name)
+	require.Equal(t, "0100000000000000", spans[1].ParentSpanID)
+	require.Equal(t, otlpSpanKindClient, spans[1].Kind)
+	require.Equal(t, otlpStatus{Code: otlpStatusError, Message: "failed"}, spans[1].Status)
+	require.Len(t, spans[1].Events, 1)
+	require.Equal(t, "signature collected", spans[1].Events[0].Name)
+
+	require.Equal(t, "last", received[1].ResourceSpans[0].ScopeSpans[0].Spans[0].Name)
+}
+
+func TestExporter_QueueFull(t *testing.T) {
+	exp := newExporter(zap.L(), exporterOptions{
+		URL:           "http://127.0.0.1:1/v1/traces",
+		FlushInterval: time.Hour,
+		BatchSize:     10,
+		QueueSize:     2,
+	})
+	defer exp.Close()
+	for i := 0; i < 5; i++ {
+		exp.ExportSpan(&trace.SpanData{Name: "span"})
+	}
+	exp.lock.Lock()
+	defer exp.lock.Unlock()
+	require.Len(t, exp.pending, 2)
+	require.Equal(t, 3, exp.dropped)
+}
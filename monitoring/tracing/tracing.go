@@ -0,0 +1,135 @@
+package tracing
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.opencensus.io/trace"
+	"go.opencensus.io/trace/propagation"
+	"go.uber.org/zap"
+)
+
+// Options are the tracing configurations
+type Options struct {
+	OTLPEndpoint string  `yaml:"OTLPEndpoint" env:"TRACING_OTLP_ENDPOINT" env-description:"OTLP/HTTP endpoint of a traces collector (e.g. http://localhost:4318), tracing is disabled if empty"`
+	SampleRate   float64 `yaml:"SampleRate" env:"TRACING_SAMPLE_RATE" env-default:"1" env-description:"Fraction of duties that are traced (0-1)"`
+	ServiceName  string  `yaml:"ServiceName" env:"TRACING_SERVICE_NAME" env-default:"ssv-node" env-description:"Service name that is reported with the spans"`
+}
+
+// Enabled returns true if spans should be exported
+func (o Options) Enabled() bool {
+	return len(o.OTLPEndpoint) > 0
+}
+
+// Setup registers an OTLP exporter according to the given options.
+// the returned function flushes the pending spans and should be called once the node stops
+func Setup(logger *zap.Logger, opts Options) (func(), error) {
+	if !opts.Enabled() {
+		trace.ApplyConfig(trace.Config{DefaultSampler: trace.NeverSample()})
+		return func() {}, nil
+	}
+	endpoint, err := tracesURL(opts.OTLPEndpoint)
+	if err != nil {
+		return nil, err
+	}
+	exp := newExporter(logger, exporterOptions{
+		URL:         endpoint,
+		ServiceName: opts.ServiceName,
+	})
+	trace.RegisterExporter(exp)
+	trace.ApplyConfig(trace.Config{DefaultSampler: trace.ProbabilitySampler(opts.SampleRate)})
+	logger.Info("tracing is enabled", zap.String("endpoint", endpoint), zap.Float64("sampleRate", opts.SampleRate))
+
+	return func() {
+		trace.UnregisterExporter(exp)
+		exp.Close()
+	}, nil
+}
+
+// tracesURL returns the url of the OTLP/HTTP traces api, appending the default path if not specified
+func tracesURL(endpoint string) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", errors.Wrap(err, "invalid OTLP endpoint")
+	}
+	if len(u.Scheme) == 0 || len(u.Host) == 0 {
+		return "", errors.Errorf("invalid OTLP endpoint %q, expected scheme and host", endpoint)
+	}
+	if len(strings.Trim(u.Path, "/")) == 0 {
+		u.Path = "/v1/traces"
+	}
+	return u.String(), nil
+}
+
+// StartSpan starts a new span, as a child of the span in the given context (if exist)
+func StartSpan(ctx context.Context, name string, attrs ...trace.Attribute) (context.Context, *trace.Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, span := trace.StartSpan(ctx, name)
+	if len(attrs) > 0 {
+		span.AddAttributes(attrs...)
+	}
+	return ctx, span
+}
+
+// StartRemoteSpan starts a new span as a child of the given (encoded) remote span context,
+// which is received from other operators. a regular span is started if the remote span context is missing
+func StartRemoteSpan(ctx context.Context, name string, remote []byte, attrs ...trace.Attribute) (context.Context, *trace.Span) {
+	parent, ok := Extract(remote)
+	if !ok {
+		return StartSpan(ctx, name, attrs...)
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, span := trace.StartSpanWithRemoteParent(ctx, name, parent)
+	if len(attrs) > 0 {
+		span.AddAttributes(attrs...)
+	}
+	return ctx, span
+}
+
+// Inject encodes the span context of the given context, so it could be sent to other operators.
+// nil is returned if the context has no span or the span is not sampled
+func Inject(ctx context.Context) []byte {
+	if ctx == nil {
+		return nil
+	}
+	return InjectSpan(trace.FromContext(ctx))
+}
+
+// InjectSpan encodes the span context of the given span
+func InjectSpan(span *trace.Span) []byte {
+	if span == nil {
+		return nil
+	}
+	sc := span.SpanContext()
+	if !sc.IsSampled() {
+		return nil
+	}
+	return propagation.Binary(sc)
+}
+
+// Extract decodes a span context that was encoded with Inject
+func Extract(b []byte) (trace.SpanContext, bool) {
+	if len(b) == 0 {
+		return trace.SpanContext{}, false
+	}
+	return propagation.FromBinary(b)
+}
+
+// SetError sets the status of the given span according to the given error
+func SetError(span *trace.Span, err error) {
+	if err != nil {
+		span.SetStatus(trace.Status{Code: trace.StatusCodeUnknown, Message: err.Error()})
+	}
+}
+
+// EndWithError ends the given span, setting its status according to the given error
+func EndWithError(span *trace.Span, err error) {
+	SetError(span, err)
+	span.End()
+}
@@ -0,0 +1,88 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/trace"
+)
+
+func TestTracesURL(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		expected string
+		err      bool
+	}{
+		{"http://localhost:4318", "http://localhost:4318/v1/traces", false},
+		{"http://localhost:4318/", "http://localhost:4318/v1/traces", false},
+		{"https://collector:443/custom/traces", "https://collector:443/custom/traces", false},
+		{"localhost:4318", "", true},
+		{"://", "", true},
+	}
+	for _, test := range tests {
+		t.Run(test.endpoint, func(t *testing.T) {
+			u, err := tracesURL(test.endpoint)
+			if test.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.expected, u)
+		})
+	}
+}
+
+func TestInjectExtract(t *testing.T) {
+	require.Nil(t, Inject(context.Background()))
+	_, ok := Extract(nil)
+	require.False(t, ok)
+
+	t.Run("sampled", func(t *testing.T) {
+		ctx, span := trace.StartSpan(context.Background(), "test", trace.WithSampler(trace.AlwaysSample()))
+		defer span.End()
+
+		encoded := Inject(ctx)
+		require.NotNil(t, encoded)
+		sc, ok := Extract(encoded)
+		require.True(t, ok)
+		require.Equal(t, span.SpanContext(), sc)
+
+		// a remote span is a child of the extracted span
+		_, child := StartRemoteSpan(context.Background(), "child", encoded)
+		defer child.End()
+		require.Equal(t, sc.TraceID, child.SpanContext().TraceID)
+		require.NotEqual(t, sc.SpanID, child.SpanContext().SpanID)
+	})
+
+	t.Run("not sampled", func(t *testing.T) {
+		ctx, span := trace.StartSpan(context.Background(), "test", trace.WithSampler(trace.NeverSample()))
+		defer span.End()
+		require.Nil(t, Inject(ctx))
+	})
+}
+
+func TestEndWithError(t *testing.T) {
+	exp := &testExporter{}
+	trace.RegisterExporter(exp)
+	defer trace.UnregisterExporter(exp)
+
+	_, span := trace.StartSpan(context.Background(), "ok", trace.WithSampler(trace.AlwaysSample()))
+	EndWithError(span, nil)
+	_, span = trace.StartSpan(context.Background(), "failed", trace.WithSampler(trace.AlwaysSample()))
+	EndWithError(span, errors.New("test error"))
+
+	require.Len(t, exp.spans, 2)
+	require.Equal(t, int32(trace.StatusCodeOK), exp.spans[0].Code)
+	require.Equal(t, int32(trace.StatusCodeUnknown), exp.spans[1].Code)
+	require.Equal(t, "test error", exp.spans[1].Message)
+}
+
+type testExporter struct {
+	spans []*trace.SpanData
+}
+
+func (e *testExporter) ExportSpan(sd *trace.SpanData) {
+	e.spans = append(e.spans, sd)
+}
@@ -0,0 +1,143 @@
+//go:build go1.18
+// +build go1.18
+
+package factory
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+
+	"github.com/bloxapp/ssv/ibft/proto"
+	"github.com/bloxapp/ssv/network"
+	forksv0 "github.com/bloxapp/ssv/network/forks/v0"
+	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
+	"github.com/bloxapp/ssv/protocol/v1/message"
+	"github.com/bloxapp/ssv/utils/logex"
+)
+
+func init() {
+	// the conversion of v0 messages logs with the global logger
+	logex.Build("test", zapcore.FatalLevel, &logex.EncodingConfig{})
+}
+
+var fuzzForks = []forksprotocol.ForkVersion{forksprotocol.V0ForkVersion, forksprotocol.V1ForkVersion, forksprotocol.V2ForkVersion}
+
+// FuzzDecodeNetworkMsg decodes the given data with all the forks, as done for every message that is received from the network:
+//	go test ./network/forks/factory -run=XXX -fuzz=FuzzDecodeNetworkMsg -fuzztime=1m
+func FuzzDecodeNetworkMsg(f *testing.F) {
+	for _, data := range seedNetworkMsgs(f) {
+		f.Add(data)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		for _, version := range fuzzForks {
+			fork := NewFork(version)
+			msg, err := fork.DecodeNetworkMsg(data)
+			if err != nil {
+				continue
+			}
+			if msgID := fork.MsgID(); msgID != nil {
+				_ = msgID(msg.Data)
+			}
+			id := msg.GetIdentifier()
+			_ = id.GetRoleType()
+			topics := fork.ValidatorTopicID(id.GetValidatorPK())
+			for _, topic := range topics {
+				_ = fork.GetTopicBaseName(fork.GetTopicFullName(topic))
+			}
+			if _, err := fork.EncodeNetworkMsg(msg); err != nil && version != forksprotocol.V0ForkVersion {
+				// v0 can't encode every v1 message (e.g. unknown types), other forks must encode what they decoded
+				t.Fatalf("could not encode decoded message with fork %s: %v", version, err)
+			}
+		}
+	})
+}
+
+// seedNetworkMsgs returns network messages of each fork, based on the messages of the encoding tests
+func seedNetworkMsgs(tb testing.TB) [][]byte {
+	pk, err := hex.DecodeString("b768cdc2b2e0a859052bf04d1cd66383c96d95096a5287d08151494ce709556ba39c1300fbb902a0e2ebb7c31dc4e400")
+	if err != nil {
+		tb.Fatal(err)
+	}
+	lambda := []byte("YTAyZjNhNGQ5ZDg2NTZkMmI0NDI3NzVjM2JlNDliMzU1ZDc0MDU5OGNiYjM5NzAyNmZhNzRkYzUxZTFlN2FhOGVmZTJjZjk3ZTQ0ZjZmYWQxMTE4NWY2M2I2MTUxY2Q0X0FUVEVTVEVS")
+	v0Msgs := []*network.Message{
+		{
+			SignedMessage: &proto.SignedMessage{
+				Message: &proto.Message{
+					Type:   proto.RoundState_Commit,
+					Round:  1,
+					Lambda: lambda,
+					Value:  []byte("data"),
+				},
+				Signature: []byte("sig"),
+				SignerIds: []uint64{1, 2, 3, 4},
+			},
+			Type: network.NetworkMsg_DecidedType,
+		},
+		{
+			SignedMessage: &proto.SignedMessage{
+				Message: &proto.Message{
+					Type:      proto.RoundState_PrePrepare,
+					Round:     1,
+					Lambda:    lambda,
+					SeqNumber: 2,
+					Value:     []byte("data"),
+				},
+				Signature: []byte("sig"),
+				SignerIds: []uint64{1},
+			},
+			Type: network.NetworkMsg_IBFTType,
+		},
+		{
+			SignedMessage: &proto.SignedMessage{
+				Message: &proto.Message{
+					Type:      proto.RoundState_ChangeRound,
+					Round:     2,
+					Lambda:    lambda,
+					SeqNumber: 2,
+					Value:     []byte(`{"round":2,"prepared_round":1,"prepared_value":"ZGF0YQ==","justification_msg":{"type":2,"round":1,"lambda":"` + string(lambda) + `","seq_number":2,"value":"ZGF0YQ=="},"justification_sig":"c2ln","signer_ids":[1,2,3]}`),
+				},
+				Signature: []byte("sig"),
+				SignerIds: []uint64{1},
+			},
+			Type: network.NetworkMsg_IBFTType,
+		},
+	}
+
+	var res [][]byte
+	v0 := &forksv0.ForkV0{}
+	for _, msg := range v0Msgs {
+		raw, err := msg.Encode()
+		if err != nil {
+			tb.Fatal(err)
+		}
+		res = append(res, raw)
+
+		v1Msg, err := v0.DecodeNetworkMsg(raw)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		for _, version := range fuzzForks[1:] {
+			raw, err := NewFork(version).EncodeNetworkMsg(v1Msg)
+			if err != nil {
+				tb.Fatal(err)
+			}
+			res = append(res, raw)
+		}
+	}
+	syncMsg := &message.SSVMessage{
+		MsgType: message.SSVSyncMsgType,
+		ID:      message.NewIdentifier(pk, message.RoleTypeAttester),
+		Data:    []byte(`{"Protocol":0,"Params":{"Height":[1],"Identifier":"AQIDBA=="},"Data":null,"Status":0}`),
+	}
+	for _, version := range fuzzForks[1:] {
+		raw, err := NewFork(version).EncodeNetworkMsg(syncMsg)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		res = append(res, raw)
+	}
+	return res
+}
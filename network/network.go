@@ -28,3 +28,24 @@ type P2PNetwork interface {
 	// Start starts the network
 	Start() error
 }
+
+// PeerInfo is the information of a peer, as exposed to operators
+type PeerInfo struct {
+	ID            string             `json:"id"`
+	Addrs         []string           `json:"addrs,omitempty"`
+	Connectedness string             `json:"connectedness"`
+	Banned        bool               `json:"banned"`
+	OperatorID    string             `json:"operator_id,omitempty"`
+	NodeVersion   string             `json:"node_version,omitempty"`
+	Scores        map[string]float64 `json:"scores,omitempty"`
+}
+
+// PeersAdmin enables operational management of peers, it is implemented by the p2p network
+type PeersAdmin interface {
+	// PeersInfo returns the info of connected and banned peers
+	PeersInfo() []PeerInfo
+	// BanPeer blocks any connection with the given peer and closes existing connections
+	BanPeer(id string) error
+	// UnbanPeer removes the ban of the given peer, returns false if the peer was not banned
+	UnbanPeer(id string) (bool, error)
+}
@@ -0,0 +1,39 @@
+package p2pv1
+
+import (
+	"github.com/bloxapp/ssv/monitoring/metrics"
+)
+
+// verifies that the network implements ComponentHealthAgent
+var _ metrics.ComponentHealthAgent = &p2pNetwork{}
+
+// HealthStatus provides health status of the network, i.e. connected peers in total and per topic.
+// the network is healthy once it's ready and connected to at least one peer
+func (n *p2pNetwork) HealthStatus() metrics.ComponentStatus {
+	if !n.isReady() {
+		return metrics.NewComponentStatus("p2p", []string{"p2p network is not ready"}, nil)
+	}
+	connected := len(n.host.Network().Peers())
+	topicPeers := make(map[string]int)
+	var emptyTopics []string
+	for _, topic := range n.topicsCtrl.Topics() {
+		peers, err := n.topicsCtrl.Peers(topic)
+		if err != nil {
+			continue
+		}
+		topicPeers[topic] = len(peers)
+		if len(peers) == 0 {
+			emptyTopics = append(emptyTopics, topic)
+		}
+	}
+	details := map[string]interface{}{
+		"connected_peers": connected,
+		"topic_peers":     topicPeers,
+		"topics_no_peers": emptyTopics,
+	}
+	var errs []string
+	if connected == 0 {
+		errs = append(errs, "no connected peers")
+	}
+	return metrics.NewComponentStatus("p2p", errs, details)
+}
@@ -48,6 +48,7 @@ type p2pNetwork struct {
 	host        host.Host
 	streamCtrl  streams.StreamController
 	idx         peers.Index
+	connGater   peers.ConnectionGater
 	disc        discovery.Service
 	topicsCtrl  topics.Controller
 	msgRouter   network.MessageRouter
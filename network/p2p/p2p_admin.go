@@ -0,0 +1,100 @@
+package p2pv1
+
+import (
+	"sort"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/network"
+	"github.com/bloxapp/ssv/network/peers"
+)
+
+// verifies that the network implements PeersAdmin
+var _ network.PeersAdmin = &p2pNetwork{}
+
+// PeersInfo returns the info of connected and banned peers, including their scores
+func (n *p2pNetwork) PeersInfo() []network.PeerInfo {
+	if n.host == nil {
+		return nil
+	}
+	ids := n.host.Network().Peers()
+	connected := make(map[peer.ID]bool, len(ids))
+	for _, id := range ids {
+		connected[id] = true
+	}
+	for _, id := range n.connGater.Banned() {
+		if !connected[id] {
+			ids = append(ids, id)
+		}
+	}
+	res := make([]network.PeerInfo, 0, len(ids))
+	for _, id := range ids {
+		res = append(res, n.peerInfo(id))
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].ID < res[j].ID
+	})
+	return res
+}
+
+// BanPeer blocks any connection with the given peer and closes existing connections
+func (n *p2pNetwork) BanPeer(id string) error {
+	pid, err := peer.Decode(id)
+	if err != nil {
+		return errors.Wrap(err, "invalid peer id")
+	}
+	if pid == n.host.ID() {
+		return errors.New("could not ban self")
+	}
+	n.connGater.Ban(pid)
+	if err := n.host.Network().ClosePeer(pid); err != nil {
+		return errors.Wrap(err, "could not close connection with banned peer")
+	}
+	n.logger.Info("peer was banned", zap.String("peer", id))
+	return nil
+}
+
+// UnbanPeer removes the ban of the given peer
+func (n *p2pNetwork) UnbanPeer(id string) (bool, error) {
+	pid, err := peer.Decode(id)
+	if err != nil {
+		return false, errors.Wrap(err, "invalid peer id")
+	}
+	unbanned := n.connGater.Unban(pid)
+	if unbanned {
+		n.logger.Info("peer was unbanned", zap.String("peer", id))
+	}
+	return unbanned, nil
+}
+
+func (n *p2pNetwork) peerInfo(id peer.ID) network.PeerInfo {
+	pi := network.PeerInfo{
+		ID:            id.String(),
+		Connectedness: n.host.Network().Connectedness(id).String(),
+		Banned:        n.connGater.IsBanned(id),
+	}
+	for _, addr := range n.host.Peerstore().Addrs(id) {
+		pi.Addrs = append(pi.Addrs, addr.String())
+	}
+	if n.idx == nil {
+		return pi
+	}
+	if ni, err := n.idx.NodeInfo(id); err == nil && ni != nil && ni.Metadata != nil {
+		pi.OperatorID = ni.Metadata.OperatorID
+		pi.NodeVersion = ni.Metadata.NodeVersion
+	}
+	scores, err := n.idx.GetScore(id, peers.ScoreNames...)
+	if err != nil {
+		n.logger.Debug("could not get peer scores", zap.String("peer", id.String()), zap.Error(err))
+		return pi
+	}
+	if len(scores) > 0 {
+		pi.Scores = make(map[string]float64, len(scores))
+		for _, s := range scores {
+			pi.Scores[s.Name] = s.Value
+		}
+	}
+	return pi
+}
@@ -0,0 +1,65 @@
+package p2pv1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
+)
+
+func TestP2pNetwork_BanPeer(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	loggerFactory := func(who string) *zap.Logger {
+		return zap.L().With(zap.String("who", who))
+	}
+	ln, err := CreateAndStartLocalNet(ctx, loggerFactory, forksprotocol.V0ForkVersion, 2, 1, false)
+	require.NoError(t, err)
+	defer func() {
+		for _, node := range ln.Nodes {
+			_ = node.Close()
+		}
+	}()
+
+	node := ln.Nodes[0].(*p2pNetwork)
+	other := ln.Nodes[1].(*p2pNetwork)
+	otherID := other.host.ID().String()
+
+	infos := node.PeersInfo()
+	require.Len(t, infos, 1)
+	require.Equal(t, otherID, infos[0].ID)
+	require.False(t, infos[0].Banned)
+
+	require.Error(t, node.BanPeer("not-a-peer-id"))
+	require.Error(t, node.BanPeer(node.host.ID().String()))
+	require.NoError(t, node.BanPeer(otherID))
+	require.Len(t, node.host.Network().Peers(), 0)
+
+	infos = node.PeersInfo()
+	require.Len(t, infos, 1)
+	require.True(t, infos[0].Banned)
+
+	// the banned peer can't connect
+	_ = other.host.Connect(ctx, node.host.Peerstore().PeerInfo(node.host.ID()))
+	require.Len(t, node.host.Network().Peers(), 0)
+	require.Len(t, node.host.Network().ConnsToPeer(other.host.ID()), 0)
+
+	unbanned, err := node.UnbanPeer(otherID)
+	require.NoError(t, err)
+	require.True(t, unbanned)
+	unbanned, err = node.UnbanPeer(otherID)
+	require.NoError(t, err)
+	require.False(t, unbanned)
+
+	ctx2, cancel2 := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel2()
+	require.NoError(t, node.host.Connect(ctx2, other.host.Peerstore().PeerInfo(other.host.ID())))
+	infos = node.PeersInfo()
+	require.Len(t, infos, 1)
+	require.False(t, infos[0].Banned)
+}
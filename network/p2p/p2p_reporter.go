@@ -18,7 +18,7 @@ func (n *p2pNetwork) ReportValidation(msg *message.SSVMessage, res protocolp2p.M
 	}
 	peers := n.msgResolver.GetPeers(msg.GetData())
 	for _, pi := range peers {
-		err := n.idx.Score(pi, ssvpeers.NodeScore{Name: "validation", Value: msgValidationScore(res)})
+		err := n.idx.Score(pi, ssvpeers.NodeScore{Name: ssvpeers.ScoreValidation, Value: msgValidationScore(res)})
 		if err != nil {
 			n.logger.Warn("could not score peer", zap.String("peer", pi.String()), zap.Error(err))
 			continue
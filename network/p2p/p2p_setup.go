@@ -89,6 +89,8 @@ func (n *p2pNetwork) SetupHost() error {
 	if err != nil {
 		return errors.Wrap(err, "could not create libp2p options")
 	}
+	n.connGater = peers.NewConnectionGater(n.logger)
+	opts = append(opts, libp2p.ConnectionGater(n.connGater))
 	host, err := libp2p.New(n.ctx, opts...)
 	if err != nil {
 		return errors.Wrap(err, "failed to create p2p host")
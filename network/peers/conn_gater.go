@@ -1,6 +1,8 @@
 package peers
 
 import (
+	"sync"
+
 	"github.com/libp2p/go-libp2p-core/connmgr"
 	"github.com/libp2p/go-libp2p-core/control"
 	libp2pnetwork "github.com/libp2p/go-libp2p-core/network"
@@ -9,26 +11,83 @@ import (
 	"go.uber.org/zap"
 )
 
+// ConnectionGater extends connmgr.ConnectionGater with banning of peers
+type ConnectionGater interface {
+	connmgr.ConnectionGater
+	// Ban blocks any connection with the given peer
+	Ban(id peer.ID)
+	// Unban removes the ban of the given peer, returns false if the peer was not banned
+	Unban(id peer.ID) bool
+	// IsBanned returns whether the given peer is banned
+	IsBanned(id peer.ID) bool
+	// Banned returns the banned peers
+	Banned() []peer.ID
+}
+
 // connGater implements ConnectionGater interface:
 // https://github.com/libp2p/go-libp2p-core/blob/master/connmgr/gater.go
+// only connections with peers that were explicitly banned are blocked
 type connGater struct {
 	logger *zap.Logger
-	idx    ConnectionIndex
+
+	lock   sync.RWMutex
+	banned map[peer.ID]struct{}
 }
 
 // NewConnectionGater creates a new instance of ConnectionGater
-func NewConnectionGater(logger *zap.Logger, idx ConnectionIndex) connmgr.ConnectionGater {
+func NewConnectionGater(logger *zap.Logger) ConnectionGater {
 	return &connGater{
 		logger: logger,
-		idx:    idx,
+		banned: make(map[peer.ID]struct{}),
+	}
+}
+
+// Ban blocks any connection with the given peer
+func (n *connGater) Ban(id peer.ID) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.banned[id] = struct{}{}
+}
+
+// Unban removes the ban of the given peer
+func (n *connGater) Unban(id peer.ID) bool {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if _, ok := n.banned[id]; !ok {
+		return false
 	}
+	delete(n.banned, id)
+	return true
+}
+
+// IsBanned returns whether the given peer is banned
+func (n *connGater) IsBanned(id peer.ID) bool {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+
+	_, ok := n.banned[id]
+	return ok
+}
+
+// Banned returns the banned peers
+func (n *connGater) Banned() []peer.ID {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+
+	ids := make([]peer.ID, 0, len(n.banned))
+	for id := range n.banned {
+		ids = append(ids, id)
+	}
+	return ids
 }
 
 // InterceptPeerDial is called on an imminent outbound peer dial request, prior
 // to the addresses of that peer being available/resolved. Blocking connections
 // at this stage is typical for blacklisting scenarios
 func (n *connGater) InterceptPeerDial(id peer.ID) bool {
-	return n.idx.IsBad(id)
+	return n.allowed(id)
 }
 
 // InterceptAddrDial is called on an imminent outbound dial to a peer on a
@@ -49,7 +108,7 @@ func (n *connGater) InterceptAccept(multiaddrs libp2pnetwork.ConnMultiaddrs) boo
 // InterceptSecured is called for both inbound and outbound connections,
 // after a security handshake has taken place and we've authenticated the peer.
 func (n *connGater) InterceptSecured(direction libp2pnetwork.Direction, id peer.ID, multiaddrs libp2pnetwork.ConnMultiaddrs) bool {
-	return n.idx.IsBad(id)
+	return n.allowed(id)
 }
 
 // InterceptUpgraded is called for inbound and outbound connections, after
@@ -58,3 +117,12 @@ func (n *connGater) InterceptSecured(direction libp2pnetwork.Direction, id peer.
 func (n *connGater) InterceptUpgraded(conn libp2pnetwork.Conn) (bool, control.DisconnectReason) {
 	return true, 0
 }
+
+// allowed returns true if the given peer is not banned
+func (n *connGater) allowed(id peer.ID) bool {
+	if n.IsBanned(id) {
+		n.logger.Debug("blocked connection with banned peer", zap.String("peer", id.String()))
+		return false
+	}
+	return true
+}
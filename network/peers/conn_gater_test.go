@@ -0,0 +1,33 @@
+package peers
+
+import (
+	"testing"
+
+	libp2pnetwork "github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestConnGater(t *testing.T) {
+	good, banned := peer.ID("good"), peer.ID("banned")
+	gater := NewConnectionGater(zap.L())
+
+	require.True(t, gater.InterceptPeerDial(good))
+	require.True(t, gater.InterceptSecured(libp2pnetwork.DirInbound, good, nil))
+	require.Empty(t, gater.Banned())
+
+	gater.Ban(banned)
+	require.True(t, gater.IsBanned(banned))
+	require.Equal(t, []peer.ID{banned}, gater.Banned())
+	require.False(t, gater.InterceptPeerDial(banned))
+	require.False(t, gater.InterceptSecured(libp2pnetwork.DirOutbound, banned, nil))
+	// other peers are not affected
+	require.True(t, gater.InterceptPeerDial(good))
+
+	require.True(t, gater.Unban(banned))
+	require.False(t, gater.Unban(banned))
+	require.False(t, gater.IsBanned(banned))
+	require.True(t, gater.InterceptPeerDial(banned))
+	require.True(t, gater.InterceptSecured(libp2pnetwork.DirInbound, banned, nil))
+}
@@ -97,6 +97,21 @@ type Index interface {
 	io.Closer
 }
 
+// names of the scores that are tracked in the index
+const (
+	// ScorePubsub is the overall pubsub score of the peer
+	ScorePubsub = "PS_Score"
+	// ScoreBehaviourPenalty is the pubsub behaviour penalty of the peer
+	ScoreBehaviourPenalty = "PS_BehaviourPenalty"
+	// ScoreIPColocationFactor is the pubsub ip colocation factor of the peer
+	ScoreIPColocationFactor = "PS_IPColocationFactor"
+	// ScoreValidation is the score of the last message validation result
+	ScoreValidation = "validation"
+)
+
+// ScoreNames are the names of all the scores that are tracked in the index
+var ScoreNames = []string{ScorePubsub, ScoreBehaviourPenalty, ScoreIPColocationFactor, ScoreValidation}
+
 func formatInfoKey(k string) string {
 	return fmt.Sprintf("ssv/info/%s", k)
 }
@@ -203,14 +203,18 @@ func (pi *peersIndex) GetScore(id peer.ID, names ...string) ([]NodeScore, error)
 	}()
 	for _, name := range names {
 		s, err := tx.Get(formatScoreKey(name))
+		if err == peerstore.ErrNotFound {
+			// the peer wasn't scored yet
+			continue
+		}
 		if err != nil {
 			return nil, err
 		}
-		score, ok := s.(NodeScore)
+		val, ok := s.(float64)
 		if !ok {
 			return nil, errors.New("could not cast node score")
 		}
-		scores = append(scores, score)
+		scores = append(scores, NodeScore{Name: name, Value: val})
 	}
 	return scores, nil
 }
@@ -36,18 +36,19 @@ func newTransactional(pid peer.ID, store peerstore.PeerMetadata) Transactional {
 // Commit finalizes the transaction, returns an error to be handled be caller
 // which MUST invoke Rollback accordingly
 func (t *transactional) Commit() error {
-	var err error
-	var data interface{}
 	t.orig = make(map[string]interface{})
 	for k, d := range t.data {
-		data, err = t.store.Get(t.pid, k)
-		if err != nil {
-			break
+		data, err := t.store.Get(t.pid, k)
+		switch err {
+		case nil:
+			t.orig[k] = data
+		case peerstore.ErrNotFound:
+			// new key, nothing to rollback
+		default:
+			return err
 		}
-		t.orig[k] = data
-		err = t.store.Put(t.pid, k, d)
-		if err != nil {
-			break
+		if err := t.store.Put(t.pid, k, d); err != nil {
+			return err
 		}
 	}
 	return nil
@@ -0,0 +1,59 @@
+//go:build go1.18
+// +build go1.18
+
+package topics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/bloxapp/ssv/network/forks"
+	forksv0 "github.com/bloxapp/ssv/network/forks/v0"
+	forksv1 "github.com/bloxapp/ssv/network/forks/v1"
+	forksv2 "github.com/bloxapp/ssv/network/forks/v2"
+	"github.com/bloxapp/ssv/utils/logex"
+)
+
+func init() {
+	// the conversion of v0 messages logs with the global logger
+	logex.Build("test", zapcore.FatalLevel, &logex.EncodingConfig{})
+}
+
+// FuzzMsgValidator runs the topic validators of all the forks on the given message:
+//	go test ./network/topics -run=XXX -fuzz=FuzzMsgValidator -fuzztime=1m
+func FuzzMsgValidator(f *testing.F) {
+	pkHex := "b768cdc2b2e0a859052bf04d1cd66383c96d95096a5287d08151494ce709556ba39c1300fbb902a0e2ebb7c31dc4e400"
+	fv1 := &forksv1.ForkV1{}
+	for _, height := range []int{0, 1, 15160} {
+		msg, err := dummySSVConsensusMsg(pkHex, height)
+		if err != nil {
+			f.Fatal(err)
+		}
+		raw, err := msg.MarshalJSON()
+		if err != nil {
+			f.Fatal(err)
+		}
+		topics := fv1.ValidatorTopicID(msg.GetIdentifier().GetValidatorPK())
+		f.Add(raw, fv1.GetTopicFullName(topics[0]))
+		f.Add(raw, fv1.GetTopicFullName(fv1.DecidedTopic()))
+	}
+	f.Add([]byte{}, "xxx")
+
+	logger := zap.NewNop()
+	self := peer.ID("16Uiu2HAmNNPRh9pV2MXASMB7oAGCqdmFrYyp5tzutFiF2LN1xFCE")
+	from := peer.ID("16Uiu2HAkyWQyCb6reWXGQeBUt9EXArk6h3aq3PsFMwLNq3pPGH1r")
+	validators := make([]MsgValidatorFunc, 0)
+	for _, fork := range []forks.Fork{&forksv0.ForkV0{}, fv1, &forksv2.ForkV2{}} {
+		validators = append(validators, NewSSVMsgValidator(logger, fork, self))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte, topic string) {
+		for _, validate := range validators {
+			_ = validate(context.Background(), from, newPBMsg(data, topic, []byte(from)))
+		}
+	})
+}
@@ -22,13 +22,13 @@ func scoreInspector(logger *zap.Logger, scoreIdx peers.ScoreIndex) func(scores m
 	return func(scores map[peer.ID]*pubsub.PeerScoreSnapshot) {
 		for pid, peerScores := range scores {
 			err := scoreIdx.Score(pid, peers.NodeScore{
-				Name:  "PS_Score",
+				Name:  peers.ScorePubsub,
 				Value: peerScores.Score,
 			}, peers.NodeScore{
-				Name:  "PS_BehaviourPenalty",
+				Name:  peers.ScoreBehaviourPenalty,
 				Value: peerScores.BehaviourPenalty,
 			}, peers.NodeScore{
-				Name:  "PS_IPColocationFactor",
+				Name:  peers.ScoreIPColocationFactor,
 				Value: peerScores.IPColocationFactor,
 			})
 			if err != nil {
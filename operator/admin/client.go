@@ -0,0 +1,30 @@
+package admin
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// NewHTTPClient returns an http client and the base url of the admin api of a running node,
+// the given address is either an http url or a unix socket (unix:<path>)
+func NewHTTPClient(addr string) (*http.Client, string) {
+	if !strings.HasPrefix(addr, unixPrefix) {
+		return http.DefaultClient, strings.TrimSuffix(addr, "/")
+	}
+	path := strings.TrimPrefix(addr, unixPrefix)
+	return &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", path)
+		},
+	}}, "http://unix"
+}
+
+// Authorize sets the token of the admin api on the given request
+func Authorize(req *http.Request, token string) {
+	if len(token) > 0 {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+}
@@ -0,0 +1,168 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/network"
+	"github.com/bloxapp/ssv/operator/validator"
+	"github.com/bloxapp/ssv/protocol/v1/message"
+	"github.com/bloxapp/ssv/utils/logex"
+)
+
+// handleLogLevel returns the current log level (GET) or changes it (POST ?level=debug)
+func (s *server) handleLogLevel(res http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		level, err := logex.GetLoggerLevelValue(req.URL.Query().Get("level"))
+		if err != nil {
+			http.Error(res, err.Error(), http.StatusBadRequest)
+			return
+		}
+		logex.SetLevel(level)
+		s.logger.Info("log level was changed", zap.String("level", level.String()))
+	default:
+		http.Error(res, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	writeJSON(s.logger, res, map[string]string{"level": logex.GetLevel().String()})
+}
+
+type validatorsHandler struct {
+	logger *zap.Logger
+	ctrl   validator.Controller
+}
+
+// list returns the validators and the states of their qbft controllers
+func (h *validatorsHandler) list(res http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(res, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	infos := h.ctrl.ValidatorsInfo()
+	if infos == nil {
+		infos = []validator.ValidatorInfo{}
+	}
+	writeJSON(h.logger, res, infos)
+}
+
+// start starts the given validator (?pubkey=<hex>)
+func (h *validatorsHandler) start(res http.ResponseWriter, req *http.Request) {
+	h.handle(res, req, h.ctrl.StartValidator)
+}
+
+// stop stops the given validator (?pubkey=<hex>)
+func (h *validatorsHandler) stop(res http.ResponseWriter, req *http.Request) {
+	h.handle(res, req, h.ctrl.StopValidator)
+}
+
+// resync syncs the decided history of the given validator (?pubkey=<hex>&role=ATTESTER)
+func (h *validatorsHandler) resync(res http.ResponseWriter, req *http.Request) {
+	role := message.RoleTypeAttester
+	if raw := req.URL.Query().Get("role"); len(raw) > 0 {
+		if role = message.RoleTypeFromString(raw); role == message.RoleTypeUnknown {
+			http.Error(res, "unknown role", http.StatusBadRequest)
+			return
+		}
+	}
+	h.handle(res, req, func(pubKey string) error {
+		return h.ctrl.ResyncDecided(pubKey, role)
+	})
+}
+
+func (h *validatorsHandler) handle(res http.ResponseWriter, req *http.Request, action func(pubKey string) error) {
+	if req.Method != http.MethodPost {
+		http.Error(res, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	pubKey := req.URL.Query().Get("pubkey")
+	if len(pubKey) == 0 {
+		http.Error(res, "missing pubkey", http.StatusBadRequest)
+		return
+	}
+	if err := action(pubKey); err != nil {
+		switch errors.Cause(err) {
+		case validator.ErrValidatorNotFound:
+			http.Error(res, err.Error(), http.StatusNotFound)
+		case validator.ErrValidatorAlreadyStarted:
+			http.Error(res, err.Error(), http.StatusConflict)
+		default:
+			http.Error(res, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	writeJSON(h.logger, res, map[string]string{"pubkey": pubKey})
+}
+
+type peersHandler struct {
+	logger *zap.Logger
+	peers  network.PeersAdmin
+}
+
+// list returns the connected and banned peers with their scores
+func (h *peersHandler) list(res http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(res, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	infos := h.peers.PeersInfo()
+	if infos == nil {
+		infos = []network.PeerInfo{}
+	}
+	writeJSON(h.logger, res, infos)
+}
+
+// ban bans the given peer (?id=<peer id>)
+func (h *peersHandler) ban(res http.ResponseWriter, req *http.Request) {
+	id, ok := peerID(res, req)
+	if !ok {
+		return
+	}
+	if err := h.peers.BanPeer(id); err != nil {
+		http.Error(res, err.Error(), http.StatusBadRequest)
+		return
+	}
+	writeJSON(h.logger, res, map[string]interface{}{"id": id, "banned": true})
+}
+
+// unban removes the ban of the given peer (?id=<peer id>)
+func (h *peersHandler) unban(res http.ResponseWriter, req *http.Request) {
+	id, ok := peerID(res, req)
+	if !ok {
+		return
+	}
+	unbanned, err := h.peers.UnbanPeer(id)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !unbanned {
+		http.Error(res, "peer is not banned", http.StatusNotFound)
+		return
+	}
+	writeJSON(h.logger, res, map[string]interface{}{"id": id, "banned": false})
+}
+
+func peerID(res http.ResponseWriter, req *http.Request) (string, bool) {
+	if req.Method != http.MethodPost {
+		http.Error(res, "method not allowed", http.StatusMethodNotAllowed)
+		return "", false
+	}
+	id := req.URL.Query().Get("id")
+	if len(id) == 0 {
+		http.Error(res, "missing id", http.StatusBadRequest)
+		return "", false
+	}
+	return id, true
+}
+
+func writeJSON(logger *zap.Logger, res http.ResponseWriter, v interface{}) {
+	res.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(res).Encode(v); err != nil {
+		logger.Warn("could not write response", zap.Error(err))
+	}
+}
@@ -0,0 +1,130 @@
+package admin
+
+import (
+	"crypto/subtle"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/network"
+	"github.com/bloxapp/ssv/operator/validator"
+	"github.com/bloxapp/ssv/storage/backup"
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+// unixPrefix is the prefix of unix socket addresses
+const unixPrefix = "unix:"
+
+// Options are the options of the admin api
+type Options struct {
+	// Addr is either a tcp address (e.g. 127.0.0.1:16000) or a unix socket (e.g. unix:/var/run/ssv/admin.sock)
+	Addr string
+	// Token is required in requests (as a bearer token) if not empty, it is mandatory for tcp addresses
+	Token  string
+	Logger *zap.Logger
+	DB     basedb.IDb
+	// ValidatorController and Peers are optional, the corresponding endpoints are not served if nil
+	ValidatorController validator.Controller
+	Peers               network.PeersAdmin
+}
+
+// Server is a local http api for operational tasks of a running node,
+// it exposes sensitive data (e.g. db backups) and therefore should not be reachable from outside
+type Server interface {
+	// Start starts to listen to incoming requests
+	Start() error
+}
+
+type server struct {
+	logger *zap.Logger
+	addr   string
+	token  string
+	mux    *http.ServeMux
+}
+
+// New creates a new admin api server
+func New(opts Options) Server {
+	return newServer(opts)
+}
+
+func newServer(opts Options) *server {
+	s := &server{
+		logger: opts.Logger.With(zap.String("component", "admin/server")),
+		addr:   opts.Addr,
+		token:  opts.Token,
+		mux:    http.NewServeMux(),
+	}
+	s.mux.Handle("/db/backup", backup.Handler(s.logger, opts.DB))
+	s.mux.HandleFunc("/log-level", s.handleLogLevel)
+	if opts.ValidatorController != nil {
+		h := &validatorsHandler{logger: s.logger, ctrl: opts.ValidatorController}
+		s.mux.HandleFunc("/validators", h.list)
+		s.mux.HandleFunc("/validators/start", h.start)
+		s.mux.HandleFunc("/validators/stop", h.stop)
+		s.mux.HandleFunc("/validators/resync", h.resync)
+	}
+	if opts.Peers != nil {
+		h := &peersHandler{logger: s.logger, peers: opts.Peers}
+		s.mux.HandleFunc("/peers", h.list)
+		s.mux.HandleFunc("/peers/ban", h.ban)
+		s.mux.HandleFunc("/peers/unban", h.unban)
+	}
+	return s
+}
+
+func (s *server) Start() error {
+	listener, err := s.listen()
+	if err != nil {
+		return err
+	}
+	s.logger.Info("starting admin api", zap.String("addr", s.addr))
+	go func() {
+		if err := http.Serve(listener, s.handler()); err != nil {
+			s.logger.Error("failed to serve admin api", zap.Error(err))
+		}
+	}()
+	return nil
+}
+
+// listen listens on the configured address, unix sockets are accessible only by the current user
+func (s *server) listen() (net.Listener, error) {
+	if !strings.HasPrefix(s.addr, unixPrefix) {
+		if len(s.token) == 0 {
+			return nil, errors.New("a token is required when the admin api listens on a tcp address")
+		}
+		return net.Listen("tcp", s.addr)
+	}
+	path := strings.TrimPrefix(s.addr, unixPrefix)
+	// removing the socket of a previous run
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return nil, errors.Wrap(err, "could not remove existing socket")
+	}
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(path, 0600); err != nil {
+		_ = listener.Close()
+		return nil, errors.Wrap(err, "could not set socket permissions")
+	}
+	return listener, nil
+}
+
+// handler wraps the mux with token authentication, if a token was configured
+func (s *server) handler() http.Handler {
+	if len(s.token) == 0 {
+		return s.mux
+	}
+	expected := []byte("Bearer " + s.token)
+	return http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(req.Header.Get("Authorization")), expected) != 1 {
+			http.Error(res, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		s.mux.ServeHTTP(res, req)
+	})
+}
@@ -0,0 +1,172 @@
+package admin
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/bloxapp/ssv/network"
+	"github.com/bloxapp/ssv/operator/validator"
+	"github.com/bloxapp/ssv/operator/validator/mocks"
+	"github.com/bloxapp/ssv/protocol/v1/message"
+	"github.com/bloxapp/ssv/utils/logex"
+)
+
+type testPeers struct {
+	banned map[string]bool
+}
+
+func (p *testPeers) PeersInfo() []network.PeerInfo {
+	var res []network.PeerInfo
+	for id, banned := range p.banned {
+		res = append(res, network.PeerInfo{ID: id, Banned: banned, Scores: map[string]float64{"validation": 5}})
+	}
+	return res
+}
+
+func (p *testPeers) BanPeer(id string) error {
+	p.banned[id] = true
+	return nil
+}
+
+func (p *testPeers) UnbanPeer(id string) (bool, error) {
+	if !p.banned[id] {
+		return false, nil
+	}
+	p.banned[id] = false
+	return true, nil
+}
+
+func doRequest(t *testing.T, h http.Handler, method, target, token string) (int, string) {
+	req := httptest.NewRequest(method, target, nil)
+	Authorize(req, token)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	body, err := ioutil.ReadAll(rec.Result().Body)
+	require.NoError(t, err)
+	return rec.Code, string(body)
+}
+
+func TestServer_Auth(t *testing.T) {
+	s := newServer(Options{Logger: zap.L(), Token: "secret"})
+	h := s.handler()
+
+	code, _ := doRequest(t, h, http.MethodGet, "/log-level", "")
+	require.Equal(t, http.StatusUnauthorized, code)
+	code, _ = doRequest(t, h, http.MethodGet, "/log-level", "wrong")
+	require.Equal(t, http.StatusUnauthorized, code)
+	code, _ = doRequest(t, h, http.MethodGet, "/log-level", "secret")
+	require.Equal(t, http.StatusOK, code)
+
+	// a token is mandatory for tcp addresses
+	_, err := newServer(Options{Logger: zap.L(), Addr: "127.0.0.1:0"}).listen()
+	require.Error(t, err)
+}
+
+func TestServer_LogLevel(t *testing.T) {
+	logex.Build("test", zapcore.InfoLevel, nil)
+	defer logex.SetLevel(zapcore.InfoLevel)
+	h := newServer(Options{Logger: zap.L()}).handler()
+
+	code, body := doRequest(t, h, http.MethodPost, "/log-level?level=debug", "")
+	require.Equal(t, http.StatusOK, code)
+	require.JSONEq(t, `{"level":"debug"}`, body)
+	require.Equal(t, zapcore.DebugLevel, logex.GetLevel())
+
+	code, _ = doRequest(t, h, http.MethodPost, "/log-level?level=xxx", "")
+	require.Equal(t, http.StatusBadRequest, code)
+	code, body = doRequest(t, h, http.MethodGet, "/log-level", "")
+	require.Equal(t, http.StatusOK, code)
+	require.JSONEq(t, `{"level":"debug"}`, body)
+}
+
+func TestServer_Validators(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	validatorCtrl := mocks.NewMockController(ctrl)
+	h := newServer(Options{Logger: zap.L(), ValidatorController: validatorCtrl}).handler()
+
+	validatorCtrl.EXPECT().ValidatorsInfo().Return([]validator.ValidatorInfo{{
+		PublicKey:   "aaaa",
+		Status:      "active_ongoing",
+		Controllers: map[string]string{"ATTESTER": "ready"},
+	}})
+	code, body := doRequest(t, h, http.MethodGet, "/validators", "")
+	require.Equal(t, http.StatusOK, code)
+	var infos []validator.ValidatorInfo
+	require.NoError(t, json.Unmarshal([]byte(body), &infos))
+	require.Len(t, infos, 1)
+	require.Equal(t, "ready", infos[0].Controllers["ATTESTER"])
+
+	validatorCtrl.EXPECT().StopValidator("aaaa").Return(nil)
+	code, _ = doRequest(t, h, http.MethodPost, "/validators/stop?pubkey=aaaa", "")
+	require.Equal(t, http.StatusOK, code)
+
+	validatorCtrl.EXPECT().StartValidator("bbbb").Return(validator.ErrValidatorNotFound)
+	code, _ = doRequest(t, h, http.MethodPost, "/validators/start?pubkey=bbbb", "")
+	require.Equal(t, http.StatusNotFound, code)
+
+	validatorCtrl.EXPECT().ResyncDecided("aaaa", message.RoleTypeValidatorRegistration).Return(nil)
+	code, _ = doRequest(t, h, http.MethodPost, "/validators/resync?pubkey=aaaa&role=VALIDATOR_REGISTRATION", "")
+	require.Equal(t, http.StatusOK, code)
+
+	code, _ = doRequest(t, h, http.MethodPost, "/validators/resync?pubkey=aaaa&role=xxx", "")
+	require.Equal(t, http.StatusBadRequest, code)
+	code, _ = doRequest(t, h, http.MethodPost, "/validators/stop", "")
+	require.Equal(t, http.StatusBadRequest, code)
+	code, _ = doRequest(t, h, http.MethodGet, "/validators/stop?pubkey=aaaa", "")
+	require.Equal(t, http.StatusMethodNotAllowed, code)
+}
+
+func TestServer_Peers(t *testing.T) {
+	peers := &testPeers{banned: map[string]bool{"peer-1": false}}
+	h := newServer(Options{Logger: zap.L(), Peers: peers}).handler()
+
+	code, body := doRequest(t, h, http.MethodPost, "/peers/ban?id=peer-1", "")
+	require.Equal(t, http.StatusOK, code)
+	require.JSONEq(t, `{"id":"peer-1","banned":true}`, body)
+
+	code, body = doRequest(t, h, http.MethodGet, "/peers", "")
+	require.Equal(t, http.StatusOK, code)
+	var infos []network.PeerInfo
+	require.NoError(t, json.Unmarshal([]byte(body), &infos))
+	require.Len(t, infos, 1)
+	require.True(t, infos[0].Banned)
+	require.Equal(t, 5.0, infos[0].Scores["validation"])
+
+	code, _ = doRequest(t, h, http.MethodPost, "/peers/unban?id=peer-1", "")
+	require.Equal(t, http.StatusOK, code)
+	code, _ = doRequest(t, h, http.MethodPost, "/peers/unban?id=peer-1", "")
+	require.Equal(t, http.StatusNotFound, code)
+}
+
+func TestServer_UnixSocket(t *testing.T) {
+	dir, err := ioutil.TempDir("", "admin")
+	require.NoError(t, err)
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+	addr := unixPrefix + filepath.Join(dir, "admin.sock")
+	require.NoError(t, New(Options{Addr: addr, Logger: zap.L()}).Start())
+
+	info, err := os.Stat(filepath.Join(dir, "admin.sock"))
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	client, baseURL := NewHTTPClient(addr)
+	res, err := client.Get(baseURL + "/log-level")
+	require.NoError(t, err)
+	defer func() {
+		_ = res.Body.Close()
+	}()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+}
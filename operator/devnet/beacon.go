@@ -0,0 +1,166 @@
+package devnet
+
+import (
+	api "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	fssz "github.com/ferranbt/fastssz"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/go-ssz"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/beacon/goclient/ekm"
+	"github.com/bloxapp/ssv/beacon/mockbeacon"
+	beaconprotocol "github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
+	"github.com/bloxapp/ssv/protocol/v1/message"
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+// Beacon is a mock beacon node that is shared by the nodes of the devnet, backed by a mock beacon chain.
+// every validator has a single attester duty in each epoch (a committee of its own),
+// attestations are verified with the validator public key and kept by slot
+type Beacon struct {
+	logger  *zap.Logger
+	network beaconprotocol.Network
+	chain   *mockbeacon.Chain
+}
+
+// NewBeacon creates a new mock beacon node
+func NewBeacon(logger *zap.Logger, network beaconprotocol.Network) *Beacon {
+	return &Beacon{
+		logger:  logger.With(zap.String("who", "beacon")),
+		network: network,
+		chain:   mockbeacon.NewChain(network),
+	}
+}
+
+// Chain returns the mock beacon chain of the devnet
+func (b *Beacon) Chain() *mockbeacon.Chain {
+	return b.chain
+}
+
+// AddValidator activates the given validator, returns its index
+func (b *Beacon) AddValidator(pk *bls.PublicKey) spec.ValidatorIndex {
+	var blsPubKey spec.BLSPubKey
+	copy(blsPubKey[:], pk.Serialize())
+	return b.chain.AddValidator(blsPubKey)
+}
+
+// Attestations returns the attestations that were submitted in the given slot
+func (b *Beacon) Attestations(slot spec.Slot) []*spec.Attestation {
+	return b.chain.Attestations(slot)
+}
+
+// ForNode returns the beacon client of a devnet node,
+// the share keys are managed by the key manager of the node (with slashing protection) that is stored in the given db
+func (b *Beacon) ForNode(db basedb.IDb) (beaconprotocol.Beacon, error) {
+	km, err := ekm.NewETHKeyManagerSigner(db, b, b.network)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create key manager")
+	}
+	return &nodeBeacon{Beacon: b, KeyManager: km}, nil
+}
+
+// nodeBeacon is the beacon client of a single node
+type nodeBeacon struct {
+	*Beacon
+	beaconprotocol.KeyManager
+}
+
+// GetDuties returns the attester duties of the given validators in the given epoch
+func (b *Beacon) GetDuties(epoch spec.Epoch, validatorIndices []spec.ValidatorIndex) ([]*beaconprotocol.Duty, error) {
+	attesterDuties, err := b.chain.AttesterDuties(epoch, validatorIndices)
+	if err != nil {
+		return nil, err
+	}
+	var duties []*beaconprotocol.Duty
+	for _, attesterDuty := range attesterDuties {
+		duties = append(duties, &beaconprotocol.Duty{
+			Type:                    message.RoleTypeAttester,
+			PubKey:                  attesterDuty.PubKey,
+			Slot:                    attesterDuty.Slot,
+			ValidatorIndex:          attesterDuty.ValidatorIndex,
+			CommitteeIndex:          attesterDuty.CommitteeIndex,
+			CommitteeLength:         attesterDuty.CommitteeLength,
+			CommitteesAtSlot:        attesterDuty.CommitteesAtSlot,
+			ValidatorCommitteeIndex: attesterDuty.ValidatorCommitteeIndex,
+		})
+	}
+	return duties, nil
+}
+
+// GetValidatorData returns the metadata of the given validators, unknown validators are omitted
+func (b *Beacon) GetValidatorData(validatorPubKeys []spec.BLSPubKey) (map[spec.ValidatorIndex]*api.Validator, error) {
+	requested := make(map[spec.BLSPubKey]bool, len(validatorPubKeys))
+	for _, pk := range validatorPubKeys {
+		requested[pk] = true
+	}
+	res := make(map[spec.ValidatorIndex]*api.Validator)
+	for _, v := range b.chain.Validators() {
+		if requested[v.Validator.PublicKey] {
+			res[v.Index] = v
+		}
+	}
+	return res, nil
+}
+
+// GetAttestationData returns the attestation data of the given slot,
+// the data is derived from the slot so all the nodes get the same data
+func (b *Beacon) GetAttestationData(slot spec.Slot, committeeIndex spec.CommitteeIndex) (*spec.AttestationData, error) {
+	return b.chain.AttestationData(slot, committeeIndex)
+}
+
+// SubmitAttestation verifies the attestation with the public key of the assigned validator, and keeps it once
+func (b *Beacon) SubmitAttestation(attestation *spec.Attestation) error {
+	if err := b.chain.SubmitAttestation(attestation); err != nil {
+		return err
+	}
+	b.logger.Info("attestation submitted", zap.Uint64("slot", uint64(attestation.Data.Slot)),
+		zap.Uint64("committeeIndex", uint64(attestation.Data.Index)))
+	return nil
+}
+
+// SubscribeToCommitteeSubnet accepts the subscriptions, there are no subnets in the devnet
+func (b *Beacon) SubscribeToCommitteeSubnet(subscription []*api.BeaconCommitteeSubscription) error {
+	return nil
+}
+
+// SubmitProposalPreparation accepts the fee recipients, there are no block proposals in the devnet
+func (b *Beacon) SubmitProposalPreparation(feeRecipients map[spec.ValidatorIndex]bellatrix.ExecutionAddress) error {
+	return nil
+}
+
+// SubmitValidatorRegistration accepts the registration, there is no builder network in the devnet
+func (b *Beacon) SubmitValidatorRegistration(registration *beaconprotocol.SignedValidatorRegistration) error {
+	return nil
+}
+
+// GetDomain returns the attester domain of the chain
+func (b *Beacon) GetDomain(data *spec.AttestationData) ([]byte, error) {
+	domain, err := b.chain.Domain()
+	if err != nil {
+		return nil, err
+	}
+	return domain[:], nil
+}
+
+// ComputeSigningRoot computes the root of the object by calculating the hash tree root of the signing data with the given domain
+func (b *Beacon) ComputeSigningRoot(object interface{}, domain []byte) ([32]byte, error) {
+	if object == nil {
+		return [32]byte{}, errors.New("cannot compute signing root of nil")
+	}
+	var objRoot [32]byte
+	var err error
+	if v, ok := object.(fssz.HashRoot); ok {
+		objRoot, err = v.HashTreeRoot()
+	} else {
+		objRoot, err = ssz.HashTreeRoot(object)
+	}
+	if err != nil {
+		return [32]byte{}, err
+	}
+	container := &spec.SigningData{ObjectRoot: objRoot}
+	copy(container.Domain[:], domain)
+	return container.HashTreeRoot()
+}
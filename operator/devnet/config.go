@@ -0,0 +1,101 @@
+package devnet
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/template"
+
+	"github.com/ilyakaznacheev/cleanenv"
+	"github.com/pkg/errors"
+
+	p2pv1 "github.com/bloxapp/ssv/network/p2p"
+	"github.com/bloxapp/ssv/operator"
+	beaconprotocol "github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+// NodeConfig is the config of a devnet node, it has the layout of the start-node config.
+// the eth1 and beacon options are missing as both are replaced by the devnet
+type NodeConfig struct {
+	DBOptions          basedb.Options         `yaml:"db"`
+	SSVOptions         operator.Options       `yaml:"ssv"`
+	ETH2Options        beaconprotocol.Options `yaml:"eth2"`
+	P2pNetworkConfig   p2pv1.Config           `yaml:"p2p"`
+	OperatorPrivateKey string                 `yaml:"OperatorPrivateKey" env:"OPERATOR_KEY" env-description:"Operator private key, used to decrypt contract events"`
+}
+
+// nodeConfigTemplate is the template of the generated node configs
+var nodeConfigTemplate = template.Must(template.New("config").Parse(`# generated by ssvnode devnet, the config is kept once created
+db:
+  Path: {{ .DBPath }}
+
+eth2:
+  # the beacon node is mocked by the devnet
+  BeaconNodeAddr: devnet
+  Network: prater
+
+p2p:
+  TcpPort: {{ .TCPPort }}
+  UdpPort: {{ .UDPPort }}
+  NetworkID: ssv-devnet
+  MaxPeers: {{ .MaxPeers }}
+
+ssv:
+  ValidatorOptions:
+    SignatureCollectionTimeout: 5s
+
+OperatorPrivateKey: {{ .OperatorPrivateKey }}
+`))
+
+// nodeConfigParams are the parameters of the node config template
+type nodeConfigParams struct {
+	DBPath             string
+	TCPPort            int
+	UDPPort            int
+	MaxPeers           int
+	OperatorPrivateKey string
+}
+
+// nodeDir returns the dir of the given operator
+func nodeDir(dir string, id uint64) string {
+	return filepath.Join(dir, operatorName(id))
+}
+
+// operatorName returns the name of the given operator
+func operatorName(id uint64) string {
+	return fmt.Sprintf("node-%d", id)
+}
+
+// writeNodeConfig writes the config of the given operator, unless it already exists
+func writeNodeConfig(path string, op *OperatorKeys, opts Options) error {
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return errors.Wrap(err, "could not create node dir")
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return errors.Wrap(err, "could not create node config")
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	return nodeConfigTemplate.Execute(f, nodeConfigParams{
+		DBPath:             filepath.Join(filepath.Dir(path), "db"),
+		TCPPort:            opts.BasePort + int(op.ID),
+		UDPPort:            opts.BasePort + 1000 + int(op.ID),
+		MaxPeers:           opts.Operators,
+		OperatorPrivateKey: op.PrivateKey,
+	})
+}
+
+// readNodeConfig reads the given node config, the missing values are set to their defaults
+func readNodeConfig(path string) (*NodeConfig, error) {
+	cfg := &NodeConfig{}
+	if err := cleanenv.ReadConfig(path, cfg); err != nil {
+		return nil, errors.Wrap(err, "could not read node config")
+	}
+	return cfg, nil
+}
@@ -0,0 +1,220 @@
+// Package devnet runs a local network of operator nodes in a single process.
+// the registry contract is replaced by a local event source and the beacon node is mocked,
+// so the whole flow (registry events, duties, consensus and attestations) runs without external services.
+package devnet
+
+import (
+	"context"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+
+	"github.com/bloxapp/eth2-key-manager/core"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/network/commons"
+	"github.com/bloxapp/ssv/network/discovery"
+	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
+	beaconprotocol "github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
+)
+
+const (
+	// setupFile is the name of the file that holds the keys of the devnet
+	setupFile = "setup.json"
+	// configFile is the name of the config file in each node dir
+	configFile = "config.yaml"
+)
+
+// Options are the options of the devnet
+type Options struct {
+	Logger *zap.Logger
+	// Dir holds the keys, configs and dbs of the devnet, an existing devnet is resumed
+	Dir string
+	// Operators is the number of operators (at least 4)
+	Operators int
+	// Validators is the number of validators, each validator is shared by all the operators
+	Validators int
+	// BasePort is the udp port of the bootnode, node i listens on BasePort+i (tcp) and BasePort+1000+i (udp)
+	BasePort int
+	// ForkVersion is the ssv fork version of the nodes
+	ForkVersion forksprotocol.ForkVersion
+}
+
+// Devnet is a local network of operator nodes
+type Devnet struct {
+	logger *zap.Logger
+	opts   Options
+
+	setup    *Setup
+	beacon   *Beacon
+	events   *EventSource
+	bootnode *discovery.Bootnode
+	nodes    []*node
+}
+
+// New creates the devnet, the keys and node configs are generated if the dir doesn't have them yet
+func New(ctx context.Context, opts Options) (*Devnet, error) {
+	if err := os.MkdirAll(opts.Dir, 0700); err != nil {
+		return nil, errors.Wrap(err, "could not create devnet dir")
+	}
+	setup, err := loadOrGenerateSetup(opts)
+	if err != nil {
+		return nil, err
+	}
+	for _, op := range setup.Operators {
+		if err := writeNodeConfig(filepath.Join(nodeDir(opts.Dir, op.ID), configFile), op, opts); err != nil {
+			return nil, err
+		}
+	}
+
+	d := &Devnet{
+		logger: opts.Logger,
+		opts:   opts,
+		setup:  setup,
+		beacon: NewBeacon(opts.Logger, beaconprotocol.NewNetwork(core.PraterNetwork)),
+		events: NewEventSource(),
+	}
+	pks, err := setup.ValidatorPublicKeys()
+	if err != nil {
+		return nil, err
+	}
+	for _, pk := range pks {
+		d.beacon.AddValidator(pk)
+	}
+	if err := setup.AddEvents(d.events); err != nil {
+		return nil, errors.Wrap(err, "could not create registry events")
+	}
+
+	setForkVersion(opts.ForkVersion)
+	if err := d.startBootnode(ctx); err != nil {
+		return nil, err
+	}
+	deps := &nodeDeps{
+		beacon:      d.beacon,
+		events:      d.events,
+		bootnode:    d.bootnode.ENR,
+		forkVersion: opts.ForkVersion,
+	}
+	for _, op := range setup.Operators {
+		cfg, err := readNodeConfig(filepath.Join(nodeDir(opts.Dir, op.ID), configFile))
+		if err != nil {
+			d.Close()
+			return nil, err
+		}
+		n, err := newNode(ctx, opts.Logger, op.ID, cfg, deps)
+		if err != nil {
+			d.Close()
+			return nil, errors.Wrapf(err, "could not create %s", operatorName(op.ID))
+		}
+		d.nodes = append(d.nodes, n)
+	}
+	return d, nil
+}
+
+// Beacon returns the mock beacon node of the devnet
+func (d *Devnet) Beacon() *Beacon {
+	return d.beacon
+}
+
+// Events returns the event source of the devnet, events that are added are streamed to the nodes
+func (d *Devnet) Events() *EventSource {
+	return d.events
+}
+
+// Run starts the nodes and blocks until the given context is done or one of the nodes failed
+func (d *Devnet) Run(ctx context.Context) error {
+	errs := make(chan error, len(d.nodes))
+	for _, n := range d.nodes {
+		go func(n *node) {
+			if err := n.start(); err != nil {
+				errs <- errors.Wrapf(err, "%s failed", operatorName(n.id))
+			}
+		}(n)
+	}
+	d.logger.Info("devnet is running", zap.Int("operators", len(d.nodes)),
+		zap.Int("validators", len(d.setup.Validators)), zap.String("dir", d.opts.Dir))
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case err := <-errs:
+		return err
+	}
+}
+
+// Close closes the nodes and the bootnode
+func (d *Devnet) Close() {
+	for _, n := range d.nodes {
+		n.close()
+	}
+	if d.bootnode != nil {
+		if err := d.bootnode.Close(); err != nil {
+			d.logger.Warn("could not close bootnode", zap.Error(err))
+		}
+	}
+}
+
+// startBootnode starts a discovery bootnode on the base port
+func (d *Devnet) startBootnode(ctx context.Context) error {
+	sk, err := commons.GenNetworkKey()
+	if err != nil {
+		return errors.Wrap(err, "could not generate bootnode key")
+	}
+	raw, err := crypto.PrivKey((*crypto.Secp256k1PrivateKey)(sk)).Raw()
+	if err != nil {
+		return errors.Wrap(err, "could not encode bootnode key")
+	}
+	d.bootnode, err = discovery.NewBootnode(ctx, &discovery.BootnodeOptions{
+		Logger:     d.logger.With(zap.String("who", "bootnode")),
+		PrivateKey: hex.EncodeToString(raw),
+		ExternalIP: "127.0.0.1",
+		Port:       d.opts.BasePort,
+	})
+	if err != nil {
+		return errors.Wrap(err, "could not start bootnode")
+	}
+	return nil
+}
+
+// loadOrGenerateSetup loads the setup of an existing devnet, or generates a new one
+func loadOrGenerateSetup(opts Options) (*Setup, error) {
+	path := filepath.Join(opts.Dir, setupFile)
+	if _, err := os.Stat(path); err == nil {
+		setup, err := LoadSetup(path)
+		if err != nil {
+			return nil, err
+		}
+		if len(setup.Operators) != opts.Operators || len(setup.Validators) != opts.Validators {
+			return nil, errors.Errorf("%s was created with %d operators and %d validators, use another dir",
+				opts.Dir, len(setup.Operators), len(setup.Validators))
+		}
+		opts.Logger.Info("loaded existing devnet", zap.String("dir", opts.Dir))
+		return setup, nil
+	}
+	setup, err := GenerateSetup(opts.Operators, opts.Validators)
+	if err != nil {
+		return nil, err
+	}
+	if err := setup.Save(path); err != nil {
+		return nil, err
+	}
+	opts.Logger.Info("generated devnet keys", zap.String("dir", opts.Dir))
+	return setup, nil
+}
+
+// setForkVersion sets the fork epochs so the given version is the current one
+func setForkVersion(forkVersion forksprotocol.ForkVersion) {
+	switch forkVersion {
+	case forksprotocol.V3ForkVersion:
+		forksprotocol.SetForkEpoch(0, forksprotocol.V3ForkVersion)
+		forksprotocol.SetForkEpoch(0, forksprotocol.V2ForkVersion)
+		forksprotocol.SetForkEpoch(0, forksprotocol.V1ForkVersion)
+	case forksprotocol.V2ForkVersion:
+		forksprotocol.SetForkEpoch(0, forksprotocol.V2ForkVersion)
+		forksprotocol.SetForkEpoch(0, forksprotocol.V1ForkVersion)
+	case forksprotocol.V1ForkVersion:
+		forksprotocol.SetForkEpoch(0, forksprotocol.V1ForkVersion)
+	}
+}
@@ -0,0 +1,116 @@
+package devnet
+
+import (
+	"encoding/base64"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/eth2-key-manager/core"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/eth1"
+	"github.com/bloxapp/ssv/eth1/abiparser"
+	beaconprotocol "github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
+	"github.com/bloxapp/ssv/utils/rsaencryption"
+)
+
+func TestSetup(t *testing.T) {
+	_, err := GenerateSetup(3, 1)
+	require.Error(t, err)
+
+	setup, err := GenerateSetup(4, 2)
+	require.NoError(t, err)
+	require.Len(t, setup.Operators, 4)
+	require.Len(t, setup.Validators, 2)
+
+	path := filepath.Join(t.TempDir(), setupFile)
+	require.NoError(t, setup.Save(path))
+	loaded, err := LoadSetup(path)
+	require.NoError(t, err)
+	require.Equal(t, setup, loaded)
+
+	source := NewEventSource()
+	require.NoError(t, loaded.AddEvents(source))
+	events := source.eventsFrom(0)
+	require.Len(t, events, 6)
+
+	// the shares can be decrypted by the operators
+	ev, ok := events[4].Data.(abiparser.ValidatorAddedEvent)
+	require.True(t, ok)
+	require.Len(t, ev.EncryptedKeys, 4)
+	for i, op := range loaded.Operators {
+		skPem, err := base64.StdEncoding.DecodeString(op.PrivateKey)
+		require.NoError(t, err)
+		sk, err := rsaencryption.ConvertPemToPrivateKey(string(skPem))
+		require.NoError(t, err)
+		share, err := rsaencryption.DecodeKey(sk, string(ev.EncryptedKeys[i]))
+		require.NoError(t, err)
+		require.Equal(t, loaded.Validators[0].Shares[op.ID], share)
+	}
+}
+
+func TestEventSource_Sync(t *testing.T) {
+	source := NewEventSource()
+	source.Add(abiparser.OperatorAdded, abiparser.OperatorAddedEvent{})
+	source.Add(abiparser.OperatorAdded, abiparser.OperatorAddedEvent{})
+
+	client := source.Client()
+	cn := make(chan *eth1.Event, 8)
+	sub := client.EventsFeed().Subscribe(cn)
+	defer sub.Unsubscribe()
+
+	require.NoError(t, client.Sync(nil))
+	require.Equal(t, uint64(1), (<-cn).Log.BlockNumber)
+	require.Equal(t, uint64(2), (<-cn).Log.BlockNumber)
+	ended, ok := (<-cn).Data.(eth1.SyncEndedEvent)
+	require.True(t, ok)
+	require.True(t, ended.Success)
+	require.Len(t, ended.Logs, 2)
+
+	// new events are streamed once the client is started
+	require.NoError(t, client.Start())
+	source.Add(abiparser.OperatorAdded, abiparser.OperatorAddedEvent{})
+	e := <-cn
+	require.Equal(t, uint64(3), e.Log.BlockNumber)
+	hash, err := client.BlockHash(new(big.Int).SetUint64(e.Log.BlockNumber))
+	require.NoError(t, err)
+	require.Equal(t, e.Log.BlockHash, hash)
+}
+
+func TestBeacon_Attestation(t *testing.T) {
+	require.NoError(t, bls.Init(bls.BLS12_381))
+
+	b := NewBeacon(zap.L(), beaconprotocol.NewNetwork(core.PraterNetwork))
+	sk := &bls.SecretKey{}
+	sk.SetByCSPRNG()
+	index := b.AddValidator(sk.GetPublicKey())
+
+	duties, err := b.GetDuties(10, []spec.ValidatorIndex{index})
+	require.NoError(t, err)
+	require.Len(t, duties, 1)
+	duty := duties[0]
+
+	data, err := b.GetAttestationData(duty.Slot, duty.CommitteeIndex)
+	require.NoError(t, err)
+	require.Equal(t, spec.Epoch(10), data.Target.Epoch)
+	domain, err := b.GetDomain(data)
+	require.NoError(t, err)
+	root, err := b.ComputeSigningRoot(data, domain)
+	require.NoError(t, err)
+
+	att := &spec.Attestation{Data: data}
+	copy(att.Signature[:], sk.SignByte(root[:]).Serialize())
+	require.NoError(t, b.SubmitAttestation(att))
+	// the same attestation is kept once
+	require.NoError(t, b.SubmitAttestation(att))
+	require.Len(t, b.Attestations(duty.Slot), 1)
+
+	other := &bls.SecretKey{}
+	other.SetByCSPRNG()
+	copy(att.Signature[:], other.SignByte(root[:]).Serialize())
+	require.Error(t, b.SubmitAttestation(att))
+}
@@ -0,0 +1,121 @@
+package devnet
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/prysmaticlabs/prysm/async/event"
+
+	"github.com/bloxapp/ssv/eth1"
+)
+
+// EventSource replaces the registry contract in the devnet.
+// it holds the registry events (one per block) and streams them to the eth1 clients of the nodes
+type EventSource struct {
+	lock   sync.RWMutex
+	events []*eth1.Event
+	// feed streams new events to the started clients
+	feed *event.Feed
+}
+
+// NewEventSource creates a new event source
+func NewEventSource() *EventSource {
+	return &EventSource{
+		feed: new(event.Feed),
+	}
+}
+
+// Add adds an event in a new block, and sends it to the started clients
+func (s *EventSource) Add(name string, data interface{}) {
+	s.lock.Lock()
+	blockNumber := uint64(len(s.events) + 1)
+	e := &eth1.Event{
+		Log: types.Log{
+			BlockNumber: blockNumber,
+			BlockHash:   blockHash(blockNumber),
+			TxHash:      common.BigToHash(new(big.Int).SetUint64(blockNumber)),
+		},
+		Name: name,
+		Data: data,
+	}
+	s.events = append(s.events, e)
+	s.lock.Unlock()
+
+	_ = s.feed.Send(e)
+}
+
+// Client returns a new eth1 client of the event source
+func (s *EventSource) Client() eth1.Client {
+	return &eth1Client{
+		source: s,
+		feed:   new(event.Feed),
+	}
+}
+
+// eventsFrom returns the events from the given block
+func (s *EventSource) eventsFrom(fromBlock uint64) []*eth1.Event {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	var res []*eth1.Event
+	for _, e := range s.events {
+		if e.Log.BlockNumber >= fromBlock {
+			res = append(res, e)
+		}
+	}
+	return res
+}
+
+// eth1Client implements eth1.Client on top of the event source
+type eth1Client struct {
+	source *EventSource
+	feed   *event.Feed
+}
+
+// EventsFeed returns the feed of the events
+func (c *eth1Client) EventsFeed() *event.Feed {
+	return c.feed
+}
+
+// Start streams the events that are added to the source from now on
+func (c *eth1Client) Start() error {
+	cn := make(chan *eth1.Event, 32)
+	c.source.feed.Subscribe(cn)
+	go func() {
+		for e := range cn {
+			_ = c.feed.Send(e)
+		}
+	}()
+	return nil
+}
+
+// Sync sends the past events from the given block, followed by a SyncEndedEvent
+func (c *eth1Client) Sync(fromBlock *big.Int) error {
+	var from uint64
+	if fromBlock != nil {
+		from = fromBlock.Uint64()
+	}
+	var logs []types.Log
+	for _, e := range c.source.eventsFrom(from) {
+		logs = append(logs, e.Log)
+		_ = c.feed.Send(e)
+	}
+	_ = c.feed.Send(&eth1.Event{Data: eth1.SyncEndedEvent{Success: true, Logs: logs}})
+	return nil
+}
+
+// BlockHash returns the hash of the block with the given number
+func (c *eth1Client) BlockHash(blockNumber *big.Int) (common.Hash, error) {
+	return blockHash(blockNumber.Uint64()), nil
+}
+
+// blockHash returns the (fake) hash of the block with the given number
+func blockHash(blockNumber uint64) common.Hash {
+	data := make([]byte, 8)
+	binary.LittleEndian.PutUint64(data, blockNumber)
+	return sha256.Sum256(append([]byte("block"), data...))
+}
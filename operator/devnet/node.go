@@ -0,0 +1,162 @@
+package devnet
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	ssv_identity "github.com/bloxapp/ssv/identity"
+	"github.com/bloxapp/ssv/migrations"
+	"github.com/bloxapp/ssv/network"
+	forksv0 "github.com/bloxapp/ssv/network/forks/v0"
+	p2pv1 "github.com/bloxapp/ssv/network/p2p"
+	"github.com/bloxapp/ssv/operator"
+	operatorstorage "github.com/bloxapp/ssv/operator/storage"
+	"github.com/bloxapp/ssv/operator/validator"
+	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/journal"
+	"github.com/bloxapp/ssv/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/utils/format"
+	"github.com/bloxapp/ssv/utils/rsaencryption"
+)
+
+// node is an in-process operator node of the devnet,
+// it is created as done by start-node, with the eth1 client and beacon node of the devnet
+type node struct {
+	id     uint64
+	logger *zap.Logger
+	db     basedb.IDb
+	net    network.P2PNetwork
+	node   operator.Node
+}
+
+// nodeDeps are the components that are shared by the devnet nodes
+type nodeDeps struct {
+	beacon      *Beacon
+	events      *EventSource
+	bootnode    string
+	forkVersion forksprotocol.ForkVersion
+}
+
+// newNode creates the node of the given operator
+func newNode(ctx context.Context, logger *zap.Logger, id uint64, cfg *NodeConfig, deps *nodeDeps) (*node, error) {
+	logger = logger.With(zap.String("who", operatorName(id)))
+
+	cfg.DBOptions.Logger = logger
+	cfg.DBOptions.Ctx = ctx
+	db, err := storage.GetStorageFactory(cfg.DBOptions)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create db")
+	}
+	n, err := setupNode(ctx, logger, db, cfg, deps)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	n.id = id
+	return n, nil
+}
+
+// setupNode creates the components of the node on top of the given db
+func setupNode(ctx context.Context, logger *zap.Logger, db basedb.IDb, cfg *NodeConfig, deps *nodeDeps) (*node, error) {
+	if err := migrations.Run(ctx, migrations.Options{
+		Db:     db,
+		Logger: logger,
+		DbPath: cfg.DBOptions.Path,
+	}); err != nil {
+		return nil, errors.Wrap(err, "failed to run migrations")
+	}
+
+	beaconClient, err := deps.beacon.ForNode(db)
+	if err != nil {
+		return nil, err
+	}
+
+	nodeStorage := operatorstorage.NewNodeStorage(db, logger)
+	if err := nodeStorage.SetupPrivateKey(false, cfg.OperatorPrivateKey); err != nil {
+		return nil, errors.Wrap(err, "failed to setup operator private key")
+	}
+	operatorPrivateKey, found, err := nodeStorage.GetPrivateKey()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get operator private key")
+	}
+	if !found {
+		return nil, errors.New("could not find operator private key")
+	}
+	operatorPubKey, err := rsaencryption.ExtractPublicKey(operatorPrivateKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to extract operator public key")
+	}
+
+	istore := ssv_identity.NewIdentityStore(db, logger)
+	netPrivKey, err := istore.SetupNetworkKey("")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to setup network private key")
+	}
+
+	cfg.P2pNetworkConfig.Bootnodes = deps.bootnode
+	cfg.P2pNetworkConfig.NetworkPrivateKey = netPrivKey
+	cfg.P2pNetworkConfig.Logger = logger
+	cfg.P2pNetworkConfig.ForkVersion = forksprotocol.NetworkForkVersion(deps.forkVersion)
+	cfg.P2pNetworkConfig.OperatorID = format.OperatorID(operatorPubKey)
+	cfg.P2pNetworkConfig.UserAgent = forksv0.GenUserAgentWithOperatorID(cfg.P2pNetworkConfig.OperatorID)
+	p2pNet := p2pv1.New(ctx, &cfg.P2pNetworkConfig)
+	if err := p2pNet.Setup(); err != nil {
+		return nil, errors.Wrap(err, "failed to setup network")
+	}
+
+	eth2Network := deps.beacon.network
+	cfg.SSVOptions.ForkVersion = deps.forkVersion
+	cfg.SSVOptions.Context = ctx
+	cfg.SSVOptions.Logger = logger
+	cfg.SSVOptions.DB = db
+	cfg.SSVOptions.Beacon = beaconClient
+	cfg.SSVOptions.ETHNetwork = eth2Network
+	cfg.SSVOptions.Network = p2pNet
+	cfg.SSVOptions.Eth1Client = deps.events.Client()
+	cfg.SSVOptions.ValidatorOptions.ForkVersion = deps.forkVersion
+	cfg.SSVOptions.ValidatorOptions.ETHNetwork = eth2Network
+	cfg.SSVOptions.ValidatorOptions.Logger = logger
+	cfg.SSVOptions.ValidatorOptions.Context = ctx
+	cfg.SSVOptions.ValidatorOptions.DB = db
+	cfg.SSVOptions.ValidatorOptions.Network = p2pNet
+	cfg.SSVOptions.ValidatorOptions.Beacon = beaconClient
+	cfg.SSVOptions.ValidatorOptions.KeyManager = beaconClient
+	cfg.SSVOptions.ValidatorOptions.Journal = journal.Nop()
+	cfg.SSVOptions.ValidatorOptions.ShareEncryptionKeyProvider = nodeStorage.GetPrivateKey
+	cfg.SSVOptions.ValidatorOptions.OperatorPubKey = operatorPubKey
+	cfg.SSVOptions.ValidatorOptions.RegistryStorage = nodeStorage
+
+	validatorCtrl := validator.NewController(cfg.SSVOptions.ValidatorOptions)
+	cfg.SSVOptions.ValidatorController = validatorCtrl
+
+	return &node{
+		logger: logger,
+		db:     db,
+		net:    p2pNet,
+		node:   operator.New(cfg.SSVOptions),
+	}, nil
+}
+
+// start syncs the registry events and starts the node, it blocks as long as the node is running
+func (n *node) start() error {
+	// all the events of the devnet are synced from the first block
+	if err := n.node.StartEth1(new(big.Int)); err != nil {
+		return errors.Wrap(err, "failed to start eth1")
+	}
+	if err := n.net.Start(); err != nil {
+		return errors.Wrap(err, "failed to start network")
+	}
+	return n.node.Start()
+}
+
+// close closes the network and db of the node
+func (n *node) close() {
+	if err := n.net.Close(); err != nil {
+		n.logger.Warn("could not close network", zap.Error(err))
+	}
+	n.db.Close()
+}
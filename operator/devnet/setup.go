@@ -0,0 +1,173 @@
+package devnet
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"math"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
+
+	"github.com/bloxapp/ssv/eth1/abiparser"
+	"github.com/bloxapp/ssv/utils/rsaencryption"
+	"github.com/bloxapp/ssv/utils/threshold"
+)
+
+// ownerAddress is the owner of the devnet operators and validators
+var ownerAddress = common.HexToAddress("0x000000000000000000000000000000000000de7e")
+
+// Setup holds the keys of the devnet, it is generated once and saved in the devnet dir
+type Setup struct {
+	Operators  []*OperatorKeys  `json:"operators"`
+	Validators []*ValidatorKeys `json:"validators"`
+}
+
+// OperatorKeys holds the keys of an operator
+type OperatorKeys struct {
+	ID uint64 `json:"id"`
+	// PrivateKey is the operator RSA key (base64 of the pem), as expected by the node config
+	PrivateKey string `json:"private_key"`
+	// PublicKey is the operator RSA public key (base64 of the pem), as registered in the contract
+	PublicKey string `json:"public_key"`
+}
+
+// ValidatorKeys holds the key of a validator and its threshold shares
+type ValidatorKeys struct {
+	// PrivateKey is the validator BLS key (hex)
+	PrivateKey string `json:"private_key"`
+	// Shares are the BLS share keys (hex) by operator id
+	Shares map[uint64]string `json:"shares"`
+}
+
+// GenerateSetup generates the keys of the given number of operators and validators,
+// each validator is split into shares of all the operators
+func GenerateSetup(operators, validators int) (*Setup, error) {
+	if operators < 4 {
+		return nil, errors.New("at least 4 operators are required")
+	}
+	threshold.Init()
+
+	s := &Setup{}
+	for i := 1; i <= operators; i++ {
+		pk, sk, err := rsaencryption.GenerateKeys()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not generate operator keys")
+		}
+		s.Operators = append(s.Operators, &OperatorKeys{
+			ID:         uint64(i),
+			PrivateKey: base64.StdEncoding.EncodeToString(sk),
+			PublicKey:  base64.StdEncoding.EncodeToString(pk),
+		})
+	}
+	// the shares threshold is the quorum of the committee
+	quorum := uint64(math.Ceil(float64(operators) * 2 / 3))
+	for i := 0; i < validators; i++ {
+		sk := &bls.SecretKey{}
+		sk.SetByCSPRNG()
+		shares, err := threshold.Create(sk.Serialize(), quorum, uint64(operators))
+		if err != nil {
+			return nil, errors.Wrap(err, "could not create threshold shares")
+		}
+		v := &ValidatorKeys{
+			PrivateKey: sk.SerializeToHexStr(),
+			Shares:     make(map[uint64]string, len(shares)),
+		}
+		for id, share := range shares {
+			v.Shares[id] = share.SerializeToHexStr()
+		}
+		s.Validators = append(s.Validators, v)
+	}
+	return s, nil
+}
+
+// LoadSetup reads the setup from the given file
+func LoadSetup(path string) (*Setup, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read setup file")
+	}
+	s := &Setup{}
+	if err := json.Unmarshal(raw, s); err != nil {
+		return nil, errors.Wrap(err, "could not parse setup file")
+	}
+	threshold.Init()
+	return s, nil
+}
+
+// Save writes the setup into the given file
+func (s *Setup) Save(path string) error {
+	raw, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "could not marshal setup")
+	}
+	return ioutil.WriteFile(path, raw, 0600)
+}
+
+// ValidatorPublicKeys returns the public keys of the validators
+func (s *Setup) ValidatorPublicKeys() ([]*bls.PublicKey, error) {
+	pks := make([]*bls.PublicKey, 0, len(s.Validators))
+	for _, v := range s.Validators {
+		sk := &bls.SecretKey{}
+		if err := sk.SetHexString(v.PrivateKey); err != nil {
+			return nil, errors.Wrap(err, "could not decode validator key")
+		}
+		pks = append(pks, sk.GetPublicKey())
+	}
+	return pks, nil
+}
+
+// AddEvents adds the registry events of the setup to the given source,
+// i.e. the registration of the operators and the validators, with the shares encrypted for each operator
+func (s *Setup) AddEvents(source *EventSource) error {
+	for _, op := range s.Operators {
+		source.Add(abiparser.OperatorAdded, abiparser.OperatorAddedEvent{
+			Id:           new(big.Int).SetUint64(op.ID),
+			Name:         operatorName(op.ID),
+			OwnerAddress: ownerAddress,
+			PublicKey:    []byte(op.PublicKey),
+			Fee:          big.NewInt(0),
+		})
+	}
+	pks, err := s.ValidatorPublicKeys()
+	if err != nil {
+		return err
+	}
+	for i, v := range s.Validators {
+		ev := abiparser.ValidatorAddedEvent{
+			PublicKey:    pks[i].Serialize(),
+			OwnerAddress: ownerAddress,
+		}
+		for _, op := range s.Operators {
+			share := &bls.SecretKey{}
+			if err := share.SetHexString(v.Shares[op.ID]); err != nil {
+				return errors.Wrap(err, "could not decode share key")
+			}
+			encrypted, err := encryptShare(op, share)
+			if err != nil {
+				return err
+			}
+			ev.OperatorIds = append(ev.OperatorIds, new(big.Int).SetUint64(op.ID))
+			ev.OperatorPublicKeys = append(ev.OperatorPublicKeys, []byte(op.PublicKey))
+			ev.SharesPublicKeys = append(ev.SharesPublicKeys, share.GetPublicKey().Serialize())
+			ev.EncryptedKeys = append(ev.EncryptedKeys, []byte(encrypted))
+		}
+		source.Add(abiparser.ValidatorAdded, ev)
+	}
+	return nil
+}
+
+// encryptShare encrypts the share key with the public key of the operator, as done by the contract users
+func encryptShare(op *OperatorKeys, share *bls.SecretKey) (string, error) {
+	skPem, err := base64.StdEncoding.DecodeString(op.PrivateKey)
+	if err != nil {
+		return "", errors.Wrap(err, "could not decode operator key")
+	}
+	sk, err := rsaencryption.ConvertPemToPrivateKey(string(skPem))
+	if err != nil {
+		return "", err
+	}
+	return rsaencryption.EncodeKey(&sk.PublicKey, share.SerializeToHexStr())
+}
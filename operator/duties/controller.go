@@ -5,15 +5,20 @@ import (
 	"encoding/hex"
 	"time"
 
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/herumi/bls-eth-go-binary/bls"
 	"github.com/pkg/errors"
 	types "github.com/prysmaticlabs/eth2-types"
 	"github.com/prysmaticlabs/prysm/time/slots"
+	"go.opencensus.io/trace"
 	"go.uber.org/zap"
 
+	"github.com/bloxapp/ssv/monitoring/tracing"
 	"github.com/bloxapp/ssv/operator/validator"
 	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
 	beaconprotocol "github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
+	"github.com/bloxapp/ssv/protocol/v1/message"
 )
 
 //go:generate mockgen -package=mocks -destination=./mocks/controller.go -source=./controller.go
@@ -24,7 +29,8 @@ const (
 
 // DutyExecutor represents the component that executes duties
 type DutyExecutor interface {
-	ExecuteDuty(duty *beaconprotocol.Duty) error
+	// ExecuteDuty executes the given duty, the context holds the trace span of the duty
+	ExecuteDuty(ctx context.Context, duty *beaconprotocol.Duty) error
 }
 
 // DutyController interface for dispatching duties execution according to slot ticker
@@ -45,6 +51,7 @@ type ControllerOptions struct {
 	GenesisEpoch        uint64
 	DutyLimit           uint64
 	ForkVersion         forksprotocol.ForkVersion
+	BuilderProposals    bool
 }
 
 // dutyController internal implementation of DutyController
@@ -58,6 +65,10 @@ type dutyController struct {
 	validatorController validator.Controller
 	genesisEpoch        uint64
 	dutyLimit           uint64
+	// beaconClient is used to submit fee recipients on every epoch
+	beaconClient     beaconprotocol.Beacon
+	builderProposals bool
+	lastEpoch        uint64
 
 	// chan
 	currentSlotC chan uint64
@@ -77,6 +88,8 @@ func NewDutyController(opts *ControllerOptions) DutyController {
 		genesisEpoch:        opts.GenesisEpoch,
 		dutyLimit:           opts.DutyLimit,
 		executor:            opts.Executor,
+		beaconClient:        opts.BeaconClient,
+		builderProposals:    opts.BuilderProposals,
 	}
 	return &dc
 }
@@ -100,10 +113,10 @@ func (dc *dutyController) CurrentSlotChan() <-chan uint64 {
 }
 
 // ExecuteDuty tries to execute the given duty
-func (dc *dutyController) ExecuteDuty(duty *beaconprotocol.Duty) error {
+func (dc *dutyController) ExecuteDuty(ctx context.Context, duty *beaconprotocol.Duty) error {
 	if dc.executor != nil {
 		// enables to work with a custom executor, e.g. readOnlyDutyExec
-		return dc.executor.ExecuteDuty(duty)
+		return dc.executor.ExecuteDuty(ctx, duty)
 	}
 	logger := dc.loggerWithDutyContext(dc.logger, duty)
 	pubKey := &bls.PublicKey{}
@@ -119,7 +132,7 @@ func (dc *dutyController) ExecuteDuty(duty *beaconprotocol.Duty) error {
 				return
 			}
 			logger.Info("starting duty processing")
-			v.ExecuteDuty(uint64(duty.Slot), duty)
+			v.ExecuteDuty(ctx, uint64(duty.Slot), duty)
 		}()
 	} else {
 		logger.Warn("could not find validator")
@@ -135,6 +148,11 @@ func (dc *dutyController) listenToTicker(slots <-chan types.Slot) {
 
 		// execute duties
 		dc.logger.Debug("slot ticker", zap.Uint64("slot", uint64(currentSlot)))
+		if epoch := uint64(dc.ethNetwork.EstimatedEpochAtSlot(currentSlot)); epoch > dc.lastEpoch {
+			// first tick of a new epoch (or the first tick after startup)
+			dc.lastEpoch = epoch
+			go dc.onEpoch(currentSlot)
+		}
 		duties, err := dc.fetcher.GetDuties(uint64(currentSlot))
 		if err != nil {
 			dc.logger.Warn("failed to get duties", zap.Error(err))
@@ -145,6 +163,41 @@ func (dc *dutyController) listenToTicker(slots <-chan types.Slot) {
 	}
 }
 
+// onEpoch submits the fee recipients of the active validators to the beacon node,
+// and dispatches validator registration duties if builder proposals are enabled
+func (dc *dutyController) onEpoch(slot types.Slot) {
+	shares := dc.validatorController.GetActiveValidatorsShares()
+	if len(shares) == 0 {
+		return
+	}
+	feeRecipients := make(map[spec.ValidatorIndex]bellatrix.ExecutionAddress, len(shares))
+	for _, share := range shares {
+		feeRecipient, err := share.FeeRecipientAddress()
+		if err != nil {
+			dc.logger.Warn("invalid fee recipient", zap.String("pubKey", share.PublicKey.SerializeToHexStr()), zap.Error(err))
+			continue
+		}
+		feeRecipients[share.Metadata.Index] = feeRecipient
+		if dc.builderProposals {
+			duty := &beaconprotocol.Duty{
+				Type:           message.RoleTypeValidatorRegistration,
+				Slot:           spec.Slot(slot),
+				ValidatorIndex: share.Metadata.Index,
+			}
+			copy(duty.PubKey[:], share.PublicKey.Serialize())
+			go dc.onDuty(duty)
+		}
+	}
+	if dc.beaconClient == nil {
+		return
+	}
+	if err := dc.beaconClient.SubmitProposalPreparation(feeRecipients); err != nil {
+		dc.logger.Warn("failed to submit proposal preparation", zap.Error(err))
+		return
+	}
+	dc.logger.Debug("submitted proposal preparation", zap.Int("count", len(feeRecipients)))
+}
+
 func (dc *dutyController) notifyCurrentSlot(slot types.Slot) {
 	if dc.currentSlotC != nil {
 		dc.currentSlotC <- uint64(slot)
@@ -153,15 +206,24 @@ func (dc *dutyController) notifyCurrentSlot(slot types.Slot) {
 
 // onDuty handles next duty
 func (dc *dutyController) onDuty(duty *beaconprotocol.Duty) {
+	ctx, span := tracing.StartSpan(dc.ctx, "dutyController.onDuty",
+		trace.StringAttribute("role", duty.Type.String()),
+		trace.Int64Attribute("slot", int64(duty.Slot)),
+		trace.Int64Attribute("validator_index", int64(duty.ValidatorIndex)),
+		trace.StringAttribute("pubkey", hex.EncodeToString(duty.PubKey[:])))
+	defer span.End()
+
 	logger := dc.loggerWithDutyContext(dc.logger, duty)
 	if dc.shouldExecute(duty) {
 		logger.Debug("duty was sent to execution")
-		if err := dc.ExecuteDuty(duty); err != nil {
+		if err := dc.ExecuteDuty(ctx, duty); err != nil {
 			logger.Warn("could not dispatch duty", zap.Error(err))
+			tracing.SetError(span, err)
 			return
 		}
 		return
 	}
+	span.Annotate(nil, "slot is irrelevant, ignoring duty")
 	logger.Warn("slot is irrelevant, ignoring duty")
 }
 
@@ -212,7 +274,7 @@ type readOnlyDutyExec struct {
 	logger *zap.Logger
 }
 
-func (e *readOnlyDutyExec) ExecuteDuty(duty *beaconprotocol.Duty) error {
+func (e *readOnlyDutyExec) ExecuteDuty(ctx context.Context, duty *beaconprotocol.Duty) error {
 	e.logger.Debug("skipping duty execution",
 		zap.Uint64("epoch", uint64(duty.Slot)/32),
 		zap.Uint64("slot", uint64(duty.Slot)),
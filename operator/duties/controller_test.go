@@ -2,19 +2,25 @@ package duties
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/golang/mock/gomock"
+	"github.com/herumi/bls-eth-go-binary/bls"
 	types "github.com/prysmaticlabs/eth2-types"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 
 	"github.com/bloxapp/eth2-key-manager/core"
 	"github.com/bloxapp/ssv/operator/duties/mocks"
+	validatormocks "github.com/bloxapp/ssv/operator/validator/mocks"
 	"github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
+	"github.com/bloxapp/ssv/protocol/v1/message"
+	"github.com/bloxapp/ssv/utils/threshold"
 )
 
 func TestDutyController_ListenToTicker(t *testing.T) {
@@ -24,7 +30,7 @@ func TestDutyController_ListenToTicker(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	mockExecutor := mocks.NewMockDutyExecutor(mockCtrl)
-	mockExecutor.EXPECT().ExecuteDuty(gomock.Any()).DoAndReturn(func(duty *beacon.Duty) error {
+	mockExecutor.EXPECT().ExecuteDuty(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, duty *beacon.Duty) error {
 		require.NotNil(t, duty)
 		require.True(t, duty.Slot > 0)
 		wg.Done()
@@ -36,10 +42,14 @@ func TestDutyController_ListenToTicker(t *testing.T) {
 		return []beacon.Duty{{Slot: spec.Slot(slot), PubKey: spec.BLSPubKey{}}}, nil
 	}).AnyTimes()
 
+	mockValidatorController := validatormocks.NewMockController(mockCtrl)
+	mockValidatorController.EXPECT().GetActiveValidatorsShares().Return(nil).AnyTimes()
+
 	dutyCtrl := &dutyController{
 		logger: zap.L(), ctx: context.Background(), ethNetwork: beacon.NewNetwork(core.PraterNetwork),
-		executor: mockExecutor,
-		fetcher:  mockFetcher,
+		executor:            mockExecutor,
+		fetcher:             mockFetcher,
+		validatorController: mockValidatorController,
 	}
 
 	cn := make(chan types.Slot)
@@ -62,6 +72,65 @@ func TestDutyController_ListenToTicker(t *testing.T) {
 	wg.Wait()
 }
 
+func TestDutyController_OnEpoch(t *testing.T) {
+	threshold.Init()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	sk := &bls.SecretKey{}
+	sk.SetByCSPRNG()
+	share := &beacon.Share{
+		PublicKey:    sk.GetPublicKey(),
+		OwnerAddress: "0x0102030405060708090a0b0c0d0e0f1011121314",
+		Metadata:     &beacon.ValidatorMetadata{Index: spec.ValidatorIndex(7)},
+	}
+
+	mockValidatorController := validatormocks.NewMockController(mockCtrl)
+	mockValidatorController.EXPECT().GetActiveValidatorsShares().Return([]*beacon.Share{share}).Times(2)
+
+	mockBeacon := beacon.NewMockBeacon(mockCtrl)
+	mockBeacon.EXPECT().SubmitProposalPreparation(gomock.Any()).DoAndReturn(func(feeRecipients map[spec.ValidatorIndex]bellatrix.ExecutionAddress) error {
+		require.Len(t, feeRecipients, 1)
+		require.Equal(t, "0x0102030405060708090a0b0c0d0e0f1011121314", fmt.Sprintf("%#x", feeRecipients[7]))
+		return nil
+	}).Times(2)
+
+	duties := make(chan *beacon.Duty, 1)
+	mockExecutor := mocks.NewMockDutyExecutor(mockCtrl)
+	mockExecutor.EXPECT().ExecuteDuty(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, duty *beacon.Duty) error {
+		duties <- duty
+		return nil
+	}).Times(1)
+
+	dutyCtrl := &dutyController{
+		logger: zap.L(), ctx: context.Background(), ethNetwork: beacon.NewNetwork(core.PraterNetwork),
+		executor:            mockExecutor,
+		validatorController: mockValidatorController,
+		beaconClient:        mockBeacon,
+		dutyLimit:           32,
+	}
+	currentSlot := dutyCtrl.ethNetwork.EstimatedCurrentSlot()
+
+	t.Run("fee recipients only", func(t *testing.T) {
+		dutyCtrl.onEpoch(currentSlot)
+	})
+
+	t.Run("with builder proposals", func(t *testing.T) {
+		dutyCtrl.builderProposals = true
+		dutyCtrl.onEpoch(currentSlot)
+
+		select {
+		case duty := <-duties:
+			require.Equal(t, message.RoleTypeValidatorRegistration, duty.Type)
+			require.Equal(t, spec.ValidatorIndex(7), duty.ValidatorIndex)
+			require.Equal(t, sk.GetPublicKey().Serialize(), duty.PubKey[:])
+		case <-time.After(time.Second * 2):
+			t.Fatal("validator registration duty was not executed")
+		}
+	})
+}
+
 func TestDutyController_ShouldExecute(t *testing.T) {
 	ctrl := dutyController{logger: zap.L(), ethNetwork: beacon.NewNetwork(core.PraterNetwork)}
 	currentSlot := uint64(ctrl.ethNetwork.EstimatedCurrentSlot())
@@ -5,6 +5,7 @@
 package mocks
 
 import (
+	context "context"
 	beacon "github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
 	gomock "github.com/golang/mock/gomock"
 	reflect "reflect"
@@ -34,17 +35,17 @@ func (m *MockDutyExecutor) EXPECT() *MockDutyExecutorMockRecorder {
 }
 
 // ExecuteDuty mocks base method
-func (m *MockDutyExecutor) ExecuteDuty(duty *beacon.Duty) error {
+func (m *MockDutyExecutor) ExecuteDuty(ctx context.Context, duty *beacon.Duty) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ExecuteDuty", duty)
+	ret := m.ctrl.Call(m, "ExecuteDuty", ctx, duty)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // ExecuteDuty indicates an expected call of ExecuteDuty
-func (mr *MockDutyExecutorMockRecorder) ExecuteDuty(duty interface{}) *gomock.Call {
+func (mr *MockDutyExecutorMockRecorder) ExecuteDuty(ctx, duty interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecuteDuty", reflect.TypeOf((*MockDutyExecutor)(nil).ExecuteDuty), duty)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecuteDuty", reflect.TypeOf((*MockDutyExecutor)(nil).ExecuteDuty), ctx, duty)
 }
 
 // MockDutyController is a mock of DutyController interface
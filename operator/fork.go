@@ -22,18 +22,22 @@ func (n *operatorNode) setFork(slot uint64) {
 		zap.String("currentFork", string(currentVersion)))
 	logger.Info("FORK")
 
+	previousNetVersion := forksprotocol.NetworkForkVersion(n.forkVersion)
 	n.forkVersion = currentVersion
 
-	// set network fork
-	netHandler, ok := n.net.(forksprotocol.ForkHandler)
-	if !ok {
-		logger.Panic("network instance is not a fork handler")
-	}
-	if err := netHandler.OnFork(currentVersion); err != nil {
-		logger.Panic("could not fork network", zap.Error(err))
+	// set network fork, unless the network is not changed in the current version
+	if netVersion := forksprotocol.NetworkForkVersion(currentVersion); netVersion != previousNetVersion {
+		netHandler, ok := n.net.(forksprotocol.ForkHandler)
+		if !ok {
+			logger.Panic("network instance is not a fork handler")
+		}
+		if err := netHandler.OnFork(netVersion); err != nil {
+			logger.Panic("could not fork network", zap.Error(err))
+		}
 	}
 
-	if n.forkVersion == forksprotocol.V1ForkVersion { // only called on v1 fork
+	// only called on v1 and v3 forks, v2 changes only the network
+	if n.forkVersion == forksprotocol.V1ForkVersion || n.forkVersion == forksprotocol.V3ForkVersion {
 		// set validator controller fork
 		vCtrlHandler, ok := n.validatorsCtrl.(forksprotocol.ForkHandler)
 		if !ok {
@@ -0,0 +1,58 @@
+package operator
+
+import (
+	"sync/atomic"
+
+	"github.com/bloxapp/ssv/monitoring/metrics"
+)
+
+// HealthReport returns the detailed health status of the node and its components,
+// the node is ready once it was started and all components are healthy
+func (n *operatorNode) HealthReport() metrics.HealthReport {
+	var components []metrics.ComponentStatus
+	if status, ok := metrics.ComponentHealth("beacon", n.beacon); ok {
+		components = append(components, status)
+	}
+	if status, ok := metrics.ComponentHealth("eth1", n.eth1Client); ok {
+		components = append(components, n.withRegistrySync(status))
+	}
+	if status, ok := metrics.ComponentHealth("p2p", n.net); ok {
+		components = append(components, status)
+	}
+	if n.validatorsCtrl != nil {
+		components = append(components, n.validatorsCtrl.HealthStatus())
+	}
+	components = append(components, n.dbHealthStatus())
+	return metrics.NewHealthReport(atomic.LoadUint32(&n.started) == 1, components...)
+}
+
+// withRegistrySync adds the registry sync offset and its distance from the eth1 head block
+func (n *operatorNode) withRegistrySync(status metrics.ComponentStatus) metrics.ComponentStatus {
+	offset, found, err := n.storage.GetSyncOffset()
+	if err != nil || !found || offset == nil {
+		return status
+	}
+	if status.Details == nil {
+		status.Details = map[string]interface{}{}
+	}
+	status.Details["registry_sync_offset"] = offset.Uint64()
+	if head, ok := status.Details["head_block"].(uint64); ok && head >= offset.Uint64() {
+		status.Details["registry_distance"] = head - offset.Uint64()
+	}
+	return status
+}
+
+// dbHealthStatus checks that the db is reachable, in addition to its own health check
+func (n *operatorNode) dbHealthStatus() metrics.ComponentStatus {
+	var errs []string
+	if _, _, err := n.storage.GetSyncOffset(); err != nil {
+		errs = append(errs, "could not read from db: "+err.Error())
+	}
+	status := metrics.NewComponentStatus("db", errs, nil)
+	if dbStatus, ok := metrics.ComponentHealth("db", n.db); ok {
+		status.Errors = append(status.Errors, dbStatus.Errors...)
+		status.Details = dbStatus.Details
+		status.Healthy = len(status.Errors) == 0
+	}
+	return status
+}
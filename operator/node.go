@@ -3,6 +3,7 @@ package operator
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
 
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
@@ -63,12 +64,16 @@ type operatorNode struct {
 	qbftStorage    qbftstorageprotocol.QBFTStore
 	eth1Client     eth1.Client
 	dutyCtrl       duties.DutyController
+	db             basedb.IDb
 	//fork           *forks.Forker
 
 	forkVersion forksprotocol.ForkVersion
 
 	ws        api.WebSocketServer
 	wsAPIPort int
+
+	// started is set once the node started its validators and duties
+	started uint32
 }
 
 // New is the constructor of operatorNode
@@ -77,6 +82,7 @@ func New(opts Options) Node {
 
 	node := &operatorNode{
 		context:        opts.Context,
+		db:             opts.DB,
 		logger:         opts.Logger.With(zap.String("component", "operatorNode")),
 		validatorsCtrl: opts.ValidatorController,
 		ethNetwork:     opts.ETHNetwork,
@@ -96,6 +102,7 @@ func New(opts Options) Node {
 			DutyLimit:           opts.DutyLimit,
 			Executor:            opts.DutyExec,
 			ForkVersion:         opts.ForkVersion,
+			BuilderProposals:    opts.ValidatorOptions.BuilderProposals,
 		}),
 
 		forkVersion: opts.ForkVersion,
@@ -136,6 +143,7 @@ func (n *operatorNode) Start() error {
 	n.validatorsCtrl.StartNetworkHandlers()
 	go n.validatorsCtrl.UpdateValidatorMetaDataLoop()
 	go n.listenForCurrentSlot()
+	atomic.StoreUint32(&n.started, 1)
 	n.dutyCtrl.Start()
 
 	return nil
@@ -153,8 +161,14 @@ func (n *operatorNode) StartEth1(syncOffset *eth1.SyncOffset) error {
 	n.logger.Info("starting operator node syncing with eth1")
 
 	handler := n.validatorsCtrl.Eth1EventHandler(false)
-	// sync past events
-	if err := eth1.SyncEth1Events(n.logger, n.eth1Client, n.storage, syncOffset, handler); err != nil {
+	// sync past events, registry data is synced again if it was affected by a reorg while the node was down
+	clean := func() error {
+		if err := n.storage.CleanRegistryData(); err != nil {
+			return err
+		}
+		return n.validatorsCtrl.CleanRegistryData()
+	}
+	if err := eth1.SyncEth1EventsOrResync(n.logger, n.eth1Client, n.storage, syncOffset, handler, clean); err != nil {
 		return errors.Wrap(err, "failed to sync contract events")
 	}
 	n.logger.Info("manage to sync contract events")
@@ -195,6 +209,9 @@ func (n *operatorNode) healthAgents() []metrics.HealthCheckAgent {
 	if agent, ok := n.beacon.(metrics.HealthCheckAgent); ok {
 		agents = append(agents, agent)
 	}
+	if agent, ok := n.db.(metrics.HealthCheckAgent); ok {
+		agents = append(agents, agent)
+	}
 	return agents
 }
 
@@ -0,0 +1,247 @@
+package snapshot
+
+import (
+	"bytes"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/eth1"
+	operatorstorage "github.com/bloxapp/ssv/operator/storage"
+	"github.com/bloxapp/ssv/operator/validator"
+	beaconprotocol "github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
+	"github.com/bloxapp/ssv/protocol/v1/message"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
+	"github.com/bloxapp/ssv/utils/rsaencryption"
+)
+
+// Version is the current version of the snapshot format
+const Version = 1
+
+var (
+	// ErrChecksumMismatch is returned when the snapshot content doesn't match its checksum
+	ErrChecksumMismatch = errors.New("snapshot checksum mismatch")
+	// ErrRegistryNotEmpty is returned when importing a snapshot into a node that already has registry data
+	ErrRegistryNotEmpty = errors.New("registry data already exists, it should be cleaned before importing a snapshot")
+)
+
+// Snapshot is a point in time copy of the registry data of a node (operators, shares and their liquidation state),
+// it allows a fresh node to resume eth1 sync from SyncOffset rather than replaying the entire contract history.
+// shares are kept in their storage format, which includes the share keys encrypted with the key of each operator
+type Snapshot struct {
+	Version       int                            `json:"version"`
+	SyncOffset    string                         `json:"syncOffset"`
+	SyncBlockHash string                         `json:"syncBlockHash,omitempty"`
+	Operators     []registrystorage.OperatorData `json:"operators"`
+	Shares        []Share                        `json:"shares"`
+	Checksum      string                         `json:"checksum"`
+}
+
+// Share is a validator share as it is saved in storage
+type Share struct {
+	PublicKey string `json:"publicKey"`
+	Data      []byte `json:"data"`
+}
+
+// ImportOptions contains the dependencies for importing a snapshot
+type ImportOptions struct {
+	Logger             *zap.Logger
+	NodeStorage        operatorstorage.Storage
+	Shares             validator.ICollection
+	KeyManager         beaconprotocol.KeyManager
+	OperatorPrivateKey *rsa.PrivateKey
+	// FeeRecipient is set to the imported shares, as done for shares that are created from contract events
+	FeeRecipient string
+}
+
+// Export creates a snapshot of the registry data
+func Export(nodeStorage operatorstorage.Storage, shares validator.ICollection) (*Snapshot, error) {
+	offset, found, err := nodeStorage.GetSyncOffset()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get sync offset")
+	}
+	if !found {
+		return nil, errors.New("registry was not synced yet")
+	}
+	s := Snapshot{
+		Version:    Version,
+		SyncOffset: offset.Text(16),
+	}
+	hash, found, err := nodeStorage.GetSyncBlockHash()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get sync block hash")
+	}
+	if found {
+		s.SyncBlockHash = hash.Hex()
+	}
+
+	s.Operators, err = nodeStorage.ListOperators(0, 0)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not list operators")
+	}
+	sort.Slice(s.Operators, func(i, j int) bool {
+		return s.Operators[i].Index < s.Operators[j].Index
+	})
+
+	allShares, err := shares.GetAllValidatorShares()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get validator shares")
+	}
+	for _, share := range allShares {
+		pk := share.PublicKey.SerializeToHexStr()
+		if len(share.EncryptedKeys) != len(share.Operators) {
+			return nil, errors.Errorf("share %s has no encrypted keys, registry data should be re-synced (CLEAN_REGISTRY_DATA)", pk)
+		}
+		data, err := share.Serialize()
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not serialize share %s", pk)
+		}
+		s.Shares = append(s.Shares, Share{PublicKey: pk, Data: data})
+	}
+	sort.Slice(s.Shares, func(i, j int) bool {
+		return s.Shares[i].PublicKey < s.Shares[j].PublicKey
+	})
+
+	if s.Checksum, err = s.computeChecksum(); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// Write encodes the snapshot into the given writer
+func (s *Snapshot) Write(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return errors.Wrap(encoder.Encode(s), "could not encode snapshot")
+}
+
+// Read decodes a snapshot and verifies its version and checksum
+func Read(r io.Reader) (*Snapshot, error) {
+	var s Snapshot
+	if err := json.NewDecoder(r).Decode(&s); err != nil {
+		return nil, errors.Wrap(err, "could not decode snapshot")
+	}
+	if s.Version != Version {
+		return nil, errors.Errorf("unsupported snapshot version %d, expected %d", s.Version, Version)
+	}
+	checksum, err := s.computeChecksum()
+	if err != nil {
+		return nil, err
+	}
+	if checksum != s.Checksum {
+		return nil, ErrChecksumMismatch
+	}
+	return &s, nil
+}
+
+// Import restores the registry data of the snapshot into an empty node,
+// the share keys of the node operator are decrypted and added to the key manager.
+// the sync offset is saved last, so eth1 sync resumes from the snapshot block only once the import is complete
+func Import(s *Snapshot, opts ImportOptions) error {
+	if _, found, err := opts.NodeStorage.GetSyncOffset(); err != nil {
+		return errors.Wrap(err, "could not get sync offset")
+	} else if found {
+		return ErrRegistryNotEmpty
+	}
+	offset := eth1.HexStringToSyncOffset(s.SyncOffset)
+	if offset == nil {
+		return errors.New("snapshot is missing sync offset")
+	}
+	operatorPubKey, err := rsaencryption.ExtractPublicKey(opts.OperatorPrivateKey)
+	if err != nil {
+		return errors.Wrap(err, "could not extract operator public key")
+	}
+
+	for i := range s.Operators {
+		if err := opts.NodeStorage.SaveOperatorData(&s.Operators[i]); err != nil {
+			return errors.Wrapf(err, "could not save operator %d", s.Operators[i].Index)
+		}
+	}
+
+	var operatorShares int
+	for _, raw := range s.Shares {
+		pk, err := hex.DecodeString(raw.PublicKey)
+		if err != nil {
+			return errors.Wrapf(err, "could not decode share public key %s", raw.PublicKey)
+		}
+		share, err := (&beaconprotocol.Share{}).Deserialize(pk, raw.Data)
+		if err != nil {
+			return errors.Wrapf(err, "could not deserialize share %s", raw.PublicKey)
+		}
+		isOperatorShare, err := restoreOperatorShare(share, operatorPubKey, opts)
+		if err != nil {
+			return errors.Wrapf(err, "could not restore share %s", raw.PublicKey)
+		}
+		if isOperatorShare {
+			operatorShares++
+		}
+		share.FeeRecipient = opts.FeeRecipient
+		if err := opts.Shares.SaveValidatorShare(share); err != nil {
+			return errors.Wrapf(err, "could not save share %s", raw.PublicKey)
+		}
+	}
+
+	if len(s.SyncBlockHash) > 0 {
+		if err := opts.NodeStorage.SaveSyncBlockHash(common.HexToHash(s.SyncBlockHash)); err != nil {
+			return errors.Wrap(err, "could not save sync block hash")
+		}
+	}
+	if err := opts.NodeStorage.SaveSyncOffset(offset); err != nil {
+		return errors.Wrap(err, "could not save sync offset")
+	}
+	opts.Logger.Info("registry snapshot was imported",
+		zap.String("syncOffset", s.SyncOffset),
+		zap.Int("operators", len(s.Operators)),
+		zap.Int("shares", len(s.Shares)),
+		zap.Int("operatorShares", operatorShares))
+	return nil
+}
+
+// restoreOperatorShare sets the node id of the share according to the node operator,
+// and adds the decrypted share key to the key manager in case the share belongs to the node operator
+func restoreOperatorShare(share *beaconprotocol.Share, operatorPubKey string, opts ImportOptions) (bool, error) {
+	share.NodeID = 0
+	for i, op := range share.Operators {
+		if !strings.EqualFold(string(op), operatorPubKey) {
+			continue
+		}
+		if i >= len(share.EncryptedKeys) {
+			return false, errors.New("share is missing encrypted keys")
+		}
+		shareSecret, err := validator.DecryptShareSecret(opts.OperatorPrivateKey, share.EncryptedKeys[i])
+		if err != nil {
+			return false, err
+		}
+		nodeID := message.OperatorID(i + 1)
+		node, ok := share.Committee[nodeID]
+		if !ok || !bytes.Equal(node.Pk, shareSecret.GetPublicKey().Serialize()) {
+			return false, errors.New("decrypted share key doesn't match the committee")
+		}
+		if err := opts.KeyManager.AddShare(shareSecret); err != nil {
+			return false, errors.Wrap(err, "could not add share secret to key manager")
+		}
+		share.NodeID = nodeID
+		return true, nil
+	}
+	return false, nil
+}
+
+// computeChecksum returns the sha256 of the snapshot content (without the checksum)
+func (s *Snapshot) computeChecksum() (string, error) {
+	content := *s
+	content.Checksum = ""
+	b, err := json.Marshal(&content)
+	if err != nil {
+		return "", errors.Wrap(err, "could not encode snapshot")
+	}
+	h := sha256.Sum256(b)
+	return hex.EncodeToString(h[:]), nil
+}
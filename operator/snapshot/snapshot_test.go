@@ -0,0 +1,204 @@
+package snapshot
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/golang/mock/gomock"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	operatorstorage "github.com/bloxapp/ssv/operator/storage"
+	"github.com/bloxapp/ssv/operator/validator"
+	beaconprotocol "github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
+	"github.com/bloxapp/ssv/protocol/v1/message"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
+	ssvstorage "github.com/bloxapp/ssv/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/utils/rsaencryption"
+	"github.com/bloxapp/ssv/utils/threshold"
+)
+
+type testNode struct {
+	nodeStorage operatorstorage.Storage
+	shares      validator.ICollection
+}
+
+func newTestNode(t *testing.T) *testNode {
+	db, err := ssvstorage.GetStorageFactory(basedb.Options{
+		Type:   "badger-memory",
+		Logger: zap.L(),
+	})
+	require.NoError(t, err)
+	t.Cleanup(db.Close)
+	return &testNode{
+		nodeStorage: operatorstorage.NewNodeStorage(db, zap.L()),
+		shares:      validator.NewCollection(validator.CollectionOptions{DB: db, Logger: zap.L()}),
+	}
+}
+
+// newTestShare creates a share of a 4 operators committee, the share keys are encrypted with the operators keys
+func newTestShare(t *testing.T, operatorKeys []*rsa.PrivateKey) (*beaconprotocol.Share, map[uint64]*bls.SecretKey) {
+	threshold.Init()
+	sk := &bls.SecretKey{}
+	sk.SetByCSPRNG()
+	splitKeys, err := threshold.Create(sk.Serialize(), 3, 4)
+	require.NoError(t, err)
+
+	share := &beaconprotocol.Share{
+		NodeID:       1,
+		PublicKey:    sk.GetPublicKey(),
+		Committee:    map[message.OperatorID]*beaconprotocol.Node{},
+		OwnerAddress: "0xFeedB14D8b2C76FdF808C29818b06b830E8C2c0e",
+		FeeRecipient: "0xFeedB14D8b2C76FdF808C29818b06b830E8C2c0e",
+	}
+	for i, operatorKey := range operatorKeys {
+		id := uint64(i + 1)
+		share.Committee[message.OperatorID(id)] = &beaconprotocol.Node{
+			IbftID: id,
+			Pk:     splitKeys[id].GetPublicKey().Serialize(),
+		}
+		pk, err := rsaencryption.ExtractPublicKey(operatorKey)
+		require.NoError(t, err)
+		share.Operators = append(share.Operators, []byte(pk))
+		encrypted, err := rsa.EncryptPKCS1v15(rand.Reader, &operatorKey.PublicKey, []byte(splitKeys[id].SerializeToHexStr()))
+		require.NoError(t, err)
+		share.EncryptedKeys = append(share.EncryptedKeys, []byte(base64.StdEncoding.EncodeToString(encrypted)))
+	}
+	return share, splitKeys
+}
+
+func TestSnapshot(t *testing.T) {
+	operatorKeys := make([]*rsa.PrivateKey, 4)
+	for i := range operatorKeys {
+		var err error
+		operatorKeys[i], err = rsa.GenerateKey(rand.Reader, 1024)
+		require.NoError(t, err)
+	}
+
+	source := newTestNode(t)
+	for i, operatorKey := range operatorKeys {
+		pk, err := rsaencryption.ExtractPublicKey(operatorKey)
+		require.NoError(t, err)
+		require.NoError(t, source.nodeStorage.SaveOperatorData(&registrystorage.OperatorData{
+			Index:     uint64(i + 1),
+			PublicKey: pk,
+			Name:      "operator",
+		}))
+	}
+	share, splitKeys := newTestShare(t, operatorKeys)
+	require.NoError(t, source.shares.SaveValidatorShare(share))
+	liquidated, liquidatedKeys := newTestShare(t, operatorKeys)
+	liquidated.Liquidated = true
+	require.NoError(t, source.shares.SaveValidatorShare(liquidated))
+	require.NoError(t, source.nodeStorage.SaveSyncOffset(big.NewInt(0x49f59c)))
+	syncBlockHash := common.HexToHash("0x1a2b")
+	require.NoError(t, source.nodeStorage.SaveSyncBlockHash(syncBlockHash))
+
+	s, err := Export(source.nodeStorage, source.shares)
+	require.NoError(t, err)
+	require.Equal(t, "49f59c", s.SyncOffset)
+	require.Len(t, s.Operators, 4)
+	require.Len(t, s.Shares, 2)
+
+	var buf bytes.Buffer
+	require.NoError(t, s.Write(&buf))
+	encoded := buf.String()
+
+	t.Run("import as another operator", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		km := beaconprotocol.NewMockKeyManager(ctrl)
+		var added []*bls.SecretKey
+		km.EXPECT().AddShare(gomock.Any()).DoAndReturn(func(shareKey *bls.SecretKey) error {
+			added = append(added, shareKey)
+			return nil
+		}).Times(2)
+
+		imported, err := Read(strings.NewReader(encoded))
+		require.NoError(t, err)
+		target := newTestNode(t)
+		require.NoError(t, Import(imported, ImportOptions{
+			Logger:             zap.L(),
+			NodeStorage:        target.nodeStorage,
+			Shares:             target.shares,
+			KeyManager:         km,
+			OperatorPrivateKey: operatorKeys[1],
+			FeeRecipient:       "0x0000000000000000000000000000000000000001",
+		}))
+
+		offset, found, err := target.nodeStorage.GetSyncOffset()
+		require.NoError(t, err)
+		require.True(t, found)
+		require.EqualValues(t, 0x49f59c, offset.Uint64())
+		hash, found, err := target.nodeStorage.GetSyncBlockHash()
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Equal(t, syncBlockHash, hash)
+
+		operators, err := target.nodeStorage.ListOperators(0, 0)
+		require.NoError(t, err)
+		require.Len(t, operators, 4)
+
+		restored, found, err := target.shares.GetValidatorShare(share.PublicKey.Serialize())
+		require.NoError(t, err)
+		require.True(t, found)
+		require.EqualValues(t, 2, restored.NodeID)
+		requireAdded(t, added, splitKeys[2])
+		require.False(t, restored.Liquidated)
+		require.Equal(t, "0x0000000000000000000000000000000000000001", restored.FeeRecipient)
+		require.Equal(t, share.EncryptedKeys, restored.EncryptedKeys)
+		restored, found, err = target.shares.GetValidatorShare(liquidated.PublicKey.Serialize())
+		require.NoError(t, err)
+		require.True(t, found)
+		require.True(t, restored.Liquidated)
+		requireAdded(t, added, liquidatedKeys[2])
+
+		t.Run("registry not empty", func(t *testing.T) {
+			require.ErrorIs(t, Import(imported, ImportOptions{
+				Logger:             zap.L(),
+				NodeStorage:        target.nodeStorage,
+				Shares:             target.shares,
+				KeyManager:         km,
+				OperatorPrivateKey: operatorKeys[1],
+			}), ErrRegistryNotEmpty)
+		})
+	})
+
+	t.Run("tampered snapshot", func(t *testing.T) {
+		tampered := strings.Replace(encoded, `"syncOffset": "49f59c"`, `"syncOffset": "49f59d"`, 1)
+		require.NotEqual(t, encoded, tampered)
+		_, err := Read(strings.NewReader(tampered))
+		require.ErrorIs(t, err, ErrChecksumMismatch)
+	})
+
+	t.Run("unsupported version", func(t *testing.T) {
+		_, err := Read(strings.NewReader(strings.Replace(encoded, `"version": 1`, `"version": 2`, 1)))
+		require.EqualError(t, err, "unsupported snapshot version 2, expected 1")
+	})
+
+	t.Run("share without encrypted keys", func(t *testing.T) {
+		legacy, _ := newTestShare(t, operatorKeys)
+		legacy.EncryptedKeys = nil
+		require.NoError(t, source.shares.SaveValidatorShare(legacy))
+		_, err := Export(source.nodeStorage, source.shares)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "has no encrypted keys")
+	})
+}
+
+func requireAdded(t *testing.T, added []*bls.SecretKey, shareKey *bls.SecretKey) {
+	for _, k := range added {
+		if k.IsEqual(shareKey) {
+			return
+		}
+	}
+	t.Fatalf("share key %s was not added to the key manager", shareKey.GetPublicKey().SerializeToHexStr())
+}
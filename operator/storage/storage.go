@@ -3,6 +3,7 @@ package storage
 import (
 	"crypto/rsa"
 	"encoding/base64"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 	"math/big"
@@ -17,6 +18,8 @@ import (
 var (
 	storagePrefix = []byte("operator-")
 	syncOffsetKey = []byte("syncOffset")
+	// syncBlockHashKey shares the prefix of syncOffsetKey, so it is cleaned together with the sync offset
+	syncBlockHashKey = []byte("syncOffsetBlockHash")
 )
 
 // Storage represents the interface for ssv node storage
@@ -57,6 +60,18 @@ func (s *storage) SaveOperatorData(operatorData *registrystorage.OperatorData) e
 	return s.operatorStore.SaveOperatorData(operatorData)
 }
 
+func (s *storage) UpdateOperatorFee(index uint64, fee *big.Int) error {
+	return s.operatorStore.UpdateOperatorFee(index, fee)
+}
+
+func (s *storage) DeleteOperatorData(index uint64) error {
+	return s.operatorStore.DeleteOperatorData(index)
+}
+
+func (s *storage) IsOperatorRemoved(operatorPubKey string) (bool, error) {
+	return s.operatorStore.IsOperatorRemoved(operatorPubKey)
+}
+
 func (s *storage) ListOperators(from uint64, to uint64) ([]registrystorage.OperatorData, error) {
 	return s.operatorStore.ListOperators(from, to)
 }
@@ -65,6 +80,10 @@ func (s *storage) GetOperatorsPrefix() []byte {
 	return s.operatorStore.GetOperatorsPrefix()
 }
 
+func (s *storage) GetRemovedOperatorsPrefix() []byte {
+	return s.operatorStore.GetRemovedOperatorsPrefix()
+}
+
 func (s *storage) CleanRegistryData() error {
 	err := s.cleanSyncOffset()
 	if err != nil {
@@ -89,7 +108,11 @@ func (s *storage) cleanSyncOffset() error {
 
 func (s *storage) cleanOperators() error {
 	operatorsPrefix := s.GetOperatorsPrefix()
-	return s.db.RemoveAllByCollection(append(storagePrefix, operatorsPrefix...))
+	if err := s.db.RemoveAllByCollection(append(storagePrefix, operatorsPrefix...)); err != nil {
+		return err
+	}
+	removedOperatorsPrefix := s.GetRemovedOperatorsPrefix()
+	return s.db.RemoveAllByCollection(append(storagePrefix, removedOperatorsPrefix...))
 }
 
 // GetSyncOffset returns the offset
@@ -106,6 +129,23 @@ func (s *storage) GetSyncOffset() (*eth1.SyncOffset, bool, error) {
 	return offset, found, nil
 }
 
+// SaveSyncBlockHash saves the hash of the block at the sync offset
+func (s *storage) SaveSyncBlockHash(hash common.Hash) error {
+	return s.db.Set(storagePrefix, syncBlockHashKey, hash.Bytes())
+}
+
+// GetSyncBlockHash returns the hash of the block at the sync offset
+func (s *storage) GetSyncBlockHash() (common.Hash, bool, error) {
+	obj, found, err := s.db.Get(storagePrefix, syncBlockHashKey)
+	if !found {
+		return common.Hash{}, found, nil
+	}
+	if err != nil {
+		return common.Hash{}, found, err
+	}
+	return common.BytesToHash(obj.Value), found, nil
+}
+
 // GetPrivateKey return rsa private key
 func (s *storage) GetPrivateKey() (*rsa.PrivateKey, bool, error) {
 	obj, found, err := s.db.Get(storagePrefix, []byte("private-key"))
@@ -4,11 +4,13 @@ import (
 	"encoding/base64"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 
 	"github.com/bloxapp/ssv/eth1"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
 	ssvstorage "github.com/bloxapp/ssv/storage"
 	"github.com/bloxapp/ssv/storage/basedb"
 	"github.com/bloxapp/ssv/utils/logex"
@@ -191,3 +193,61 @@ func TestStorage_SaveAndGetSyncOffset(t *testing.T) {
 	require.NoError(t, err)
 	require.Zero(t, offset.Cmp(o))
 }
+
+func TestStorage_SaveAndGetSyncBlockHash(t *testing.T) {
+	logger := zap.L()
+	db, err := ssvstorage.GetStorageFactory(basedb.Options{
+		Type:   "badger-memory",
+		Logger: logger,
+		Path:   "",
+	})
+	require.NoError(t, err)
+	s := NewNodeStorage(db, logger)
+
+	_, found, err := s.GetSyncBlockHash()
+	require.NoError(t, err)
+	require.False(t, found)
+
+	offset := new(eth1.SyncOffset)
+	offset.SetString("49e08f", 16)
+	require.NoError(t, s.SaveSyncOffset(offset))
+	hash := common.HexToHash("0x9542ecebe9d541e2575cb5577dfd4b73c9b0c3ab634fcac4ce0ff319249c90e4")
+	require.NoError(t, s.SaveSyncBlockHash(hash))
+
+	h, found, err := s.GetSyncBlockHash()
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, hash, h)
+
+	// cleaning the registry data removes the block hash as well
+	require.NoError(t, s.CleanRegistryData())
+	_, found, err = s.GetSyncBlockHash()
+	require.NoError(t, err)
+	require.False(t, found)
+	_, found, err = s.GetSyncOffset()
+	require.NoError(t, err)
+	require.False(t, found)
+}
+
+func TestStorage_CleanRemovedOperators(t *testing.T) {
+	logger := zap.L()
+	db, err := ssvstorage.GetStorageFactory(basedb.Options{
+		Type:   "badger-memory",
+		Logger: logger,
+		Path:   "",
+	})
+	require.NoError(t, err)
+	s := NewNodeStorage(db, logger)
+
+	require.NoError(t, s.SaveOperatorData(&registrystorage.OperatorData{Index: 1, PublicKey: "operator-pk"}))
+	require.NoError(t, s.DeleteOperatorData(1))
+	removed, err := s.IsOperatorRemoved("operator-pk")
+	require.NoError(t, err)
+	require.True(t, removed)
+
+	// removed operators are synced again as well
+	require.NoError(t, s.CleanRegistryData())
+	removed, err = s.IsOperatorRemoved("operator-pk")
+	require.NoError(t, err)
+	require.False(t, removed)
+}
@@ -0,0 +1,127 @@
+package validator
+
+import (
+	"encoding/hex"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/protocol/v1/message"
+	qbftcontroller "github.com/bloxapp/ssv/protocol/v1/qbft/controller"
+	"github.com/bloxapp/ssv/protocol/v1/validator"
+)
+
+var (
+	// ErrValidatorNotFound is returned when the requested validator doesn't exist
+	ErrValidatorNotFound = errors.New("validator not found")
+	// ErrValidatorAlreadyStarted is returned when trying to start a running validator
+	ErrValidatorAlreadyStarted = errors.New("validator was already started")
+)
+
+// ValidatorInfo is the operational info of a validator
+type ValidatorInfo struct {
+	PublicKey string     `json:"public_key"`
+	Index     uint64     `json:"index"`
+	Status    string     `json:"status"`
+	Started   bool       `json:"started"`
+	StartedAt *time.Time `json:"started_at,omitempty"`
+	// Controllers maps a role to the state of its qbft controller
+	Controllers map[string]string `json:"controllers,omitempty"`
+}
+
+// ValidatorsInfo returns the info of the validators that are managed by the controller
+func (c *controller) ValidatorsInfo() []ValidatorInfo {
+	var res []ValidatorInfo
+	_ = c.validatorsMap.ForEach(func(v validator.IValidator) error {
+		share := v.GetShare()
+		info := ValidatorInfo{
+			PublicKey: share.PublicKey.SerializeToHexStr(),
+			Status:    "unknown",
+		}
+		if share.HasMetadata() {
+			info.Index = uint64(share.Metadata.Index)
+			info.Status = share.Metadata.Status.String()
+		}
+		if t, ok := c.startedAt.Load(info.PublicKey); ok {
+			startedAt := t.(time.Time)
+			info.Started = true
+			info.StartedAt = &startedAt
+		}
+		if provider, ok := v.(ibftsProvider); ok {
+			info.Controllers = make(map[string]string)
+			for role, ibft := range provider.Ibfts() {
+				info.Controllers[role.String()] = qbftcontroller.StateName(ibft.State())
+			}
+		}
+		res = append(res, info)
+		return nil
+	})
+	return res
+}
+
+// StartValidator starts the validator of the given public key,
+// the validator is loaded from storage if it was stopped
+func (c *controller) StartValidator(pubKey string) error {
+	if _, ok := c.startedAt.Load(pubKey); ok {
+		return ErrValidatorAlreadyStarted
+	}
+	v, ok := c.validatorsMap.GetValidator(pubKey)
+	if !ok {
+		pk, err := hex.DecodeString(pubKey)
+		if err != nil {
+			return errors.Wrap(err, "could not decode public key")
+		}
+		share, found, err := c.collection.GetValidatorShare(pk)
+		if err != nil {
+			return errors.Wrap(err, "could not get validator share")
+		}
+		if !found || !share.IsOperatorShare(c.operatorPubKey) {
+			return ErrValidatorNotFound
+		}
+		if share.Liquidated {
+			return errors.New("could not start liquidated validator")
+		}
+		v = c.validatorsMap.GetOrCreateValidator(share)
+	}
+	if _, err := c.startValidator(v); err != nil {
+		return err
+	}
+	c.logger.Info("validator was started", zap.String("pubKey", pubKey))
+	return nil
+}
+
+// StopValidator stops the validator of the given public key, the share is kept in storage.
+// the validator will be started again upon restart of the node
+func (c *controller) StopValidator(pubKey string) error {
+	v := c.validatorsMap.RemoveValidator(pubKey)
+	if v == nil {
+		return ErrValidatorNotFound
+	}
+	c.startedAt.Delete(pubKey)
+	if err := c.network.Unsubscribe(v.GetShare().PublicKey.Serialize()); err != nil {
+		c.logger.Debug("could not unsubscribe validator topic", zap.String("pubKey", pubKey), zap.Error(err))
+	}
+	if err := v.Close(); err != nil {
+		return errors.Wrap(err, "could not close validator")
+	}
+	c.logger.Info("validator was stopped", zap.String("pubKey", pubKey))
+	return nil
+}
+
+// ResyncDecided syncs the decided history of the given validator and role from peers
+func (c *controller) ResyncDecided(pubKey string, role message.RoleType) error {
+	v, ok := c.validatorsMap.GetValidator(pubKey)
+	if !ok {
+		return ErrValidatorNotFound
+	}
+	provider, ok := v.(ibftsProvider)
+	if !ok {
+		return errors.New("validator doesn't expose its qbft controllers")
+	}
+	ibft, ok := provider.Ibfts()[role]
+	if !ok {
+		return errors.Errorf("no qbft controller for role %s", role.String())
+	}
+	return ibft.ResyncDecided()
+}
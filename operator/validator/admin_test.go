@@ -0,0 +1,93 @@
+package validator
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/network"
+	"github.com/bloxapp/ssv/protocol/v1/message"
+	qbftcontroller "github.com/bloxapp/ssv/protocol/v1/qbft/controller"
+	"github.com/bloxapp/ssv/protocol/v1/validator"
+	"github.com/bloxapp/ssv/utils/logex"
+	"github.com/bloxapp/ssv/utils/threshold"
+)
+
+type testNetwork struct {
+	network.P2PNetwork
+	unsubscribed []message.ValidatorPK
+}
+
+func (n *testNetwork) Unsubscribe(pk message.ValidatorPK) error {
+	n.unsubscribed = append(n.unsubscribed, pk)
+	return nil
+}
+
+func (t *testIBFT) ResyncDecided() error {
+	t.resyncs++
+	return nil
+}
+
+func (v *testValidator) Close() error {
+	return nil
+}
+
+func TestController_Admin(t *testing.T) {
+	threshold.Init()
+	ready := newTestValidator(qbftcontroller.Ready)
+	notStarted := newTestValidator(qbftcontroller.NotStarted)
+	readyPK := ready.share.PublicKey.SerializeToHexStr()
+	notStartedPK := notStarted.share.PublicKey.SerializeToHexStr()
+	ctr := setupController(logex.GetLogger(), map[string]validator.IValidator{
+		readyPK:      ready,
+		notStartedPK: notStarted,
+	})
+	net := &testNetwork{}
+	ctr.network = net
+	ctr.startedAt = &sync.Map{}
+	ctr.startedAt.Store(readyPK, time.Now())
+
+	t.Run("validators info", func(t *testing.T) {
+		infos := ctr.ValidatorsInfo()
+		require.Len(t, infos, 2)
+		for _, info := range infos {
+			require.Equal(t, "unknown", info.Status)
+			switch info.PublicKey {
+			case readyPK:
+				require.True(t, info.Started)
+				require.NotNil(t, info.StartedAt)
+				require.Equal(t, map[string]string{"ATTESTER": "ready"}, info.Controllers)
+			case notStartedPK:
+				require.False(t, info.Started)
+				require.Nil(t, info.StartedAt)
+				require.Equal(t, map[string]string{"ATTESTER": "not_started"}, info.Controllers)
+			default:
+				t.Fatalf("unexpected validator %s", info.PublicKey)
+			}
+		}
+	})
+
+	t.Run("resync decided", func(t *testing.T) {
+		require.NoError(t, ctr.ResyncDecided(readyPK, message.RoleTypeAttester))
+		require.Equal(t, 1, ready.ibfts[message.RoleTypeAttester].(*testIBFT).resyncs)
+		require.Error(t, ctr.ResyncDecided(readyPK, message.RoleTypeValidatorRegistration))
+		require.Equal(t, ErrValidatorNotFound, ctr.ResyncDecided("aaaa", message.RoleTypeAttester))
+	})
+
+	t.Run("start validator", func(t *testing.T) {
+		require.Equal(t, ErrValidatorAlreadyStarted, ctr.StartValidator(readyPK))
+	})
+
+	t.Run("stop validator", func(t *testing.T) {
+		require.NoError(t, ctr.StopValidator(readyPK))
+		require.Len(t, net.unsubscribed, 1)
+		_, found := ctr.GetValidator(readyPK)
+		require.False(t, found)
+		_, started := ctr.startedAt.Load(readyPK)
+		require.False(t, started)
+		require.Len(t, ctr.ValidatorsInfo(), 1)
+		require.Equal(t, ErrValidatorNotFound, ctr.StopValidator(readyPK))
+	})
+}
@@ -11,11 +11,15 @@ import (
 	"github.com/bloxapp/ssv/eth1"
 	"github.com/bloxapp/ssv/eth1/abiparser"
 	"github.com/bloxapp/ssv/ibft/storage"
+	"github.com/bloxapp/ssv/monitoring/metrics"
 	"github.com/bloxapp/ssv/network"
 	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
 	beaconprotocol "github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
 	"github.com/bloxapp/ssv/protocol/v1/message"
 	p2pprotocol "github.com/bloxapp/ssv/protocol/v1/p2p"
+	"github.com/bloxapp/ssv/protocol/v1/qbft"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/journal"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/strategy/fullnode"
 	utilsprotocol "github.com/bloxapp/ssv/protocol/v1/queue"
 	"github.com/bloxapp/ssv/protocol/v1/queue/worker"
 	"github.com/bloxapp/ssv/protocol/v1/sync/handlers"
@@ -39,6 +43,10 @@ const (
 // ShareEncryptionKeyProvider is a function that returns the operator private key
 type ShareEncryptionKeyProvider = func() (*rsa.PrivateKey, bool, error)
 
+// RegistryEventHandler is called with the registry contract events that were handled during ongoing sync,
+// e.g. in order to notify the exporter stream
+type RegistryEventHandler func(e eth1.Event)
+
 // ShareEventHandlerFunc is a function that handles event in an extended mode
 type ShareEventHandlerFunc func(share *beaconprotocol.Share)
 
@@ -56,16 +64,43 @@ type ControllerOptions struct {
 	Shares                     []ShareOptions `yaml:"Shares"`
 	ShareEncryptionKeyProvider ShareEncryptionKeyProvider
 	CleanRegistryData          bool
-	FullNode                   bool `yaml:"FullNode" env:"FULLNODE" env-default:"false" env-description:"Flag that indicates whether the node saves decided history or just the latest messages"`
+	FullNode                   bool                      `yaml:"FullNode" env:"FULLNODE" env-default:"false" env-description:"Flag that indicates whether the node saves decided history or just the latest messages"`
+	DecidedRetention           fullnode.RetentionOptions `yaml:"DecidedRetention"`
 	KeyManager                 beaconprotocol.KeyManager
 	OperatorPubKey             string
 	RegistryStorage            registrystorage.OperatorsCollection
 	ForkVersion                forksprotocol.ForkVersion
 	NewDecidedHandler          qbftcontroller.NewDecidedHandler
+	RegistryEventHandler       RegistryEventHandler
+	FeeRecipient               string `yaml:"FeeRecipient" env:"FEE_RECIPIENT" env-description:"Default fee recipient for new validators, the owner address is used if empty"`
+	GasLimit                   uint64 `yaml:"GasLimit" env:"GAS_LIMIT" env-default:"30000000" env-description:"Gas limit for validator registrations"`
+	BuilderProposals           bool   `yaml:"BuilderProposals" env:"BUILDER_PROPOSALS" env-default:"false" env-description:"Register validators to the builder network (e.g. mev-boost) every epoch"`
+	Journal                    journal.Journal
+	// Consensus maps a role (e.g. ATTESTER) to its qbft config, unset fields take the default values
+	Consensus map[string]qbft.InstanceConfig `yaml:"Consensus"`
 
 	// worker flags
 	WorkersCount    int `yaml:"MsgWorkersCount" env:"MSG_WORKERS_COUNT" env-default:"4" env-description:"Number of goroutines to use for message workers"`
 	QueueBufferSize int `yaml:"MsgWorkerBufferSize" env:"MSG_WORKER_BUFFER_SIZE" env-default:"256" env-description:"Buffer size for message workers"`
+	// QueueWorkersCount is the size of the shared pool that processes the qbft msg queues of all validators
+	QueueWorkersCount int `yaml:"QueueWorkersCount" env:"QUEUE_WORKERS_COUNT" env-default:"0" env-description:"Number of goroutines that process the msg queues of all validators, if 0 each qbft controller runs its own queue consumer"`
+}
+
+// parseInstanceConfigs returns the qbft configs by role, unset fields are filled with the default values of the role
+func parseInstanceConfigs(configs map[string]qbft.InstanceConfig) (map[message.RoleType]*qbft.InstanceConfig, error) {
+	res := make(map[message.RoleType]*qbft.InstanceConfig, len(configs))
+	for name, cfg := range configs {
+		role := message.RoleTypeFromString(name)
+		if role == message.RoleTypeUnknown {
+			return nil, errors.Errorf("unknown role %q", name)
+		}
+		instanceConfig := cfg.WithRoleDefaults(role)
+		if err := instanceConfig.Validate(); err != nil {
+			return nil, errors.Wrapf(err, "invalid config of role %s", name)
+		}
+		res[role] = instanceConfig
+	}
+	return res, nil
 }
 
 // Controller represent the validators controller,
@@ -74,12 +109,24 @@ type Controller interface {
 	ListenToEth1Events(feed *event.Feed)
 	StartValidators()
 	GetValidatorsIndices() []spec.ValidatorIndex
+	GetActiveValidatorsShares() []*beaconprotocol.Share
 	GetValidator(pubKey string) (validator.IValidator, bool)
 	UpdateValidatorMetaDataLoop()
 	StartNetworkHandlers()
 	Eth1EventHandler(ongoingSync bool) eth1.SyncEventHandler
 	GetAllValidatorShares() ([]*beaconprotocol.Share, error)
+	// CleanRegistryData removes all the validator shares, so they can be synced again from eth1
+	CleanRegistryData() error
 	OnFork(forkVersion forksprotocol.ForkVersion) error
+	HealthStatus() metrics.ComponentStatus
+	// ValidatorsInfo returns the info of the validators that are managed by the controller
+	ValidatorsInfo() []ValidatorInfo
+	// StartValidator starts the validator of the given public key
+	StartValidator(pubKey string) error
+	// StopValidator stops the validator of the given public key
+	StopValidator(pubKey string) error
+	// ResyncDecided syncs the decided history of the given validator and role from peers
+	ResyncDecided(pubKey string, role message.RoleType) error
 }
 
 // controller implements Controller
@@ -93,6 +140,8 @@ type controller struct {
 
 	shareEncryptionKeyProvider ShareEncryptionKeyProvider
 	operatorPubKey             string
+	feeRecipient               string
+	registryEventHandler       RegistryEventHandler
 
 	validatorsMap    *validatorsMap
 	validatorOptions *validator.Options // TODO(nkryuchkov): check if it's needed
@@ -105,6 +154,11 @@ type controller struct {
 	forkVersion   forksprotocol.ForkVersion
 	messageRouter *messageRouter
 	messageWorker *worker.Worker
+
+	decidedPruner *fullnode.Pruner
+
+	// startedAt holds the time each validator was started, used to detect stuck validators
+	startedAt *sync.Map
 }
 
 // OnFork called upon a fork, it will propagate the fork event to all internal components.
@@ -175,6 +229,21 @@ func NewController(options ControllerOptions) Controller {
 		ReadMode:                   false, // set to false for committee validators. if non committee, we set validator with true value
 		FullNode:                   options.FullNode,
 		NewDecidedHandler:          options.NewDecidedHandler,
+		GasLimit:                   options.GasLimit,
+		Journal:                    options.Journal,
+	}
+	instanceConfigs, err := parseInstanceConfigs(options.Consensus)
+	if err != nil {
+		options.Logger.Panic("invalid consensus config", zap.Error(err))
+	}
+	validatorOptions.InstanceConfigs = instanceConfigs
+	if options.QueueWorkersCount > 0 {
+		validatorOptions.Scheduler = worker.NewScheduler(&worker.Config{
+			Ctx:          options.Context,
+			Logger:       options.Logger,
+			WorkersCount: options.QueueWorkersCount,
+			MetrixPrefix: "qbft_queue",
+		})
 	}
 	ctrl := controller{
 		collection:                 collection,
@@ -184,6 +253,8 @@ func NewController(options ControllerOptions) Controller {
 		beacon:                     options.Beacon,
 		shareEncryptionKeyProvider: options.ShareEncryptionKeyProvider,
 		operatorPubKey:             options.OperatorPubKey,
+		feeRecipient:               options.FeeRecipient,
+		registryEventHandler:       options.RegistryEventHandler,
 		keyManager:                 options.KeyManager,
 		network:                    options.Network,
 		forkVersion:                options.ForkVersion,
@@ -195,11 +266,17 @@ func NewController(options ControllerOptions) Controller {
 		metadataUpdateInterval: options.MetadataUpdateInterval,
 
 		operatorsIDs: operatorsIDs,
+		startedAt:    &sync.Map{},
 
 		messageRouter: newMessageRouter(options.Logger),
 		messageWorker: worker.NewWorker(workerCfg),
 	}
 
+	if options.FullNode && options.DecidedRetention.Enabled() {
+		ctrl.decidedPruner = fullnode.NewPruner(options.Logger, qbftStorage, options.ETHNetwork,
+			ctrl.decidedIdentifiers, options.DecidedRetention)
+	}
+
 	if err := ctrl.initShares(options); err != nil {
 		ctrl.logger.Panic("could not initialize shares", zap.Error(err))
 	}
@@ -231,6 +308,25 @@ func (c *controller) GetAllValidatorShares() ([]*beaconprotocol.Share, error) {
 	return c.collection.GetAllValidatorShares()
 }
 
+// CleanRegistryData removes all the validator shares, should be called before validators are started
+func (c *controller) CleanRegistryData() error {
+	return c.collection.CleanRegistryData()
+}
+
+// decidedIdentifiers returns the identifiers of all the validators whose decided messages might be saved
+func (c *controller) decidedIdentifiers() []message.Identifier {
+	shares, err := c.collection.GetAllValidatorShares()
+	if err != nil {
+		c.logger.Warn("could not get validators shares", zap.Error(err))
+		return nil
+	}
+	identifiers := make([]message.Identifier, 0, len(shares))
+	for _, share := range shares {
+		identifiers = append(identifiers, message.NewIdentifier(share.PublicKey.Serialize(), message.RoleTypeAttester))
+	}
+	return identifiers
+}
+
 func (c *controller) handleRouterMessages() {
 	ctx, cancel := context.WithCancel(c.context)
 	defer cancel()
@@ -311,6 +407,14 @@ func (c *controller) ListenToEth1Events(feed *event.Feed) {
 
 // StartValidators loads all persisted shares and setup the corresponding validators
 func (c *controller) StartValidators() {
+	// decided history of all validators is pruned, regardless of the operator validators
+	if c.decidedPruner != nil {
+		c.decidedPruner.Start(c.context)
+	}
+	if c.operatorRemoved() {
+		c.logger.Warn("operator was removed, validators won't start")
+		return
+	}
 	shares, err := c.collection.GetEnabledOperatorValidatorShares(c.operatorPubKey)
 	if err != nil {
 		c.logger.Fatal("failed to get validators shares", zap.Error(err))
@@ -334,6 +438,27 @@ func (c *controller) StartValidators() {
 	c.logger.Debug("relevant operators", zap.Int("len", len(ids)), zap.Strings("op_ids", ids))
 }
 
+// operatorRemoved returns whether an OperatorRemoved event of the node operator was processed.
+// missing operator data alone doesn't mean that the operator was removed,
+// e.g. the sync offset might be later than the operator registration
+func (c *controller) operatorRemoved() bool {
+	if c.storage == nil {
+		return false
+	}
+	removed, err := c.storage.IsOperatorRemoved(c.operatorPubKey)
+	if err != nil {
+		c.logger.Warn("could not check if the operator was removed", zap.Error(err))
+		return false
+	}
+	if removed {
+		return true
+	}
+	if _, found, err := c.storage.GetOperatorDataByPubKey(c.operatorPubKey); err == nil && !found {
+		c.logger.Warn("could not find operator data, starting validators anyway")
+	}
+	return false
+}
+
 // setupValidators setup and starts validators from the given shares
 // shares w/o validator's metadata won't start, but the metadata will be fetched and the validator will start afterwards
 func (c *controller) setupValidators(shares []*beaconprotocol.Share) {
@@ -431,6 +556,21 @@ func (c *controller) GetValidatorsIndices() []spec.ValidatorIndex {
 	return indices
 }
 
+// GetActiveValidatorsShares returns the shares of all the active validators
+func (c *controller) GetActiveValidatorsShares() []*beaconprotocol.Share {
+	var shares []*beaconprotocol.Share
+	err := c.validatorsMap.ForEach(func(v validator.IValidator) error {
+		if v.GetShare().HasMetadata() && v.GetShare().Metadata.IsActive() {
+			shares = append(shares, v.GetShare())
+		}
+		return nil
+	})
+	if err != nil {
+		c.logger.Warn("failed to get active validators shares", zap.Error(err))
+	}
+	return shares
+}
+
 // onMetadataUpdated is called when validator's metadata was updated
 func (c *controller) onMetadataUpdated(pk string, meta *beaconprotocol.ValidatorMetadata) {
 	if meta == nil {
@@ -467,6 +607,8 @@ func (c *controller) onShareCreate(validatorEvent abiparser.ValidatorAddedEvent)
 		return nil, false, errors.Wrap(err, "could not extract validator share from event")
 	}
 
+	share.FeeRecipient = c.feeRecipient
+
 	// determine if the share belongs to operator
 	isOperatorShare := share.IsOperatorShare(c.operatorPubKey)
 
@@ -504,6 +646,7 @@ func (c *controller) onShareCreate(validatorEvent abiparser.ValidatorAddedEvent)
 func (c *controller) onShareRemove(pk string, removeSecret bool) error {
 	// remove from validatorsMap
 	v := c.validatorsMap.RemoveValidator(pk)
+	c.startedAt.Delete(pk)
 
 	// stop instance
 	if v != nil {
@@ -542,6 +685,7 @@ func (c *controller) startValidator(v validator.IValidator) (bool, error) {
 		metricsValidatorStatus.WithLabelValues(v.GetShare().PublicKey.SerializeToHexStr()).Set(float64(validatorStatusError))
 		return false, errors.Wrap(err, "could not start validator")
 	}
+	c.startedAt.LoadOrStore(v.GetShare().PublicKey.SerializeToHexStr(), time.Now())
 	return true, nil
 }
 
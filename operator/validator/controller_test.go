@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
 	"github.com/bloxapp/ssv/protocol/v1/message"
+	"github.com/bloxapp/ssv/protocol/v1/qbft"
 	"github.com/bloxapp/ssv/protocol/v1/queue/worker"
 	"github.com/bloxapp/ssv/protocol/v1/validator"
 	"github.com/bloxapp/ssv/utils/logex"
@@ -18,6 +19,32 @@ func init() {
 	logex.Build("test", zap.DebugLevel, nil)
 }
 
+func TestParseInstanceConfigs(t *testing.T) {
+	configs, err := parseInstanceConfigs(map[string]qbft.InstanceConfig{
+		"ATTESTER": {
+			TimeoutSchedule:            qbft.TimeoutScheduleTwoPhase,
+			RoundChangeDurationSeconds: 2,
+			QuickRounds:                2,
+			SlowRoundTimeoutSeconds:    6,
+			DutyDeadline:               12 * time.Second,
+		},
+		"PROPOSER": {MaxRoundTimeoutSeconds: 20},
+	})
+	require.NoError(t, err)
+	require.Len(t, configs, 2)
+	require.Equal(t, qbft.TimeoutScheduleTwoPhase, configs[message.RoleTypeAttester].TimeoutSchedule)
+	require.Equal(t, 12*time.Second, configs[message.RoleTypeAttester].DutyDeadline)
+	// unset fields take the default values
+	require.Equal(t, qbft.TimeoutScheduleExponential, configs[message.RoleTypeProposer].TimeoutSchedule)
+	require.Equal(t, float32(3), configs[message.RoleTypeProposer].RoundChangeDurationSeconds)
+	require.Equal(t, 12*time.Second, configs[message.RoleTypeProposer].DutyDeadline)
+
+	_, err = parseInstanceConfigs(map[string]qbft.InstanceConfig{"SYNC_COMMITTEE": {}})
+	require.EqualError(t, err, `unknown role "SYNC_COMMITTEE"`)
+	_, err = parseInstanceConfigs(map[string]qbft.InstanceConfig{"ATTESTER": {TimeoutSchedule: qbft.TimeoutScheduleTwoPhase}})
+	require.EqualError(t, err, "invalid config of role ATTESTER: slow round timeout must be positive in two-phase schedule")
+}
+
 func TestHandleNonCommitteeMessages(t *testing.T) {
 	logger := logex.GetLogger()
 	ctr := setupController(logger, map[string]validator.IValidator{}) // none committee
@@ -24,6 +24,9 @@ func (e *ErrorNotFound) Error() string {
 // Eth1EventHandler is a factory function for creating eth1 event handler
 func (c *controller) Eth1EventHandler(ongoingSync bool) eth1.SyncEventHandler {
 	return func(e eth1.Event) error {
+		if e.Log.Removed {
+			return c.revertEth1Event(e, ongoingSync)
+		}
 		switch e.Name {
 		case abiparser.ValidatorAdded:
 			ev := e.Data.(abiparser.ValidatorAddedEvent)
@@ -101,13 +104,117 @@ func (c *controller) Eth1EventHandler(ongoingSync bool) eth1.SyncEventHandler {
 				c.logger.Error("could not handle AccountEnabled event", zap.Error(err))
 				return err
 			}
+		case abiparser.ValidatorUpdated:
+			ev := e.Data.(abiparser.ValidatorUpdatedEvent)
+			err := c.handleValidatorUpdatedEvent(ev, ongoingSync)
+			if err != nil {
+				logger := c.logger.With(
+					zap.Uint64("blockNumber", e.Log.BlockNumber),
+					zap.String("txHash", e.Log.TxHash.Hex()),
+					zap.String("publicKey", hex.EncodeToString(ev.PublicKey)),
+					zap.Error(err),
+				)
+				var errNotFound *ErrorNotFound
+				var decryptErr *abiparser.DecryptError
+				var blsSecretKeySetHexErr *abiparser.BlsSecretKeySetHexStrError
+				if errors.As(err, &errNotFound) || errors.As(err, &decryptErr) || errors.As(err, &blsSecretKeySetHexErr) {
+					logger.Warn("could not handle ValidatorUpdated event")
+					return nil
+				}
+				logger.Error("could not handle ValidatorUpdated event")
+				return err
+			}
+		case abiparser.OperatorRemoved:
+			ev := e.Data.(abiparser.OperatorRemovedEvent)
+			err := c.handleOperatorRemovedEvent(ev, ongoingSync)
+			if err != nil {
+				logger := c.logger.With(zap.Uint64("operatorId", ev.OperatorId.Uint64()), zap.Error(err))
+				var errNotFound *ErrorNotFound
+				if errors.As(err, &errNotFound) {
+					logger.Warn("could not handle OperatorRemoved event")
+					return nil
+				}
+				logger.Error("could not handle OperatorRemoved event")
+				return err
+			}
+		case abiparser.OperatorFeeSet, abiparser.OperatorFeeSetCanceled, abiparser.OperatorFeeApproved:
+			ev := e.Data.(abiparser.OperatorFeeEvent)
+			err := c.handleOperatorFeeEvent(e.Name, ev)
+			if err != nil {
+				logger := c.logger.With(zap.String("event", e.Name), zap.Uint64("operatorId", ev.OperatorId.Uint64()), zap.Error(err))
+				var errNotFound *ErrorNotFound
+				if errors.As(err, &errNotFound) {
+					logger.Warn("could not handle operator fee event")
+					return nil
+				}
+				logger.Error("could not handle operator fee event")
+				return err
+			}
 		default:
 			c.logger.Warn("could not handle unknown event")
+			return nil
+		}
+		if ongoingSync && c.registryEventHandler != nil {
+			c.registryEventHandler(e)
 		}
 		return nil
 	}
 }
 
+// revertEth1Event reverts the changes of an event that was removed from the chain due to a reorg
+func (c *controller) revertEth1Event(e eth1.Event, ongoingSync bool) error {
+	logger := c.logger.With(
+		zap.String("event", e.Name),
+		zap.Uint64("blockNumber", e.Log.BlockNumber),
+		zap.String("txHash", e.Log.TxHash.Hex()),
+	)
+	logger.Warn("reverting eth1 event that was removed by a reorg")
+	switch e.Name {
+	case abiparser.ValidatorAdded:
+		ev := e.Data.(abiparser.ValidatorAddedEvent)
+		err := c.handleValidatorRemovedEvent(abiparser.ValidatorRemovedEvent{
+			OwnerAddress: ev.OwnerAddress,
+			PublicKey:    ev.PublicKey,
+		}, ongoingSync)
+		var errNotFound *ErrorNotFound
+		if err != nil && !errors.As(err, &errNotFound) {
+			logger.Error("could not revert ValidatorAdded event", zap.Error(err))
+			return err
+		}
+	case abiparser.AccountLiquidated:
+		ev := e.Data.(abiparser.AccountLiquidatedEvent)
+		err := c.handleAccountEnabledEvent(abiparser.AccountEnabledEvent{OwnerAddress: ev.OwnerAddress}, ongoingSync)
+		if err != nil {
+			logger.Error("could not revert AccountLiquidated event", zap.Error(err))
+			return err
+		}
+	case abiparser.AccountEnabled:
+		ev := e.Data.(abiparser.AccountEnabledEvent)
+		err := c.handleAccountLiquidatedEvent(abiparser.AccountLiquidatedEvent{OwnerAddress: ev.OwnerAddress}, ongoingSync)
+		if err != nil {
+			logger.Error("could not revert AccountEnabled event", zap.Error(err))
+			return err
+		}
+	case abiparser.ValidatorRemoved:
+		// the share can't be restored as the encrypted key is available only in the original ValidatorAdded event
+		logger.Error("could not revert ValidatorRemoved event, registry data must be re-synced")
+	case abiparser.OperatorAdded:
+		// operator data doesn't affect the running validators, it will be overridden if the operator is added again
+		logger.Debug("keeping operator data of removed OperatorAdded event")
+	case abiparser.ValidatorUpdated, abiparser.OperatorRemoved:
+		// the previous share keys / operator data are not available anymore
+		logger.Error("could not revert event, registry data must be re-synced")
+	case abiparser.OperatorFeeApproved:
+		logger.Warn("could not revert OperatorFeeApproved event, the operator fee is updated on the next approved fee")
+	case abiparser.OperatorFeeSet, abiparser.OperatorFeeSetCanceled:
+		// pending fees are not saved
+		logger.Debug("nothing to revert")
+	default:
+		logger.Warn("could not revert unknown event")
+	}
+	return nil
+}
+
 // handleValidatorAddedEvent handles registry contract event for validator added
 func (c *controller) handleValidatorAddedEvent(
 	validatorAddedEvent abiparser.ValidatorAddedEvent,
@@ -181,6 +288,7 @@ func (c *controller) handleOperatorAddedEvent(event abiparser.OperatorAddedEvent
 		Name:         event.Name,
 		OwnerAddress: event.OwnerAddress,
 		Index:        event.Id.Uint64(),
+		Fee:          event.Fee,
 	}
 	if err := c.storage.SaveOperatorData(&od); err != nil {
 		return errors.Wrap(err, "could not save operator data")
@@ -189,6 +297,137 @@ func (c *controller) handleOperatorAddedEvent(event abiparser.OperatorAddedEvent
 	return nil
 }
 
+// handleValidatorUpdatedEvent handles registry contract event for validator updated,
+// the share is re-created with the new operators and keys and the validator is restarted with the new committee
+func (c *controller) handleValidatorUpdatedEvent(
+	event abiparser.ValidatorUpdatedEvent,
+	ongoingSync bool,
+) error {
+	oldShare, found, err := c.collection.GetValidatorShare(event.PublicKey)
+	if err != nil {
+		return errors.Wrap(err, "could not check if validator share exist")
+	}
+	if !found {
+		return &ErrorNotFound{
+			Err: errors.New("could not find validator share"),
+		}
+	}
+	pubKey := oldShare.PublicKey.SerializeToHexStr()
+	logger := c.logger.With(zap.String("pubKey", pubKey))
+
+	share, shareSecret, err := ShareFromValidatorEvent(
+		abiparser.ValidatorAddedEvent(event),
+		c.storage,
+		c.shareEncryptionKeyProvider,
+		c.operatorPubKey,
+	)
+	if err != nil {
+		return errors.Wrap(err, "could not extract validator share from event")
+	}
+	share.Metadata = oldShare.Metadata
+	share.Liquidated = oldShare.Liquidated
+	share.FeeRecipient = oldShare.FeeRecipient
+
+	wasOperatorShare := oldShare.IsOperatorShare(c.operatorPubKey)
+	isOperatorShare := share.IsOperatorShare(c.operatorPubKey)
+	if isOperatorShare && shareSecret == nil {
+		return errors.New("could not decode shareSecret key from ValidatorUpdated event")
+	}
+
+	if wasOperatorShare {
+		// the running validator is stopped, its controllers are created again with the new committee
+		if err := c.onShareRemove(pubKey, false); err != nil {
+			return err
+		}
+		oldSharePubKey, err := oldShare.OperatorSharePubKey()
+		if err != nil {
+			return errors.Wrap(err, "could not get operator share public key")
+		}
+		// the secret is kept if the share key didn't change, so its slashing protection data is kept as well
+		if shareSecret == nil || !shareSecret.GetPublicKey().IsEqual(oldSharePubKey) {
+			if err := c.keyManager.RemoveShare(oldSharePubKey.SerializeToHexStr()); err != nil {
+				return errors.Wrap(err, "could not remove share secret from key manager")
+			}
+		}
+	}
+	if isOperatorShare {
+		if err := c.keyManager.AddShare(shareSecret); err != nil {
+			return errors.Wrap(err, "could not add share secret to key manager")
+		}
+	}
+
+	if err := c.collection.SaveValidatorShare(share); err != nil {
+		return errors.Wrap(err, "could not save validator share")
+	}
+	logger.Debug("ValidatorUpdated event was handled successfully",
+		zap.Bool("wasOperatorShare", wasOperatorShare), zap.Bool("isOperatorShare", isOperatorShare))
+
+	if ongoingSync && isOperatorShare && !share.Liquidated {
+		c.onShareStart(share)
+	}
+	return nil
+}
+
+// handleOperatorRemovedEvent removes the operator data,
+// in case the node operator was removed its validators are stopped
+func (c *controller) handleOperatorRemovedEvent(
+	event abiparser.OperatorRemovedEvent,
+	ongoingSync bool,
+) error {
+	od, found, err := c.storage.GetOperatorData(event.OperatorId.Uint64())
+	if err != nil {
+		return errors.Wrap(err, "could not get operator data")
+	}
+	if !found {
+		return &ErrorNotFound{
+			Err: errors.New("could not find operator data"),
+		}
+	}
+	if err := c.storage.DeleteOperatorData(od.Index); err != nil {
+		return errors.Wrap(err, "could not delete operator data")
+	}
+	if !strings.EqualFold(od.PublicKey, c.operatorPubKey) {
+		return nil
+	}
+
+	c.logger.Warn("node operator was removed, stopping validators", zap.Uint64("operatorId", od.Index))
+	if !ongoingSync {
+		return nil
+	}
+	shares, err := c.collection.GetEnabledOperatorValidatorShares(c.operatorPubKey)
+	if err != nil {
+		return errors.Wrap(err, "could not get operator validator shares")
+	}
+	for _, share := range shares {
+		if err := c.onShareRemove(share.PublicKey.SerializeToHexStr(), false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// handleOperatorFeeEvent updates the operator fee once a new fee is approved,
+// declared (OperatorFeeSet) and canceled fees don't affect the operator until they are approved
+func (c *controller) handleOperatorFeeEvent(eventName string, event abiparser.OperatorFeeEvent) error {
+	if eventName != abiparser.OperatorFeeApproved {
+		c.logger.Debug("pending operator fee was changed", zap.String("event", eventName),
+			zap.Uint64("operatorId", event.OperatorId.Uint64()))
+		return nil
+	}
+	// the operator might be unknown, e.g. if it was registered before the sync offset
+	if _, found, err := c.storage.GetOperatorData(event.OperatorId.Uint64()); err != nil {
+		return errors.Wrap(err, "could not get operator data")
+	} else if !found {
+		return &ErrorNotFound{
+			Err: errors.New("could not find operator data"),
+		}
+	}
+	if err := c.storage.UpdateOperatorFee(event.OperatorId.Uint64(), event.Fee); err != nil {
+		return errors.Wrap(err, "could not update operator fee")
+	}
+	return nil
+}
+
 // handleAccountLiquidatedEvent handles registry contract event for account liquidated
 func (c *controller) handleAccountLiquidatedEvent(
 	event abiparser.AccountLiquidatedEvent,
@@ -0,0 +1,223 @@
+package validator
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/eth1"
+	"github.com/bloxapp/ssv/eth1/abiparser"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
+	"github.com/bloxapp/ssv/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/utils/threshold"
+)
+
+func TestEth1EventHandler_RemovedEvents(t *testing.T) {
+	options := basedb.Options{
+		Type:   "badger-memory",
+		Logger: zap.L(),
+		Path:   "",
+	}
+	db, err := storage.GetStorageFactory(options)
+	require.NoError(t, err)
+	defer db.Close()
+
+	threshold.Init()
+	sk := &bls.SecretKey{}
+	sk.SetByCSPRNG()
+	splitKeys, err := threshold.Create(sk.Serialize(), 3, 4)
+	require.NoError(t, err)
+
+	operatorPubKey := "operator-pk"
+	share, _ := generateRandomValidatorShare(splitKeys)
+	share.Operators = [][]byte{[]byte(operatorPubKey)}
+
+	ctr := setupController(zap.L(), nil)
+	ctr.operatorPubKey = operatorPubKey
+	ctr.collection = NewCollection(CollectionOptions{
+		DB:     db,
+		Logger: options.Logger,
+	})
+	require.NoError(t, ctr.collection.SaveValidatorShare(share))
+
+	handler := ctr.Eth1EventHandler(false)
+	ownerAddress := common.HexToAddress(share.OwnerAddress)
+	removedLog := types.Log{BlockNumber: 10, Removed: true}
+
+	t.Run("removed AccountEnabled", func(t *testing.T) {
+		require.NoError(t, handler(eth1.Event{
+			Log:  removedLog,
+			Name: abiparser.AccountEnabled,
+			Data: abiparser.AccountEnabledEvent{OwnerAddress: ownerAddress},
+		}))
+		s, found, err := ctr.collection.GetValidatorShare(share.PublicKey.Serialize())
+		require.NoError(t, err)
+		require.True(t, found)
+		require.True(t, s.Liquidated)
+	})
+
+	t.Run("removed AccountLiquidated", func(t *testing.T) {
+		require.NoError(t, handler(eth1.Event{
+			Log:  removedLog,
+			Name: abiparser.AccountLiquidated,
+			Data: abiparser.AccountLiquidatedEvent{OwnerAddress: ownerAddress},
+		}))
+		s, found, err := ctr.collection.GetValidatorShare(share.PublicKey.Serialize())
+		require.NoError(t, err)
+		require.True(t, found)
+		require.False(t, s.Liquidated)
+	})
+
+	t.Run("removed ValidatorAdded", func(t *testing.T) {
+		ev := eth1.Event{
+			Log:  removedLog,
+			Name: abiparser.ValidatorAdded,
+			Data: abiparser.ValidatorAddedEvent{OwnerAddress: ownerAddress, PublicKey: share.PublicKey.Serialize()},
+		}
+		require.NoError(t, handler(ev))
+		_, found, err := ctr.collection.GetValidatorShare(share.PublicKey.Serialize())
+		require.NoError(t, err)
+		require.False(t, found)
+		// reverting again is a no-op
+		require.NoError(t, handler(ev))
+	})
+}
+
+func TestEth1EventHandler_OperatorEvents(t *testing.T) {
+	options := basedb.Options{
+		Type:   "badger-memory",
+		Logger: zap.L(),
+		Path:   "",
+	}
+	db, err := storage.GetStorageFactory(options)
+	require.NoError(t, err)
+	defer db.Close()
+
+	operatorPubKey := "operator-pk"
+	ctr := setupController(zap.L(), nil)
+	ctr.operatorPubKey = operatorPubKey
+	ctr.storage = registrystorage.NewOperatorsStorage(db, zap.L(), []byte("test"))
+	ctr.collection = NewCollection(CollectionOptions{
+		DB:     db,
+		Logger: options.Logger,
+	})
+	var published []eth1.Event
+	ctr.registryEventHandler = func(e eth1.Event) {
+		published = append(published, e)
+	}
+
+	ownerAddress := common.HexToAddress("0xFeedB14D8b2C76FdF808C29818b06b830E8C2c0e")
+	handler := ctr.Eth1EventHandler(true)
+	require.NoError(t, handler(eth1.Event{
+		Log:  types.Log{BlockNumber: 10},
+		Name: abiparser.OperatorAdded,
+		Data: abiparser.OperatorAddedEvent{
+			Id:           big.NewInt(1),
+			Name:         "my_operator",
+			OwnerAddress: ownerAddress,
+			PublicKey:    []byte("other-operator-pk"),
+			Fee:          big.NewInt(10),
+		},
+	}))
+
+	t.Run("OperatorFeeSet doesn't change the fee", func(t *testing.T) {
+		require.NoError(t, handler(eth1.Event{
+			Log:  types.Log{BlockNumber: 11},
+			Name: abiparser.OperatorFeeSet,
+			Data: abiparser.OperatorFeeEvent{OwnerAddress: ownerAddress, OperatorId: big.NewInt(1), Fee: big.NewInt(20)},
+		}))
+		od, found, err := ctr.storage.GetOperatorData(1)
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Equal(t, int64(10), od.Fee.Int64())
+	})
+
+	t.Run("OperatorFeeApproved", func(t *testing.T) {
+		require.NoError(t, handler(eth1.Event{
+			Log:  types.Log{BlockNumber: 12},
+			Name: abiparser.OperatorFeeApproved,
+			Data: abiparser.OperatorFeeEvent{OwnerAddress: ownerAddress, OperatorId: big.NewInt(1), Fee: big.NewInt(20)},
+		}))
+		od, found, err := ctr.storage.GetOperatorData(1)
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Equal(t, int64(20), od.Fee.Int64())
+	})
+
+	t.Run("OperatorFeeApproved of unknown operator is ignored", func(t *testing.T) {
+		require.NoError(t, handler(eth1.Event{
+			Log:  types.Log{BlockNumber: 12},
+			Name: abiparser.OperatorFeeApproved,
+			Data: abiparser.OperatorFeeEvent{OwnerAddress: ownerAddress, OperatorId: big.NewInt(5), Fee: big.NewInt(20)},
+		}))
+	})
+
+	t.Run("OperatorRemoved", func(t *testing.T) {
+		ev := eth1.Event{
+			Log:  types.Log{BlockNumber: 13},
+			Name: abiparser.OperatorRemoved,
+			Data: abiparser.OperatorRemovedEvent{OwnerAddress: ownerAddress, OperatorId: big.NewInt(1)},
+		}
+		require.NoError(t, handler(ev))
+		_, found, err := ctr.storage.GetOperatorData(1)
+		require.NoError(t, err)
+		require.False(t, found)
+		// unknown operator is ignored
+		require.NoError(t, handler(ev))
+	})
+
+	t.Run("ValidatorUpdated of unknown validator is ignored", func(t *testing.T) {
+		require.NoError(t, handler(eth1.Event{
+			Log:  types.Log{BlockNumber: 14},
+			Name: abiparser.ValidatorUpdated,
+			Data: abiparser.ValidatorUpdatedEvent{OwnerAddress: ownerAddress, PublicKey: []byte("unknown")},
+		}))
+	})
+
+	// only events that were applied are published
+	require.Len(t, published, 4)
+	require.Equal(t, abiparser.OperatorAdded, published[0].Name)
+	require.Equal(t, abiparser.OperatorRemoved, published[3].Name)
+}
+
+func TestController_OperatorRemoved(t *testing.T) {
+	db, err := storage.GetStorageFactory(basedb.Options{
+		Type:   "badger-memory",
+		Logger: zap.L(),
+	})
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctr := setupController(zap.L(), nil)
+	ctr.operatorPubKey = "operator-pk"
+	ctr.storage = registrystorage.NewOperatorsStorage(db, zap.L(), []byte("test"))
+	ctr.collection = NewCollection(CollectionOptions{
+		DB:     db,
+		Logger: zap.L(),
+	})
+	handler := ctr.Eth1EventHandler(false)
+
+	// unknown operator data (e.g. sync offset after the registration) doesn't stop the validators
+	require.False(t, ctr.operatorRemoved())
+
+	ownerAddress := common.HexToAddress("0xFeedB14D8b2C76FdF808C29818b06b830E8C2c0e")
+	require.NoError(t, handler(eth1.Event{
+		Log:  types.Log{BlockNumber: 10},
+		Name: abiparser.OperatorAdded,
+		Data: abiparser.OperatorAddedEvent{Id: big.NewInt(1), OwnerAddress: ownerAddress, PublicKey: []byte("operator-pk")},
+	}))
+	require.False(t, ctr.operatorRemoved())
+
+	require.NoError(t, handler(eth1.Event{
+		Log:  types.Log{BlockNumber: 11},
+		Name: abiparser.OperatorRemoved,
+		Data: abiparser.OperatorRemovedEvent{OwnerAddress: ownerAddress, OperatorId: big.NewInt(1)},
+	}))
+	require.True(t, ctr.operatorRemoved())
+}
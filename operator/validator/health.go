@@ -0,0 +1,66 @@
+package validator
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/bloxapp/ssv/monitoring/metrics"
+	qbftcontroller "github.com/bloxapp/ssv/protocol/v1/qbft/controller"
+	"github.com/bloxapp/ssv/protocol/v1/validator"
+)
+
+// stuckValidatorThreshold is the time a started validator has to get its qbft controllers ready,
+// validators that are not ready by then are reported as stuck
+const stuckValidatorThreshold = 10 * time.Minute
+
+// ibftsProvider is implemented by validators that expose their qbft controllers
+type ibftsProvider interface {
+	Ibfts() qbftcontroller.Controllers
+}
+
+// HealthStatus provides health status of the validators, the controller is unhealthy if any validator is stuck
+func (c *controller) HealthStatus() metrics.ComponentStatus {
+	now := time.Now()
+	var total, started, ready int
+	var stuck []string
+	_ = c.validatorsMap.ForEach(func(v validator.IValidator) error {
+		total++
+		pk := v.GetShare().PublicKey.SerializeToHexStr()
+		t, ok := c.startedAt.Load(pk)
+		if !ok {
+			return nil
+		}
+		started++
+		if isReady(v) {
+			ready++
+		} else if now.Sub(t.(time.Time)) > stuckValidatorThreshold {
+			stuck = append(stuck, pk)
+		}
+		return nil
+	})
+	var errs []string
+	if len(stuck) > 0 {
+		errs = append(errs, fmt.Sprintf("%d validators are not ready for more than %s", len(stuck), stuckValidatorThreshold))
+	}
+	return metrics.NewComponentStatus("validators", errs, map[string]interface{}{
+		"total":       total,
+		"started":     started,
+		"not_started": total - started,
+		"ready":       ready,
+		"stuck":       stuck,
+	})
+}
+
+// isReady returns true if all the qbft controllers of the given validator are ready
+func isReady(v validator.IValidator) bool {
+	provider, ok := v.(ibftsProvider)
+	if !ok {
+		return true
+	}
+	for _, ibft := range provider.Ibfts() {
+		if ibft.State() != qbftcontroller.Ready {
+			return false
+		}
+	}
+	return true
+}
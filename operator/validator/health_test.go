@@ -0,0 +1,81 @@
+package validator
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
+	"github.com/bloxapp/ssv/protocol/v1/message"
+	qbftcontroller "github.com/bloxapp/ssv/protocol/v1/qbft/controller"
+	"github.com/bloxapp/ssv/protocol/v1/validator"
+	"github.com/bloxapp/ssv/utils/logex"
+	"github.com/bloxapp/ssv/utils/threshold"
+)
+
+type testIBFT struct {
+	qbftcontroller.IController
+	state   uint32
+	resyncs int
+}
+
+func (t *testIBFT) State() uint32 {
+	return t.state
+}
+
+type testValidator struct {
+	validator.IValidator
+	share *beacon.Share
+	ibfts qbftcontroller.Controllers
+}
+
+func (v *testValidator) GetShare() *beacon.Share {
+	return v.share
+}
+
+func (v *testValidator) Ibfts() qbftcontroller.Controllers {
+	return v.ibfts
+}
+
+func newTestValidator(state uint32) *testValidator {
+	sk := &bls.SecretKey{}
+	sk.SetByCSPRNG()
+	return &testValidator{
+		share: &beacon.Share{PublicKey: sk.GetPublicKey()},
+		ibfts: qbftcontroller.Controllers{message.RoleTypeAttester: &testIBFT{state: state}},
+	}
+}
+
+func TestController_HealthStatus(t *testing.T) {
+	threshold.Init()
+	ready := newTestValidator(qbftcontroller.Ready)
+	initializing := newTestValidator(qbftcontroller.WaitingForPeers)
+	stuck := newTestValidator(qbftcontroller.WaitingForPeers)
+	notStarted := newTestValidator(qbftcontroller.NotStarted)
+	validators := map[string]validator.IValidator{}
+	for _, v := range []*testValidator{ready, initializing, stuck, notStarted} {
+		validators[v.share.PublicKey.SerializeToHexStr()] = v
+	}
+	ctr := setupController(logex.GetLogger(), validators)
+	ctr.startedAt = &sync.Map{}
+	ctr.startedAt.Store(ready.share.PublicKey.SerializeToHexStr(), time.Now().Add(-time.Hour))
+	ctr.startedAt.Store(initializing.share.PublicKey.SerializeToHexStr(), time.Now())
+	ctr.startedAt.Store(stuck.share.PublicKey.SerializeToHexStr(), time.Now().Add(-stuckValidatorThreshold-time.Minute))
+
+	status := ctr.HealthStatus()
+	require.False(t, status.Healthy)
+	require.Len(t, status.Errors, 1)
+	require.Equal(t, 4, status.Details["total"])
+	require.Equal(t, 3, status.Details["started"])
+	require.Equal(t, 1, status.Details["not_started"])
+	require.Equal(t, 1, status.Details["ready"])
+	require.Equal(t, []string{stuck.share.PublicKey.SerializeToHexStr()}, status.Details["stuck"])
+
+	ctr.startedAt.Delete(stuck.share.PublicKey.SerializeToHexStr())
+	status = ctr.HealthStatus()
+	require.True(t, status.Healthy)
+	require.Empty(t, status.Details["stuck"])
+}
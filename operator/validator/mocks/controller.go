@@ -7,8 +7,11 @@ package mocks
 import (
 	phase0 "github.com/attestantio/go-eth2-client/spec/phase0"
 	eth1 "github.com/bloxapp/ssv/eth1"
+	metrics "github.com/bloxapp/ssv/monitoring/metrics"
 	validator "github.com/bloxapp/ssv/operator/validator"
+	forks "github.com/bloxapp/ssv/protocol/forks"
 	message "github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
+	message0 "github.com/bloxapp/ssv/protocol/v1/message"
 	validator0 "github.com/bloxapp/ssv/protocol/v1/validator"
 	gomock "github.com/golang/mock/gomock"
 	event "github.com/prysmaticlabs/prysm/async/event"
@@ -76,6 +79,20 @@ func (mr *MockControllerMockRecorder) GetValidatorsIndices() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValidatorsIndices", reflect.TypeOf((*MockController)(nil).GetValidatorsIndices))
 }
 
+// GetActiveValidatorsShares mocks base method
+func (m *MockController) GetActiveValidatorsShares() []*message.Share {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveValidatorsShares")
+	ret0, _ := ret[0].([]*message.Share)
+	return ret0
+}
+
+// GetActiveValidatorsShares indicates an expected call of GetActiveValidatorsShares
+func (mr *MockControllerMockRecorder) GetActiveValidatorsShares() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveValidatorsShares", reflect.TypeOf((*MockController)(nil).GetActiveValidatorsShares))
+}
+
 // GetValidator mocks base method
 func (m *MockController) GetValidator(pubKey string) (validator0.IValidator, bool) {
 	m.ctrl.T.Helper()
@@ -103,34 +120,30 @@ func (mr *MockControllerMockRecorder) UpdateValidatorMetaDataLoop() *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateValidatorMetaDataLoop", reflect.TypeOf((*MockController)(nil).UpdateValidatorMetaDataLoop))
 }
 
-// StartNetworkMediators mocks base method
-func (m *MockController) StartNetworkMediators() {
+// StartNetworkHandlers mocks base method
+func (m *MockController) StartNetworkHandlers() {
 	m.ctrl.T.Helper()
 	m.ctrl.Call(m, "StartNetworkHandlers")
 }
 
-// StartNetworkMediators indicates an expected call of StartNetworkMediators
-func (mr *MockControllerMockRecorder) StartNetworkMediators() *gomock.Call {
+// StartNetworkHandlers indicates an expected call of StartNetworkHandlers
+func (mr *MockControllerMockRecorder) StartNetworkHandlers() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartNetworkHandlers", reflect.TypeOf((*MockController)(nil).StartNetworkMediators))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartNetworkHandlers", reflect.TypeOf((*MockController)(nil).StartNetworkHandlers))
 }
 
 // Eth1EventHandler mocks base method
-func (m *MockController) Eth1EventHandler(handlers ...validator.ShareEventHandlerFunc) eth1.SyncEventHandler {
+func (m *MockController) Eth1EventHandler(ongoingSync bool) eth1.SyncEventHandler {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{}
-	for _, a := range handlers {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "Eth1EventHandler", varargs...)
+	ret := m.ctrl.Call(m, "Eth1EventHandler", ongoingSync)
 	ret0, _ := ret[0].(eth1.SyncEventHandler)
 	return ret0
 }
 
 // Eth1EventHandler indicates an expected call of Eth1EventHandler
-func (mr *MockControllerMockRecorder) Eth1EventHandler(handlers ...interface{}) *gomock.Call {
+func (mr *MockControllerMockRecorder) Eth1EventHandler(ongoingSync interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Eth1EventHandler", reflect.TypeOf((*MockController)(nil).Eth1EventHandler), handlers...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Eth1EventHandler", reflect.TypeOf((*MockController)(nil).Eth1EventHandler), ongoingSync)
 }
 
 // GetAllValidatorShares mocks base method
@@ -147,3 +160,101 @@ func (mr *MockControllerMockRecorder) GetAllValidatorShares() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllValidatorShares", reflect.TypeOf((*MockController)(nil).GetAllValidatorShares))
 }
+
+// CleanRegistryData mocks base method
+func (m *MockController) CleanRegistryData() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CleanRegistryData")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CleanRegistryData indicates an expected call of CleanRegistryData
+func (mr *MockControllerMockRecorder) CleanRegistryData() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanRegistryData", reflect.TypeOf((*MockController)(nil).CleanRegistryData))
+}
+
+// OnFork mocks base method
+func (m *MockController) OnFork(forkVersion forks.ForkVersion) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OnFork", forkVersion)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// OnFork indicates an expected call of OnFork
+func (mr *MockControllerMockRecorder) OnFork(forkVersion interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnFork", reflect.TypeOf((*MockController)(nil).OnFork), forkVersion)
+}
+
+// HealthStatus mocks base method
+func (m *MockController) HealthStatus() metrics.ComponentStatus {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HealthStatus")
+	ret0, _ := ret[0].(metrics.ComponentStatus)
+	return ret0
+}
+
+// HealthStatus indicates an expected call of HealthStatus
+func (mr *MockControllerMockRecorder) HealthStatus() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HealthStatus", reflect.TypeOf((*MockController)(nil).HealthStatus))
+}
+
+// ValidatorsInfo mocks base method
+func (m *MockController) ValidatorsInfo() []validator.ValidatorInfo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidatorsInfo")
+	ret0, _ := ret[0].([]validator.ValidatorInfo)
+	return ret0
+}
+
+// ValidatorsInfo indicates an expected call of ValidatorsInfo
+func (mr *MockControllerMockRecorder) ValidatorsInfo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidatorsInfo", reflect.TypeOf((*MockController)(nil).ValidatorsInfo))
+}
+
+// StartValidator mocks base method
+func (m *MockController) StartValidator(pubKey string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartValidator", pubKey)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartValidator indicates an expected call of StartValidator
+func (mr *MockControllerMockRecorder) StartValidator(pubKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartValidator", reflect.TypeOf((*MockController)(nil).StartValidator), pubKey)
+}
+
+// StopValidator mocks base method
+func (m *MockController) StopValidator(pubKey string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopValidator", pubKey)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StopValidator indicates an expected call of StopValidator
+func (mr *MockControllerMockRecorder) StopValidator(pubKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopValidator", reflect.TypeOf((*MockController)(nil).StopValidator), pubKey)
+}
+
+// ResyncDecided mocks base method
+func (m *MockController) ResyncDecided(pubKey string, role message0.RoleType) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResyncDecided", pubKey, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResyncDecided indicates an expected call of ResyncDecided
+func (mr *MockControllerMockRecorder) ResyncDecided(pubKey, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResyncDecided", reflect.TypeOf((*MockController)(nil).ResyncDecided), pubKey, role)
+}
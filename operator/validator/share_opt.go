@@ -16,6 +16,7 @@ type ShareOptions struct {
 	Committee    map[string]int `yaml:"Committee" env:"LOCAL_COMMITTEE" env-description:"Local validator committee array"`
 	OwnerAddress string         `yaml:"OwnerAddress" env:"LOCAL_OWNER_ADDRESS" env-description:"Local validator owner address"`
 	Operators    []string       `yaml:"Operators" env:"LOCAL_OPERATORS" env-description:"Local validator selected operators"`
+	FeeRecipient string         `yaml:"FeeRecipient" env:"LOCAL_FEE_RECIPIENT" env-description:"Local validator fee recipient"`
 }
 
 // ToShare creates a Share instance from ShareOptions
@@ -59,6 +60,7 @@ func (options *ShareOptions) ToShare() (*beacon.Share, error) {
 			Committee:    ibftCommittee,
 			OwnerAddress: options.OwnerAddress,
 			Operators:    operators,
+			FeeRecipient: options.FeeRecipient,
 		}
 		return &share, nil
 	}
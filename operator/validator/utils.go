@@ -1,6 +1,7 @@
 package validator
 
 import (
+	"crypto/rsa"
 	"math/big"
 	"strings"
 
@@ -74,27 +75,37 @@ func ShareFromValidatorEvent(
 				return nil, nil, errors.New("could not find operator private key")
 			}
 
-			shareSecret = &bls.SecretKey{}
-			decryptedSharePrivateKey, err := rsaencryption.DecodeKey(operatorPrivateKey, string(validatorAddedEvent.EncryptedKeys[i]))
+			shareSecret, err = DecryptShareSecret(operatorPrivateKey, validatorAddedEvent.EncryptedKeys[i])
 			if err != nil {
-				return nil, nil, &abiparser.DecryptError{
-					Err: errors.Wrap(err, "failed to decrypt share private key"),
-				}
-			}
-			decryptedSharePrivateKey = strings.Replace(decryptedSharePrivateKey, "0x", "", 1)
-			if err := shareSecret.SetHexString(decryptedSharePrivateKey); err != nil {
-				return nil, nil, &abiparser.BlsSecretKeySetHexStrError{
-					Err: errors.Wrap(err, "failed to set decrypted share private key"),
-				}
+				return nil, nil, err
 			}
 		}
 	}
 	validatorShare.Committee = ibftCommittee
 	validatorShare.SetOperators(validatorAddedEvent.OperatorPublicKeys)
+	validatorShare.EncryptedKeys = validatorAddedEvent.EncryptedKeys
 
 	return &validatorShare, shareSecret, nil
 }
 
+// DecryptShareSecret decrypts the share private key that was encrypted with the operator public key
+func DecryptShareSecret(operatorPrivateKey *rsa.PrivateKey, encryptedKey []byte) (*bls.SecretKey, error) {
+	decryptedSharePrivateKey, err := rsaencryption.DecodeKey(operatorPrivateKey, string(encryptedKey))
+	if err != nil {
+		return nil, &abiparser.DecryptError{
+			Err: errors.Wrap(err, "failed to decrypt share private key"),
+		}
+	}
+	decryptedSharePrivateKey = strings.Replace(decryptedSharePrivateKey, "0x", "", 1)
+	shareSecret := &bls.SecretKey{}
+	if err := shareSecret.SetHexString(decryptedSharePrivateKey); err != nil {
+		return nil, &abiparser.BlsSecretKeySetHexStrError{
+			Err: errors.Wrap(err, "failed to set decrypted share private key"),
+		}
+	}
+	return shareSecret, nil
+}
+
 // SetOperatorPublicKeys extracts the operator public keys from the storage and fill the event
 func SetOperatorPublicKeys(
 	registryStorage registrystorage.OperatorsCollection,
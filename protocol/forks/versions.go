@@ -22,6 +22,8 @@ const (
 	V1ForkVersion ForkVersion = "v1"
 	// V2ForkVersion is the version for v2
 	V2ForkVersion ForkVersion = "v2"
+	// V3ForkVersion is the version for v3
+	V3ForkVersion ForkVersion = "v3"
 )
 
 var (
@@ -32,6 +34,10 @@ var (
 	// v2ForkEpoch is the epoch for fork version 1
 	// TODO: set actual epoch when decided
 	v2ForkEpoch = types.Epoch(math.MaxUint64)
+
+	// v3ForkEpoch is the epoch for fork version 3
+	// TODO: set actual epoch when decided
+	v3ForkEpoch = types.Epoch(math.MaxUint64)
 )
 
 // ForkHandler handles a fork event
@@ -43,7 +49,9 @@ type ForkHandler interface {
 // GetCurrentForkVersion returns the current fork version
 func GetCurrentForkVersion(currentEpoch types.Epoch) ForkVersion {
 	switch epoch := currentEpoch; {
-	case epoch >= v2ForkEpoch: // check highest first
+	case epoch >= v3ForkEpoch: // check highest first
+		return V3ForkVersion
+	case epoch >= v2ForkEpoch:
 		return V2ForkVersion
 	case epoch >= v1ForkEpoch:
 		return V1ForkVersion
@@ -61,5 +69,16 @@ func SetForkEpoch(targetEpoch types.Epoch, forkVersion ForkVersion) {
 		v1ForkEpoch = targetEpoch
 	case V2ForkVersion:
 		v2ForkEpoch = targetEpoch
+	case V3ForkVersion:
+		v3ForkEpoch = targetEpoch
+	}
+}
+
+// NetworkForkVersion returns the fork version of the network layer in the given fork version,
+// v3 changes only the qbft leader selection so the network keeps running v2
+func NetworkForkVersion(forkVersion ForkVersion) ForkVersion {
+	if forkVersion == V3ForkVersion {
+		return V2ForkVersion
 	}
+	return forkVersion
 }
@@ -0,0 +1,32 @@
+package forksprotocol
+
+import (
+	"math"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetCurrentForkVersion(t *testing.T) {
+	defer func() {
+		SetForkEpoch(types.Epoch(math.MaxUint64), V1ForkVersion)
+		SetForkEpoch(types.Epoch(math.MaxUint64), V2ForkVersion)
+		SetForkEpoch(types.Epoch(math.MaxUint64), V3ForkVersion)
+	}()
+	SetForkEpoch(10, V1ForkVersion)
+	SetForkEpoch(20, V2ForkVersion)
+	SetForkEpoch(30, V3ForkVersion)
+
+	require.Equal(t, V0ForkVersion, GetCurrentForkVersion(9))
+	require.Equal(t, V1ForkVersion, GetCurrentForkVersion(10))
+	require.Equal(t, V2ForkVersion, GetCurrentForkVersion(29))
+	require.Equal(t, V3ForkVersion, GetCurrentForkVersion(30))
+}
+
+func TestNetworkForkVersion(t *testing.T) {
+	require.Equal(t, V1ForkVersion, NetworkForkVersion(V1ForkVersion))
+	require.Equal(t, V2ForkVersion, NetworkForkVersion(V2ForkVersion))
+	// v3 doesn't change the network
+	require.Equal(t, V2ForkVersion, NetworkForkVersion(V3ForkVersion))
+}
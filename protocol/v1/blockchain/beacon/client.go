@@ -4,6 +4,7 @@ import (
 	"context"
 
 	api "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/herumi/bls-eth-go-binary/bls"
 	"go.uber.org/zap"
@@ -33,6 +34,12 @@ type Beacon interface {
 
 	// SubscribeToCommitteeSubnet subscribe committee to subnet (p2p topic)
 	SubscribeToCommitteeSubnet(subscription []*api.BeaconCommitteeSubscription) error
+
+	// SubmitProposalPreparation submits the fee recipients of the given validators (prepare_beacon_proposer)
+	SubmitProposalPreparation(feeRecipients map[spec.ValidatorIndex]bellatrix.ExecutionAddress) error
+
+	// SubmitValidatorRegistration submits the given signed validator registration to the builder network via the node
+	SubmitValidatorRegistration(registration *SignedValidatorRegistration) error
 }
 
 // KeyManager is an interface responsible for all key manager functions
@@ -50,6 +57,8 @@ type Signer interface {
 	SignIBFTMessage(message *message.ConsensusMessage, pk []byte, forkVersion string) ([]byte, error)
 	// SignAttestation signs the given attestation
 	SignAttestation(data *spec.AttestationData, duty *Duty, pk []byte) (*spec.Attestation, []byte, error)
+	// SignValidatorRegistration signs the given validator registration with the builder domain, returns the signature and signing root
+	SignValidatorRegistration(registration *ValidatorRegistration, pk []byte) ([]byte, []byte, error)
 }
 
 // SigningUtil is an interface for beacon node signing specific methods
@@ -7,7 +7,7 @@ import (
 
 // Duty represent data regarding the duty type with the duty data
 type Duty struct {
-	// Type is the duty type (attest, propose)
+	// Type is the duty type (attest, propose, validator registration)
 	Type message.RoleType
 	// PubKey is the public key of the validator that should attest.
 	PubKey spec.BLSPubKey
@@ -13,6 +13,7 @@ type DutyData struct {
 	Data IsInputValueData `protobuf_oneof:"data"`
 	// Types that are valid to be assigned to SignedData:
 	//	*InputValueAttestation
+	//	*InputValueValidatorRegistration
 	//	*InputValue_Aggregation
 	//	*InputValue_Block
 	SignedData IsInputValueSignedData `protobuf_oneof:"signed_data"`
@@ -75,3 +76,19 @@ func (m *DutyData) GetAttestation() *phase0.Attestation {
 	}
 	return nil
 }
+
+// InputValueValidatorRegistration implementing IsInputValueSignedData
+type InputValueValidatorRegistration struct {
+	ValidatorRegistration *SignedValidatorRegistration
+}
+
+// isInputValueSignedData implementation
+func (*InputValueValidatorRegistration) isInputValueSignedData() {}
+
+// GetValidatorRegistration return cast validator registration input data
+func (m *DutyData) GetValidatorRegistration() *SignedValidatorRegistration {
+	if x, ok := m.GetSignedData().(*InputValueValidatorRegistration); ok {
+		return x.ValidatorRegistration
+	}
+	return nil
+}
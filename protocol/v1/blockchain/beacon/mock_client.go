@@ -6,6 +6,7 @@ package beacon
 
 import (
 	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	bellatrix "github.com/attestantio/go-eth2-client/spec/bellatrix"
 	phase0 "github.com/attestantio/go-eth2-client/spec/phase0"
 	message "github.com/bloxapp/ssv/protocol/v1/message"
 	gomock "github.com/golang/mock/gomock"
@@ -67,6 +68,22 @@ func (mr *MockBeaconMockRecorder) SignAttestation(data, duty, pk interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignAttestation", reflect.TypeOf((*MockBeacon)(nil).SignAttestation), data, duty, pk)
 }
 
+// SignValidatorRegistration mocks base method
+func (m *MockBeacon) SignValidatorRegistration(registration *ValidatorRegistration, pk []byte) ([]byte, []byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignValidatorRegistration", registration, pk)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].([]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SignValidatorRegistration indicates an expected call of SignValidatorRegistration
+func (mr *MockBeaconMockRecorder) SignValidatorRegistration(registration, pk interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignValidatorRegistration", reflect.TypeOf((*MockBeacon)(nil).SignValidatorRegistration), registration, pk)
+}
+
 // AddShare mocks base method
 func (m *MockBeacon) AddShare(shareKey *bls.SecretKey) error {
 	m.ctrl.T.Helper()
@@ -189,6 +206,34 @@ func (mr *MockBeaconMockRecorder) SubscribeToCommitteeSubnet(subscription interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeToCommitteeSubnet", reflect.TypeOf((*MockBeacon)(nil).SubscribeToCommitteeSubnet), subscription)
 }
 
+// SubmitProposalPreparation mocks base method
+func (m *MockBeacon) SubmitProposalPreparation(feeRecipients map[phase0.ValidatorIndex]bellatrix.ExecutionAddress) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitProposalPreparation", feeRecipients)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SubmitProposalPreparation indicates an expected call of SubmitProposalPreparation
+func (mr *MockBeaconMockRecorder) SubmitProposalPreparation(feeRecipients interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitProposalPreparation", reflect.TypeOf((*MockBeacon)(nil).SubmitProposalPreparation), feeRecipients)
+}
+
+// SubmitValidatorRegistration mocks base method
+func (m *MockBeacon) SubmitValidatorRegistration(registration *SignedValidatorRegistration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitValidatorRegistration", registration)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SubmitValidatorRegistration indicates an expected call of SubmitValidatorRegistration
+func (mr *MockBeaconMockRecorder) SubmitValidatorRegistration(registration interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitValidatorRegistration", reflect.TypeOf((*MockBeacon)(nil).SubmitValidatorRegistration), registration)
+}
+
 // MockKeyManager is a mock of KeyManager interface
 type MockKeyManager struct {
 	ctrl     *gomock.Controller
@@ -243,6 +288,22 @@ func (mr *MockKeyManagerMockRecorder) SignAttestation(data, duty, pk interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignAttestation", reflect.TypeOf((*MockKeyManager)(nil).SignAttestation), data, duty, pk)
 }
 
+// SignValidatorRegistration mocks base method
+func (m *MockKeyManager) SignValidatorRegistration(registration *ValidatorRegistration, pk []byte) ([]byte, []byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignValidatorRegistration", registration, pk)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].([]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SignValidatorRegistration indicates an expected call of SignValidatorRegistration
+func (mr *MockKeyManagerMockRecorder) SignValidatorRegistration(registration, pk interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignValidatorRegistration", reflect.TypeOf((*MockKeyManager)(nil).SignValidatorRegistration), registration, pk)
+}
+
 // AddShare mocks base method
 func (m *MockKeyManager) AddShare(shareKey *bls.SecretKey) error {
 	m.ctrl.T.Helper()
@@ -257,6 +318,20 @@ func (mr *MockKeyManagerMockRecorder) AddShare(shareKey interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddShare", reflect.TypeOf((*MockKeyManager)(nil).AddShare), shareKey)
 }
 
+// RemoveShare mocks base method
+func (m *MockKeyManager) RemoveShare(pubKey string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveShare", pubKey)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveShare indicates an expected call of RemoveShare
+func (mr *MockKeyManagerMockRecorder) RemoveShare(pubKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveShare", reflect.TypeOf((*MockKeyManager)(nil).RemoveShare), pubKey)
+}
+
 // MockSigner is a mock of Signer interface
 type MockSigner struct {
 	ctrl     *gomock.Controller
@@ -311,6 +386,22 @@ func (mr *MockSignerMockRecorder) SignAttestation(data, duty, pk interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignAttestation", reflect.TypeOf((*MockSigner)(nil).SignAttestation), data, duty, pk)
 }
 
+// SignValidatorRegistration mocks base method
+func (m *MockSigner) SignValidatorRegistration(registration *ValidatorRegistration, pk []byte) ([]byte, []byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignValidatorRegistration", registration, pk)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].([]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SignValidatorRegistration indicates an expected call of SignValidatorRegistration
+func (mr *MockSignerMockRecorder) SignValidatorRegistration(registration, pk interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignValidatorRegistration", reflect.TypeOf((*MockSigner)(nil).SignValidatorRegistration), registration, pk)
+}
+
 // MockSigningUtil is a mock of SigningUtil interface
 type MockSigningUtil struct {
 	ctrl     *gomock.Controller
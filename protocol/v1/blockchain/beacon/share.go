@@ -8,6 +8,7 @@ import (
 	"github.com/bloxapp/ssv/protocol/v1/message"
 	"math"
 
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
 	"github.com/herumi/bls-eth-go-binary/bls"
 	"github.com/pkg/errors"
 )
@@ -39,17 +40,22 @@ type Share struct {
 	OwnerAddress string
 	Operators    [][]byte
 	Liquidated   bool
+	FeeRecipient string
+	// EncryptedKeys are the share keys as emitted by the contract, encrypted with the key of each operator (aligned with Operators)
+	EncryptedKeys [][]byte
 }
 
 //  serializedShare struct
 type serializedShare struct {
-	NodeID       message.OperatorID
-	ShareKey     []byte
-	Committee    map[message.OperatorID]*Node
-	Metadata     *ValidatorMetadata // pointer in order to support nil
-	OwnerAddress string
-	Operators    [][]byte
-	Liquidated   bool
+	NodeID        message.OperatorID
+	ShareKey      []byte
+	Committee     map[message.OperatorID]*Node
+	Metadata      *ValidatorMetadata // pointer in order to support nil
+	OwnerAddress  string
+	Operators     [][]byte
+	Liquidated    bool
+	FeeRecipient  string
+	EncryptedKeys [][]byte
 }
 
 // IsOperatorShare checks whether the share belongs to operator
@@ -141,12 +147,14 @@ func (s *Share) VerifySignedMessage(msg *message.SignedMessage, forkVersion stri
 // Serialize share to []byte
 func (s *Share) Serialize() ([]byte, error) {
 	value := serializedShare{
-		NodeID:       s.NodeID,
-		Committee:    map[message.OperatorID]*Node{},
-		Metadata:     s.Metadata,
-		OwnerAddress: s.OwnerAddress,
-		Operators:    s.Operators,
-		Liquidated:   s.Liquidated,
+		NodeID:        s.NodeID,
+		Committee:     map[message.OperatorID]*Node{},
+		Metadata:      s.Metadata,
+		OwnerAddress:  s.OwnerAddress,
+		Operators:     s.Operators,
+		Liquidated:    s.Liquidated,
+		FeeRecipient:  s.FeeRecipient,
+		EncryptedKeys: s.EncryptedKeys,
 	}
 	// copy committee by value
 	for k, n := range s.Committee {
@@ -182,13 +190,15 @@ func (s *Share) Deserialize(key []byte, val []byte) (*Share, error) {
 		return nil, errors.Wrap(err, "Failed to get pubkey")
 	}
 	return &Share{
-		NodeID:       value.NodeID,
-		PublicKey:    pubKey,
-		Committee:    value.Committee,
-		Metadata:     value.Metadata,
-		OwnerAddress: value.OwnerAddress,
-		Operators:    value.Operators,
-		Liquidated:   value.Liquidated,
+		NodeID:        value.NodeID,
+		PublicKey:     pubKey,
+		Committee:     value.Committee,
+		Metadata:      value.Metadata,
+		OwnerAddress:  value.OwnerAddress,
+		Operators:     value.Operators,
+		Liquidated:    value.Liquidated,
+		FeeRecipient:  value.FeeRecipient,
+		EncryptedKeys: value.EncryptedKeys,
 	}, nil
 }
 
@@ -208,6 +218,20 @@ func (s *Share) SetOperators(ops [][]byte) {
 	copy(s.Operators, ops)
 }
 
+// FeeRecipientAddress returns the execution address that should receive the validator's fees,
+// the owner address is used in case no fee recipient was set for the share
+func (s *Share) FeeRecipientAddress() (bellatrix.ExecutionAddress, error) {
+	addr := s.FeeRecipient
+	if len(addr) == 0 {
+		addr = s.OwnerAddress
+	}
+	res := bellatrix.ExecutionAddress{}
+	if err := decodeHexInto(res[:], addr); err != nil {
+		return res, errors.Wrap(err, "invalid fee recipient address")
+	}
+	return res, nil
+}
+
 // HashOperators hash all Operators keys key
 func (s *Share) HashOperators() []string {
 	hashes := make([]string, len(s.Operators))
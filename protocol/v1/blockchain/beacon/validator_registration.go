@@ -0,0 +1,188 @@
+package beacon
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	ssz "github.com/ferranbt/fastssz"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultGasLimit is the gas limit that is used in validator registrations by default
+	DefaultGasLimit uint64 = 30000000
+	// validatorRegistrationSize is the ssz encoded size of ValidatorRegistration
+	validatorRegistrationSize = 84
+)
+
+// DomainApplicationBuilder is the domain type used for builder API signatures (e.g. validator registrations)
+var DomainApplicationBuilder = spec.DomainType{0x00, 0x00, 0x00, 0x01}
+
+// ValidatorRegistration is the message that is sent to the builder network (e.g. mev-boost)
+// in order to register the fee recipient and gas limit of a validator
+type ValidatorRegistration struct {
+	FeeRecipient bellatrix.ExecutionAddress
+	GasLimit     uint64
+	Timestamp    uint64
+	Pubkey       spec.BLSPubKey
+}
+
+// SignedValidatorRegistration is a validator registration with the (reconstructed) validator signature
+type SignedValidatorRegistration struct {
+	Message   *ValidatorRegistration
+	Signature spec.BLSSignature
+}
+
+// ComputeBuilderDomain returns the builder domain, which is computed with the genesis fork version
+// and an empty genesis validators root as defined in the builder specs
+func ComputeBuilderDomain(genesisForkVersion []byte) (spec.Domain, error) {
+	forkData := &spec.ForkData{}
+	copy(forkData.CurrentVersion[:], genesisForkVersion)
+	root, err := forkData.HashTreeRoot()
+	if err != nil {
+		return spec.Domain{}, errors.Wrap(err, "could not compute fork data root")
+	}
+	domain := spec.Domain{}
+	copy(domain[:], DomainApplicationBuilder[:])
+	copy(domain[4:], root[:28])
+	return domain, nil
+}
+
+// MarshalSSZ ssz marshals the ValidatorRegistration object
+func (vr *ValidatorRegistration) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(vr)
+}
+
+// MarshalSSZTo ssz marshals the ValidatorRegistration object to a target array
+func (vr *ValidatorRegistration) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+	dst = append(dst, vr.FeeRecipient[:]...)
+	dst = ssz.MarshalUint64(dst, vr.GasLimit)
+	dst = ssz.MarshalUint64(dst, vr.Timestamp)
+	dst = append(dst, vr.Pubkey[:]...)
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the ValidatorRegistration object
+func (vr *ValidatorRegistration) UnmarshalSSZ(buf []byte) error {
+	if len(buf) != validatorRegistrationSize {
+		return ssz.ErrSize
+	}
+	copy(vr.FeeRecipient[:], buf[0:20])
+	vr.GasLimit = ssz.UnmarshallUint64(buf[20:28])
+	vr.Timestamp = ssz.UnmarshallUint64(buf[28:36])
+	copy(vr.Pubkey[:], buf[36:84])
+	return nil
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the ValidatorRegistration object
+func (vr *ValidatorRegistration) SizeSSZ() int {
+	return validatorRegistrationSize
+}
+
+// HashTreeRoot ssz hashes the ValidatorRegistration object
+func (vr *ValidatorRegistration) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(vr)
+}
+
+// HashTreeRootWith ssz hashes the ValidatorRegistration object with a hasher
+func (vr *ValidatorRegistration) HashTreeRootWith(hh *ssz.Hasher) error {
+	indx := hh.Index()
+	hh.PutBytes(vr.FeeRecipient[:])
+	hh.PutUint64(vr.GasLimit)
+	hh.PutUint64(vr.Timestamp)
+	hh.PutBytes(vr.Pubkey[:])
+	hh.Merkleize(indx)
+	return nil
+}
+
+// validatorRegistrationJSON is the builder API representation of ValidatorRegistration
+type validatorRegistrationJSON struct {
+	FeeRecipient string `json:"fee_recipient"`
+	GasLimit     string `json:"gas_limit"`
+	Timestamp    string `json:"timestamp"`
+	Pubkey       string `json:"pubkey"`
+}
+
+// MarshalJSON implements json.Marshaler
+func (vr *ValidatorRegistration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&validatorRegistrationJSON{
+		FeeRecipient: fmt.Sprintf("%#x", vr.FeeRecipient),
+		GasLimit:     strconv.FormatUint(vr.GasLimit, 10),
+		Timestamp:    strconv.FormatUint(vr.Timestamp, 10),
+		Pubkey:       fmt.Sprintf("%#x", vr.Pubkey),
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (vr *ValidatorRegistration) UnmarshalJSON(input []byte) error {
+	var data validatorRegistrationJSON
+	if err := json.Unmarshal(input, &data); err != nil {
+		return errors.Wrap(err, "invalid JSON")
+	}
+	if err := decodeHexInto(vr.FeeRecipient[:], data.FeeRecipient); err != nil {
+		return errors.Wrap(err, "invalid fee recipient")
+	}
+	gasLimit, err := strconv.ParseUint(data.GasLimit, 10, 64)
+	if err != nil {
+		return errors.Wrap(err, "invalid gas limit")
+	}
+	vr.GasLimit = gasLimit
+	timestamp, err := strconv.ParseUint(data.Timestamp, 10, 64)
+	if err != nil {
+		return errors.Wrap(err, "invalid timestamp")
+	}
+	vr.Timestamp = timestamp
+	if err := decodeHexInto(vr.Pubkey[:], data.Pubkey); err != nil {
+		return errors.Wrap(err, "invalid public key")
+	}
+	return nil
+}
+
+// signedValidatorRegistrationJSON is the builder API representation of SignedValidatorRegistration
+type signedValidatorRegistrationJSON struct {
+	Message   *ValidatorRegistration `json:"message"`
+	Signature string                 `json:"signature"`
+}
+
+// MarshalJSON implements json.Marshaler
+func (svr *SignedValidatorRegistration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&signedValidatorRegistrationJSON{
+		Message:   svr.Message,
+		Signature: fmt.Sprintf("%#x", svr.Signature),
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (svr *SignedValidatorRegistration) UnmarshalJSON(input []byte) error {
+	var data signedValidatorRegistrationJSON
+	if err := json.Unmarshal(input, &data); err != nil {
+		return errors.Wrap(err, "invalid JSON")
+	}
+	if data.Message == nil {
+		return errors.New("message missing")
+	}
+	svr.Message = data.Message
+	if err := decodeHexInto(svr.Signature[:], data.Signature); err != nil {
+		return errors.Wrap(err, "invalid signature")
+	}
+	return nil
+}
+
+// decodeHexInto decodes the given 0x prefixed hex string into a fixed size target
+func decodeHexInto(target []byte, val string) error {
+	b, err := hex.DecodeString(strings.TrimPrefix(val, "0x"))
+	if err != nil {
+		return err
+	}
+	if len(b) != len(target) {
+		return errors.Errorf("incorrect length %d, expected %d", len(b), len(target))
+	}
+	copy(target, b)
+	return nil
+}
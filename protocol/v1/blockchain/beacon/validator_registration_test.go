@@ -0,0 +1,50 @@
+package beacon
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeBuilderDomain(t *testing.T) {
+	// mainnet builder domain, as used by mev-boost
+	domain, err := ComputeBuilderDomain([]byte{0, 0, 0, 0})
+	require.NoError(t, err)
+	require.Equal(t, "00000001f5a5fd42d16a20302798ef6ed309979b43003d2320d9f0e8ea9831a9", hex.EncodeToString(domain[:]))
+}
+
+func TestValidatorRegistration_Encoding(t *testing.T) {
+	registration := &ValidatorRegistration{
+		GasLimit:  DefaultGasLimit,
+		Timestamp: 1606824023,
+	}
+	copy(registration.FeeRecipient[:], []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20})
+	registration.Pubkey[0] = 0xaa
+
+	t.Run("ssz", func(t *testing.T) {
+		data, err := registration.MarshalSSZ()
+		require.NoError(t, err)
+		require.Len(t, data, registration.SizeSSZ())
+
+		decoded := &ValidatorRegistration{}
+		require.NoError(t, decoded.UnmarshalSSZ(data))
+		require.Equal(t, registration, decoded)
+		require.Error(t, decoded.UnmarshalSSZ(data[1:]))
+	})
+
+	t.Run("json", func(t *testing.T) {
+		signed := &SignedValidatorRegistration{Message: registration}
+		signed.Signature[0] = 0xbb
+
+		data, err := json.Marshal(signed)
+		require.NoError(t, err)
+		require.Contains(t, string(data), `"fee_recipient":"0x0102030405060708090a0b0c0d0e0f1011121314"`)
+		require.Contains(t, string(data), `"gas_limit":"30000000"`)
+
+		decoded := &SignedValidatorRegistration{}
+		require.NoError(t, json.Unmarshal(data, decoded))
+		require.Equal(t, signed, decoded)
+	})
+}
@@ -0,0 +1,229 @@
+//go:build go1.18
+// +build go1.18
+
+package message
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
+)
+
+// the fuzz targets below run the seed corpus as part of go test, use -fuzz to fuzz a single target:
+//	go test ./protocol/v1/message -run=XXX -fuzz=FuzzSignedMessageDecode -fuzztime=1m
+
+func FuzzSSVMessageDecode(f *testing.F) {
+	for _, msg := range seedSSVMessages(f) {
+		raw, err := msg.Encode()
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(raw)
+	}
+	f.Add([]byte(`{"type":"00","id":"0102"}`))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		msg := &SSVMessage{}
+		if err := msg.Decode(data); err != nil {
+			return
+		}
+		id := msg.GetIdentifier()
+		_ = id.GetRoleType().String()
+		_ = id.GetValidatorPK()
+		_ = id.String()
+		_ = msg.GetType().String()
+		if _, err := msg.Encode(); err != nil {
+			t.Fatalf("could not encode decoded message: %v", err)
+		}
+	})
+}
+
+func FuzzSignedMessageDecode(f *testing.F) {
+	for _, msg := range seedSignedMessages(f) {
+		raw, err := msg.Encode()
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(raw)
+	}
+	f.Add([]byte(`{"Signature":"c2ln","Signers":[1],"Message":null}`))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		msg := &SignedMessage{}
+		if err := msg.Decode(data); err != nil {
+			return
+		}
+		fuzzSignedMessage(msg)
+		if _, err := msg.Encode(); err != nil {
+			t.Fatalf("could not encode decoded message: %v", err)
+		}
+	})
+}
+
+func FuzzConsensusMessageData(f *testing.F) {
+	for _, msg := range seedSignedMessages(f) {
+		f.Add(uint8(msg.Message.MsgType), []byte(msg.Message.Identifier), msg.Message.Data)
+	}
+
+	f.Fuzz(func(t *testing.T, msgType uint8, identifier []byte, data []byte) {
+		msg := &ConsensusMessage{
+			MsgType:    ConsensusMessageType(msgType),
+			Height:     1,
+			Round:      1,
+			Identifier: identifier,
+			Data:       data,
+		}
+		fuzzConsensusMessage(msg)
+	})
+}
+
+func FuzzSyncMessageDecode(f *testing.F) {
+	signed := seedSignedMessages(f)
+	identifier := signed[0].Message.Identifier
+	for _, msg := range []*SyncMessage{
+		{Protocol: LastDecidedType, Params: &SyncParams{Identifier: identifier}},
+		{Protocol: LastDecidedType, Params: &SyncParams{Identifier: identifier}, Data: signed[2:3], Status: StatusSuccess},
+		{Protocol: DecidedHistoryType, Params: &SyncParams{Height: []Height{1, 5}, Identifier: identifier}, Data: signed, Status: StatusSuccess, LowestHeight: 1},
+		{Protocol: LastChangeRoundType, Params: &SyncParams{Height: []Height{1}, Identifier: identifier}, Status: StatusNotFound},
+	} {
+		raw, err := msg.Encode()
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(raw)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		msg := &SyncMessage{}
+		if err := msg.Decode(data); err != nil {
+			return
+		}
+		_ = msg.Status.String()
+		if msg.Params != nil {
+			_ = msg.Params.Identifier.GetValidatorPK()
+			_ = msg.Params.Identifier.GetRoleType()
+		}
+		for _, signed := range msg.Data {
+			if signed != nil {
+				fuzzSignedMessage(signed)
+			}
+		}
+	})
+}
+
+// fuzzSignedMessage calls the functions that are used on received messages
+func fuzzSignedMessage(msg *SignedMessage) {
+	_ = msg.GetSigners()
+	_ = msg.GetSignature()
+	if msg.Message == nil {
+		return
+	}
+	_ = msg.DeepCopy()
+	fuzzConsensusMessage(msg.Message)
+}
+
+func fuzzConsensusMessage(msg *ConsensusMessage) {
+	_ = msg.MsgType.String()
+	_ = msg.Identifier.GetValidatorPK()
+	_ = msg.Identifier.GetRoleType()
+	_, _ = msg.GetRoot(forksprotocol.V0ForkVersion.String())
+	_, _ = msg.GetRoot(forksprotocol.V1ForkVersion.String())
+
+	switch msg.MsgType {
+	case ProposalMsgType:
+		_, _ = msg.GetProposalData()
+	case PrepareMsgType:
+		_, _ = msg.GetPrepareData()
+	case CommitMsgType:
+		_, _ = msg.GetCommitData()
+	case RoundChangeMsgType:
+		if data, err := msg.GetRoundChangeData(); err == nil {
+			_ = data.GetPreparedValue()
+			_ = data.GetPreparedRound()
+			_ = data.GetRoundChangeJustification()
+		}
+	}
+}
+
+// seedSignedMessages returns a message of each type, based on the messages of the other tests
+func seedSignedMessages(tb testing.TB) []*SignedMessage {
+	pk, err := hex.DecodeString("b768cdc2b2e0a859052bf04d1cd66383c96d95096a5287d08151494ce709556ba39c1300fbb902a0e2ebb7c31dc4e400")
+	if err != nil {
+		tb.Fatal(err)
+	}
+	identifier := NewIdentifier(pk, RoleTypeAttester)
+	value := []byte("value")
+
+	encode := func(data interface{ Encode() ([]byte, error) }) []byte {
+		raw, err := data.Encode()
+		if err != nil {
+			tb.Fatal(err)
+		}
+		return raw
+	}
+	signed := func(msgType ConsensusMessageType, round Round, data []byte, signers ...OperatorID) *SignedMessage {
+		return &SignedMessage{
+			Signature: []byte("sVV0fsvqQlqliKvN"),
+			Signers:   signers,
+			Message: &ConsensusMessage{
+				MsgType:    msgType,
+				Height:     1,
+				Round:      round,
+				Identifier: identifier,
+				Data:       data,
+			},
+		}
+	}
+
+	prepared := signed(PrepareMsgType, 1, encode(&PrepareData{Data: value}), 1, 2, 3)
+	roundChange := signed(RoundChangeMsgType, 2, encode(&RoundChangeData{
+		PreparedValue:            value,
+		Round:                    1,
+		RoundChangeJustification: []*SignedMessage{prepared},
+	}), 1)
+	return []*SignedMessage{
+		signed(ProposalMsgType, 1, encode(&ProposalData{Data: value}), 1),
+		signed(PrepareMsgType, 1, encode(&PrepareData{Data: value}), 1),
+		signed(CommitMsgType, 1, encode(&CommitData{Data: value}), 1, 3, 4),
+		signed(RoundChangeMsgType, 2, encode(&RoundChangeData{}), 2),
+		roundChange,
+		signed(ProposalMsgType, 2, encode(&ProposalData{
+			Data:                     value,
+			RoundChangeJustification: []*SignedMessage{roundChange},
+			PrepareJustification:     []*SignedMessage{prepared},
+		}), 1),
+	}
+}
+
+func seedSSVMessages(tb testing.TB) []*SSVMessage {
+	signed := seedSignedMessages(tb)
+	identifier := signed[0].Message.Identifier
+	msgs := []*SSVMessage{
+		{MsgType: SSVConsensusMsgType, ID: identifier, Data: []byte(fmt.Sprintf(`{
+	  "message": {
+		"type": 3,
+		"round": 2,
+		"identifier": "%s",
+		"height": 1,
+		"value": "bk0iAAAAAAACAAAAA"
+	  },
+	  "signature": "sVV0fsvqQlqliKvN",
+	  "signers": [1,3,4]
+	}`, identifier))},
+		{MsgType: SSVPostConsensusMsgType, ID: identifier, Data: []byte("data"), TraceContext: []byte{0, 0, 1, 2, 3}},
+	}
+	for i, s := range signed {
+		raw, err := s.Encode()
+		if err != nil {
+			tb.Fatal(err)
+		}
+		msgType := SSVConsensusMsgType
+		if i == 2 {
+			msgType = SSVDecidedMsgType
+		}
+		msgs = append(msgs, &SSVMessage{MsgType: msgType, ID: identifier, Data: raw})
+	}
+	return msgs
+}
@@ -43,6 +43,9 @@ type ValidatorPK []byte
 
 // MessageIDBelongs returns true if message ID belongs to validator
 func (vid ValidatorPK) MessageIDBelongs(msgID Identifier) bool {
+	if len(msgID) < len(vid) {
+		return false
+	}
 	toMatch := msgID[:len(vid)]
 	return bytes.Equal(vid, toMatch)
 }
@@ -50,30 +53,33 @@ func (vid ValidatorPK) MessageIDBelongs(msgID Identifier) bool {
 // Identifier is used to identify and route messages to the right validator and DutyRunner
 type Identifier []byte
 
+// roleTypeSize is the size of the role type suffix of an identifier
+const roleTypeSize = 4
+
 // NewIdentifier creates a new Identifier. expect pk hex sting as byte[]
 func NewIdentifier(pk []byte, role RoleType) Identifier {
-	roleByts := make([]byte, 4)
+	roleByts := make([]byte, roleTypeSize)
 	binary.LittleEndian.PutUint32(roleByts, uint32(role))
 	id := make([]byte, len(pk))
 	copy(id, pk)
 	return append(id, roleByts...)
 }
 
-// GetRoleType extracts the role type from the id
+// GetRoleType extracts the role type from the id, identifiers that are too short have an unknown role
 func (msgID Identifier) GetRoleType() RoleType {
-	if len(msgID) == 0 {
+	if len(msgID) < roleTypeSize {
 		return RoleTypeUnknown
 	}
-	roleByts := msgID[len(msgID)-4:]
+	roleByts := msgID[len(msgID)-roleTypeSize:]
 	return RoleType(binary.LittleEndian.Uint32(roleByts))
 }
 
-// GetValidatorPK extracts the validator public key from the id
+// GetValidatorPK extracts the validator public key from the id, identifiers that are too short have an empty public key
 func (msgID Identifier) GetValidatorPK() ValidatorPK {
-	if len(msgID) == 0 {
+	if len(msgID) < roleTypeSize {
 		return []byte{}
 	}
-	vpk := msgID[:len(msgID)-4]
+	vpk := msgID[:len(msgID)-roleTypeSize]
 	return ValidatorPK(vpk)
 }
 
@@ -87,6 +93,8 @@ type SSVMessage struct {
 	MsgType MsgType
 	ID      Identifier
 	Data    []byte
+	// TraceContext is the (binary encoded) span context of the sender, used to correlate the traces of operators
+	TraceContext []byte
 	//Version string
 }
 
@@ -139,6 +147,10 @@ func (msg *SSVMessage) MarshalJSON() ([]byte, error) {
 		}
 		m["Data"] = hex.EncodeToString(data)
 	}
+
+	if len(msg.TraceContext) > 0 {
+		m["trace"] = hex.EncodeToString(msg.TraceContext)
+	}
 	return json.Marshal(m)
 }
 
@@ -176,5 +188,13 @@ func (msg *SSVMessage) UnmarshalJSON(data []byte) error {
 			return errors.Wrap(err, "could not unmarshal Data")
 		}
 	}
+
+	if val, ok := m["trace"]; ok {
+		tc, err := hex.DecodeString(val)
+		if err != nil {
+			return errors.Wrap(err, "trace context decode string failed")
+		}
+		msg.TraceContext = tc
+	}
 	return nil
 }
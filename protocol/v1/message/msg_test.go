@@ -38,3 +38,27 @@ func TestSSVMessage_JSONEncoding(t *testing.T) {
 	require.NoError(t, decoded.Decode(encoded))
 	require.True(t, bytes.Equal(msg.GetIdentifier(), decoded.GetIdentifier()))
 }
+
+func TestSSVMessage_TraceContext(t *testing.T) {
+	id := NewIdentifier([]byte{1, 2, 3, 4}, RoleTypeAttester)
+	msg := SSVMessage{
+		MsgType: SSVPostConsensusMsgType,
+		ID:      id,
+		Data:    []byte("data"),
+	}
+
+	encoded, err := msg.Encode()
+	require.NoError(t, err)
+	require.NotContains(t, string(encoded), "trace")
+	decoded := SSVMessage{}
+	require.NoError(t, decoded.Decode(encoded))
+	require.Nil(t, decoded.TraceContext)
+
+	msg.TraceContext = []byte{0, 0, 1, 2, 3}
+	encoded, err = msg.Encode()
+	require.NoError(t, err)
+	decoded = SSVMessage{}
+	require.NoError(t, decoded.Decode(encoded))
+	require.Equal(t, msg.TraceContext, decoded.TraceContext)
+	require.Equal(t, msg.Data, decoded.Data)
+}
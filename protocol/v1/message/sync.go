@@ -79,6 +79,9 @@ type SyncMessage struct {
 	Data []*SignedMessage
 	// Status is the status code of the operation
 	Status StatusCode
+	// LowestHeight is the lowest decided height that is available on the responding peer,
+	// it is set by peers that pruned their decided history and therefore won't serve lower heights
+	LowestHeight Height `json:",omitempty"`
 }
 
 // Encode encodes the message
@@ -14,6 +14,8 @@ func (r RoleType) String() string {
 		return "AGGREGATOR"
 	case RoleTypeProposer:
 		return "PROPOSER"
+	case RoleTypeValidatorRegistration:
+		return "VALIDATOR_REGISTRATION"
 	default:
 		return "UNDEFINED"
 	}
@@ -30,15 +32,24 @@ func RoleTypeFromString(rt string) RoleType {
 		return RoleTypeAggregator
 	case "PROPOSER":
 		return RoleTypeProposer
+	case "VALIDATOR_REGISTRATION":
+		return RoleTypeValidatorRegistration
 	default:
 		return RoleTypeUnknown
 	}
 }
 
+// HasConsensus returns true if the role's duties are decided with a QBFT instance,
+// otherwise the duty data is deterministic and only partial signatures are exchanged
+func (r RoleType) HasConsensus() bool {
+	return r != RoleTypeValidatorRegistration
+}
+
 // List of roles
 const (
 	RoleTypeUnknown RoleType = iota
 	RoleTypeAttester
 	RoleTypeAggregator
 	RoleTypeProposer
+	RoleTypeValidatorRegistration
 )
@@ -0,0 +1,138 @@
+package qbft
+
+import (
+	"math"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/bloxapp/ssv/protocol/v1/message"
+)
+
+// schedules of round timeouts
+const (
+	// TimeoutScheduleExponential sets the timeout of round r to RoundChangeDurationSeconds^r,
+	// capped by MaxRoundTimeoutSeconds (if set)
+	TimeoutScheduleExponential = "exponential"
+	// TimeoutScheduleTwoPhase sets the timeout of the first QuickRounds rounds to RoundChangeDurationSeconds,
+	// and the timeout of the following rounds to SlowRoundTimeoutSeconds
+	TimeoutScheduleTwoPhase = "two-phase"
+)
+
+// slot timing of the beacon chain, used to derive the default duty deadlines
+const (
+	slotDuration  = 12 * time.Second
+	slotsPerEpoch = 32
+)
+
+// InstanceConfig is the configuration of the instance
+type InstanceConfig struct {
+	RoundChangeDurationSeconds float32 `yaml:"RoundChangeDurationSeconds"`
+	// LeaderPreprepareDelaySeconds is the delay of the leader before broadcasting the proposal of round 1, 0 (default) means no delay
+	LeaderPreprepareDelaySeconds float32 `yaml:"LeaderPreprepareDelaySeconds"`
+	// TimeoutSchedule is the schedule of round timeouts, exponential (default) or two-phase
+	TimeoutSchedule string `yaml:"TimeoutSchedule"`
+	// MaxRoundTimeoutSeconds caps the timeouts of the exponential schedule, 0 means no cap
+	MaxRoundTimeoutSeconds float32 `yaml:"MaxRoundTimeoutSeconds"`
+	// QuickRounds is the number of rounds with a quick timeout in the two-phase schedule
+	QuickRounds uint64 `yaml:"QuickRounds"`
+	// SlowRoundTimeoutSeconds is the timeout of the rounds that follow the quick rounds in the two-phase schedule
+	SlowRoundTimeoutSeconds float32 `yaml:"SlowRoundTimeoutSeconds"`
+	// DutyDeadline is the time since the start of the duty's slot after which the duty is useless,
+	// instances that didn't decide until then are aborted. 0 means no deadline,
+	// configs of roles are set to the deadline of the role (see DefaultDutyDeadline) if missing
+	DutyDeadline time.Duration `yaml:"DutyDeadline"`
+}
+
+//DefaultConsensusParams returns the default round change duration time
+func DefaultConsensusParams() *InstanceConfig {
+	return &InstanceConfig{
+		RoundChangeDurationSeconds: 3,
+		TimeoutSchedule:            TimeoutScheduleExponential,
+	}
+}
+
+// DefaultRoleConsensusParams returns the default config of the given role, which includes the duty deadline of the role
+func DefaultRoleConsensusParams(role message.RoleType) *InstanceConfig {
+	c := DefaultConsensusParams()
+	c.DutyDeadline = DefaultDutyDeadline(role)
+	return c
+}
+
+// DefaultDutyDeadline returns the time since the start of the slot after which a duty of the given role is useless:
+// attestations, aggregations and blocks have to land within their slot, registrations are submitted once per epoch.
+// 0 is returned for unknown roles (no deadline)
+func DefaultDutyDeadline(role message.RoleType) time.Duration {
+	switch role {
+	case message.RoleTypeAttester, message.RoleTypeAggregator, message.RoleTypeProposer:
+		return slotDuration
+	case message.RoleTypeValidatorRegistration:
+		return slotsPerEpoch * slotDuration
+	default:
+		return 0
+	}
+}
+
+// WithRoleDefaults returns a copy of the config where missing fields are set to the defaults of the given role
+func (c InstanceConfig) WithRoleDefaults(role message.RoleType) *InstanceConfig {
+	cfg := c.WithDefaults()
+	if cfg.DutyDeadline == 0 {
+		cfg.DutyDeadline = DefaultDutyDeadline(role)
+	}
+	return cfg
+}
+
+// WithDefaults returns a copy of the config where the round change duration and the schedule are set to the defaults if missing
+func (c InstanceConfig) WithDefaults() *InstanceConfig {
+	defaults := DefaultConsensusParams()
+	if c.RoundChangeDurationSeconds == 0 {
+		c.RoundChangeDurationSeconds = defaults.RoundChangeDurationSeconds
+	}
+	if len(c.TimeoutSchedule) == 0 {
+		c.TimeoutSchedule = defaults.TimeoutSchedule
+	}
+	return &c
+}
+
+// Validate returns an error if the config is invalid
+func (c *InstanceConfig) Validate() error {
+	if c.RoundChangeDurationSeconds <= 0 {
+		return errors.New("round change duration must be positive")
+	}
+	if c.LeaderPreprepareDelaySeconds < 0 || c.MaxRoundTimeoutSeconds < 0 || c.DutyDeadline < 0 {
+		return errors.New("timeouts must not be negative")
+	}
+	switch c.TimeoutSchedule {
+	case "", TimeoutScheduleExponential:
+	case TimeoutScheduleTwoPhase:
+		if c.SlowRoundTimeoutSeconds <= 0 {
+			return errors.New("slow round timeout must be positive in two-phase schedule")
+		}
+	default:
+		return errors.Errorf("unknown timeout schedule %q", c.TimeoutSchedule)
+	}
+	return nil
+}
+
+// RoundTimeout returns the timeout of the given round according to the schedule
+func (c *InstanceConfig) RoundTimeout(round message.Round) time.Duration {
+	var seconds float64
+	switch c.TimeoutSchedule {
+	case TimeoutScheduleTwoPhase:
+		seconds = float64(c.RoundChangeDurationSeconds)
+		if uint64(round) > c.QuickRounds {
+			seconds = float64(c.SlowRoundTimeoutSeconds)
+		}
+	default:
+		seconds = math.Pow(float64(c.RoundChangeDurationSeconds), float64(round))
+		if c.MaxRoundTimeoutSeconds > 0 {
+			seconds = math.Min(seconds, float64(c.MaxRoundTimeoutSeconds))
+		}
+	}
+	return time.Duration(float64(time.Second) * seconds)
+}
+
+// LeaderPreprepareDelay returns the delay of the leader before broadcasting the proposal of round 1
+func (c *InstanceConfig) LeaderPreprepareDelay() time.Duration {
+	return time.Duration(float64(time.Second) * float64(c.LeaderPreprepareDelaySeconds))
+}
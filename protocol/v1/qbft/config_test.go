@@ -0,0 +1,70 @@
+package qbft
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/protocol/v1/message"
+)
+
+func TestInstanceConfig_RoundTimeout(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   InstanceConfig
+		expected []time.Duration
+	}{
+		{
+			"default",
+			*DefaultConsensusParams(),
+			[]time.Duration{3 * time.Second, 9 * time.Second, 27 * time.Second, 81 * time.Second},
+		},
+		{
+			"exponential with cap",
+			InstanceConfig{RoundChangeDurationSeconds: 2, TimeoutSchedule: TimeoutScheduleExponential, MaxRoundTimeoutSeconds: 10},
+			[]time.Duration{2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second},
+		},
+		{
+			"two-phase",
+			InstanceConfig{RoundChangeDurationSeconds: 2, TimeoutSchedule: TimeoutScheduleTwoPhase, QuickRounds: 2, SlowRoundTimeoutSeconds: 30},
+			[]time.Duration{2 * time.Second, 2 * time.Second, 30 * time.Second, 30 * time.Second},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.NoError(t, test.config.Validate())
+			for i, expected := range test.expected {
+				require.Equal(t, expected, test.config.RoundTimeout(message.Round(i+1)), "round %d", i+1)
+			}
+		})
+	}
+}
+
+func TestInstanceConfig_Validate(t *testing.T) {
+	require.NoError(t, InstanceConfig{}.WithDefaults().Validate())
+	require.EqualError(t, InstanceConfig{RoundChangeDurationSeconds: 2, TimeoutSchedule: "linear"}.WithDefaults().Validate(),
+		`unknown timeout schedule "linear"`)
+	require.EqualError(t, InstanceConfig{TimeoutSchedule: TimeoutScheduleTwoPhase}.WithDefaults().Validate(),
+		"slow round timeout must be positive in two-phase schedule")
+	require.EqualError(t, InstanceConfig{DutyDeadline: -time.Second}.WithDefaults().Validate(),
+		"timeouts must not be negative")
+}
+
+func TestInstanceConfig_WithDefaults(t *testing.T) {
+	cfg := InstanceConfig{LeaderPreprepareDelaySeconds: 0.5, DutyDeadline: 12 * time.Second}.WithDefaults()
+	require.Equal(t, float32(3), cfg.RoundChangeDurationSeconds)
+	require.Equal(t, TimeoutScheduleExponential, cfg.TimeoutSchedule)
+	require.Equal(t, 500*time.Millisecond, cfg.LeaderPreprepareDelay())
+	require.Equal(t, 12*time.Second, cfg.DutyDeadline)
+}
+
+func TestInstanceConfig_WithRoleDefaults(t *testing.T) {
+	// missing deadlines are derived from the slot timing of the role
+	require.Equal(t, 12*time.Second, InstanceConfig{}.WithRoleDefaults(message.RoleTypeAttester).DutyDeadline)
+	require.Equal(t, 12*time.Second, InstanceConfig{}.WithRoleDefaults(message.RoleTypeAggregator).DutyDeadline)
+	require.Equal(t, 12*time.Second, InstanceConfig{}.WithRoleDefaults(message.RoleTypeProposer).DutyDeadline)
+	require.Equal(t, 32*12*time.Second, InstanceConfig{}.WithRoleDefaults(message.RoleTypeValidatorRegistration).DutyDeadline)
+	require.Equal(t, 4*time.Second, InstanceConfig{DutyDeadline: 4 * time.Second}.WithRoleDefaults(message.RoleTypeAttester).DutyDeadline)
+	require.Zero(t, DefaultConsensusParams().DutyDeadline)
+}
@@ -1,6 +1,8 @@
 package controller
 
 import (
+	"context"
+
 	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
 	beaconprotocol "github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
 	"github.com/bloxapp/ssv/protocol/v1/message"
@@ -32,8 +34,14 @@ type IController interface {
 	ProcessSignatureMessage(msg *message.SignedPostConsensusMessage) error
 
 	// PostConsensusDutyExecution signs the eth2 duty after iBFT came to consensus and start signature state
-	PostConsensusDutyExecution(logger *zap.Logger, height message.Height, decidedValue []byte, signaturesCount int, duty *beaconprotocol.Duty) error
+	PostConsensusDutyExecution(ctx context.Context, logger *zap.Logger, height message.Height, decidedValue []byte, signaturesCount int, duty *beaconprotocol.Duty) error
 
 	// OnFork called when fork occur.
 	OnFork(forkVersion forksprotocol.ForkVersion) error
+
+	// State returns the current state of the controller (e.g. NotStarted, Ready)
+	State() uint32
+
+	// ResyncDecided syncs the decided history from peers, regardless of the local history
+	ResyncDecided() error
 }
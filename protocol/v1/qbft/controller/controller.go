@@ -7,8 +7,10 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"go.opencensus.io/trace"
 	"go.uber.org/zap"
 
+	"github.com/bloxapp/ssv/monitoring/tracing"
 	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
 	beaconprotocol "github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
 	"github.com/bloxapp/ssv/protocol/v1/message"
@@ -17,15 +19,20 @@ import (
 	"github.com/bloxapp/ssv/protocol/v1/qbft/controller/forks"
 	forksfactory "github.com/bloxapp/ssv/protocol/v1/qbft/controller/forks/factory"
 	"github.com/bloxapp/ssv/protocol/v1/qbft/instance"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/journal"
 	"github.com/bloxapp/ssv/protocol/v1/qbft/msgqueue"
 	qbftstorage "github.com/bloxapp/ssv/protocol/v1/qbft/storage"
 	"github.com/bloxapp/ssv/protocol/v1/qbft/strategy"
 	"github.com/bloxapp/ssv/protocol/v1/qbft/strategy/factory"
+	"github.com/bloxapp/ssv/protocol/v1/queue/worker"
 )
 
 // ErrAlreadyRunning is used to express that some process is already running, e.g. sync
 var ErrAlreadyRunning = errors.New("already running")
 
+// ErrDutyDeadlinePassed is returned when an instance was aborted (or not started) as the deadline of the duty has passed
+var ErrDutyDeadlinePassed = errors.New("duty deadline has passed")
+
 // NewDecidedHandler handles newly saved decided messages.
 // it will be called in a new goroutine to avoid concurrency issues
 type NewDecidedHandler func(msg *message.SignedMessage)
@@ -48,6 +55,11 @@ type Options struct {
 	ReadMode          bool
 	FullNode          bool
 	NewDecidedHandler NewDecidedHandler
+	// Journal records the consensus events of the instances (optional)
+	Journal journal.Journal
+	// Scheduler processes the msg queue on a shared pool of workers (optional),
+	// otherwise the controller runs its own queue consumer
+	Scheduler *worker.Scheduler
 }
 
 // set of states for the controller
@@ -60,6 +72,25 @@ const (
 	Forking
 )
 
+// StateName returns the name of the given controller state
+func StateName(state uint32) string {
+	switch state {
+	case NotStarted:
+		return "not_started"
+	case InitiatedHandlers:
+		return "initiated_handlers"
+	case WaitingForPeers:
+		return "waiting_for_peers"
+	case FoundPeers:
+		return "found_peers"
+	case Ready:
+		return "ready"
+	case Forking:
+		return "forking"
+	}
+	return "unknown"
+}
+
 // Controller implements Controller interface
 type Controller struct {
 	ctx context.Context
@@ -93,10 +124,17 @@ type Controller struct {
 	fullNode bool
 
 	q msgqueue.MsgQueue
+	// stateChanged wakes up the queue consumer when the messages it looks for might change,
+	// e.g. a new instance, a stage change or the end of a fork
+	stateChanged msgqueue.Notifier
+	scheduler    *worker.Scheduler
+	// deadline is the start time (unix nano) of the slot of the current duty, used to prioritize the queue processing
+	deadline int64
 
 	decidedFactory    *factory.Factory
 	decidedStrategy   strategy.Decided
 	newDecidedHandler NewDecidedHandler
+	journal           journal.Journal
 }
 
 // New is the constructor of Controller
@@ -127,6 +165,8 @@ func New(opts Options) IController {
 		forkLock:            &sync.Mutex{},
 
 		newDecidedHandler: opts.NewDecidedHandler,
+		journal:           opts.Journal,
+		scheduler:         opts.Scheduler,
 	}
 
 	if !opts.ReadMode {
@@ -162,6 +202,20 @@ func (c *Controller) setCurrentInstance(instance instance.Instancer) {
 	defer c.currentInstanceLock.Unlock()
 
 	c.currentInstance = instance
+	c.notifyStateChanged()
+}
+
+// notifyStateChanged wakes up the queue consumer as the relevant messages might have changed
+func (c *Controller) notifyStateChanged() {
+	c.stateChanged.Notify()
+	c.schedule()
+}
+
+// schedule queues the controller in the scheduler, if the queue is processed by a shared scheduler
+func (c *Controller) schedule() {
+	if c.scheduler != nil {
+		c.scheduler.Schedule(c.Identifier.String())
+	}
 }
 
 // OnFork called upon fork, it will make sure all decided messages were processed
@@ -169,7 +223,10 @@ func (c *Controller) setCurrentInstance(instance instance.Instancer) {
 // it also recreates the fork instance and decided strategy with the new fork version
 func (c *Controller) OnFork(forkVersion forksprotocol.ForkVersion) error {
 	atomic.StoreUint32(&c.state, Forking)
-	defer atomic.StoreUint32(&c.state, Ready)
+	defer func() {
+		atomic.StoreUint32(&c.state, Ready)
+		c.notifyStateChanged()
+	}()
 
 	if i := c.getCurrentInstance(); i != nil {
 		i.Stop()
@@ -195,13 +252,36 @@ func (c *Controller) syncDecided(from, to *message.SignedMessage) error {
 	return decidedStrategy.Sync(c.ctx, c.Identifier, from, to, fork.ValidateDecidedMsg(c.ValidatorShare))
 }
 
+// ResyncDecided syncs the decided history from peers, regardless of the local history.
+// it can be used to recover a missing or corrupted history
+func (c *Controller) ResyncDecided() error {
+	if ok, err := c.initialized(); !ok {
+		return err
+	}
+	c.logger.Info("resyncing decided history")
+	if err := c.syncDecided(nil, nil); err != nil {
+		return errors.Wrap(err, "could not sync history")
+	}
+	return nil
+}
+
 // Init sets all major processes of iBFT while blocking until completed.
 // if init fails to sync
 func (c *Controller) Init() error {
 	// checks if notStarted. if so, preform init handlers and set state to new state
 	if atomic.CompareAndSwapUint32(&c.state, NotStarted, InitiatedHandlers) {
 		c.logger.Info("start qbft ctrl handler init")
-		go c.startQueueConsumer(c.messageHandler)
+		if c.scheduler != nil {
+			c.scheduler.Register(c.Identifier.String(), &queueTask{ctrl: c, handler: c.messageHandler, identifier: c.Identifier.String()})
+			c.schedule()
+		} else {
+			go c.startQueueConsumer(c.messageHandler)
+		}
+		if !c.Identifier.GetRoleType().HasConsensus() {
+			// no decided history to sync for roles without consensus
+			atomic.StoreUint32(&c.state, Ready)
+			return nil
+		}
 		ReportIBFTStatus(c.ValidatorShare.PublicKey.SerializeToHexStr(), false, false)
 		//c.logger.Debug("managed to setup iBFT handlers")
 	}
@@ -274,12 +354,21 @@ func (c *Controller) StartInstance(opts instance.ControllerStartInstanceOptions)
 		return nil, errors.WithMessage(err, "can't start new iBFT instance")
 	}
 
+	if !opts.Deadline.IsZero() {
+		atomic.StoreInt64(&c.deadline, opts.Deadline.UnixNano())
+	}
+	abortAt := c.abortTime(opts.Deadline)
+	if !abortAt.IsZero() && !time.Now().Before(abortAt) {
+		reportDeadlineAborted(c.Identifier)
+		return nil, ErrDutyDeadlinePassed
+	}
+
 	done := reportIBFTInstanceStart(c.ValidatorShare.PublicKey.SerializeToHexStr())
 
 	c.signatureState.setHeight(opts.SeqNumber)                       // update sig state once height determent
 	instanceOpts.ChangeRoundStore = c.changeRoundStorage             // in order to set the last change round msg
 	instanceOpts.ChangeRoundStore.CleanLastChangeRound(c.Identifier) // clean previews last change round msg's (TODO place in instance?)
-	res, err = c.startInstanceWithOptions(instanceOpts, opts.Value)
+	res, err = c.startInstanceWithOptions(instanceOpts, opts.Value, abortAt)
 	defer func() {
 		done()
 		// report error status if the instance returned error
@@ -292,11 +381,25 @@ func (c *Controller) StartInstance(opts instance.ControllerStartInstanceOptions)
 	return res, err
 }
 
+// abortTime returns the time that an instance of a duty that started at the given time should be aborted,
+// zero time means that the instance is not aborted
+func (c *Controller) abortTime(dutyStart time.Time) time.Time {
+	if dutyStart.IsZero() || c.instanceConfig == nil || c.instanceConfig.DutyDeadline == 0 {
+		return time.Time{}
+	}
+	return dutyStart.Add(c.instanceConfig.DutyDeadline)
+}
+
 // GetIBFTCommittee returns a map of the iBFT committee where the key is the member's id.
 func (c *Controller) GetIBFTCommittee() map[message.OperatorID]*beaconprotocol.Node {
 	return c.ValidatorShare.Committee
 }
 
+// State returns the current state of the controller
+func (c *Controller) State() uint32 {
+	return atomic.LoadUint32(&c.state)
+}
+
 // GetIdentifier returns ibft identifier made of public key and role (type)
 func (c *Controller) GetIdentifier() []byte {
 	return c.Identifier // TODO should use mutex to lock var?
@@ -321,11 +424,21 @@ func (c *Controller) ProcessMsg(msg *message.SSVMessage) error {
 	)
 	c.logger.Debug("got message, add to queue", fields...)
 	c.q.Add(msg)
+	c.schedule()
 	return nil
 }
 
 // messageHandler process message from queue,
-func (c *Controller) messageHandler(msg *message.SSVMessage) error {
+func (c *Controller) messageHandler(msg *message.SSVMessage) (err error) {
+	// messages of traced duties are processed within a child span of the sender's span
+	if len(msg.TraceContext) > 0 {
+		_, span := tracing.StartRemoteSpan(c.ctx, "Controller.messageHandler", msg.TraceContext,
+			trace.StringAttribute("msg_type", msg.GetType().String()),
+			trace.Int64Attribute("operator_id", int64(c.ValidatorShare.NodeID)))
+		defer func() {
+			tracing.EndWithError(span, err)
+		}()
+	}
 	switch msg.GetType() {
 	case message.SSVConsensusMsgType:
 		signedMsg := &message.SignedMessage{}
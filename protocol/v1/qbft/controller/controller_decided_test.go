@@ -642,6 +642,59 @@ func TestValidateDecidedMsg(t *testing.T) {
 
 // TODO: (lint) fix test
 //nolint
+func TestResyncDecided(t *testing.T) {
+	uids := []message.OperatorID{message.OperatorID(1), message.OperatorID(2), message.OperatorID(3), message.OperatorID(4)}
+	sks, nodes := testingprotocol.GenerateBLSKeys(uids...)
+	db, _ := kv.New(basedb.Options{
+		Type:   "badger-memory",
+		Path:   "",
+		Logger: zap.L(),
+	})
+	pi, err := protocolp2p.GenPeerID()
+	require.NoError(t, err)
+
+	identifier := []byte("Identifier_11")
+	decidedMsg := testingprotocol.AggregateSign(t, sks, uids, &message.ConsensusMessage{
+		MsgType:    message.CommitMsgType,
+		Height:     message.Height(10),
+		Round:      message.Round(3),
+		Identifier: identifier,
+		Data:       commitDataToBytes(t, &message.CommitData{Data: []byte("value")}),
+	})
+
+	network := protocolp2p.NewMockNetwork(zap.L(), pi, 10)
+	network.SetLastDecidedHandler(generateLastDecidedHandler(t, identifier, decidedMsg))
+	network.SetGetHistoryHandler(generateGetHistoryHandler(t, sks, uids, identifier, 0, 10))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	network.Start(ctx)
+	network.AddPeers(message.Identifier(identifier).GetValidatorPK(), network)
+
+	s1 := qbftstorage.NewQBFTStore(db, zap.L(), "attestations")
+	i1 := populatedIbft(1, identifier, network, s1, sks, nodes, newTestSigner())
+
+	_ = populatedIbft(2, identifier, network, testingprotocol.PopulatedStorage(t, sks, 3, 10), sks, nodes, newTestSigner())
+
+	i1.(*Controller).state = WaitingForPeers
+	require.EqualError(t, i1.ResyncDecided(), "iBFT hasn't initialized yet")
+
+	i1.(*Controller).state = Ready
+	require.NoError(t, i1.ResyncDecided())
+	highest, err := i1.(*Controller).decidedStrategy.GetLastDecided(identifier)
+	require.NoError(t, err)
+	require.NotNil(t, highest)
+	require.EqualValues(t, 10, highest.Message.Height)
+}
+
+func TestStateName(t *testing.T) {
+	require.Equal(t, "not_started", StateName(NotStarted))
+	require.Equal(t, "ready", StateName(Ready))
+	require.Equal(t, "forking", StateName(Forking))
+	require.Equal(t, "unknown", StateName(100))
+}
+
 func populatedIbft(
 	nodeID message.OperatorID,
 	identifier []byte,
@@ -698,6 +751,10 @@ func (s *testSigner) SignAttestation(data *spec.AttestationData, duty *beaconpro
 	return nil, nil, nil
 }
 
+func (s *testSigner) SignValidatorRegistration(registration *beaconprotocol.ValidatorRegistration, pk []byte) ([]byte, []byte, error) {
+	return nil, nil, nil
+}
+
 func commitDataToBytes(t *testing.T, input *message.CommitData) []byte {
 	ret, err := input.Encode()
 	require.NoError(t, err)
@@ -0,0 +1,25 @@
+package controller
+
+import (
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/protocol/v1/message"
+)
+
+// decidedRounds returns the rounds in which the heights of the given range were decided, as used by the leader selection of some forks.
+// the rounds are taken only from the decided history in storage (which is synced with peers), so all operators use the same rounds
+func (c *Controller) decidedRounds(from, to message.Height) map[message.Height]message.Round {
+	res := make(map[message.Height]message.Round)
+	msgs, err := c.decidedStrategy.GetDecided(c.Identifier, from, to)
+	if err != nil {
+		c.logger.Debug("could not read decided history", zap.Error(err))
+		return res
+	}
+	for _, msg := range msgs {
+		if msg == nil || msg.Message == nil {
+			continue
+		}
+		res[msg.Message.Height] = msg.Message.Round
+	}
+	return res
+}
@@ -2,6 +2,8 @@ package controller
 
 import (
 	"encoding/hex"
+	"time"
+
 	"github.com/bloxapp/ssv/protocol/v1/qbft/msgqueue"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
@@ -13,8 +15,9 @@ import (
 )
 
 // startInstanceWithOptions will start an iBFT instance with the provided options.
+// the instance is stopped once abortAt has passed (unless zero), in such case ErrDutyDeadlinePassed is returned.
 // Does not pre-check instance validity and start validity!
-func (c *Controller) startInstanceWithOptions(instanceOpts *instance.Options, value []byte) (*instance.Result, error) {
+func (c *Controller) startInstanceWithOptions(instanceOpts *instance.Options, value []byte, abortAt time.Time) (*instance.Result, error) {
 	newInstance := instance.NewInstance(instanceOpts)
 
 	c.setCurrentInstance(newInstance)
@@ -32,16 +35,42 @@ func (c *Controller) startInstanceWithOptions(instanceOpts *instance.Options, va
 	// catch up if we can
 	go c.fastChangeRoundCatchup(newInstance)
 
+	var deadline <-chan time.Time
+	if !abortAt.IsZero() {
+		timer := time.NewTimer(time.Until(abortAt))
+		defer timer.Stop()
+		deadline = timer.C
+	}
+	aborted := false
+
 	// main instance callback loop
 	var retRes *instance.Result
 	var err error
 instanceLoop:
 	for {
-		stage := <-stageChan
+		var stage qbft.RoundState
+		select {
+		case stage = <-stageChan:
+		case <-deadline:
+			// stopping in a new goroutine as the stopped stage is sent to the stage channel
+			c.logger.Warn("duty deadline has passed, aborting instance",
+				zap.Int64("height", int64(newInstance.State().GetHeight())),
+				zap.Int64("round", int64(newInstance.State().GetRound())))
+			reportDeadlineAborted(c.Identifier)
+			aborted = true
+			deadline = nil
+			go newInstance.Stop()
+			continue
+		}
+		c.notifyStateChanged()
 		if c.getCurrentInstance() == nil {
 			c.logger.Debug("stage channel was invoked but instance is already empty", zap.Any("stage", stage))
 			break instanceLoop
 		}
+		if aborted && stage == qbft.RoundStateStopped {
+			err = ErrDutyDeadlinePassed
+			break instanceLoop
+		}
 		exit, e := c.instanceStageChange(stage)
 		if e != nil {
 			err = e
@@ -199,6 +228,7 @@ func (c *Controller) fastChangeRoundCatchup(instance instance.Instancer) {
 			ID:      c.Identifier,
 			Data:    encodedMsg,
 		})
+		c.schedule()
 		count++
 		return nil
 	}
@@ -1,7 +1,6 @@
 package controller
 
 import (
-	"strconv"
 	"time"
 
 	"github.com/pkg/errors"
@@ -10,7 +9,6 @@ import (
 	"github.com/bloxapp/ssv/protocol/v1/message"
 	protcolp2p "github.com/bloxapp/ssv/protocol/v1/p2p"
 	"github.com/bloxapp/ssv/protocol/v1/qbft/instance"
-	"github.com/bloxapp/ssv/protocol/v1/qbft/instance/leader/deterministic"
 )
 
 /**
@@ -74,8 +72,8 @@ func (c *Controller) NextSeqNumber() (message.Height, error) {
 }
 
 func (c *Controller) instanceOptionsFromStartOptions(opts instance.ControllerStartInstanceOptions) (*instance.Options, error) {
-	leaderSelectionSeed := append(c.fork.Identifier(c.Identifier.GetValidatorPK(), c.Identifier.GetRoleType()), []byte(strconv.FormatUint(uint64(opts.SeqNumber), 10))...)
-	leaderSelc, err := deterministic.New(leaderSelectionSeed, uint64(c.ValidatorShare.CommitteeSize()))
+	identifier := c.fork.Identifier(c.Identifier.GetValidatorPK(), c.Identifier.GetRoleType())
+	leaderSelc, err := c.fork.LeaderSelector(identifier, uint64(c.ValidatorShare.CommitteeSize()), opts.SeqNumber, c.decidedRounds)
 	if err != nil {
 		return nil, err
 	}
@@ -91,5 +89,7 @@ func (c *Controller) instanceOptionsFromStartOptions(opts instance.ControllerSta
 		Fork:            c.fork.InstanceFork(),
 		RequireMinPeers: opts.RequireMinPeers,
 		Signer:          c.signer,
+		Ctx:             opts.Ctx,
+		Journal:         c.journal,
 	}, nil
 }
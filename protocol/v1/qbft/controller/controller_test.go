@@ -0,0 +1,54 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/protocol/v1/message"
+	"github.com/bloxapp/ssv/protocol/v1/qbft"
+	qbftstorage "github.com/bloxapp/ssv/protocol/v1/qbft/storage"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/strategy"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/strategy/factory"
+)
+
+func TestAbortTime(t *testing.T) {
+	dutyStart := time.Now()
+
+	c := &Controller{instanceConfig: qbft.DefaultConsensusParams()}
+	require.True(t, c.abortTime(dutyStart).IsZero(), "no deadline without a role")
+
+	c.instanceConfig = qbft.DefaultRoleConsensusParams(message.RoleTypeAttester)
+	require.Equal(t, dutyStart.Add(12*time.Second), c.abortTime(dutyStart))
+	c.instanceConfig = qbft.DefaultRoleConsensusParams(message.RoleTypeValidatorRegistration)
+	require.Equal(t, dutyStart.Add(32*12*time.Second), c.abortTime(dutyStart))
+
+	c.instanceConfig = &qbft.InstanceConfig{RoundChangeDurationSeconds: 2, DutyDeadline: 12 * time.Second}
+	require.Equal(t, dutyStart.Add(12*time.Second), c.abortTime(dutyStart))
+	require.True(t, c.abortTime(time.Time{}).IsZero(), "no deadline without the duty start time")
+}
+
+func TestDecidedRounds(t *testing.T) {
+	store := qbftstorage.NewQBFTStore(newInMemDb(), zap.L(), "attestation")
+	identifier := message.NewIdentifier([]byte("pk"), message.RoleTypeAttester)
+	c := &Controller{Identifier: identifier, logger: zap.L()}
+	c.decidedFactory = factory.NewDecidedFactory(zap.L(), strategy.ModeLightNode, store, nil)
+	c.decidedStrategy = c.decidedFactory.GetStrategy()
+	require.Empty(t, c.decidedRounds(0, 10))
+
+	for h := message.Height(0); h < 10; h++ {
+		_, err := c.decidedStrategy.UpdateDecided(&message.SignedMessage{
+			Signers: []message.OperatorID{1, 2, 3},
+			Message: &message.ConsensusMessage{Identifier: identifier, Height: h, Round: message.Round(h%3 + 1)},
+		})
+		require.NoError(t, err)
+	}
+
+	// the rounds are read from the decided history in storage
+	rounds := c.decidedRounds(2, 5)
+	require.Len(t, rounds, 4)
+	require.Equal(t, message.Round(3), rounds[2])
+	require.Equal(t, message.Round(1), rounds[3])
+}
@@ -1,11 +1,15 @@
 package controller
 
 import (
+	"context"
 	"encoding/hex"
 
 	"github.com/pkg/errors"
+	"go.opencensus.io/trace"
 	"go.uber.org/zap"
 
+	"github.com/bloxapp/ssv/monitoring/tracing"
+
 	beaconprotocol "github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
 	"github.com/bloxapp/ssv/protocol/v1/message"
 	"github.com/bloxapp/ssv/protocol/v1/qbft/msgqueue"
@@ -44,6 +48,9 @@ func (c *Controller) ProcessSignatureMessage(msg *message.SignedPostConsensusMes
 	}
 
 	logger.Info("signature verified")
+	c.signatureState.span.Annotate([]trace.Attribute{
+		trace.Int64Attribute("signer", int64(msg.GetSigners()[0])),
+	}, "signature collected")
 
 	c.signatureState.signatures[msg.GetSigners()[0]] = msg.Message.DutySignature
 	if len(c.signatureState.signatures) >= c.signatureState.sigCount {
@@ -59,6 +66,7 @@ func (c *Controller) ProcessSignatureMessage(msg *message.SignedPostConsensusMes
 		)
 
 		err := c.broadcastSignature()
+		tracing.EndWithError(c.signatureState.span, err)
 		c.signatureState.clear()
 		return err
 	}
@@ -68,7 +76,8 @@ func (c *Controller) ProcessSignatureMessage(msg *message.SignedPostConsensusMes
 // broadcastSignature reconstruct sigs and broadcast to network
 func (c *Controller) broadcastSignature() error {
 	// Reconstruct signatures
-	if err := c.reconstructAndBroadcastSignature(c.signatureState.signatures, c.signatureState.root, c.signatureState.valueStruct, c.signatureState.duty); err != nil {
+	ctx := trace.NewContext(context.Background(), c.signatureState.span)
+	if err := c.reconstructAndBroadcastSignature(ctx, c.signatureState.signatures, c.signatureState.root, c.signatureState.valueStruct, c.signatureState.duty); err != nil {
 		return errors.Wrap(err, "failed to reconstruct and broadcast signature")
 	}
 	c.logger.Info("Successfully submitted role!")
@@ -76,23 +85,36 @@ func (c *Controller) broadcastSignature() error {
 }
 
 // PostConsensusDutyExecution signs the eth2 duty after iBFT came to consensus and start signature state
-func (c *Controller) PostConsensusDutyExecution(logger *zap.Logger, height message.Height, decidedValue []byte, signaturesCount int, duty *beaconprotocol.Duty) error {
+// the collection of signatures is traced with a span that ends once the signature was submitted (or timed out)
+func (c *Controller) PostConsensusDutyExecution(ctx context.Context, logger *zap.Logger, height message.Height, decidedValue []byte, signaturesCount int, duty *beaconprotocol.Duty) error {
+	_, span := tracing.StartSpan(ctx, "qbft.signatureCollection",
+		trace.Int64Attribute("height", int64(height)),
+		trace.Int64Attribute("signatures_count", int64(signaturesCount)))
 	// sign input value and broadcast
 	sig, root, valueStruct, err := c.signDuty(decidedValue, duty)
 	if err != nil {
-		return errors.Wrap(err, "failed to sign input data")
+		err = errors.Wrap(err, "failed to sign input data")
+		tracing.EndWithError(span, err)
+		return err
 	}
 	ssvMsg, err := c.generateSignatureMessage(sig, root, height)
 	if err != nil {
-		return errors.Wrap(err, "failed to generate sig message")
+		err = errors.Wrap(err, "failed to generate sig message")
+		tracing.EndWithError(span, err)
+		return err
 	}
+	ssvMsg.TraceContext = tracing.InjectSpan(span)
 	if err := c.network.Broadcast(ssvMsg); err != nil {
-		return errors.Wrap(err, "failed to broadcast signature")
+		err = errors.Wrap(err, "failed to broadcast signature")
+		tracing.EndWithError(span, err)
+		return err
 	}
 	logger.Info("broadcasting partial signature post consensus")
 
 	//	start timer, clear new map and set var's
-	c.signatureState.start(c.logger, height, signaturesCount, root, valueStruct, duty)
+	c.signatureState.start(c.logger, span, height, signaturesCount, root, valueStruct, duty)
+	// post consensus messages of the new height might be pending in the queue
+	c.notifyStateChanged()
 	return nil
 }
 
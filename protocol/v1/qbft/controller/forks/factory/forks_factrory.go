@@ -5,6 +5,7 @@ import (
 	"github.com/bloxapp/ssv/protocol/v1/qbft/controller/forks"
 	v0 "github.com/bloxapp/ssv/protocol/v1/qbft/controller/forks/v0"
 	v1 "github.com/bloxapp/ssv/protocol/v1/qbft/controller/forks/v1"
+	v3 "github.com/bloxapp/ssv/protocol/v1/qbft/controller/forks/v3"
 )
 
 // NewFork returns a new fork instance from the given version
@@ -14,6 +15,8 @@ func NewFork(forkVersion forksprotocol.ForkVersion) forks.Fork {
 		return &v0.ForkV0{}
 	case forksprotocol.V1ForkVersion, forksprotocol.V2ForkVersion: // v2 has no different from v1
 		return &v1.ForkV1{}
+	case forksprotocol.V3ForkVersion: // v3 changes only the leader selection
+		return &v3.ForkV3{}
 	default:
 		return nil
 	}
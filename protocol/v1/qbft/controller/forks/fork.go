@@ -1,12 +1,18 @@
 package forks
 
 import (
+	"strconv"
+
 	"github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
 	"github.com/bloxapp/ssv/protocol/v1/message"
 	"github.com/bloxapp/ssv/protocol/v1/qbft/instance/forks"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/instance/leader"
 	"github.com/bloxapp/ssv/protocol/v1/qbft/pipelines"
 )
 
+// DecidedRoundsFunc returns the rounds in which the heights of the given range were decided, missing heights are omitted
+type DecidedRoundsFunc func(from, to message.Height) map[message.Height]message.Round
+
 // Fork holds all fork related implementations for the controller
 type Fork interface {
 	InstanceFork() forks.Fork
@@ -14,4 +20,13 @@ type Fork interface {
 	ValidateChangeRoundMsg(share *beacon.Share, identifier message.Identifier) pipelines.SignedMessagePipeline
 	VersionName() string
 	Identifier(pk []byte, role message.RoleType) []byte
+	// LeaderSelector returns the leader selection of the given height, the identifier is the one returned by Identifier()
+	LeaderSelector(identifier []byte, committeeSize uint64, height message.Height, decidedRounds DecidedRoundsFunc) (leader.Selector, error)
+}
+
+// LeaderSeed returns the seed of the leader selection of the given identifier and height
+func LeaderSeed(identifier []byte, height message.Height) []byte {
+	seed := make([]byte, 0, len(identifier)+20)
+	seed = append(seed, identifier...)
+	return append(seed, []byte(strconv.FormatUint(uint64(height), 10))...)
 }
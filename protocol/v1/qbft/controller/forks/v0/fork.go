@@ -6,6 +6,8 @@ import (
 	controllerfork "github.com/bloxapp/ssv/protocol/v1/qbft/controller/forks"
 	instancefork "github.com/bloxapp/ssv/protocol/v1/qbft/instance/forks"
 	forkv0 "github.com/bloxapp/ssv/protocol/v1/qbft/instance/forks/v0"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/instance/leader"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/instance/leader/deterministic"
 	"github.com/bloxapp/ssv/protocol/v1/qbft/pipelines"
 	"github.com/bloxapp/ssv/protocol/v1/qbft/validation/changeround"
 	"github.com/bloxapp/ssv/protocol/v1/qbft/validation/signedmsg"
@@ -57,3 +59,12 @@ func (v0 *ForkV0) ValidateChangeRoundMsg(share *beacon.Share, identifier message
 func (v0 *ForkV0) Identifier(pk []byte, role message.RoleType) []byte {
 	return []byte(format.IdentifierFormat(pk, role.String())) // need to support same seed as v0 versions
 }
+
+// LeaderSelector returns a round-robin leader selection, seeded by the identifier and height
+func (v0 *ForkV0) LeaderSelector(identifier []byte, committeeSize uint64, height message.Height, decidedRounds controllerfork.DecidedRoundsFunc) (leader.Selector, error) {
+	selector, err := deterministic.New(controllerfork.LeaderSeed(identifier, height), committeeSize)
+	if err != nil {
+		return nil, err
+	}
+	return selector, nil
+}
@@ -6,6 +6,8 @@ import (
 	controcllerfork "github.com/bloxapp/ssv/protocol/v1/qbft/controller/forks"
 	instancefork "github.com/bloxapp/ssv/protocol/v1/qbft/instance/forks"
 	forkV1 "github.com/bloxapp/ssv/protocol/v1/qbft/instance/forks/v1"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/instance/leader"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/instance/leader/deterministic"
 	"github.com/bloxapp/ssv/protocol/v1/qbft/pipelines"
 	"github.com/bloxapp/ssv/protocol/v1/qbft/validation/changeround"
 	"github.com/bloxapp/ssv/protocol/v1/qbft/validation/signedmsg"
@@ -56,3 +58,12 @@ func (v1 *ForkV1) ValidateChangeRoundMsg(share *beacon.Share, identifier message
 func (v1 *ForkV1) Identifier(pk []byte, role message.RoleType) []byte {
 	return message.NewIdentifier(pk, role)
 }
+
+// LeaderSelector returns a round-robin leader selection, seeded by the identifier and height
+func (v1 *ForkV1) LeaderSelector(identifier []byte, committeeSize uint64, height message.Height, decidedRounds controcllerfork.DecidedRoundsFunc) (leader.Selector, error) {
+	selector, err := deterministic.New(controcllerfork.LeaderSeed(identifier, height), committeeSize)
+	if err != nil {
+		return nil, err
+	}
+	return selector, nil
+}
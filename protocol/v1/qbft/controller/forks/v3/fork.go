@@ -0,0 +1,39 @@
+package v3
+
+import (
+	"github.com/bloxapp/ssv/protocol/v1/message"
+	controllerfork "github.com/bloxapp/ssv/protocol/v1/qbft/controller/forks"
+	v1 "github.com/bloxapp/ssv/protocol/v1/qbft/controller/forks/v1"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/instance/leader"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/instance/leader/reputation"
+)
+
+// ForkV3 activates the reputation based leader selection,
+// the rest is the same as v1 (including the version name, which is part of the signatures)
+type ForkV3 struct {
+	v1.ForkV1
+}
+
+// New returns new ForkV3
+func New() controllerfork.Fork {
+	return &ForkV3{}
+}
+
+// LeaderSelector returns a leader selection that skips operators that failed to lead previous heights of the reputation window
+func (v3 *ForkV3) LeaderSelector(identifier []byte, committeeSize uint64, height message.Height, decidedRounds controllerfork.DecidedRoundsFunc) (leader.Selector, error) {
+	from := message.Height(reputation.WindowStart(uint64(height), reputation.Window))
+	rounds := make(map[uint64]uint64)
+	if height > from {
+		for h, r := range decidedRounds(from, height-1) {
+			rounds[uint64(h)] = uint64(r)
+		}
+	}
+	seed := func(h uint64) []byte {
+		return controllerfork.LeaderSeed(identifier, message.Height(h))
+	}
+	selector, err := reputation.New(seed, committeeSize, uint64(height), reputation.Window, rounds)
+	if err != nil {
+		return nil, err
+	}
+	return selector, nil
+}
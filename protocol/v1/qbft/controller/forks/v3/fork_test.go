@@ -0,0 +1,82 @@
+package v3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/protocol/v1/message"
+	controllerfork "github.com/bloxapp/ssv/protocol/v1/qbft/controller/forks"
+	v1 "github.com/bloxapp/ssv/protocol/v1/qbft/controller/forks/v1"
+)
+
+// simulateRoundChanges runs the given amount of heights with a committee where one operator is offline,
+// every round led by the offline operator times out. returns the total amount of round changes
+func simulateRoundChanges(t *testing.T, fork controllerfork.Fork, committeeSize uint64, faulty uint64, heights int) int {
+	identifier := fork.Identifier([]byte("validator-pk"), message.RoleTypeAttester)
+	history := make(map[message.Height]message.Round)
+	decidedRounds := func(from, to message.Height) map[message.Height]message.Round {
+		res := make(map[message.Height]message.Round)
+		for h := from; h <= to; h++ {
+			if r, ok := history[h]; ok {
+				res[h] = r
+			}
+		}
+		return res
+	}
+
+	roundChanges := 0
+	for h := message.Height(0); h < message.Height(heights); h++ {
+		// all operators derive the same selector from the same history
+		selector, err := fork.LeaderSelector(identifier, committeeSize, h, decidedRounds)
+		require.NoError(t, err)
+		other, err := fork.LeaderSelector(identifier, committeeSize, h, decidedRounds)
+		require.NoError(t, err)
+
+		for round := uint64(1); round <= committeeSize; round++ {
+			require.Equal(t, selector.Calculate(round), other.Calculate(round))
+		}
+
+		round := uint64(1)
+		for ; selector.Calculate(round) == faulty; round++ {
+			roundChanges++
+		}
+		history[h] = message.Round(round)
+	}
+	return roundChanges
+}
+
+func TestForkV3_LeaderSelection(t *testing.T) {
+	const heights = 1000
+	for _, committeeSize := range []uint64{4, 7} {
+		for faulty := uint64(0); faulty < committeeSize; faulty++ {
+			roundRobin := simulateRoundChanges(t, &v1.ForkV1{}, committeeSize, faulty, heights)
+			withReputation := simulateRoundChanges(t, New(), committeeSize, faulty, heights)
+			t.Logf("committee %d, faulty operator %d: %d round changes with round-robin, %d with reputation",
+				committeeSize, faulty+1, roundRobin, withReputation)
+			// the offline operator leads round 1 about once per window instead of once per committee size
+			require.Less(t, withReputation*3, roundRobin)
+		}
+	}
+}
+
+func TestForkV3_NoFailures(t *testing.T) {
+	identifier := New().Identifier([]byte("validator-pk"), message.RoleTypeAttester)
+	decidedRounds := func(from, to message.Height) map[message.Height]message.Round {
+		res := make(map[message.Height]message.Round)
+		for h := from; h <= to; h++ {
+			res[h] = 1
+		}
+		return res
+	}
+	// the leaders are the same as v1 as long as all heights were decided in round 1
+	for h := message.Height(0); h < 100; h++ {
+		s1, err := (&v1.ForkV1{}).LeaderSelector(identifier, 4, h, decidedRounds)
+		require.NoError(t, err)
+		s2, err := New().LeaderSelector(identifier, 4, h, decidedRounds)
+		require.NoError(t, err)
+		for round := uint64(1); round <= 8; round++ {
+			require.Equal(t, s1.Calculate(round), s2.Calculate(round))
+		}
+	}
+}
@@ -1,9 +1,12 @@
 package controller
 
 import (
+	"encoding/hex"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"log"
+
+	"github.com/bloxapp/ssv/protocol/v1/message"
 )
 
 var (
@@ -20,6 +23,10 @@ var (
 		Name: "ssv:validator:running_ibfts_count",
 		Help: "Count running IBFTs by validator pub key",
 	}, []string{"pubKey"})
+	metricsDeadlineAborted = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ssv:validator:ibft_deadline_aborted",
+		Help: "Count instances that were aborted as the duty deadline has passed",
+	}, []string{"lambda", "pubKey"})
 )
 
 func init() {
@@ -32,6 +39,9 @@ func init() {
 	if err := prometheus.Register(metricsRunningIBFTs); err != nil {
 		log.Println("could not register prometheus collector")
 	}
+	if err := prometheus.Register(metricsDeadlineAborted); err != nil {
+		log.Println("could not register prometheus collector")
+	}
 }
 
 type ibftStatus int32
@@ -57,6 +67,11 @@ func reportIBFTInstanceStart(pubKey string) func() {
 	}
 }
 
+// reportDeadlineAborted counts an instance that was aborted (or not started) as the duty deadline has passed
+func reportDeadlineAborted(identifier message.Identifier) {
+	metricsDeadlineAborted.WithLabelValues(identifier.GetRoleType().String(), hex.EncodeToString(identifier.GetValidatorPK())).Inc()
+}
+
 // ReportIBFTStatus reports the current iBFT status
 func ReportIBFTStatus(pk string, finished, errorFound bool) {
 	if errorFound {
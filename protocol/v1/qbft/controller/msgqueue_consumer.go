@@ -20,59 +20,97 @@ func (c *Controller) startQueueConsumer(handler MessageHandler) {
 	defer cancel()
 
 	for ctx.Err() == nil {
-		err := c.ConsumeQueue(handler, time.Millisecond*50)
+		err := c.ConsumeQueue(handler)
 		if err != nil {
 			c.logger.Warn("could not consume queue", zap.Error(err))
 		}
 	}
 }
 
-// ConsumeQueue consumes messages from the msgqueue.Queue of the controller
-// it checks for current state
-func (c *Controller) ConsumeQueue(handler MessageHandler, interval time.Duration) error {
+// ConsumeQueue consumes messages from the msgqueue.Queue of the controller according to the current state.
+// once there are no relevant messages, it blocks until a message is added or the state changes
+func (c *Controller) ConsumeQueue(handler MessageHandler) error {
 	ctx, cancel := context.WithCancel(c.ctx)
 	defer cancel()
 
 	identifier := c.Identifier.String()
 
 	for ctx.Err() == nil {
-		time.Sleep(interval)
+		// taking the channels before checking the queue, so updates that happen meanwhile are not missed
+		queueUpdated := c.q.Updated()
+		stateChanged := c.stateChanged.Wait()
 
-		// no msg's in the queue
-		if c.q.Len() == 0 {
-			time.Sleep(interval)
-			continue // no msg's at all. need to prevent cpu usage in query
-		}
-		// avoid process messages on fork
-		if atomic.LoadUint32(&c.state) == Forking {
-			time.Sleep(interval)
+		if c.processNext(handler, identifier) {
 			continue
 		}
-
-		lastHeight := c.signatureState.getHeight()
-
-		if processed := c.processNoRunningInstance(handler, identifier, lastHeight); processed {
-			c.logger.Debug("process none running instance is done")
-			continue
+		select {
+		case <-queueUpdated:
+		case <-stateChanged:
+		case <-ctx.Done():
 		}
-		if processed := c.processByState(handler, identifier); processed {
-			c.logger.Debug("process by state is done")
-			continue
-		}
-		if processed := c.processDefault(handler, identifier, lastHeight); processed {
-			c.logger.Debug("process default is done")
-			continue
-		}
-
-		// clean all old messages. (when stuck on change round stage, msgs not deleted)
-		c.q.Clean(func(index msgqueue.Index) bool {
-			return index.H <= (lastHeight - 2) // remove all msg's that are 2 heights old
-		})
 	}
 	c.logger.Warn("queue consumer is closed")
 	return nil
 }
 
+// queueTask processes the msg queue of a controller on a shared worker.Scheduler,
+// the controller schedules the task whenever a message is added or the state changes
+type queueTask struct {
+	ctrl       *Controller
+	handler    MessageHandler
+	identifier string
+}
+
+// Run processes the next relevant message
+func (t *queueTask) Run() bool {
+	if t.ctrl.ctx.Err() != nil {
+		return false
+	}
+	return t.ctrl.processNext(t.handler, t.identifier)
+}
+
+// Deadline returns the start time of the slot of the current duty
+func (t *queueTask) Deadline() time.Time {
+	deadline := atomic.LoadInt64(&t.ctrl.deadline)
+	if deadline == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, deadline)
+}
+
+// processNext processes the next relevant message, returns false if there is no such message
+func (c *Controller) processNext(handler MessageHandler, identifier string) bool {
+	// no msg's in the queue
+	if c.q.Len() == 0 {
+		return false
+	}
+	// avoid process messages on fork
+	if atomic.LoadUint32(&c.state) == Forking {
+		return false
+	}
+
+	lastHeight := c.signatureState.getHeight()
+
+	if processed := c.processNoRunningInstance(handler, identifier, lastHeight); processed {
+		c.logger.Debug("process none running instance is done")
+		return true
+	}
+	if processed := c.processByState(handler, identifier); processed {
+		c.logger.Debug("process by state is done")
+		return true
+	}
+	if processed := c.processDefault(handler, identifier, lastHeight); processed {
+		c.logger.Debug("process default is done")
+		return true
+	}
+
+	// clean all old messages. (when stuck on change round stage, msgs not deleted)
+	c.q.Clean(func(index msgqueue.Index) bool {
+		return index.H <= (lastHeight - 2) // remove all msg's that are 2 heights old
+	})
+	return false
+}
+
 // processNoRunningInstance pop msg's only if no current instance running
 func (c *Controller) processNoRunningInstance(handler MessageHandler, identifier string, lastHeight message.Height) bool {
 	instance := c.getCurrentInstance()
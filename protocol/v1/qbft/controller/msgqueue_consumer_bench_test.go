@@ -0,0 +1,139 @@
+//go:build linux || darwin
+// +build linux darwin
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/protocol/v1/message"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/msgqueue"
+)
+
+// idleMeasureDuration is the time that idle cpu usage is measured for
+const idleMeasureDuration = time.Second
+
+// BenchmarkQueueConsumer measures the latency of processing a message (ns/op) and the cpu usage of idle consumers,
+// for the event driven consumer and for the legacy consumer that polls the queue every 50ms.
+// the polling consumer is slow, run with a fixed amount of iterations:
+//
+//	go test -run xxx -bench BenchmarkQueueConsumer -benchtime 200x ./protocol/v1/qbft/controller/
+func BenchmarkQueueConsumer(b *testing.B) {
+	consumers := map[string]func(c *Controller, handler MessageHandler){
+		"event-driven": func(c *Controller, handler MessageHandler) {
+			c.startQueueConsumer(handler)
+		},
+		"polling": func(c *Controller, handler MessageHandler) {
+			pollQueue(c, handler, 50*time.Millisecond)
+		},
+	}
+	for _, n := range []int{1000, 5000} {
+		for _, name := range []string{"event-driven", "polling"} {
+			consume := consumers[name]
+			b.Run(fmt.Sprintf("%s/validators=%d", name, n), func(b *testing.B) {
+				benchmarkQueueConsumer(b, n, consume)
+			})
+		}
+	}
+}
+
+func benchmarkQueueConsumer(b *testing.B, n int, consume func(c *Controller, handler MessageHandler)) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	processed := make(chan struct{})
+	handler := func(msg *message.SSVMessage) error {
+		processed <- struct{}{}
+		return nil
+	}
+	ctrls := make([]*Controller, n)
+	msgs := make([]*message.SSVMessage, n)
+	var wg sync.WaitGroup
+	for i := range ctrls {
+		ctrls[i] = newBenchController(ctx, b, i)
+		msgs[i] = newBenchDecidedMsg(b, ctrls[i].Identifier)
+		wg.Add(1)
+		go func(c *Controller) {
+			defer wg.Done()
+			consume(c, handler)
+		}(ctrls[i])
+	}
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	idle := idleCPU(b)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ctrls[i%n].q.Add(msgs[i%n])
+		<-processed
+	}
+	b.StopTimer()
+	// reported after the loop, as ResetTimer deletes reported metrics
+	b.ReportMetric(idle, "idle-cpu-%")
+}
+
+func newBenchController(ctx context.Context, b *testing.B, i int) *Controller {
+	q, err := msgqueue.New(zap.NewNop(),
+		msgqueue.WithIndexers(msgqueue.SignedMsgIndexer(), msgqueue.DecidedMsgIndexer(), msgqueue.SignedPostConsensusMsgIndexer()))
+	require.NoError(b, err)
+	return &Controller{
+		ctx:                 ctx,
+		logger:              zap.NewNop(),
+		q:                   q,
+		Identifier:          message.NewIdentifier([]byte(fmt.Sprintf("pk-%d", i)), message.RoleTypeAttester),
+		currentInstanceLock: &sync.RWMutex{},
+		forkLock:            &sync.Mutex{},
+	}
+}
+
+func newBenchDecidedMsg(b *testing.B, id message.Identifier) *message.SSVMessage {
+	signedMsg := message.SignedMessage{
+		Message: &message.ConsensusMessage{
+			MsgType:    message.CommitMsgType,
+			Height:     0,
+			Identifier: id,
+		},
+	}
+	data, err := signedMsg.Encode()
+	require.NoError(b, err)
+	return &message.SSVMessage{MsgType: message.SSVDecidedMsgType, ID: id, Data: data}
+}
+
+// idleCPU returns the cpu usage (% of a single core) of the process while the consumers are idle
+func idleCPU(b *testing.B) float64 {
+	// let the consumers reach their idle state
+	time.Sleep(100 * time.Millisecond)
+	before := cpuTime(b)
+	time.Sleep(idleMeasureDuration)
+	return float64(cpuTime(b)-before) / float64(idleMeasureDuration) * 100
+}
+
+func cpuTime(b *testing.B) time.Duration {
+	var usage syscall.Rusage
+	require.NoError(b, syscall.Getrusage(syscall.RUSAGE_SELF, &usage))
+	return time.Duration(usage.Utime.Nano() + usage.Stime.Nano())
+}
+
+// pollQueue is the legacy consumer, it checks the queue in a fixed interval
+func pollQueue(c *Controller, handler MessageHandler, interval time.Duration) {
+	identifier := c.Identifier.String()
+	for c.ctx.Err() == nil {
+		time.Sleep(interval)
+		if c.q.Len() == 0 {
+			time.Sleep(interval)
+			continue
+		}
+		c.processNext(handler, identifier)
+	}
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/bloxapp/ssv/protocol/v1/qbft/instance"
 	"github.com/bloxapp/ssv/protocol/v1/qbft/msgqueue"
 	"github.com/bloxapp/ssv/protocol/v1/qbft/pipelines"
+	"github.com/bloxapp/ssv/protocol/v1/queue/worker"
 	"github.com/bloxapp/ssv/utils/logex"
 )
 
@@ -337,6 +338,70 @@ func TestConsumeMessages(t *testing.T) {
 	}
 }
 
+func TestConsumeMessagesWithScheduler(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	scheduler := worker.NewScheduler(&worker.Config{
+		Ctx:          ctx,
+		Logger:       logex.GetLogger(),
+		WorkersCount: 2,
+	})
+	q, err := msgqueue.New(
+		logex.GetLogger().With(zap.String("who", "msg_q")),
+		msgqueue.WithIndexers(msgqueue.SignedMsgIndexer(), msgqueue.DecidedMsgIndexer(), msgqueue.SignedPostConsensusMsgIndexer()),
+	)
+	require.NoError(t, err)
+	ctrl := &Controller{
+		ctx:                 ctx,
+		logger:              logex.GetLogger().With(zap.String("who", "controller")),
+		q:                   q,
+		Identifier:          message.NewIdentifier([]byte("1"), message.RoleTypeAttester),
+		currentInstanceLock: &sync.RWMutex{},
+		forkLock:            &sync.Mutex{},
+		scheduler:           scheduler,
+	}
+	ctrl.signatureState.setHeight(1)
+
+	processed := make(chan *message.SSVMessage, 10)
+	task := &queueTask{ctrl: ctrl, identifier: ctrl.Identifier.String(), handler: func(msg *message.SSVMessage) error {
+		processed <- msg
+		return nil
+	}}
+	scheduler.Register(ctrl.Identifier.String(), task)
+
+	require.True(t, task.Deadline().IsZero())
+	deadline := time.Now().Add(time.Second)
+	ctrl.deadline = deadline.UnixNano()
+	require.True(t, task.Deadline().Equal(deadline))
+
+	// the controller schedules its task once a message is added
+	ctrl.q.Add(generatePostConsensusOrSig(t, message.SSVPostConsensusMsgType, message.Height(1), ctrl.Identifier))
+	ctrl.schedule()
+	select {
+	case msg := <-processed:
+		require.Equal(t, message.SSVPostConsensusMsgType, msg.MsgType)
+	case <-time.After(time.Second):
+		require.Fail(t, "message was not processed")
+	}
+
+	// a commit message is processed only once the instance is running
+	ctrl.setCurrentInstance(generateInstance(1, 1, qbft.RoundStatePrepare))
+	ctrl.q.Add(generateConsensusMsg(t, message.Height(1), 1, ctrl.Identifier, message.CommitMsgType))
+	ctrl.schedule()
+	select {
+	case msg := <-processed:
+		require.Equal(t, message.SSVConsensusMsgType, msg.MsgType)
+	case <-time.After(time.Second):
+		require.Fail(t, "message was not processed")
+	}
+	select {
+	case <-processed:
+		require.Fail(t, "unexpected message was processed")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
 func generateInstance(height message.Height, round message.Round, stage qbft.RoundState) instance.Instancer {
 	i := &InstanceMock{state: &qbft.State{
 		Height: qbft.NewHeight(height),
@@ -1,15 +1,18 @@
 package controller
 
 import (
+	"context"
 	"encoding/base64"
 	"time"
 
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/herumi/bls-eth-go-binary/bls"
 	"github.com/pkg/errors"
+	"go.opencensus.io/trace"
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
 
+	"github.com/bloxapp/ssv/monitoring/tracing"
 	beaconprotocol "github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
 	"github.com/bloxapp/ssv/protocol/v1/message"
 	"github.com/bloxapp/ssv/protocol/v1/utils/threshold"
@@ -49,6 +52,8 @@ type SignatureState struct {
 	root                       []byte
 	valueStruct                *beaconprotocol.DutyData
 	duty                       *beaconprotocol.Duty
+	// span traces the collection of signatures
+	span *trace.Span
 }
 
 func (s *SignatureState) getHeight() message.Height {
@@ -63,8 +68,9 @@ func (s *SignatureState) setHeight(height message.Height) {
 	s.height.Store(height)
 }
 
-func (s *SignatureState) start(logger *zap.Logger, height message.Height, signaturesCount int, root []byte, valueStruct *beaconprotocol.DutyData, duty *beaconprotocol.Duty) {
+func (s *SignatureState) start(logger *zap.Logger, span *trace.Span, height message.Height, signaturesCount int, root []byte, valueStruct *beaconprotocol.DutyData, duty *beaconprotocol.Duty) {
 	// set var's
+	s.span = span
 	s.setHeight(height)
 	s.sigCount = signaturesCount
 	s.root = root
@@ -77,7 +83,10 @@ func (s *SignatureState) start(logger *zap.Logger, height message.Height, signat
 			logger.Debug("signatures were collected before timeout", zap.Int("received", len(s.signatures)))
 			return
 		}
-		logger.Warn("could not process post consensus signature", zap.Error(errors.Errorf("timed out waiting for post consensus signatures, received %d", len(s.signatures))))
+		err := errors.Errorf("timed out waiting for post consensus signatures, received %d", len(s.signatures))
+		logger.Warn("could not process post consensus signature", zap.Error(err))
+		span.SetStatus(trace.Status{Code: trace.StatusCodeDeadlineExceeded, Message: err.Error()})
+		span.End()
 	})
 	//s.timer = time.NewTimer(s.SignatureCollectionTimeout)
 	s.state.Store(StateRunning)
@@ -96,6 +105,7 @@ func (s *SignatureState) clear() {
 	s.root = nil
 	s.valueStruct = nil
 	s.duty = nil
+	s.span = nil
 	s.state.Store(StateSleep)
 	// don't reset height until new height set
 }
@@ -157,6 +167,20 @@ func (c *Controller) signDuty(decidedValue []byte, duty *beaconprotocol.Duty) ([
 		retValueStruct.GetAttestation().AggregationBits = signedAttestation.AggregationBits
 		sig = signedAttestation.Signature[:]
 		root = ensureRoot(r)
+	case message.RoleTypeValidatorRegistration:
+		registration := &beaconprotocol.ValidatorRegistration{}
+		if err := registration.UnmarshalSSZ(decidedValue); err != nil {
+			return nil, nil, nil, errors.Wrap(err, "failed to unmarshal validator registration")
+		}
+		s, r, err := c.signer.SignValidatorRegistration(registration, pk.Serialize())
+		if err != nil {
+			return nil, nil, nil, errors.Wrap(err, "failed to sign validator registration")
+		}
+		retValueStruct.SignedData = &beaconprotocol.InputValueValidatorRegistration{
+			ValidatorRegistration: &beaconprotocol.SignedValidatorRegistration{Message: registration},
+		}
+		sig = s
+		root = ensureRoot(r)
 	default:
 		return nil, nil, nil, errors.New("unsupported role, can't sign")
 	}
@@ -165,7 +189,7 @@ func (c *Controller) signDuty(decidedValue []byte, duty *beaconprotocol.Duty) ([
 
 // reconstructAndBroadcastSignature reconstructs the received signatures from other
 // nodes and broadcasts the reconstructed signature to the beacon-chain
-func (c *Controller) reconstructAndBroadcastSignature(signatures map[message.OperatorID][]byte, root []byte, inputValue *beaconprotocol.DutyData, duty *beaconprotocol.Duty) error {
+func (c *Controller) reconstructAndBroadcastSignature(ctx context.Context, signatures map[message.OperatorID][]byte, root []byte, inputValue *beaconprotocol.DutyData, duty *beaconprotocol.Duty) error {
 	// Reconstruct signatures
 	signature, err := threshold.ReconstructSignatures(signatures)
 	if err != nil {
@@ -185,9 +209,22 @@ func (c *Controller) reconstructAndBroadcastSignature(signatures map[message.Ope
 		blsSig := spec.BLSSignature{}
 		copy(blsSig[:], signature.Serialize()[:])
 		inputValue.GetAttestation().Signature = blsSig
-		if err := c.beacon.SubmitAttestation(inputValue.GetAttestation()); err != nil {
+		_, span := tracing.StartSpan(ctx, "beacon.SubmitAttestation")
+		err := c.beacon.SubmitAttestation(inputValue.GetAttestation())
+		tracing.EndWithError(span, err)
+		if err != nil {
 			return errors.Wrap(err, "failed to broadcast attestation")
 		}
+	case message.RoleTypeValidatorRegistration:
+		c.logger.Debug("submitting validator registration")
+		registration := inputValue.GetValidatorRegistration()
+		copy(registration.Signature[:], signature.Serialize())
+		_, span := tracing.StartSpan(ctx, "beacon.SubmitValidatorRegistration")
+		err := c.beacon.SubmitValidatorRegistration(registration)
+		tracing.EndWithError(span, err)
+		if err != nil {
+			return errors.Wrap(err, "failed to submit validator registration")
+		}
 	default:
 		return errors.New("role is undefined, can't reconstruct signature")
 	}
@@ -1,11 +1,13 @@
 package controller
 
 import (
+	"context"
 	"encoding/hex"
 	"testing"
 	"time"
 
 	api "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/herumi/bls-eth-go-binary/bls"
 	"github.com/stretchr/testify/require"
@@ -157,6 +159,66 @@ func TestVerifyPartialSignature(t *testing.T) {
 	}
 }
 
+func TestReconstructAndBroadcastSignature_ValidatorRegistration(t *testing.T) {
+	require.NoError(t, bls.Init(bls.BLS12_381))
+
+	pk := &bls.PublicKey{}
+	require.NoError(t, pk.Deserialize(refPk))
+	share := &beacon.Share{
+		NodeID:    1,
+		PublicKey: pk,
+		Committee: map[message.OperatorID]*beacon.Node{
+			1: {IbftID: 1, Pk: refSplitSharesPubKeys[0]},
+			2: {IbftID: 2, Pk: refSplitSharesPubKeys[1]},
+			3: {IbftID: 3, Pk: refSplitSharesPubKeys[2]},
+			4: {IbftID: 4, Pk: refSplitSharesPubKeys[3]},
+		},
+	}
+	b := newTestBeacon(t)
+	role := message.RoleTypeValidatorRegistration
+	ctrl := New(Options{
+		Role:           role,
+		Identifier:     message.NewIdentifier(share.PublicKey.Serialize(), role),
+		Logger:         zap.L(),
+		InstanceConfig: qbft.DefaultConsensusParams(),
+		ValidatorShare: share,
+		Beacon:         b,
+		Signer:         b,
+		SigTimeout:     time.Second * 2,
+		Version:        forksprotocol.V1ForkVersion,
+	}).(*Controller)
+
+	registration := &beacon.ValidatorRegistration{
+		FeeRecipient: bellatrix.ExecutionAddress{1, 2, 3},
+		GasLimit:     beacon.DefaultGasLimit,
+		Timestamp:    1660000000,
+	}
+	copy(registration.Pubkey[:], refPk)
+	root := []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	signatures := make(map[message.OperatorID][]byte)
+	for i, skByts := range refSplitShares[:3] {
+		sk := &bls.SecretKey{}
+		require.NoError(t, sk.Deserialize(skByts))
+		signatures[message.OperatorID(i+1)] = sk.SignByte(root).Serialize()
+	}
+	inputValue := &beacon.DutyData{
+		SignedData: &beacon.InputValueValidatorRegistration{
+			ValidatorRegistration: &beacon.SignedValidatorRegistration{Message: registration},
+		},
+	}
+	duty := &beacon.Duty{Type: role}
+
+	require.NoError(t, ctrl.reconstructAndBroadcastSignature(context.Background(), signatures, root, inputValue, duty))
+	submitted := b.LastSubmittedRegistration
+	require.NotNil(t, submitted)
+	require.Equal(t, registration, submitted.Message)
+	// the submitted signature is the reconstructed validator signature
+	sigByts := submitted.Signature
+	sig := &bls.Sign{}
+	require.NoError(t, sig.Deserialize(sigByts[:]))
+	require.True(t, sig.VerifyByte(pk, root))
+}
+
 var (
 	refAttestationDataByts = _byteArray("000000000000000000000000000000003a43a4bf26fb5947e809c1f24f7dc6857c8ac007e535d48e6e4eca2122fd776b0000000000000000000000000000000000000000000000000000000000000000000000000000000002000000000000003a43a4bf26fb5947e809c1f24f7dc6857c8ac007e535d48e6e4eca2122fd776b")
 
@@ -200,8 +262,9 @@ testBeacon
 */
 // TODO: replace with gomock
 type testBeacon struct {
-	refAttestationData       *spec.AttestationData
-	LastSubmittedAttestation *spec.Attestation
+	refAttestationData        *spec.AttestationData
+	LastSubmittedAttestation  *spec.Attestation
+	LastSubmittedRegistration *beacon.SignedValidatorRegistration
 }
 
 func newTestBeacon(t *testing.T) *testBeacon {
@@ -249,6 +312,19 @@ func (b *testBeacon) SubscribeToCommitteeSubnet(subscription []*api.BeaconCommit
 	panic("implement me")
 }
 
+func (b *testBeacon) SubmitProposalPreparation(feeRecipients map[spec.ValidatorIndex]bellatrix.ExecutionAddress) error {
+	panic("implement me")
+}
+
+func (b *testBeacon) SubmitValidatorRegistration(registration *beacon.SignedValidatorRegistration) error {
+	b.LastSubmittedRegistration = registration
+	return nil
+}
+
+func (b *testBeacon) SignValidatorRegistration(registration *beacon.ValidatorRegistration, pk []byte) ([]byte, []byte, error) {
+	panic("implement me")
+}
+
 func (b *testBeacon) AddShare(shareKey *bls.SecretKey) error {
 	panic("implement me")
 }
@@ -3,7 +3,6 @@ package instance
 import (
 	"encoding/json"
 	"fmt"
-	"math"
 	"time"
 
 	"github.com/bloxapp/ssv/protocol/v1/message"
@@ -282,6 +281,5 @@ func (i *Instance) generateChangeRoundMessage() (*message.ConsensusMessage, erro
 }
 
 func (i *Instance) roundTimeoutSeconds() time.Duration {
-	roundTimeout := math.Pow(float64(i.Config.RoundChangeDurationSeconds), float64(i.State().GetRound()))
-	return time.Duration(float64(time.Second) * roundTimeout)
+	return i.Config.RoundTimeout(i.State().GetRound())
 }
@@ -0,0 +1,256 @@
+//go:build go1.18
+// +build go1.18
+
+package instance_test
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"go.uber.org/zap"
+
+	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
+	"github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
+	"github.com/bloxapp/ssv/protocol/v1/message"
+	protocolp2p "github.com/bloxapp/ssv/protocol/v1/p2p"
+	"github.com/bloxapp/ssv/protocol/v1/qbft"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/instance"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/instance/forks"
+	forksv0 "github.com/bloxapp/ssv/protocol/v1/qbft/instance/forks/v0"
+	forksv1 "github.com/bloxapp/ssv/protocol/v1/qbft/instance/forks/v1"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/instance/leader/constant"
+	qbftstorage "github.com/bloxapp/ssv/protocol/v1/qbft/storage"
+	"github.com/bloxapp/ssv/protocol/v1/utils/threshold"
+)
+
+// FuzzPipelines processes a sequence of signed messages with an instance of operator 1,
+// which runs all the pipelines (pre-prepare, prepare, commit and change round) of the fork.
+// the share is seeded so the corpus contains validly signed messages that reach the deeper stages:
+//	go test ./protocol/v1/qbft/instance -run=XXX -fuzz=FuzzPipelines -fuzztime=5m
+func FuzzPipelines(f *testing.F) {
+	share := newFuzzShare(f)
+	for _, v1 := range []bool{false, true} {
+		for _, msgs := range share.seeds(v1) {
+			raw, err := json.Marshal(msgs)
+			if err != nil {
+				f.Fatal(err)
+			}
+			f.Add(v1, raw)
+		}
+	}
+
+	logger := zap.NewNop()
+	self, err := protocolp2p.GenPeerID()
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	f.Fuzz(func(t *testing.T, v1 bool, data []byte) {
+		var msgs []*message.SignedMessage
+		if err := json.Unmarshal(data, &msgs); err != nil {
+			return
+		}
+		inst := share.newInstance(logger, protocolp2p.NewMockNetwork(logger, self, 10), v1)
+		defer inst.Stop()
+
+		for _, msg := range msgs {
+			// the message queue drops messages without a consensus message
+			if msg == nil || msg.Message == nil {
+				continue
+			}
+			_, _ = inst.ProcessMsg(msg)
+		}
+	})
+}
+
+// fuzzShare is a committee of 4 operators with deterministic keys
+type fuzzShare struct {
+	tb          testing.TB
+	sks         map[message.OperatorID]*bls.SecretKey
+	validatorPK *bls.PublicKey
+	identifier  message.Identifier
+}
+
+func newFuzzShare(tb testing.TB) *fuzzShare {
+	threshold.Init()
+	key := func(seed string) *bls.SecretKey {
+		h := sha256.Sum256([]byte(seed))
+		sk := &bls.SecretKey{}
+		if err := sk.SetLittleEndianMod(h[:]); err != nil {
+			tb.Fatal(err)
+		}
+		return sk
+	}
+	s := &fuzzShare{
+		tb:          tb,
+		sks:         make(map[message.OperatorID]*bls.SecretKey),
+		validatorPK: key("validator").GetPublicKey(),
+	}
+	for id := message.OperatorID(1); id <= 4; id++ {
+		s.sks[id] = key(fmt.Sprintf("operator %d", id))
+	}
+	s.identifier = message.NewIdentifier(s.validatorPK.Serialize(), message.RoleTypeAttester)
+	return s
+}
+
+// newInstance creates an instance of operator 1 at height 1, round 1.
+// operator 2 is the leader of all rounds
+func (s *fuzzShare) newInstance(logger *zap.Logger, net protocolp2p.Network, v1 bool) *instance.Instance {
+	committee := make(map[message.OperatorID]*beacon.Node)
+	for id, sk := range s.sks {
+		committee[id] = &beacon.Node{IbftID: uint64(id), Pk: sk.GetPublicKey().Serialize()}
+	}
+	var fork forks.Fork = forksv0.New()
+	if v1 {
+		fork = forksv1.New()
+	}
+	inst := instance.NewInstance(&instance.Options{
+		Logger: logger,
+		ValidatorShare: &beacon.Share{
+			NodeID:    1,
+			PublicKey: s.validatorPK,
+			Committee: committee,
+		},
+		Network:          net,
+		LeaderSelector:   &constant.Constant{LeaderIndex: 1},
+		Config:           qbft.DefaultConsensusParams(),
+		Identifier:       s.identifier,
+		Height:           1,
+		Fork:             fork,
+		Signer:           &fuzzSigner{sk: s.sks[1]},
+		ChangeRoundStore: &fuzzChangeRoundStore{},
+	}).(*instance.Instance)
+	inst.State().InputValue.Store([]byte("input value"))
+	inst.State().Round.Store(message.Round(1))
+	return inst
+}
+
+// seeds returns sequences of messages that go through the stages of an instance
+func (s *fuzzShare) seeds(v1 bool) [][]*message.SignedMessage {
+	value := []byte("value")
+	prepared := s.sign(v1, s.msg(message.PrepareMsgType, 1, &message.PrepareData{Data: value}), 2, 3, 4)
+	roundChange := &message.RoundChangeData{
+		PreparedValue:            value,
+		Round:                    1,
+		RoundChangeJustification: []*message.SignedMessage{prepared},
+	}
+
+	var happyFlow []*message.SignedMessage
+	happyFlow = append(happyFlow, s.sign(v1, s.msg(message.ProposalMsgType, 1, &message.ProposalData{Data: value}), 2))
+	happyFlow = append(happyFlow, s.each(v1, s.msg(message.PrepareMsgType, 1, &message.PrepareData{Data: value}), 2, 3, 4)...)
+	happyFlow = append(happyFlow, s.each(v1, s.msg(message.CommitMsgType, 1, &message.CommitData{Data: value}), 2, 3, 4)...)
+
+	var aggregated []*message.SignedMessage
+	aggregated = append(aggregated, happyFlow[:4]...)
+	aggregated = append(aggregated, s.sign(v1, s.msg(message.CommitMsgType, 1, &message.CommitData{Data: value}), 2, 3, 4))
+
+	var roundChanges []*message.SignedMessage
+	roundChanges = append(roundChanges, s.each(v1, s.msg(message.RoundChangeMsgType, 2, &message.RoundChangeData{}), 2, 3)...)
+	roundChanges = append(roundChanges, s.sign(v1, s.msg(message.RoundChangeMsgType, 2, roundChange), 4))
+	roundChanges = append(roundChanges, s.sign(v1, s.msg(message.ProposalMsgType, 2, &message.ProposalData{Data: value}), 2))
+	roundChanges = append(roundChanges, s.each(v1, s.msg(message.PrepareMsgType, 2, &message.PrepareData{Data: value}), 2, 3, 4)...)
+
+	return [][]*message.SignedMessage{happyFlow, aggregated, roundChanges}
+}
+
+func (s *fuzzShare) msg(msgType message.ConsensusMessageType, round message.Round, data interface{ Encode() ([]byte, error) }) *message.ConsensusMessage {
+	encoded, err := data.Encode()
+	if err != nil {
+		s.tb.Fatal(err)
+	}
+	return &message.ConsensusMessage{
+		MsgType:    msgType,
+		Height:     1,
+		Round:      round,
+		Identifier: s.identifier,
+		Data:       encoded,
+	}
+}
+
+// sign returns the message signed by the given signers, the signature is aggregated
+func (s *fuzzShare) sign(v1 bool, msg *message.ConsensusMessage, signers ...message.OperatorID) *message.SignedMessage {
+	var agg *bls.Sign
+	for _, id := range signers {
+		sig, err := msg.Sign(s.sks[id], forkVersion(v1))
+		if err != nil {
+			s.tb.Fatal(err)
+		}
+		if agg == nil {
+			agg = sig
+		} else {
+			agg.Add(sig)
+		}
+	}
+	return &message.SignedMessage{
+		Message:   msg,
+		Signature: agg.Serialize(),
+		Signers:   signers,
+	}
+}
+
+// each returns the message signed by each of the given signers
+func (s *fuzzShare) each(v1 bool, msg *message.ConsensusMessage, signers ...message.OperatorID) []*message.SignedMessage {
+	msgs := make([]*message.SignedMessage, 0, len(signers))
+	for _, id := range signers {
+		msgs = append(msgs, s.sign(v1, msg, id))
+	}
+	return msgs
+}
+
+func forkVersion(v1 bool) string {
+	if v1 {
+		return forksprotocol.V1ForkVersion.String()
+	}
+	return forksprotocol.V0ForkVersion.String()
+}
+
+type fuzzSigner struct {
+	sk *bls.SecretKey
+}
+
+func (s *fuzzSigner) SignIBFTMessage(msg *message.ConsensusMessage, pk []byte, forkVersion string) ([]byte, error) {
+	sig, err := msg.Sign(s.sk, forkVersion)
+	if err != nil {
+		return nil, err
+	}
+	return sig.Serialize(), nil
+}
+
+func (s *fuzzSigner) SignAttestation(data *spec.AttestationData, duty *beacon.Duty, pk []byte) (*spec.Attestation, []byte, error) {
+	return nil, nil, nil
+}
+
+func (s *fuzzSigner) SignValidatorRegistration(registration *beacon.ValidatorRegistration, pk []byte) ([]byte, []byte, error) {
+	return nil, nil, nil
+}
+
+type fuzzChangeRoundStore struct {
+	lock sync.Mutex
+	last *message.SignedMessage
+}
+
+var _ qbftstorage.ChangeRoundStore = (*fuzzChangeRoundStore)(nil)
+
+func (s *fuzzChangeRoundStore) GetLastChangeRoundMsg(identifier message.Identifier) (*message.SignedMessage, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.last, nil
+}
+
+func (s *fuzzChangeRoundStore) SaveLastChangeRoundMsg(msg *message.SignedMessage) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.last = msg
+	return nil
+}
+
+func (s *fuzzChangeRoundStore) CleanLastChangeRound(identifier message.Identifier) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.last = nil
+}
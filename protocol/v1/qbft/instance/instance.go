@@ -7,8 +7,10 @@ import (
 	"sync"
 	"time"
 
+	"go.opencensus.io/trace"
 	"go.uber.org/atomic"
 
+	"github.com/bloxapp/ssv/protocol/v1/qbft/journal"
 	"github.com/bloxapp/ssv/protocol/v1/qbft/pipelines"
 
 	"github.com/pkg/errors"
@@ -41,6 +43,12 @@ type Options struct {
 	Fork             forks.Fork
 	Signer           beaconprotocol.Signer
 	ChangeRoundStore qbftstorage.ChangeRoundStore
+	// Ctx holds the trace span of the duty, the spans of the instance are its children
+	Ctx context.Context
+	// Journal records the consensus events of the instance (optional)
+	Journal journal.Journal
+	// RoundTimer replaces the round timer of the instance (optional), a RoundTimer is used by default
+	RoundTimer roundtimer.Timer
 }
 
 // Instance defines the instance attributes
@@ -50,7 +58,7 @@ type Instance struct {
 	network        protcolp2p.Network
 	LeaderSelector leader.Selector
 	Config         *qbft.InstanceConfig
-	roundTimer     *roundtimer.RoundTimer
+	roundTimer     roundtimer.Timer
 	Logger         *zap.Logger
 	fork           forks.Fork
 	signer         beaconprotocol.Signer
@@ -79,6 +87,13 @@ type Instance struct {
 	stageChanCloseChan           sync.Mutex
 
 	changeRoundStore qbftstorage.ChangeRoundStore
+	journal          journal.Journal
+
+	// tracing
+	ctx       context.Context
+	span      *trace.Span
+	stageSpan *trace.Span
+	traceLock sync.Mutex
 }
 
 // NewInstanceWithState used for testing, not PROD!
@@ -109,8 +124,6 @@ func NewInstance(opts *Options) Instancer {
 		CommitMessages:      msgcontinmem.New(uint64(opts.ValidatorShare.ThresholdSize()), uint64(opts.ValidatorShare.PartialThresholdSize())),
 		ChangeRoundMessages: msgcontinmem.New(uint64(opts.ValidatorShare.ThresholdSize()), uint64(opts.ValidatorShare.PartialThresholdSize())),
 
-		roundTimer: roundtimer.New(context.Background(), logger.With(zap.String("who", "RoundTimer"))),
-
 		// locks
 		runInitOnce:                  &sync.Once{},
 		runStopOnce:                  &sync.Once{},
@@ -121,10 +134,18 @@ func NewInstance(opts *Options) Instancer {
 		stageChanCloseChan:           sync.Mutex{},
 
 		changeRoundStore: opts.ChangeRoundStore,
+		journal:          opts.Journal,
+
+		ctx: opts.Ctx,
 
 		stopped: *atomic.NewBool(false),
 	}
 
+	ret.roundTimer = opts.RoundTimer
+	if ret.roundTimer == nil {
+		ret.roundTimer = roundtimer.New(context.Background(), logger.With(zap.String("who", "RoundTimer")))
+	}
+
 	ret.setFork(opts.Fork)
 
 	return ret
@@ -166,6 +187,7 @@ func (i *Instance) Start(inputValue []byte) error {
 	}
 
 	i.Logger.Info("Node is starting iBFT instance", zap.String("Lambda", i.State().GetIdentifier().String()))
+	i.startTracing()
 	i.State().InputValue.Store(inputValue)
 	i.State().Round.Store(message.Round(1)) // start from 1
 	metricsIBFTRound.WithLabelValues(i.State().GetIdentifier().GetRoleType().String(), hex.EncodeToString(i.State().GetIdentifier().GetValidatorPK())).Set(1)
@@ -178,7 +200,8 @@ func (i *Instance) Start(inputValue []byte) error {
 
 			// LeaderPreprepareDelaySeconds waits to let other nodes complete their instance start or round change.
 			// Waiting will allow a more stable msg receiving for all parties.
-			time.Sleep(time.Duration(i.Config.LeaderPreprepareDelaySeconds))
+			// NOTE: there is no delay by default, as it adds to the latency of every instance
+			time.Sleep(i.Config.LeaderPreprepareDelay())
 
 			msg, err := i.generatePrePrepareMessage(i.State().GetInputValue())
 			if err != nil {
@@ -258,7 +281,9 @@ func (i *Instance) ProcessMsg(msg *message.SignedMessage) (bool, error) {
 		i.Logger.Warn("undefined message type", zap.Any("msg", msg))
 		return false, errors.Errorf("undefined message type")
 	}
+	i.recordMessage(msg)
 	if err := pp.Run(msg); err != nil {
+		i.recordPipelineFailure(msg, err)
 		return false, err
 	}
 
@@ -281,6 +306,7 @@ func (i *Instance) bumpToRound(round message.Round) {
 	role := i.State().GetIdentifier().GetRoleType()
 	pk := i.State().GetIdentifier().GetValidatorPK()
 	metricsIBFTRound.WithLabelValues(role.String(), hex.EncodeToString(pk)).Set(float64(newRound))
+	i.recordRound()
 }
 
 // ProcessStageChange set the state's round state and pushed the new state into the state channel
@@ -299,6 +325,8 @@ func (i *Instance) ProcessStageChange(stage qbft.RoundState) {
 	metricsIBFTStage.WithLabelValues(role, hex.EncodeToString(pk)).Set(float64(stage))
 
 	i.State().Stage.Store(int32(stage))
+	i.traceStage(stage)
+	i.recordStage(stage)
 
 	// blocking send to channel
 	i.stageChanCloseChan.Lock()
@@ -345,9 +373,10 @@ func (i *Instance) SignAndBroadcast(msg *message.ConsensusMessage) error {
 		return errors.New("failed to encode consensus message")
 	}
 	ssvMsg := message.SSVMessage{
-		MsgType: message.SSVConsensusMsgType,
-		ID:      i.State().GetIdentifier(),
-		Data:    encodedMsg,
+		MsgType:      message.SSVConsensusMsgType,
+		ID:           i.State().GetIdentifier(),
+		Data:         encodedMsg,
+		TraceContext: i.traceContext(),
 	}
 	if i.network != nil {
 		return i.network.Broadcast(ssvMsg)
@@ -0,0 +1,70 @@
+package instance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
+	"github.com/bloxapp/ssv/protocol/v1/message"
+	protocolp2p "github.com/bloxapp/ssv/protocol/v1/p2p"
+	"github.com/bloxapp/ssv/protocol/v1/qbft"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/instance/leader/constant"
+)
+
+// broadcastRecorder records the times of broadcasted messages
+type broadcastRecorder struct {
+	protocolp2p.MockNetwork
+	broadcasts chan time.Time
+}
+
+func (n *broadcastRecorder) Broadcast(msg message.SSVMessage) error {
+	n.broadcasts <- time.Now()
+	return nil
+}
+
+func TestInstance_LeaderPreprepareDelay(t *testing.T) {
+	// the delay is opt-in, it adds to the latency of every instance
+	require.Zero(t, qbft.DefaultConsensusParams().LeaderPreprepareDelay())
+
+	secretKeys, nodes := GenerateNodes(4)
+	pi, err := protocolp2p.GenPeerID()
+	require.NoError(t, err)
+	network := &broadcastRecorder{
+		MockNetwork: protocolp2p.NewMockNetwork(zap.L(), pi, 10),
+		broadcasts:  make(chan time.Time, 1),
+	}
+	config := qbft.DefaultConsensusParams()
+	config.LeaderPreprepareDelaySeconds = 0.2
+	i := NewInstance(&Options{
+		Logger: zap.L(),
+		ValidatorShare: &beacon.Share{
+			Committee: nodes,
+			NodeID:    1,
+			PublicKey: secretKeys[1].GetPublicKey(),
+		},
+		Network:        network,
+		LeaderSelector: &constant.Constant{LeaderIndex: 0},
+		Config:         config,
+		Identifier:     message.NewIdentifier([]byte("pk"), message.RoleTypeAttester),
+		Height:         1,
+		Signer:         newTestSigner(),
+		Ctx:            context.Background(),
+	}).(*Instance)
+	i.fork = testingFork(i)
+	defer i.Stop()
+
+	i.Init()
+	start := time.Now()
+	require.NoError(t, i.Start([]byte("value")))
+	select {
+	case broadcasted := <-network.broadcasts:
+		// the leader waits before broadcasting the proposal
+		require.GreaterOrEqual(t, int64(broadcasted.Sub(start)), int64(config.LeaderPreprepareDelay()))
+	case <-time.After(2 * time.Second):
+		t.Fatal("proposal was not broadcasted")
+	}
+}
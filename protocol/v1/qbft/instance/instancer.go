@@ -1,6 +1,9 @@
 package instance
 
 import (
+	"context"
+	"time"
+
 	"github.com/bloxapp/ssv/protocol/v1/message"
 	"github.com/bloxapp/ssv/protocol/v1/qbft"
 	"github.com/bloxapp/ssv/protocol/v1/qbft/validation"
@@ -10,12 +13,17 @@ import (
 
 // ControllerStartInstanceOptions defines type for Controller instance options
 type ControllerStartInstanceOptions struct {
+	// Ctx holds the trace span of the duty (optional)
+	Ctx       context.Context
 	Logger    *zap.Logger
 	SeqNumber message.Height
 	Value     []byte
 	// RequireMinPeers flag to require minimum peers before starting an instance
 	// useful for tests where we want (sometimes) to avoid networking
 	RequireMinPeers bool
+	// Deadline is the start time of the duty's slot, it is used to prioritize the processing of messages
+	// and to abort the instance once the duty deadline (InstanceConfig.DutyDeadline) has passed (optional)
+	Deadline time.Time
 }
 
 // Result is a struct holding the result of a single iBFT instance
@@ -0,0 +1,62 @@
+package instance
+
+import (
+	"github.com/bloxapp/ssv/protocol/v1/message"
+	"github.com/bloxapp/ssv/protocol/v1/qbft"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/journal"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/pipelines"
+)
+
+func (i *Instance) newJournalEvent(eventType journal.EventType) journal.Event {
+	return journal.NewEvent(eventType, i.State().GetIdentifier(), i.State().GetHeight(), i.State().GetRound())
+}
+
+// recordMessage records a message that was received by the instance
+func (i *Instance) recordMessage(msg *message.SignedMessage) {
+	if i.journal == nil {
+		return
+	}
+	e := journal.NewEvent(journal.EventMessage, i.State().GetIdentifier(), msg.Message.Height, msg.Message.Round)
+	e.MsgType = msg.Message.MsgType.String()
+	e.Signers = msg.GetSigners()
+	i.journal.Record(e)
+}
+
+// recordPipelineFailure records a message that failed in one of the pipelines
+func (i *Instance) recordPipelineFailure(msg *message.SignedMessage, err error) {
+	if i.journal == nil {
+		return
+	}
+	e := journal.NewEvent(journal.EventPipelineFailure, i.State().GetIdentifier(), msg.Message.Height, msg.Message.Round)
+	e.MsgType = msg.Message.MsgType.String()
+	e.Signers = msg.GetSigners()
+	e.Pipeline, _ = pipelines.FailedPipeline(err)
+	e.Error = err.Error()
+	i.journal.Record(e)
+}
+
+// recordTimeout records an expiration of the round timer
+func (i *Instance) recordTimeout() {
+	if i.journal == nil {
+		return
+	}
+	i.journal.Record(i.newJournalEvent(journal.EventTimeout))
+}
+
+// recordStage records a state transition
+func (i *Instance) recordStage(stage qbft.RoundState) {
+	if i.journal == nil {
+		return
+	}
+	e := i.newJournalEvent(journal.EventStage)
+	e.Stage = qbft.RoundStateName[int32(stage)]
+	i.journal.Record(e)
+}
+
+// recordRound records the move to a new round
+func (i *Instance) recordRound() {
+	if i.journal == nil {
+		return
+	}
+	i.journal.Record(i.newJournalEvent(journal.EventRound))
+}
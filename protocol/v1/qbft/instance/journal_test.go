@@ -0,0 +1,49 @@
+package instance
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/protocol/v1/message"
+	"github.com/bloxapp/ssv/protocol/v1/qbft"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/journal"
+)
+
+type testJournal struct {
+	lock   sync.Mutex
+	events []journal.Event
+}
+
+func (j *testJournal) Record(e journal.Event) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	j.events = append(j.events, e)
+}
+
+func (j *testJournal) Close() error {
+	return nil
+}
+
+func TestInstanceJournal(t *testing.T) {
+	j := &testJournal{}
+	i := newTracedInstance(nil)
+	i.journal = j
+
+	i.ProcessStageChange(qbft.RoundStatePrePrepare)
+	i.recordTimeout()
+	i.bumpToRound(2)
+	i.ProcessStageChange(qbft.RoundStateChangeRound)
+
+	require.Len(t, j.events, 4)
+	require.Equal(t, journal.EventStage, j.events[0].Type)
+	require.Equal(t, "PrePrepare", j.events[0].Stage)
+	require.Equal(t, message.Height(3), j.events[0].Height)
+	require.Equal(t, "ATTESTER", j.events[0].Role)
+	require.Equal(t, journal.EventTimeout, j.events[1].Type)
+	require.Equal(t, message.Round(1), j.events[1].Round)
+	require.Equal(t, journal.EventRound, j.events[2].Type)
+	require.Equal(t, message.Round(2), j.events[2].Round)
+	require.Equal(t, "ChangeRound", j.events[3].Stage)
+}
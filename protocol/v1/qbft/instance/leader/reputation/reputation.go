@@ -0,0 +1,83 @@
+package reputation
+
+import (
+	"github.com/bloxapp/ssv/protocol/v1/qbft/instance/leader/deterministic"
+)
+
+// Window is the default amount of heights that share the same reputation window
+const Window = 32
+
+// SeedFunc returns the seed of the deterministic leader selection of the given height
+type SeedFunc func(height uint64) []byte
+
+// Reputation is a leader selection that skips operators that failed to lead recently.
+//
+// the base order of each height is the round-robin of deterministic.Deterministic.
+// heights are grouped into fixed windows (see WindowStart), the reputation is replayed from the start of the window:
+// the leaders of the rounds that failed in a previous height of the window (under the order that was used in that height)
+// are penalized, penalized operators are moved to the end of the order until the window ends.
+// as the penalties are derived only from the decided history, all operators select the same leaders without extra messages.
+type Reputation struct {
+	order []uint64
+}
+
+// New returns the leader selection of the given height, decidedRounds maps heights to the round they were decided in.
+// only the previous heights of the window are used, missing heights are considered as decided in round 1
+func New(seed SeedFunc, committeeSize uint64, height uint64, window uint64, decidedRounds map[uint64]uint64) (*Reputation, error) {
+	penalized := make(map[uint64]bool)
+	for h := WindowStart(height, window); h < height; h++ {
+		order, err := leaderOrder(seed(h), committeeSize, penalized)
+		if err != nil {
+			return nil, err
+		}
+		round, ok := decidedRounds[h]
+		if !ok {
+			continue
+		}
+		// the leaders of all the rounds before the decided round failed to lead
+		for r := uint64(1); r < round && r <= committeeSize; r++ {
+			penalized[order[r-1]] = true
+		}
+	}
+	order, err := leaderOrder(seed(height), committeeSize, penalized)
+	if err != nil {
+		return nil, err
+	}
+	return &Reputation{order: order}, nil
+}
+
+// WindowStart returns the first height of the reputation window of the given height
+func WindowStart(height uint64, window uint64) uint64 {
+	if window == 0 {
+		return height
+	}
+	return height - height%window
+}
+
+// Calculate returns the leader of the given round
+func (r *Reputation) Calculate(round uint64) uint64 {
+	if round == 0 {
+		round = 1
+	}
+	return r.order[(round-1)%uint64(len(r.order))]
+}
+
+// leaderOrder returns the leaders of rounds 1 to committeeSize,
+// reputable operators first in the order of the base rotation, followed by the penalized operators
+func leaderOrder(seed []byte, committeeSize uint64, penalized map[uint64]bool) ([]uint64, error) {
+	base, err := deterministic.New(seed, committeeSize)
+	if err != nil {
+		return nil, err
+	}
+	order := make([]uint64, 0, committeeSize)
+	var skipped []uint64
+	for r := uint64(1); r <= committeeSize; r++ {
+		leader := base.Calculate(r)
+		if penalized[leader] {
+			skipped = append(skipped, leader)
+			continue
+		}
+		order = append(order, leader)
+	}
+	return append(order, skipped...), nil
+}
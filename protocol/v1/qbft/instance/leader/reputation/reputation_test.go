@@ -0,0 +1,121 @@
+package reputation
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/protocol/v1/qbft/instance/leader/deterministic"
+)
+
+func testSeed(h uint64) []byte {
+	return []byte("identifier" + strconv.FormatUint(h, 10))
+}
+
+// baseLeader returns the round-robin leader of round 1 in the given height
+func baseLeader(t *testing.T, committee uint64, h uint64) uint64 {
+	d, err := deterministic.New(testSeed(h), committee)
+	require.NoError(t, err)
+	return d.Calculate(1)
+}
+
+func TestReputation_NoHistory(t *testing.T) {
+	// without failures the order is the same as the deterministic round-robin
+	for h := uint64(0); h < 20; h++ {
+		r, err := New(testSeed, 4, h, Window, nil)
+		require.NoError(t, err)
+		d, err := deterministic.New(testSeed(h), 4)
+		require.NoError(t, err)
+		for round := uint64(1); round < 10; round++ {
+			require.Equal(t, d.Calculate(round), r.Calculate(round), "height %d round %d", h, round)
+		}
+	}
+}
+
+func TestReputation_Penalty(t *testing.T) {
+	committee := uint64(4)
+	height := uint64(10)
+	// finding a previous height of the window where the round 1 leader is the same as the one of the current height
+	failing := baseLeader(t, committee, height)
+	failedHeight := uint64(0)
+	for h := height - 1; h > 0; h-- {
+		if baseLeader(t, committee, h) == failing {
+			failedHeight = h
+			break
+		}
+	}
+	require.NotZero(t, failedHeight)
+
+	t.Run("penalized", func(t *testing.T) {
+		r, err := New(testSeed, committee, height, Window, map[uint64]uint64{failedHeight: 2})
+		require.NoError(t, err)
+		require.NotEqual(t, failing, r.Calculate(1))
+		// the penalized operator is the last one
+		require.Equal(t, failing, r.Calculate(committee))
+		// all operators are leaders once
+		leaders := make(map[uint64]bool)
+		for round := uint64(1); round <= committee; round++ {
+			leaders[r.Calculate(round)] = true
+		}
+		require.Len(t, leaders, int(committee))
+	})
+
+	t.Run("decided in round 1", func(t *testing.T) {
+		r, err := New(testSeed, committee, height, Window, map[uint64]uint64{failedHeight: 1})
+		require.NoError(t, err)
+		require.Equal(t, failing, r.Calculate(1))
+	})
+
+	t.Run("previous window", func(t *testing.T) {
+		// the failure is in the window of [0, window), the current height starts a new window
+		window := height - failedHeight + 1
+		next := WindowStart(height, window)
+		require.Greater(t, next, failedHeight)
+		r, err := New(testSeed, committee, next, window, map[uint64]uint64{failedHeight: 2})
+		require.NoError(t, err)
+		d, err := deterministic.New(testSeed(next), committee)
+		require.NoError(t, err)
+		require.Equal(t, d.Calculate(1), r.Calculate(1))
+	})
+}
+
+func TestReputation_SubstitutedLeaderFails(t *testing.T) {
+	committee := uint64(4)
+	// two heights of the window with the same round-robin leader of round 1
+	first, second := uint64(0), uint64(0)
+	for h := uint64(1); h < Window-1 && second == 0; h++ {
+		for prev := uint64(0); prev < h; prev++ {
+			if baseLeader(t, committee, prev) == baseLeader(t, committee, h) {
+				first, second = prev, h
+				break
+			}
+		}
+	}
+	require.NotZero(t, second)
+	offline := baseLeader(t, committee, first)
+
+	// the offline operator failed in the first height, so another operator leads round 1 of the second height
+	r, err := New(testSeed, committee, second, Window, map[uint64]uint64{first: 2})
+	require.NoError(t, err)
+	substitute := r.Calculate(1)
+	require.NotEqual(t, offline, substitute)
+
+	// the substitute fails as well, both operators are penalized in the next height
+	r, err = New(testSeed, committee, second+1, Window, map[uint64]uint64{first: 2, second: 2})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []uint64{offline, substitute}, []uint64{r.Calculate(3), r.Calculate(4)})
+	require.NotContains(t, []uint64{offline, substitute}, r.Calculate(1))
+
+	// the leaders of all the failed rounds are penalized
+	r, err = New(testSeed, committee, second+1, Window, map[uint64]uint64{first: 3})
+	require.NoError(t, err)
+	firstOrder, err := New(testSeed, committee, first, Window, nil)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []uint64{firstOrder.Calculate(1), firstOrder.Calculate(2)}, []uint64{r.Calculate(3), r.Calculate(4)})
+}
+
+func TestReputation_InvalidSeed(t *testing.T) {
+	_, err := New(func(h uint64) []byte { return nil }, 4, 1, Window, nil)
+	require.EqualError(t, err, "input seed can't be nil or of length 0")
+}
@@ -313,6 +313,10 @@ func (s *testSigner) SignAttestation(data *spec.AttestationData, duty *beacon.Du
 	return nil, nil, nil
 }
 
+func (s *testSigner) SignValidatorRegistration(registration *beacon.ValidatorRegistration, pk []byte) ([]byte, []byte, error) {
+	return nil, nil, nil
+}
+
 func proposalDataToBytes(t *testing.T, input *message.ProposalData) []byte {
 	ret, err := json.Marshal(input)
 	require.NoError(t, err)
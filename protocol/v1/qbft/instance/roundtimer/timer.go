@@ -15,6 +15,19 @@ const (
 	stateRunning   uint32 = 2
 )
 
+// Timer is the round timer of an instance, see RoundTimer.
+// it can be replaced (e.g. by a virtual clock in simulations) using instance.Options
+type Timer interface {
+	// ResultChan returns the result chan, true if the timer lapsed or false if it was stopped
+	ResultChan() <-chan bool
+	// Reset starts the timer with the given timeout, the previous timeout is canceled
+	Reset(d time.Duration)
+	// Kill stops the timer
+	Kill()
+	// Stopped returns whether the timer has stopped
+	Stopped() bool
+}
+
 // RoundTimer helps to manage current instance rounds.
 // it should be killed (Kill()) once the instance finished and recreated for each new IBFT instance,
 // in that case 'false' is returned in result channel.
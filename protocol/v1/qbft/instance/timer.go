@@ -12,6 +12,7 @@ loop:
 		}
 		res := <-i.roundTimer.ResultChan()
 		if res { // timed out
+			i.recordTimeout()
 			i.uponChangeRoundTrigger()
 		} else { // stopped
 			i.Logger.Info("stopped timeout clock", zap.Uint64("round", uint64(i.State().GetRound())))
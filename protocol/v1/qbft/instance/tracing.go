@@ -0,0 +1,91 @@
+package instance
+
+import (
+	"context"
+	"encoding/hex"
+
+	"go.opencensus.io/trace"
+
+	"github.com/bloxapp/ssv/monitoring/tracing"
+	"github.com/bloxapp/ssv/protocol/v1/qbft"
+)
+
+// stageSpanNames maps a stage to the name of the span that follows it,
+// i.e. the span measures the time it takes to reach the next stage
+var stageSpanNames = map[qbft.RoundState]string{
+	qbft.RoundStateNotStarted:  "qbft.pre-prepare",
+	qbft.RoundStatePrePrepare:  "qbft.prepare",
+	qbft.RoundStatePrepare:     "qbft.commit",
+	qbft.RoundStateCommit:      "qbft.commit",
+	qbft.RoundStateChangeRound: "qbft.round-change",
+}
+
+// startTracing starts the span of the instance (as a child of the duty span, if exist) and the first stage span
+func (i *Instance) startTracing() {
+	i.traceLock.Lock()
+	defer i.traceLock.Unlock()
+
+	if i.span != nil {
+		return
+	}
+	identifier := i.State().GetIdentifier()
+	height := i.State().GetHeight()
+	_, i.span = tracing.StartSpan(i.ctx, "qbft.Instance",
+		trace.StringAttribute("role", identifier.GetRoleType().String()),
+		trace.StringAttribute("pubkey", hex.EncodeToString(identifier.GetValidatorPK())),
+		trace.Int64Attribute("height", int64(height)),
+		trace.Int64Attribute("operator_id", int64(i.ValidatorShare.NodeID)))
+	i.startStageSpan(qbft.RoundStateNotStarted)
+}
+
+// traceStage ends the current stage span and starts the span of the next stage.
+// the instance span ends once the instance was decided or stopped
+func (i *Instance) traceStage(stage qbft.RoundState) {
+	i.traceLock.Lock()
+	defer i.traceLock.Unlock()
+
+	if i.span == nil {
+		return
+	}
+	if i.stageSpan != nil {
+		i.stageSpan.End()
+		i.stageSpan = nil
+	}
+	switch stage {
+	case qbft.RoundStateDecided:
+		i.span.AddAttributes(trace.Int64Attribute("round", int64(i.State().GetRound())))
+		i.span.End()
+		i.span = nil
+	case qbft.RoundStateStopped:
+		i.span.SetStatus(trace.Status{Code: trace.StatusCodeAborted, Message: "stopped before decided"})
+		i.span.End()
+		i.span = nil
+	default:
+		i.startStageSpan(stage)
+	}
+}
+
+// traceContext returns the encoded span context that is attached to broadcasted messages
+func (i *Instance) traceContext() []byte {
+	i.traceLock.Lock()
+	defer i.traceLock.Unlock()
+
+	if i.stageSpan != nil {
+		return tracing.InjectSpan(i.stageSpan)
+	}
+	return tracing.InjectSpan(i.span)
+}
+
+// startStageSpan should be called while holding traceLock
+func (i *Instance) startStageSpan(stage qbft.RoundState) {
+	name, ok := stageSpanNames[stage]
+	if !ok {
+		return
+	}
+	ctx := i.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	_, i.stageSpan = tracing.StartSpan(trace.NewContext(ctx, i.span), name,
+		trace.Int64Attribute("round", int64(i.State().GetRound())))
+}
@@ -0,0 +1,106 @@
+package instance
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/trace"
+
+	"github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
+	"github.com/bloxapp/ssv/protocol/v1/message"
+	"github.com/bloxapp/ssv/protocol/v1/qbft"
+)
+
+type testExporter struct {
+	lock  sync.Mutex
+	spans []*trace.SpanData
+}
+
+func (e *testExporter) ExportSpan(sd *trace.SpanData) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.spans = append(e.spans, sd)
+}
+
+func (e *testExporter) names() []string {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	var names []string
+	for _, sd := range e.spans {
+		names = append(names, sd.Name)
+	}
+	return names
+}
+
+func newTracedInstance(ctx context.Context) *Instance {
+	i := &Instance{
+		ValidatorShare: &beacon.Share{NodeID: 1},
+		ctx:            ctx,
+		state: &qbft.State{
+			Round:  qbft.NewRound(message.Round(1)),
+			Height: qbft.NewHeight(message.Height(3)),
+		},
+	}
+	i.state.Identifier.Store(message.NewIdentifier([]byte("pk"), message.RoleTypeAttester))
+	return i
+}
+
+func TestInstanceTracing(t *testing.T) {
+	exp := &testExporter{}
+	trace.RegisterExporter(exp)
+	defer trace.UnregisterExporter(exp)
+
+	t.Run("decided", func(t *testing.T) {
+		exp.spans = nil
+		ctx, duty := trace.StartSpan(context.Background(), "duty", trace.WithSampler(trace.AlwaysSample()))
+		i := newTracedInstance(ctx)
+		i.startTracing()
+		require.NotNil(t, i.traceContext())
+
+		i.ProcessStageChange(qbft.RoundStatePrePrepare)
+		i.ProcessStageChange(qbft.RoundStatePrepare)
+		i.ProcessStageChange(qbft.RoundStateDecided)
+		i.ProcessStageChange(qbft.RoundStateStopped)
+		require.Nil(t, i.traceContext())
+		duty.End()
+
+		require.Equal(t, []string{"qbft.pre-prepare", "qbft.prepare", "qbft.commit", "qbft.Instance", "duty"}, exp.names())
+		instanceSpan := exp.spans[3]
+		require.Equal(t, duty.SpanContext().SpanID, instanceSpan.ParentSpanID)
+		require.Equal(t, int32(trace.StatusCodeOK), instanceSpan.Code)
+		require.Equal(t, int64(3), instanceSpan.Attributes["height"])
+		for _, stage := range exp.spans[:3] {
+			require.Equal(t, instanceSpan.SpanID, stage.ParentSpanID)
+		}
+	})
+
+	t.Run("round change and stopped", func(t *testing.T) {
+		exp.spans = nil
+		ctx, duty := trace.StartSpan(context.Background(), "duty", trace.WithSampler(trace.AlwaysSample()))
+		i := newTracedInstance(ctx)
+		i.startTracing()
+
+		i.State().Round.Store(message.Round(2))
+		i.ProcessStageChange(qbft.RoundStateChangeRound)
+		i.ProcessStageChange(qbft.RoundStateStopped)
+		duty.End()
+
+		require.Equal(t, []string{"qbft.pre-prepare", "qbft.round-change", "qbft.Instance", "duty"}, exp.names())
+		require.Equal(t, int64(2), exp.spans[1].Attributes["round"])
+		require.Equal(t, int32(trace.StatusCodeAborted), exp.spans[2].Code)
+	})
+
+	t.Run("not traced", func(t *testing.T) {
+		exp.spans = nil
+		trace.ApplyConfig(trace.Config{DefaultSampler: trace.NeverSample()})
+		defer trace.ApplyConfig(trace.Config{DefaultSampler: trace.ProbabilitySampler(1e-4)})
+		i := newTracedInstance(nil)
+		i.startTracing()
+		i.ProcessStageChange(qbft.RoundStatePrePrepare)
+		require.Nil(t, i.traceContext())
+		i.ProcessStageChange(qbft.RoundStateStopped)
+		require.Empty(t, exp.names())
+	})
+}
@@ -0,0 +1,218 @@
+package journal
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+)
+
+const (
+	filePrefix = "journal-"
+	fileSuffix = ".jsonl"
+	// filesCount is the amount of files that the journal is split into,
+	// once the journal exceeds its max size the oldest file is removed
+	filesCount    = 8
+	minFileSize   = 64 * 1024
+	queueSize     = 2048
+	flushInterval = time.Second
+)
+
+// fileJournal writes events as json lines into rotated files.
+// events are written in the background, they are dropped if the queue is full
+type fileJournal struct {
+	logger      *zap.Logger
+	dir         string
+	maxSize     int64
+	maxFileSize int64
+
+	queue   chan Event
+	dropped atomic.Int64
+
+	file    *os.File
+	w       *bufio.Writer
+	written int64
+
+	stopC chan struct{}
+	doneC chan struct{}
+	once  sync.Once
+}
+
+func newFileJournal(logger *zap.Logger, dir string, maxSize int64) (*fileJournal, error) {
+	if maxSize <= 0 {
+		return nil, errors.New("journal max size must be positive")
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, errors.Wrap(err, "could not create journal dir")
+	}
+	maxFileSize := maxSize / filesCount
+	if maxFileSize < minFileSize {
+		maxFileSize = minFileSize
+	}
+	j := &fileJournal{
+		logger:      logger.With(zap.String("who", "consensusJournal")),
+		dir:         dir,
+		maxSize:     maxSize,
+		maxFileSize: maxFileSize,
+		queue:       make(chan Event, queueSize),
+		stopC:       make(chan struct{}),
+		doneC:       make(chan struct{}),
+	}
+	if err := j.openFile(); err != nil {
+		return nil, err
+	}
+	go j.loop()
+	return j, nil
+}
+
+// Record implements Journal
+func (j *fileJournal) Record(e Event) {
+	select {
+	case j.queue <- e:
+	default:
+		j.dropped.Inc()
+	}
+}
+
+// Close implements Journal
+func (j *fileJournal) Close() error {
+	var err error
+	j.once.Do(func() {
+		close(j.stopC)
+		<-j.doneC
+		err = j.closeFile()
+	})
+	return err
+}
+
+func (j *fileJournal) loop() {
+	defer close(j.doneC)
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case e := <-j.queue:
+			j.write(e)
+		case <-ticker.C:
+			j.flush()
+		case <-j.stopC:
+			// writing the remaining events
+			for {
+				select {
+				case e := <-j.queue:
+					j.write(e)
+				default:
+					j.flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+func (j *fileJournal) write(e Event) {
+	raw, err := json.Marshal(e)
+	if err != nil {
+		j.logger.Debug("could not encode event", zap.Error(err))
+		return
+	}
+	n, err := j.w.Write(append(raw, '\n'))
+	j.written += int64(n)
+	if err != nil {
+		j.logger.Warn("could not write event", zap.Error(err))
+		return
+	}
+	if j.written >= j.maxFileSize {
+		if err := j.rotate(); err != nil {
+			j.logger.Warn("could not rotate journal file", zap.Error(err))
+		}
+	}
+}
+
+func (j *fileJournal) flush() {
+	if dropped := j.dropped.Swap(0); dropped > 0 {
+		j.logger.Warn("events were dropped as the queue is full", zap.Int64("dropped", dropped))
+	}
+	if err := j.w.Flush(); err != nil {
+		j.logger.Warn("could not flush journal", zap.Error(err))
+	}
+}
+
+// rotate closes the current file and opens a new one, the oldest files are removed if the journal is too big
+func (j *fileJournal) rotate() error {
+	if err := j.closeFile(); err != nil {
+		return err
+	}
+	if err := j.openFile(); err != nil {
+		return err
+	}
+	return j.prune()
+}
+
+func (j *fileJournal) openFile() error {
+	name := filepath.Join(j.dir, fmt.Sprintf("%s%020d%s", filePrefix, time.Now().UnixNano(), fileSuffix))
+	f, err := os.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return errors.Wrap(err, "could not open journal file")
+	}
+	j.file = f
+	j.w = bufio.NewWriter(f)
+	j.written = 0
+	return nil
+}
+
+func (j *fileJournal) closeFile() error {
+	if err := j.w.Flush(); err != nil {
+		return errors.Wrap(err, "could not flush journal file")
+	}
+	return j.file.Close()
+}
+
+// prune removes the oldest files while the total size exceeds the max size, the current file is kept
+func (j *fileJournal) prune() error {
+	files, err := journalFiles(j.dir)
+	if err != nil {
+		return err
+	}
+	var total int64
+	for _, f := range files {
+		total += f.Size()
+	}
+	for len(files) > 1 && total > j.maxSize {
+		if err := os.Remove(filepath.Join(j.dir, files[0].Name())); err != nil {
+			return errors.Wrap(err, "could not remove journal file")
+		}
+		total -= files[0].Size()
+		files = files[1:]
+	}
+	return nil
+}
+
+// journalFiles returns the journal files in the given dir, from oldest to newest
+func journalFiles(dir string) ([]os.FileInfo, error) {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read journal dir")
+	}
+	var files []os.FileInfo
+	for _, info := range infos {
+		if info.IsDir() || !strings.HasPrefix(info.Name(), filePrefix) || !strings.HasSuffix(info.Name(), fileSuffix) {
+			continue
+		}
+		files = append(files, info)
+	}
+	sort.Slice(files, func(i, k int) bool {
+		return files[i].Name() < files[k].Name()
+	})
+	return files, nil
+}
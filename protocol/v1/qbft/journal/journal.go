@@ -0,0 +1,93 @@
+package journal
+
+import (
+	"encoding/hex"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/protocol/v1/message"
+)
+
+// Options are the configurations of the journal
+type Options struct {
+	Dir     string `yaml:"Dir" env:"JOURNAL_DIR" env-description:"Directory of the consensus events journal, the journal is disabled if empty"`
+	MaxSize int    `yaml:"MaxSize" env:"JOURNAL_MAX_SIZE" env-default:"128" env-description:"Max size (MB) of the journal on disk, the oldest events are removed once exceeded"`
+}
+
+// Enabled returns true if the journal should be written
+func (o Options) Enabled() bool {
+	return len(o.Dir) > 0
+}
+
+// EventType is the type of a journal event
+type EventType string
+
+// EventType values
+const (
+	// EventMessage is recorded for every consensus message that was received by an instance
+	EventMessage EventType = "message"
+	// EventPipelineFailure is recorded when a message failed in one of the validation pipelines
+	EventPipelineFailure EventType = "pipeline_failure"
+	// EventTimeout is recorded when the round timer expires
+	EventTimeout EventType = "timeout"
+	// EventStage is recorded upon a state transition of the instance
+	EventStage EventType = "stage"
+	// EventRound is recorded when the instance moves to a new round
+	EventRound EventType = "round"
+)
+
+// Event is a single consensus event of an instance
+type Event struct {
+	Time   time.Time      `json:"time"`
+	Type   EventType      `json:"type"`
+	PubKey string         `json:"pubkey"`
+	Role   string         `json:"role"`
+	Height message.Height `json:"height"`
+	// Round is the round of the message for message events, otherwise the round of the instance
+	Round    message.Round        `json:"round"`
+	MsgType  string               `json:"msg_type,omitempty"`
+	Signers  []message.OperatorID `json:"signers,omitempty"`
+	Pipeline string               `json:"pipeline,omitempty"`
+	Stage    string               `json:"stage,omitempty"`
+	Error    string               `json:"error,omitempty"`
+}
+
+// NewEvent creates a new event of the given instance
+func NewEvent(eventType EventType, identifier message.Identifier, height message.Height, round message.Round) Event {
+	return Event{
+		Time:   time.Now(),
+		Type:   eventType,
+		PubKey: hex.EncodeToString(identifier.GetValidatorPK()),
+		Role:   identifier.GetRoleType().String(),
+		Height: height,
+		Round:  round,
+	}
+}
+
+// Journal records consensus events, recording must not block the consensus
+type Journal interface {
+	// Record records the given event
+	Record(e Event)
+	// Close flushes the pending events and closes the journal
+	Close() error
+}
+
+// New creates a new journal according to the given options, a nop journal is returned if disabled
+func New(logger *zap.Logger, opts Options) (Journal, error) {
+	if !opts.Enabled() {
+		return Nop(), nil
+	}
+	return newFileJournal(logger, opts.Dir, int64(opts.MaxSize)*1024*1024)
+}
+
+type nopJournal struct{}
+
+// Nop returns a journal that drops all events
+func Nop() Journal {
+	return nopJournal{}
+}
+
+func (nopJournal) Record(e Event) {}
+
+func (nopJournal) Close() error { return nil }
@@ -0,0 +1,126 @@
+package journal
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/protocol/v1/message"
+)
+
+var testIdentifier = message.NewIdentifier([]byte{0xab, 0xcd}, message.RoleTypeAttester)
+
+func TestNew(t *testing.T) {
+	j, err := New(zap.L(), Options{})
+	require.NoError(t, err)
+	require.Equal(t, Nop(), j)
+	j.Record(NewEvent(EventTimeout, testIdentifier, 1, 1))
+	require.NoError(t, j.Close())
+}
+
+func TestFileJournal(t *testing.T) {
+	dir := t.TempDir()
+	j, err := New(zap.L(), Options{Dir: dir, MaxSize: 1})
+	require.NoError(t, err)
+
+	start := time.Now()
+	msg := NewEvent(EventMessage, testIdentifier, 2, 1)
+	msg.Time = start
+	msg.MsgType = message.ProposalMsgType.String()
+	msg.Signers = []message.OperatorID{1}
+	j.Record(msg)
+	failure := NewEvent(EventPipelineFailure, testIdentifier, 2, 1)
+	failure.Time = start.Add(time.Millisecond)
+	failure.MsgType = message.PrepareMsgType.String()
+	failure.Signers = []message.OperatorID{3}
+	failure.Pipeline = "validate signature"
+	failure.Error = "invalid signature"
+	j.Record(failure)
+	timeout := NewEvent(EventTimeout, testIdentifier, 2, 1)
+	timeout.Time = start.Add(2 * time.Second)
+	j.Record(timeout)
+	round := NewEvent(EventRound, testIdentifier, 2, 2)
+	round.Time = start.Add(2001 * time.Millisecond)
+	j.Record(round)
+	// other instances
+	j.Record(NewEvent(EventStage, testIdentifier, 3, 1))
+	j.Record(NewEvent(EventStage, message.NewIdentifier([]byte{0xab, 0xcd}, message.RoleTypeValidatorRegistration), 2, 1))
+	require.NoError(t, j.Close())
+
+	events, err := Read(dir, Filter{PubKey: "ABCD", Role: "ATTESTER", Height: 2})
+	require.NoError(t, err)
+	require.Len(t, events, 4)
+	require.Equal(t, EventMessage, events[0].Type)
+	require.Equal(t, []message.OperatorID{1}, events[0].Signers)
+	require.Equal(t, "validate signature", events[1].Pipeline)
+
+	all, err := Read(dir, Filter{PubKey: "abcd", Height: 2})
+	require.NoError(t, err)
+	require.Len(t, all, 5)
+
+	out := new(bytes.Buffer)
+	require.NoError(t, WriteTimeline(out, events))
+	lines := bytes.Split(bytes.TrimSpace(out.Bytes()), []byte("\n"))
+	require.Len(t, lines, 4)
+	require.Contains(t, string(lines[0]), "+0.000s")
+	require.Contains(t, string(lines[0]), "received propose from [1]")
+	require.Contains(t, string(lines[1]), `prepare from [3] failed in pipeline "validate signature": invalid signature`)
+	require.Contains(t, string(lines[2]), "+2.000s")
+	require.Contains(t, string(lines[2]), "round timer expired")
+	require.Contains(t, string(lines[3]), "moved to round 2")
+
+	out.Reset()
+	require.NoError(t, WriteJSON(out, events[:1]))
+	require.Contains(t, out.String(), `"msg_type": "propose"`)
+}
+
+func TestRead_MalformedLines(t *testing.T) {
+	dir := t.TempDir()
+	content := `{"type":"timeout","pubkey":"abcd","role":"ATTESTER","height":1,"round":1}
+{"type":"stage","pubkey":"abcd","rol`
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, filePrefix+"1"+fileSuffix), []byte(content), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "other.txt"), []byte(content), 0600))
+
+	events, err := Read(dir, Filter{PubKey: "abcd", Height: 1})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, EventTimeout, events[0].Type)
+}
+
+func TestFileJournal_Rotation(t *testing.T) {
+	dir := t.TempDir()
+	j, err := newFileJournal(zap.L(), dir, 4096)
+	require.NoError(t, err)
+	// smaller files for testing
+	j.maxFileSize = 1024
+
+	for i := 0; i < 200; i++ {
+		e := NewEvent(EventMessage, testIdentifier, message.Height(i), 1)
+		e.Error = fmt.Sprintf("event %d", i)
+		j.Record(e)
+	}
+	require.NoError(t, j.Close())
+
+	files, err := journalFiles(dir)
+	require.NoError(t, err)
+	require.Greater(t, len(files), 1)
+	var total int64
+	for _, f := range files {
+		total += f.Size()
+	}
+	require.LessOrEqual(t, total, int64(4096+1024+512))
+
+	// the oldest events were removed
+	events, err := Read(dir, Filter{PubKey: "abcd", Height: 0})
+	require.NoError(t, err)
+	require.Empty(t, events)
+	events, err = Read(dir, Filter{PubKey: "abcd", Height: 199})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+}
@@ -0,0 +1,131 @@
+package journal
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/bloxapp/ssv/protocol/v1/message"
+)
+
+// maxLineSize is the max size of a single event in the journal files
+const maxLineSize = 1024 * 1024
+
+// Filter selects the events of an instance
+type Filter struct {
+	PubKey string
+	// Role is optional, events of all roles are selected if empty
+	Role   string
+	Height message.Height
+}
+
+func (f Filter) match(e Event) bool {
+	if !strings.EqualFold(e.PubKey, f.PubKey) || e.Height != f.Height {
+		return false
+	}
+	return len(f.Role) == 0 || strings.EqualFold(e.Role, f.Role)
+}
+
+// Read reads the events of the given instance from the journal files in dir, sorted by time.
+// malformed lines are skipped, e.g. the last line of a file that is being written
+func Read(dir string, filter Filter) ([]Event, error) {
+	files, err := journalFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	var events []Event
+	for _, info := range files {
+		fileEvents, err := readFile(filepath.Join(dir, info.Name()), filter)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, fileEvents...)
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Time.Before(events[j].Time)
+	})
+	return events, nil
+}
+
+func readFile(path string, filter Filter) ([]Event, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) { // removed while reading
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "could not open journal file")
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	var events []Event
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	for scanner.Scan() {
+		var e Event
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue
+		}
+		if filter.match(e) {
+			events = append(events, e)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrapf(err, "could not read journal file %s", path)
+	}
+	return events, nil
+}
+
+// WriteJSON writes the given events as a json array
+func WriteJSON(w io.Writer, events []Event) error {
+	if events == nil {
+		events = []Event{}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(events)
+}
+
+// WriteTimeline writes the given events as a human readable timeline
+func WriteTimeline(w io.Writer, events []Event) error {
+	if len(events) == 0 {
+		_, err := fmt.Fprintln(w, "no events were found")
+		return err
+	}
+	start := events[0].Time
+	for _, e := range events {
+		_, err := fmt.Fprintf(w, "%s  +%-9s  %-8s  round %-3d  %s\n",
+			e.Time.Format("15:04:05.000"),
+			fmt.Sprintf("%.3fs", e.Time.Sub(start).Seconds()),
+			e.Role, e.Round, describe(e))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// describe returns a human readable description of the given event
+func describe(e Event) string {
+	switch e.Type {
+	case EventMessage:
+		return fmt.Sprintf("received %s from %v", e.MsgType, e.Signers)
+	case EventPipelineFailure:
+		return fmt.Sprintf("%s from %v failed in pipeline %q: %s", e.MsgType, e.Signers, e.Pipeline, e.Error)
+	case EventTimeout:
+		return "round timer expired"
+	case EventStage:
+		return fmt.Sprintf("stage changed to %s", e.Stage)
+	case EventRound:
+		return fmt.Sprintf("moved to round %d", e.Round)
+	}
+	return string(e.Type)
+}
@@ -0,0 +1,32 @@
+package msgqueue
+
+import "sync"
+
+// Notifier wakes up the goroutines that wait for a change, the zero value is ready to use.
+// waiters should take the channel before checking for the change, so changes that happen in between are not missed
+type Notifier struct {
+	lock sync.Mutex
+	c    chan struct{}
+}
+
+// Wait returns a channel that is closed upon the next call to Notify
+func (n *Notifier) Wait() <-chan struct{} {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if n.c == nil {
+		n.c = make(chan struct{})
+	}
+	return n.c
+}
+
+// Notify wakes up all the current waiters
+func (n *Notifier) Notify() {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if n.c != nil {
+		close(n.c)
+		n.c = nil
+	}
+}
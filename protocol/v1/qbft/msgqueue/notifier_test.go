@@ -0,0 +1,46 @@
+package msgqueue
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotifier(t *testing.T) {
+	var n Notifier
+	// no waiters
+	n.Notify()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		c := n.Wait()
+		go func() {
+			defer wg.Done()
+			<-c
+		}()
+	}
+	n.Notify()
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("waiters were not notified")
+	}
+
+	// a new channel is returned after notify
+	c := n.Wait()
+	select {
+	case <-c:
+		t.Fatal("should not be notified")
+	default:
+	}
+	require.Equal(t, c, n.Wait())
+}
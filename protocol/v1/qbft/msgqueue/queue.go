@@ -40,6 +40,8 @@ type MsgQueue interface {
 	Count(idx Index) int
 	// Len counts all messages
 	Len() int
+	// Updated returns a channel that is closed once a message is added to the queue
+	Updated() <-chan struct{}
 }
 
 // New creates a new MsgQueue
@@ -88,6 +90,8 @@ type queue struct {
 
 	itemsLock *sync.RWMutex
 	items     map[Index][]*MsgContainer
+
+	updates Notifier
 }
 
 func (q *queue) Add(msg *message.SSVMessage) {
@@ -110,9 +114,16 @@ func (q *queue) Add(msg *message.SSVMessage) {
 		q.items[idx] = msgs
 		metricsMsgQRatio.WithLabelValues(idx.ID, idx.Name, idx.Mt.String(), idx.Cmt.String()).Inc()
 	}
+	if len(indices) > 0 {
+		q.updates.Notify()
+	}
 	q.logger.Debug("message added to queue", zap.Any("indices", indices))
 }
 
+func (q *queue) Updated() <-chan struct{} {
+	return q.updates.Wait()
+}
+
 func (q *queue) Purge(idx Index) int64 {
 	q.itemsLock.Lock()
 	defer q.itemsLock.Unlock()
@@ -5,6 +5,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zaptest"
 	"testing"
+	"time"
 )
 
 func TestNewMsgQueue(t *testing.T) {
@@ -97,6 +98,23 @@ func TestNewMsgQueue(t *testing.T) {
 			require.Equal(t, 0, q.Count(idx))
 		}
 	})
+	t.Run("updated", func(t *testing.T) {
+		q, err := New(logger, WithIndexers(DefaultMsgIndexer()))
+		require.NoError(t, err)
+		updated := q.Updated()
+		select {
+		case <-updated:
+			t.Fatal("queue should not be updated")
+		default:
+		}
+		q.Add(msg1)
+		select {
+		case <-updated:
+		case <-time.After(time.Second):
+			t.Fatal("queue should be updated")
+		}
+		require.NotEqual(t, updated, q.Updated())
+	})
 }
 
 func generateConsensusMsg(t *testing.T, ssvMsgType message.MsgType, height message.Height, round message.Round, id message.Identifier, consensusType message.ConsensusMessageType) *message.SSVMessage {
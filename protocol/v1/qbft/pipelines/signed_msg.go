@@ -1,6 +1,8 @@
 package pipelines
 
 import (
+	"github.com/pkg/errors"
+
 	"github.com/bloxapp/ssv/protocol/v1/message"
 )
 
@@ -70,12 +72,50 @@ func WrapFunc(name string, fn func(signedMessage *message.SignedMessage) error)
 	}
 }
 
-// Run implements SignedMessagePipeline interface
+// Run implements SignedMessagePipeline interface,
+// errors are wrapped with the name of the pipeline (unless they were already wrapped by an inner pipeline)
 func (p *pipelineFunc) Run(signedMessage *message.SignedMessage) error {
-	return p.fn(signedMessage)
+	err := p.fn(signedMessage)
+	if err == nil {
+		return nil
+	}
+	if _, ok := FailedPipeline(err); ok {
+		return err
+	}
+	return &Error{Pipeline: p.name, Err: err}
 }
 
 // Name implements SignedMessagePipeline interface
 func (p *pipelineFunc) Name() string {
 	return p.name
 }
+
+// Error is returned when a message failed in a pipeline, it has the same message as the underlying error
+type Error struct {
+	Pipeline string
+	Err      error
+}
+
+// Error implements error interface
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+// Cause returns the underlying error
+func (e *Error) Cause() error {
+	return e.Err
+}
+
+// Unwrap returns the underlying error
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// FailedPipeline returns the name of the pipeline that returned the given error
+func FailedPipeline(err error) (string, bool) {
+	var pErr *Error
+	if errors.As(err, &pErr) {
+		return pErr.Pipeline, true
+	}
+	return "", false
+}
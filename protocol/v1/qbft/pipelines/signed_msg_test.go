@@ -44,3 +44,21 @@ func IfFirstTrueContinueToSecond(a, b SignedMessagePipeline) SignedMessagePipeli
 		return nil
 	})
 }
+
+func TestFailedPipeline(t *testing.T) {
+	validPipeline := WrapFunc("valid", func(signedMessage *message.SignedMessage) error {
+		return nil
+	})
+	invalidPipeline := WrapFunc("invalid", func(signedMessage *message.SignedMessage) error {
+		return fmt.Errorf("error")
+	})
+
+	err := Combine(validPipeline, CombineQuiet(validPipeline, invalidPipeline)).Run(nil)
+	require.EqualError(t, err, "error")
+	name, ok := FailedPipeline(err)
+	require.True(t, ok)
+	require.Equal(t, "invalid", name)
+
+	_, ok = FailedPipeline(fmt.Errorf("error"))
+	require.False(t, ok)
+}
@@ -0,0 +1,129 @@
+package simulation
+
+import (
+	"fmt"
+
+	"github.com/bloxapp/ssv/protocol/v1/message"
+)
+
+// conflictingValue returns the value that byzantine operators use in place of the honest one
+func conflictingValue(height message.Height, round message.Round) []byte {
+	return []byte(fmt.Sprintf("height %d round %d conflicting value", height, round))
+}
+
+// tamper returns the message that the byzantine operator sends to the given peer instead of the honest message
+func (n *node) tamper(to message.OperatorID, msg *message.SSVMessage) *message.SSVMessage {
+	if msg.MsgType != message.SSVConsensusMsgType {
+		return msg
+	}
+	signedMsg := &message.SignedMessage{}
+	if err := signedMsg.Decode(msg.Data); err != nil || signedMsg.Message == nil {
+		return msg
+	}
+	consensusMsg := signedMsg.Message
+	value := conflictingValue(consensusMsg.Height, consensusMsg.Round)
+
+	var data []byte
+	var err error
+	switch n.behavior {
+	case Equivocation:
+		// peers with an odd id receive the honest message
+		if to%2 == 1 {
+			return msg
+		}
+		switch consensusMsg.MsgType {
+		case message.ProposalMsgType:
+			data, err = withProposedValue(consensusMsg, value)
+		case message.PrepareMsgType:
+			data, err = (&message.PrepareData{Data: value}).Encode()
+		case message.CommitMsgType:
+			data, err = (&message.CommitData{Data: value}).Encode()
+		default:
+			return msg
+		}
+	case InvalidJustification:
+		switch consensusMsg.MsgType {
+		case message.RoundChangeMsgType:
+			data, err = n.forgedRoundChange(consensusMsg, value)
+		case message.ProposalMsgType:
+			// any value is valid in the first round
+			if consensusMsg.Round <= 1 {
+				return msg
+			}
+			data, err = withProposedValue(consensusMsg, value)
+		default:
+			return msg
+		}
+	default:
+		return msg
+	}
+	if err != nil {
+		n.sim.logger.Warn("could not tamper message")
+		return msg
+	}
+	consensusMsg.Data = data
+	return n.signed(msg, consensusMsg, []message.OperatorID{n.id})
+}
+
+// withProposedValue returns the proposal data of the given proposal with another value
+func withProposedValue(proposal *message.ConsensusMessage, value []byte) ([]byte, error) {
+	proposalData, err := proposal.GetProposalData()
+	if err != nil {
+		return nil, err
+	}
+	proposalData.Data = value
+	return proposalData.Encode()
+}
+
+// forgedRoundChange returns round change data that claims the given value was prepared in the previous round.
+// the justification is signed only by this operator, and either declares it as the only signer (not a quorum)
+// or declares a quorum of signers (invalid aggregated signature)
+func (n *node) forgedRoundChange(roundChange *message.ConsensusMessage, value []byte) ([]byte, error) {
+	preparedRound := roundChange.Round - 1
+	prepareData, err := (&message.PrepareData{Data: value}).Encode()
+	if err != nil {
+		return nil, err
+	}
+	prepare := &message.ConsensusMessage{
+		MsgType:    message.PrepareMsgType,
+		Height:     roundChange.Height,
+		Round:      preparedRound,
+		Identifier: roundChange.Identifier,
+		Data:       prepareData,
+	}
+	signers := []message.OperatorID{n.id}
+	if n.sim.rand.Intn(2) == 0 {
+		signers = n.sim.operators()
+	}
+	sig, err := prepare.Sign(n.sk, n.sim.forkVersion)
+	if err != nil {
+		return nil, err
+	}
+	return (&message.RoundChangeData{
+		PreparedValue: value,
+		Round:         preparedRound,
+		RoundChangeJustification: []*message.SignedMessage{{
+			Message:   prepare,
+			Signature: sig.Serialize(),
+			Signers:   signers,
+		}},
+	}).Encode()
+}
+
+// signed returns a copy of the given message with the given consensus message, signed by this operator
+func (n *node) signed(msg *message.SSVMessage, consensusMsg *message.ConsensusMessage, signers []message.OperatorID) *message.SSVMessage {
+	sig, err := consensusMsg.Sign(n.sk, n.sim.forkVersion)
+	if err != nil {
+		n.sim.logger.Warn("could not sign tampered message")
+		return msg
+	}
+	data, err := (&message.SignedMessage{
+		Message:   consensusMsg,
+		Signature: sig.Serialize(),
+		Signers:   signers,
+	}).Encode()
+	if err != nil {
+		return msg
+	}
+	return &message.SSVMessage{MsgType: msg.MsgType, ID: msg.ID, Data: data}
+}
@@ -0,0 +1,104 @@
+package simulation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/pkg/errors"
+
+	"github.com/bloxapp/ssv/protocol/v1/message"
+	protcolp2p "github.com/bloxapp/ssv/protocol/v1/p2p"
+)
+
+// errNotSupported is returned by the sync protocols, which are not part of the simulation
+var errNotSupported = errors.New("not supported in simulation")
+
+// network is the network of a single operator, messages are handed to the simulator which delivers them in virtual time
+type network struct {
+	sim  *Simulator
+	self message.OperatorID
+}
+
+var _ protcolp2p.MockNetwork = (*network)(nil)
+
+func peerID(id message.OperatorID) peer.ID {
+	return peer.ID(fmt.Sprintf("operator-%d", id))
+}
+
+// Broadcast sends the message to all the operators of the committee, including self
+func (n *network) Broadcast(msg message.SSVMessage) error {
+	n.sim.send(n.self, 0, &msg)
+	return nil
+}
+
+// PushMsg delivers the message to this operator
+func (n *network) PushMsg(e protcolp2p.MockMessageEvent) {
+	if e.Msg != nil {
+		n.sim.send(n.self, n.self, e.Msg)
+	}
+}
+
+// Self returns the peer id of this operator
+func (n *network) Self() peer.ID {
+	return peerID(n.self)
+}
+
+// Peers returns the peers of the committee
+func (n *network) Peers(pk message.ValidatorPK) ([]peer.ID, error) {
+	var peers []peer.ID
+	for _, id := range n.sim.operators() {
+		if id != n.self {
+			peers = append(peers, peerID(id))
+		}
+	}
+	return peers, nil
+}
+
+// Subscribe is a nop, operators are always subscribed to the validator
+func (n *network) Subscribe(pk message.ValidatorPK) error {
+	return nil
+}
+
+// Unsubscribe is a nop, operators are always subscribed to the validator
+func (n *network) Unsubscribe(pk message.ValidatorPK) error {
+	return nil
+}
+
+// ReportValidation is a nop
+func (n *network) ReportValidation(message *message.SSVMessage, res protcolp2p.MsgValidationResult) {}
+
+// RegisterHandlers is a nop, sync protocols are not simulated
+func (n *network) RegisterHandlers(handlers ...*protcolp2p.SyncHandler) {}
+
+// LastDecided is not supported, decided messages are broadcasted instead
+func (n *network) LastDecided(mid message.Identifier) ([]protcolp2p.SyncResult, error) {
+	return nil, errNotSupported
+}
+
+// GetHistory is not supported
+func (n *network) GetHistory(mid message.Identifier, from, to message.Height, targets ...string) ([]protcolp2p.SyncResult, message.Height, error) {
+	return nil, 0, errNotSupported
+}
+
+// LastChangeRound is not supported
+func (n *network) LastChangeRound(mid message.Identifier, height message.Height) ([]protcolp2p.SyncResult, error) {
+	return nil, errNotSupported
+}
+
+// SendStreamMessage is not supported
+func (n *network) SendStreamMessage(protocol string, pi peer.ID, msg *message.SSVMessage) error {
+	return errNotSupported
+}
+
+// AddPeers is a nop, the committee is connected by the simulator
+func (n *network) AddPeers(pk message.ValidatorPK, toAdd ...protcolp2p.MockNetwork) {}
+
+// Start is a nop, messages are delivered by the simulator
+func (n *network) Start(ctx context.Context) {}
+
+// SetLastDecidedHandler is a nop
+func (n *network) SetLastDecidedHandler(lastDecidedHandler protcolp2p.EventHandler) {}
+
+// SetGetHistoryHandler is a nop
+func (n *network) SetGetHistoryHandler(getHistoryHandler protcolp2p.EventHandler) {}
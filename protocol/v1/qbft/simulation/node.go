@@ -0,0 +1,245 @@
+package simulation
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
+	"github.com/bloxapp/ssv/protocol/v1/message"
+	"github.com/bloxapp/ssv/protocol/v1/qbft"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/instance"
+)
+
+// node is a simulated operator, it plays the role of the controller for its instances
+type node struct {
+	id       message.OperatorID
+	sim      *Simulator
+	sk       *bls.SecretKey
+	share    *beacon.Share
+	network  *network
+	journal  *stageCounter
+	behavior Behavior
+	crashed  bool
+
+	// handled is the number of stage changes that were processed
+	handled int64
+	// broadcasts is the number of messages that were sent
+	broadcasts int64
+
+	// the current instance
+	height message.Height
+	inst   *instance.Instance
+	timer  *virtualTimer
+
+	// future holds messages of heights that were not started yet
+	future map[message.Height][]*envelope
+
+	decidedLock   sync.Mutex
+	decidedRounds map[message.Height]message.Round
+}
+
+// idle returns true if all the stage changes of the instances were processed
+func (n *node) idle() bool {
+	return n.journal.stagesCount() == atomic.LoadInt64(&n.handled)
+}
+
+// start stops the previous instance (if still running) and starts an instance of the given height
+func (n *node) start(height message.Height, slotStart time.Duration) error {
+	if n.crashed {
+		return nil
+	}
+	n.stopInstance(n.height)
+	if err := n.sim.waitIdle(); err != nil {
+		return err
+	}
+
+	fork := n.sim.fork
+	identifier := fork.Identifier(n.sim.identifier.GetValidatorPK(), n.sim.identifier.GetRoleType())
+	leaderSelector, err := fork.LeaderSelector(identifier, uint64(n.share.CommitteeSize()), height, n.recentDecidedRounds)
+	if err != nil {
+		return errors.Wrap(err, "could not create leader selector")
+	}
+	n.timer = newVirtualTimer(n.sim.clock)
+	n.inst = instance.NewInstance(&instance.Options{
+		Logger:           n.sim.logger.With(zap.Uint64("operator", uint64(n.id))),
+		ValidatorShare:   n.share,
+		Network:          n.network,
+		LeaderSelector:   leaderSelector,
+		Config:           n.sim.scenario.Config,
+		Identifier:       n.sim.identifier,
+		Height:           height,
+		Fork:             fork.InstanceFork(),
+		Signer:           &signer{sk: n.sk},
+		ChangeRoundStore: &changeRoundStore{},
+		Journal:          n.journal,
+		RoundTimer:       n.timer,
+	}).(*instance.Instance)
+	n.height = height
+
+	n.inst.Init()
+	go n.handleStages(n.inst, n.inst.GetStageChan(), height, slotStart)
+
+	broadcasts := atomic.LoadInt64(&n.broadcasts)
+	if err := n.inst.Start(inputValue(height, n.id)); err != nil {
+		return errors.Wrap(err, "could not start instance")
+	}
+	if n.inst.IsLeader() {
+		// the leader broadcasts the proposal in the background
+		if err := waitFor(func() bool { return atomic.LoadInt64(&n.broadcasts) > broadcasts }); err != nil {
+			return errors.Wrap(err, "leader didn't propose")
+		}
+	}
+	if err := n.sim.waitIdle(); err != nil {
+		return err
+	}
+
+	pending := n.future[height]
+	for h := range n.future {
+		if h <= height {
+			delete(n.future, h)
+		}
+	}
+	for _, env := range pending {
+		if err := n.deliver(env.from, env.msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// inputValue returns the value that the given operator proposes in the given height,
+// each operator has a different value so a safety violation can't go unnoticed
+func inputValue(height message.Height, id message.OperatorID) []byte {
+	return []byte(fmt.Sprintf("height %d value of operator %d", height, id))
+}
+
+// stopInstance stops the instance of the given height, returns false if it was already stopped
+func (n *node) stopInstance(height message.Height) bool {
+	if n.inst == nil || n.height != height || n.inst.Stopped() {
+		return false
+	}
+	n.inst.Stop()
+	return true
+}
+
+// deliver processes a message, messages of future heights are kept until the height starts
+func (n *node) deliver(from message.OperatorID, msg *message.SSVMessage) error {
+	if n.crashed {
+		return nil
+	}
+	signedMsg := &message.SignedMessage{}
+	if err := signedMsg.Decode(msg.Data); err != nil || signedMsg.Message == nil {
+		return nil
+	}
+	height := signedMsg.Message.Height
+	if n.inst == nil || height > n.height {
+		n.future[height] = append(n.future[height], &envelope{from: from, to: n.id, msg: msg})
+		return nil
+	}
+	if height < n.height || n.inst.Stopped() {
+		return nil
+	}
+
+	n.sim.record("deliver %d->%d %s %s h=%d r=%d signers=%v", from, n.id, msg.MsgType, signedMsg.Message.MsgType,
+		height, signedMsg.Message.Round, signedMsg.GetSigners())
+	n.sim.result.Delivered++
+	switch msg.MsgType {
+	case message.SSVConsensusMsgType:
+		if _, err := n.inst.ProcessMsg(signedMsg); err != nil {
+			n.sim.result.Rejected++
+		}
+	case message.SSVDecidedMsgType:
+		if n.inst.State().Stage.Load() != int32(qbft.RoundStateDecided) {
+			n.inst.ForceDecide(signedMsg)
+		}
+	}
+	return n.sim.waitIdle()
+}
+
+// handleStages processes the stage changes of the instance like the controller does
+func (n *node) handleStages(inst *instance.Instance, stages <-chan qbft.RoundState, height message.Height, slotStart time.Duration) {
+	for stage := range stages {
+		switch stage {
+		case qbft.RoundStateChangeRound:
+			inst.ResetRoundTimer()
+			if err := inst.BroadcastChangeRound(); err != nil {
+				n.sim.logger.Warn("could not broadcast round change", zap.Error(err))
+			}
+		case qbft.RoundStateDecided:
+			if err := n.onDecided(inst, height, slotStart); err != nil {
+				n.sim.logger.Warn("could not process decided instance", zap.Error(err))
+			}
+			inst.Stop()
+		}
+		atomic.AddInt64(&n.handled, 1)
+	}
+}
+
+// onDecided records the decision and broadcasts the decided message
+func (n *node) onDecided(inst *instance.Instance, height message.Height, slotStart time.Duration) error {
+	agg, err := inst.CommittedAggregatedMsg()
+	if err != nil {
+		return err
+	}
+	commitData, err := agg.Message.GetCommitData()
+	if err != nil {
+		return err
+	}
+	n.sim.decided(n, height, Decision{
+		Value: commitData.Data,
+		Round: agg.Message.Round,
+		At:    n.sim.clock.Now() - slotStart,
+	})
+
+	n.decidedLock.Lock()
+	n.decidedRounds[height] = agg.Message.Round
+	n.decidedLock.Unlock()
+
+	decidedMsg, err := inst.GetCommittedAggSSVMessage()
+	if err != nil {
+		return err
+	}
+	return n.network.Broadcast(decidedMsg)
+}
+
+// recentDecidedRounds returns the rounds of the heights that this operator decided
+func (n *node) recentDecidedRounds(from, to message.Height) map[message.Height]message.Round {
+	n.decidedLock.Lock()
+	defer n.decidedLock.Unlock()
+
+	rounds := make(map[message.Height]message.Round)
+	for h, r := range n.decidedRounds {
+		if h >= from && h <= to {
+			rounds[h] = r
+		}
+	}
+	return rounds
+}
+
+// signer signs consensus messages with the operator key
+type signer struct {
+	sk *bls.SecretKey
+}
+
+func (s *signer) SignIBFTMessage(msg *message.ConsensusMessage, pk []byte, forkVersion string) ([]byte, error) {
+	sig, err := msg.Sign(s.sk, forkVersion)
+	if err != nil {
+		return nil, err
+	}
+	return sig.Serialize(), nil
+}
+
+func (s *signer) SignAttestation(data *spec.AttestationData, duty *beacon.Duty, pk []byte) (*spec.Attestation, []byte, error) {
+	return nil, nil, errNotSupported
+}
+
+func (s *signer) SignValidatorRegistration(registration *beacon.ValidatorRegistration, pk []byte) ([]byte, []byte, error) {
+	return nil, nil, errNotSupported
+}
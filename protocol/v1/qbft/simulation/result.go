@@ -0,0 +1,104 @@
+package simulation
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/bloxapp/ssv/protocol/v1/message"
+)
+
+// Decision is the outcome of an instance of an operator
+type Decision struct {
+	Value []byte
+	Round message.Round
+	// At is the time from the start of the slot until the operator decided
+	At time.Duration
+}
+
+// Result is the outcome of a simulation
+type Result struct {
+	Scenario string
+	Seed     int64
+	// Decided holds the decisions of each height by operator
+	Decided map[message.Height]map[message.OperatorID]Decision
+	// Delivered is the number of messages that were processed by instances
+	Delivered int
+	// Dropped is the number of messages that were dropped by the network
+	Dropped int
+	// Rejected is the number of messages that failed in the pipelines of the instances
+	Rejected int
+	// Trace is a digest of all the steps of the simulation, runs with equal traces are identical
+	Trace string
+}
+
+// CheckSafety returns an error if two operators that are not byzantine decided different values in the same height
+func (r *Result) CheckSafety(byzantine map[message.OperatorID]Behavior) error {
+	for height, decisions := range r.Decided {
+		var value []byte
+		var decider message.OperatorID
+		for id, d := range decisions {
+			if byzantine[id] != 0 {
+				continue
+			}
+			if value == nil {
+				value, decider = d.Value, id
+				continue
+			}
+			if !bytes.Equal(value, d.Value) {
+				return errors.Errorf("safety violation in height %d: operator %d decided %q, operator %d decided %q",
+					height, decider, value, id, d.Value)
+			}
+		}
+	}
+	return nil
+}
+
+// CheckLiveness returns an error if one of the given operators didn't decide one of the heights within the bound
+func (r *Result) CheckLiveness(heights int, operators []message.OperatorID, bound time.Duration) error {
+	for h := 0; h < heights; h++ {
+		height := message.Height(h)
+		for _, id := range operators {
+			d, ok := r.Decided[height][id]
+			if !ok {
+				return errors.Errorf("liveness violation in height %d: operator %d didn't decide", height, id)
+			}
+			if d.At > bound {
+				return errors.Errorf("liveness violation in height %d: operator %d decided after %s (round %d)",
+					height, id, d.At, d.Round)
+			}
+		}
+	}
+	return nil
+}
+
+// RoundChanges returns the number of round changes until the given operators decided, summed over the heights.
+// the rounds of a height are counted by the last operator to decide
+func (r *Result) RoundChanges(operators []message.OperatorID) int {
+	changes := 0
+	for _, decisions := range r.Decided {
+		var round message.Round
+		for _, id := range operators {
+			if d, ok := decisions[id]; ok && d.Round > round {
+				round = d.Round
+			}
+		}
+		if round > 1 {
+			changes += int(round - 1)
+		}
+	}
+	return changes
+}
+
+// Verify checks the safety of the result, and its liveness if the scenario declares a bound
+func (s Scenario) Verify(r *Result) error {
+	s = s.withDefaults()
+	if err := r.CheckSafety(s.Byzantine); err != nil {
+		return err
+	}
+	if s.LivenessBound > 0 {
+		return r.CheckLiveness(s.Heights, s.Correct(), s.LivenessBound)
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package simulation
+
+import (
+	"time"
+
+	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
+	"github.com/bloxapp/ssv/protocol/v1/message"
+	"github.com/bloxapp/ssv/protocol/v1/qbft"
+)
+
+// Behavior is the misbehavior of a byzantine operator
+type Behavior int
+
+const (
+	// Equivocation sends conflicting proposals, prepares and commits to different peers
+	Equivocation Behavior = iota + 1
+	// InvalidJustification sends round changes that claim a prepared value with an invalid justification,
+	// and proposals that ignore the justified value of the round change quorum
+	InvalidJustification
+)
+
+// Partition splits the committee into groups that can't communicate with each other,
+// messages that are sent between groups within [Start, End) are dropped.
+// operators that are not part of any group are grouped together
+type Partition struct {
+	Start  time.Duration
+	End    time.Duration
+	Groups [][]message.OperatorID
+}
+
+// Crash stops the operator at the given time, a crashed operator doesn't recover
+type Crash struct {
+	Operator message.OperatorID
+	At       time.Duration
+}
+
+// Scenario declares the committee, the network conditions and the faults of a simulation.
+// all times are virtual, relative to the start of the simulation
+type Scenario struct {
+	Name string
+	// Committee is the size of the committee, 4 by default
+	Committee int
+	// Heights is the number of consecutive heights to run, one per slot, 1 by default
+	Heights int
+	// SlotDuration is the time between the starts of consecutive heights, 12s by default
+	SlotDuration time.Duration
+	// StartSkew is the max delay of operators in starting an instance, e.g. due to clock drift
+	StartSkew time.Duration
+	// Config is the instance config, the default consensus params are used if nil.
+	// instances are aborted once the DutyDeadline has passed, or at the start of the next slot.
+	// LeaderPreprepareDelaySeconds is ignored, leaders propose without delay
+	Config *qbft.InstanceConfig
+	// Fork is the version of the controller fork, which determines the leader selection, v1 by default
+	Fork forksprotocol.ForkVersion
+
+	// MinDelay and MaxDelay are the bounds of the (uniformly distributed) message delay
+	MinDelay time.Duration
+	MaxDelay time.Duration
+	// DropRate is the probability of a message to be dropped
+	DropRate float64
+	// DuplicateRate is the probability of a message to be delivered twice
+	DuplicateRate float64
+	// ReorderRate is the probability of a message to be held back by up to ReorderDelay,
+	// so it arrives after messages that were sent later
+	ReorderRate  float64
+	ReorderDelay time.Duration
+
+	Partitions []Partition
+	Crashes    []Crash
+	Byzantine  map[message.OperatorID]Behavior
+
+	// LivenessBound is the max time from the start of a slot until all correct operators decide, 0 skips the liveness check
+	LivenessBound time.Duration
+}
+
+// withDefaults returns a copy of the scenario where missing values are set to the defaults
+func (s Scenario) withDefaults() Scenario {
+	if s.Committee == 0 {
+		s.Committee = 4
+	}
+	if s.Heights == 0 {
+		s.Heights = 1
+	}
+	if s.SlotDuration == 0 {
+		s.SlotDuration = 12 * time.Second
+	}
+	if s.Config == nil {
+		s.Config = qbft.DefaultConsensusParams()
+	}
+	cfg := s.Config.WithDefaults()
+	cfg.LeaderPreprepareDelaySeconds = 0
+	s.Config = cfg
+	if len(s.Fork) == 0 {
+		s.Fork = forksprotocol.V1ForkVersion
+	}
+	if s.MaxDelay < s.MinDelay {
+		s.MaxDelay = s.MinDelay
+	}
+	if s.ReorderDelay == 0 {
+		s.ReorderDelay = time.Second
+	}
+	return s
+}
+
+// Correct returns the operators that are expected to decide, i.e. neither crashed nor byzantine
+func (s Scenario) Correct() []message.OperatorID {
+	s = s.withDefaults()
+	var ids []message.OperatorID
+	for id := message.OperatorID(1); id <= message.OperatorID(s.Committee); id++ {
+		if s.crashed(id) || s.Byzantine[id] != 0 {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func (s Scenario) crashed(id message.OperatorID) bool {
+	for _, c := range s.Crashes {
+		if c.Operator == id {
+			return true
+		}
+	}
+	return false
+}
+
+// partitioned returns true if the given operators can't communicate at the given time
+func (s Scenario) partitioned(at time.Duration, from, to message.OperatorID) bool {
+	for _, p := range s.Partitions {
+		if at < p.Start || at >= p.End {
+			continue
+		}
+		if group(p, from) != group(p, to) {
+			return true
+		}
+	}
+	return false
+}
+
+// group returns the index of the group of the given operator, or -1 if it is not part of any group
+func group(p Partition, id message.OperatorID) int {
+	for i, g := range p.Groups {
+		for _, member := range g {
+			if member == id {
+				return i
+			}
+		}
+	}
+	return -1
+}
@@ -0,0 +1,485 @@
+package simulation
+
+import (
+	"bytes"
+	"container/heap"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"math/rand"
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
+	"github.com/bloxapp/ssv/protocol/v1/message"
+	"github.com/bloxapp/ssv/protocol/v1/qbft"
+	controllerforks "github.com/bloxapp/ssv/protocol/v1/qbft/controller/forks"
+	forksfactory "github.com/bloxapp/ssv/protocol/v1/qbft/controller/forks/factory"
+	qbftstorage "github.com/bloxapp/ssv/protocol/v1/qbft/storage"
+)
+
+// stallTimeout is the (real) time to wait for an operator to process a step,
+// exceeding it means that an instance is stuck
+const stallTimeout = 10 * time.Second
+
+// Simulator runs a committee of qbft instances in a single process, over a simulated network and a virtual clock.
+//
+// the simulation is a sequence of steps (a delivery of a message, an expiry of a round timer, a start of an instance etc.),
+// each step is processed until all the operators are idle before the virtual clock moves on.
+// the messages that were sent during a step are ordered and scheduled with delays that are drawn from the seeded source,
+// therefore a scenario and a seed always produce the same run.
+type Simulator struct {
+	logger   *zap.Logger
+	scenario Scenario
+	seed     int64
+	rand     *rand.Rand
+	clock    *clock
+	fork     controllerforks.Fork
+	// forkVersion is the version of the instance fork, which is part of the signatures
+	forkVersion string
+
+	identifier message.Identifier
+	nodes      []*node
+
+	events eventQueue
+	seq    uint64
+
+	outboxLock sync.Mutex
+	outbox     []*envelope
+
+	resultLock sync.Mutex
+	result     *Result
+	trace      hash.Hash
+}
+
+// Run runs the given scenario with the given seed
+func Run(logger *zap.Logger, scenario Scenario, seed int64) (*Result, error) {
+	s, err := newSimulator(logger, scenario, seed)
+	if err != nil {
+		return nil, err
+	}
+	return s.run()
+}
+
+var initBLS sync.Once
+
+func newSimulator(logger *zap.Logger, scenario Scenario, seed int64) (*Simulator, error) {
+	initBLS.Do(func() {
+		_ = bls.Init(bls.BLS12_381)
+	})
+
+	scenario = scenario.withDefaults()
+	if err := scenario.Config.Validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid instance config")
+	}
+	s := &Simulator{
+		logger:   logger.With(zap.String("who", "simulator"), zap.String("scenario", scenario.Name), zap.Int64("seed", seed)),
+		scenario: scenario,
+		seed:     seed,
+		rand:     rand.New(rand.NewSource(seed)),
+		clock:    &clock{},
+		fork:     forksfactory.NewFork(scenario.Fork),
+		result: &Result{
+			Scenario: scenario.Name,
+			Seed:     seed,
+			Decided:  make(map[message.Height]map[message.OperatorID]Decision),
+		},
+		trace: sha256.New(),
+	}
+
+	s.forkVersion = s.fork.InstanceFork().VersionName()
+
+	validatorKey := newKey("validator")
+	s.identifier = message.NewIdentifier(validatorKey.GetPublicKey().Serialize(), message.RoleTypeAttester)
+	committee := make(map[message.OperatorID]*beacon.Node)
+	keys := make(map[message.OperatorID]*bls.SecretKey)
+	for id := message.OperatorID(1); id <= message.OperatorID(scenario.Committee); id++ {
+		keys[id] = newKey(fmt.Sprintf("operator-%d", id))
+		committee[id] = &beacon.Node{IbftID: uint64(id), Pk: keys[id].GetPublicKey().Serialize()}
+	}
+	for id := message.OperatorID(1); id <= message.OperatorID(scenario.Committee); id++ {
+		s.nodes = append(s.nodes, &node{
+			id:  id,
+			sim: s,
+			sk:  keys[id],
+			share: &beacon.Share{
+				NodeID:    id,
+				PublicKey: validatorKey.GetPublicKey(),
+				Committee: committee,
+			},
+			network:       &network{sim: s, self: id},
+			journal:       &stageCounter{},
+			behavior:      scenario.Byzantine[id],
+			future:        make(map[message.Height][]*envelope),
+			decidedRounds: make(map[message.Height]message.Round),
+		})
+	}
+
+	for h := 0; h < scenario.Heights; h++ {
+		height := message.Height(h)
+		slotStart := time.Duration(h) * scenario.SlotDuration
+		abortAt := slotStart + scenario.SlotDuration
+		if scenario.Config.DutyDeadline > 0 && scenario.Config.DutyDeadline < scenario.SlotDuration {
+			abortAt = slotStart + scenario.Config.DutyDeadline
+		}
+		for _, n := range s.nodes {
+			start := slotStart
+			if scenario.StartSkew > 0 {
+				start += time.Duration(s.rand.Int63n(int64(scenario.StartSkew)))
+			}
+			s.schedule(&event{at: start, kind: eventStart, to: n.id, height: height, slotStart: slotStart})
+			s.schedule(&event{at: abortAt, kind: eventAbort, to: n.id, height: height})
+		}
+	}
+	for _, c := range scenario.Crashes {
+		s.schedule(&event{at: c.At, kind: eventCrash, to: c.Operator})
+	}
+	return s, nil
+}
+
+// newKey returns a deterministic key, so signatures (and therefore the order of messages) are the same in all runs
+func newKey(name string) *bls.SecretKey {
+	seed := sha256.Sum256([]byte("ssv-simulation-" + name))
+	sk := &bls.SecretKey{}
+	_ = sk.SetLittleEndianMod(seed[:])
+	return sk
+}
+
+// run processes steps until there is nothing left to do, or the last slot has ended
+func (s *Simulator) run() (*Result, error) {
+	defer s.shutdown()
+
+	end := time.Duration(s.scenario.Heights) * s.scenario.SlotDuration
+	for {
+		e, timerNode := s.next()
+		if e == nil && timerNode == nil {
+			break
+		}
+		var at time.Duration
+		if timerNode != nil {
+			at, _ = timerNode.timer.next()
+		} else {
+			at = e.at
+		}
+		if at > end {
+			break
+		}
+		s.clock.set(at)
+		var err error
+		if timerNode != nil {
+			err = s.fireTimer(timerNode)
+		} else {
+			heap.Pop(&s.events)
+			err = s.process(e)
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "step failed at %s", at)
+		}
+		s.flush()
+	}
+	s.result.Trace = hex.EncodeToString(s.trace.Sum(nil))
+	return s.result, nil
+}
+
+// next returns the next event, or the operator whose round timer expires first
+func (s *Simulator) next() (*event, *node) {
+	var e *event
+	if s.events.Len() > 0 {
+		e = s.events[0]
+	}
+	var timerNode *node
+	var timerAt time.Duration
+	for _, n := range s.nodes {
+		if n.timer == nil {
+			continue
+		}
+		if at, armed := n.timer.next(); armed && (timerNode == nil || at < timerAt) {
+			timerNode, timerAt = n, at
+		}
+	}
+	if timerNode != nil && (e == nil || timerAt < e.at) {
+		return nil, timerNode
+	}
+	return e, nil
+}
+
+func (s *Simulator) process(e *event) error {
+	n := s.node(e.to)
+	switch e.kind {
+	case eventStart:
+		s.record("start %d h=%d", n.id, e.height)
+		return n.start(e.height, e.slotStart)
+	case eventAbort:
+		if n.stopInstance(e.height) {
+			s.record("abort %d h=%d", n.id, e.height)
+		}
+		return s.waitIdle()
+	case eventCrash:
+		s.record("crash %d", n.id)
+		n.crashed = true
+		n.stopInstance(n.height)
+		return s.waitIdle()
+	case eventDeliver:
+		return n.deliver(e.from, e.msg)
+	}
+	return errors.Errorf("unknown event kind %d", e.kind)
+}
+
+// fireTimer expires the round timer of the given operator and waits for the round change
+func (s *Simulator) fireTimer(n *node) error {
+	if n.crashed || n.inst == nil || n.inst.Stopped() || n.inst.State().Stage.Load() == int32(qbft.RoundStateDecided) {
+		n.timer.disarm()
+		return nil
+	}
+	s.record("timeout %d h=%d r=%d", n.id, n.height, n.inst.State().GetRound())
+	stages := n.journal.stagesCount()
+	n.timer.fire()
+	// the round change is processed by the timer loop of the instance
+	if err := waitFor(func() bool { return n.journal.stagesCount() > stages }); err != nil {
+		return errors.Wrap(err, "round timer expiry was not processed")
+	}
+	return s.waitIdle()
+}
+
+// waitIdle waits until all operators processed their stage changes
+func (s *Simulator) waitIdle() error {
+	return waitFor(func() bool {
+		for _, n := range s.nodes {
+			if !n.idle() {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func waitFor(cond func() bool) error {
+	deadline := time.Now().Add(stallTimeout)
+	for !cond() {
+		if time.Now().After(deadline) {
+			return errors.New("simulation stalled")
+		}
+		runtime.Gosched()
+	}
+	return nil
+}
+
+// send is called by the network of an operator, the message is scheduled once the step is done.
+// to is zero for broadcasts
+func (s *Simulator) send(from, to message.OperatorID, msg *message.SSVMessage) {
+	s.outboxLock.Lock()
+	defer s.outboxLock.Unlock()
+
+	s.outbox = append(s.outbox, &envelope{from: from, to: to, msg: msg})
+	atomic.AddInt64(&s.node(from).broadcasts, 1)
+}
+
+// flush schedules the messages that were sent in the last step.
+// messages are sorted, as goroutines of the instances might have sent them in any order
+func (s *Simulator) flush() {
+	s.outboxLock.Lock()
+	outbox := s.outbox
+	s.outbox = nil
+	s.outboxLock.Unlock()
+
+	sort.SliceStable(outbox, func(i, j int) bool {
+		a, b := outbox[i], outbox[j]
+		if a.from != b.from {
+			return a.from < b.from
+		}
+		if a.to != b.to {
+			return a.to < b.to
+		}
+		if a.msg.MsgType != b.msg.MsgType {
+			return a.msg.MsgType < b.msg.MsgType
+		}
+		return bytes.Compare(a.msg.Data, b.msg.Data) < 0
+	})
+
+	now := s.clock.Now()
+	for _, env := range outbox {
+		sender := s.node(env.from)
+		if sender.crashed {
+			continue
+		}
+		recipients := s.operators()
+		if env.to != 0 {
+			recipients = []message.OperatorID{env.to}
+		}
+		for _, to := range recipients {
+			msg := env.msg
+			if sender.behavior != 0 && to != env.from {
+				msg = sender.tamper(to, msg)
+			}
+			s.route(now, env.from, to, msg)
+		}
+	}
+}
+
+// route schedules the delivery of a message according to the network conditions of the scenario
+func (s *Simulator) route(now time.Duration, from, to message.OperatorID, msg *message.SSVMessage) {
+	if from == to {
+		// operators receive their own messages immediately
+		s.schedule(&event{at: now, kind: eventDeliver, from: from, to: to, msg: msg})
+		return
+	}
+	if s.scenario.partitioned(now, from, to) {
+		s.result.Dropped++
+		return
+	}
+	if s.scenario.DropRate > 0 && s.rand.Float64() < s.scenario.DropRate {
+		s.result.Dropped++
+		return
+	}
+	copies := 1
+	if s.scenario.DuplicateRate > 0 && s.rand.Float64() < s.scenario.DuplicateRate {
+		copies++
+	}
+	for i := 0; i < copies; i++ {
+		delay := s.scenario.MinDelay
+		if s.scenario.MaxDelay > s.scenario.MinDelay {
+			delay += time.Duration(s.rand.Int63n(int64(s.scenario.MaxDelay - s.scenario.MinDelay)))
+		}
+		if s.scenario.ReorderRate > 0 && s.rand.Float64() < s.scenario.ReorderRate {
+			delay += time.Duration(s.rand.Int63n(int64(s.scenario.ReorderDelay)))
+		}
+		s.schedule(&event{at: now + delay, kind: eventDeliver, from: from, to: to, msg: msg})
+	}
+}
+
+func (s *Simulator) schedule(e *event) {
+	s.seq++
+	e.seq = s.seq
+	heap.Push(&s.events, e)
+}
+
+// shutdown stops the running instances so their goroutines will exit
+func (s *Simulator) shutdown() {
+	for _, n := range s.nodes {
+		n.stopInstance(n.height)
+	}
+	if err := s.waitIdle(); err != nil {
+		s.logger.Warn("could not stop instances", zap.Error(err))
+	}
+}
+
+// decided records a decision of an operator
+func (s *Simulator) decided(n *node, height message.Height, d Decision) {
+	s.resultLock.Lock()
+	defer s.resultLock.Unlock()
+
+	decisions, ok := s.result.Decided[height]
+	if !ok {
+		decisions = make(map[message.OperatorID]Decision)
+		s.result.Decided[height] = decisions
+	}
+	decisions[n.id] = d
+}
+
+// record adds a step to the trace of the run, which is used to compare runs
+func (s *Simulator) record(format string, args ...interface{}) {
+	_, _ = fmt.Fprintf(s.trace, "%d ", s.clock.Now())
+	_, _ = fmt.Fprintf(s.trace, format, args...)
+	_, _ = s.trace.Write([]byte{'\n'})
+}
+
+func (s *Simulator) node(id message.OperatorID) *node {
+	return s.nodes[id-1]
+}
+
+func (s *Simulator) operators() []message.OperatorID {
+	ids := make([]message.OperatorID, len(s.nodes))
+	for i, n := range s.nodes {
+		ids[i] = n.id
+	}
+	return ids
+}
+
+// envelope is a message that was sent by an operator
+type envelope struct {
+	from message.OperatorID
+	to   message.OperatorID
+	msg  *message.SSVMessage
+}
+
+// kinds of events
+const (
+	eventStart = iota
+	eventAbort
+	eventCrash
+	eventDeliver
+)
+
+type event struct {
+	at   time.Duration
+	seq  uint64
+	kind int
+
+	from      message.OperatorID
+	to        message.OperatorID
+	msg       *message.SSVMessage
+	height    message.Height
+	slotStart time.Duration
+}
+
+// eventQueue is a priority queue of events, ordered by time and then by the order of scheduling
+type eventQueue []*event
+
+func (q eventQueue) Len() int { return len(q) }
+
+func (q eventQueue) Less(i, j int) bool {
+	if q[i].at != q[j].at {
+		return q[i].at < q[j].at
+	}
+	return q[i].seq < q[j].seq
+}
+
+func (q eventQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *eventQueue) Push(x interface{}) {
+	*q = append(*q, x.(*event))
+}
+
+func (q *eventQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	e := old[n-1]
+	old[n-1] = nil
+	*q = old[:n-1]
+	return e
+}
+
+// changeRoundStore keeps the last change round message in memory
+type changeRoundStore struct {
+	lock sync.Mutex
+	last *message.SignedMessage
+}
+
+var _ qbftstorage.ChangeRoundStore = (*changeRoundStore)(nil)
+
+func (s *changeRoundStore) GetLastChangeRoundMsg(identifier message.Identifier) (*message.SignedMessage, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.last, nil
+}
+
+func (s *changeRoundStore) SaveLastChangeRoundMsg(msg *message.SignedMessage) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.last = msg
+	return nil
+}
+
+func (s *changeRoundStore) CleanLastChangeRound(identifier message.Identifier) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.last = nil
+}
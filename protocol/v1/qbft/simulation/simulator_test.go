@@ -0,0 +1,202 @@
+package simulation
+
+import (
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
+	"github.com/bloxapp/ssv/protocol/v1/message"
+	"github.com/bloxapp/ssv/protocol/v1/qbft"
+)
+
+// runsCount returns the number of seeds to run per scenario, can be increased with SIMULATION_RUNS (e.g. to thousands)
+func runsCount(t *testing.T, defaultRuns int) int {
+	if v := os.Getenv("SIMULATION_RUNS"); len(v) > 0 {
+		runs, err := strconv.Atoi(v)
+		require.NoError(t, err)
+		return runs
+	}
+	if testing.Short() {
+		return 1
+	}
+	return defaultRuns
+}
+
+func TestScenarios(t *testing.T) {
+	scenarios := []Scenario{
+		{
+			Name:          "happy flow",
+			Heights:       3,
+			MinDelay:      10 * time.Millisecond,
+			MaxDelay:      100 * time.Millisecond,
+			LivenessBound: time.Second,
+		},
+		{
+			Name:          "lossy network",
+			Heights:       2,
+			MinDelay:      10 * time.Millisecond,
+			MaxDelay:      500 * time.Millisecond,
+			DropRate:      0.1,
+			DuplicateRate: 0.1,
+			ReorderRate:   0.2,
+			StartSkew:     500 * time.Millisecond,
+		},
+		{
+			Name:          "crashed operator",
+			Heights:       4,
+			MinDelay:      10 * time.Millisecond,
+			MaxDelay:      200 * time.Millisecond,
+			Crashes:       []Crash{{Operator: 2}},
+			LivenessBound: 11 * time.Second,
+		},
+		{
+			Name: "partition heals",
+			Config: &qbft.InstanceConfig{
+				RoundChangeDurationSeconds: 2,
+				TimeoutSchedule:            qbft.TimeoutScheduleTwoPhase,
+				QuickRounds:                6,
+				SlowRoundTimeoutSeconds:    10,
+			},
+			MinDelay: 10 * time.Millisecond,
+			MaxDelay: 200 * time.Millisecond,
+			Partitions: []Partition{{
+				End:    5 * time.Second,
+				Groups: [][]message.OperatorID{{1, 2}, {3, 4}},
+			}},
+			LivenessBound: 7 * time.Second,
+		},
+		{
+			Name:          "equivocating operator",
+			Heights:       4,
+			MinDelay:      10 * time.Millisecond,
+			MaxDelay:      200 * time.Millisecond,
+			Byzantine:     map[message.OperatorID]Behavior{1: Equivocation},
+			LivenessBound: 12 * time.Second,
+		},
+		{
+			// the crashed operator leads some of the heights, so there are round changes to tamper with
+			Name:          "invalid justifications",
+			Heights:       4,
+			Committee:     7,
+			MinDelay:      10 * time.Millisecond,
+			MaxDelay:      200 * time.Millisecond,
+			ReorderRate:   0.2,
+			Crashes:       []Crash{{Operator: 4}},
+			Byzantine:     map[message.OperatorID]Behavior{3: InvalidJustification},
+			LivenessBound: 12 * time.Second,
+		},
+		{
+			// safety only, there are more faults than the committee tolerates
+			Name:      "byzantine operators and crash in lossy network",
+			Heights:   2,
+			Committee: 7,
+			MinDelay:  10 * time.Millisecond,
+			MaxDelay:  time.Second,
+			DropRate:  0.2,
+			Crashes:   []Crash{{Operator: 4, At: 2 * time.Second}},
+			Byzantine: map[message.OperatorID]Behavior{1: Equivocation, 2: InvalidJustification},
+		},
+		{
+			Name:          "reputation with crashed operator",
+			Heights:       6,
+			Fork:          forksprotocol.V3ForkVersion,
+			MinDelay:      10 * time.Millisecond,
+			MaxDelay:      200 * time.Millisecond,
+			Crashes:       []Crash{{Operator: 4}},
+			LivenessBound: 11 * time.Second,
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario := scenario
+		t.Run(scenario.Name, func(t *testing.T) {
+			t.Parallel()
+			runs := runsCount(t, 5)
+			for seed := int64(1); seed <= int64(runs); seed++ {
+				res, err := Run(zap.NewNop(), scenario, seed)
+				require.NoError(t, err, "seed %d", seed)
+				require.NoError(t, scenario.Verify(res), "seed %d", seed)
+			}
+		})
+	}
+}
+
+func TestReputationRoundChanges(t *testing.T) {
+	// the crashed operator leads round 1 of some heights in the round-robin order (v1),
+	// with the reputation (v3) it leads only until its first failure in the window
+	scenario := Scenario{
+		Name:          "leader selection with crashed operator",
+		Heights:       12,
+		MinDelay:      10 * time.Millisecond,
+		MaxDelay:      200 * time.Millisecond,
+		Crashes:       []Crash{{Operator: 4}},
+		LivenessBound: 11 * time.Second,
+	}
+	runs := runsCount(t, 3)
+	for seed := int64(1); seed <= int64(runs); seed++ {
+		changes := make(map[forksprotocol.ForkVersion]int)
+		for _, fork := range []forksprotocol.ForkVersion{forksprotocol.V1ForkVersion, forksprotocol.V3ForkVersion} {
+			s := scenario
+			s.Fork = fork
+			res, err := Run(zap.NewNop(), s, seed)
+			require.NoError(t, err, "seed %d", seed)
+			require.NoError(t, s.Verify(res), "seed %d", seed)
+			changes[fork] = res.RoundChanges(s.Correct())
+		}
+		require.Less(t, changes[forksprotocol.V3ForkVersion], changes[forksprotocol.V1ForkVersion], "seed %d", seed)
+	}
+}
+
+func TestDeterminism(t *testing.T) {
+	scenario := Scenario{
+		Name:          "lossy network with byzantine operator",
+		Heights:       2,
+		MinDelay:      10 * time.Millisecond,
+		MaxDelay:      time.Second,
+		DropRate:      0.2,
+		DuplicateRate: 0.1,
+		ReorderRate:   0.2,
+		StartSkew:     time.Second,
+		Byzantine:     map[message.OperatorID]Behavior{2: InvalidJustification},
+	}
+	first, err := Run(zap.NewNop(), scenario, 42)
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		res, err := Run(zap.NewNop(), scenario, 42)
+		require.NoError(t, err)
+		require.Equal(t, first, res)
+	}
+
+	other, err := Run(zap.NewNop(), scenario, 43)
+	require.NoError(t, err)
+	require.NotEqual(t, first.Trace, other.Trace)
+}
+
+func TestVerify(t *testing.T) {
+	scenario := Scenario{Committee: 4, LivenessBound: time.Second}
+	res := &Result{Decided: map[message.Height]map[message.OperatorID]Decision{
+		0: {
+			1: {Value: []byte("a"), At: 100 * time.Millisecond},
+			2: {Value: []byte("a"), At: 100 * time.Millisecond},
+			3: {Value: []byte("a"), At: 100 * time.Millisecond},
+			4: {Value: []byte("a"), At: 100 * time.Millisecond},
+		},
+	}}
+	require.NoError(t, scenario.Verify(res))
+
+	res.Decided[0][4] = Decision{Value: []byte("a"), At: 2 * time.Second}
+	require.EqualError(t, scenario.Verify(res), "liveness violation in height 0: operator 4 decided after 2s (round 0)")
+
+	res.Decided[0][4] = Decision{Value: []byte("b")}
+	require.Error(t, scenario.Verify(res))
+
+	// byzantine operators are not expected to be consistent
+	scenario.Byzantine = map[message.OperatorID]Behavior{4: Equivocation}
+	scenario.LivenessBound = 0
+	require.NoError(t, scenario.Verify(res))
+}
@@ -0,0 +1,140 @@
+package simulation
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/bloxapp/ssv/protocol/v1/qbft/instance/roundtimer"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/journal"
+)
+
+// clock is the virtual clock of the simulation, it moves only between steps
+type clock struct {
+	lock sync.RWMutex
+	now  time.Duration
+}
+
+// Now returns the current virtual time
+func (c *clock) Now() time.Duration {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.now
+}
+
+func (c *clock) set(now time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.now = now
+}
+
+// virtualTimer is a round timer that expires according to the virtual clock,
+// the simulator fires it once the deadline is the next event
+type virtualTimer struct {
+	clock *clock
+
+	lock     sync.Mutex
+	deadline time.Duration
+	armed    bool
+	killed   bool
+	result   chan bool
+}
+
+var _ roundtimer.Timer = (*virtualTimer)(nil)
+
+func newVirtualTimer(c *clock) *virtualTimer {
+	return &virtualTimer{
+		clock:  c,
+		result: make(chan bool, 1),
+	}
+}
+
+// ResultChan returns the result chan
+func (t *virtualTimer) ResultChan() <-chan bool {
+	return t.result
+}
+
+// Reset sets the deadline to the given duration from now
+func (t *virtualTimer) Reset(d time.Duration) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.killed {
+		return
+	}
+	t.deadline = t.clock.Now() + d
+	t.armed = true
+}
+
+// Kill stops the timer, false is always pushed so the timer loop of the instance will exit
+func (t *virtualTimer) Kill() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.killed {
+		return
+	}
+	t.killed = true
+	t.armed = false
+	select {
+	case t.result <- false:
+	default:
+	}
+}
+
+// Stopped returns whether the timer has stopped
+func (t *virtualTimer) Stopped() bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return !t.armed
+}
+
+// next returns the deadline of the timer, if armed
+func (t *virtualTimer) next() (time.Duration, bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.deadline, t.armed
+}
+
+// fire expires the timer
+func (t *virtualTimer) fire() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if !t.armed {
+		return
+	}
+	t.armed = false
+	t.result <- true
+}
+
+// disarm cancels the current deadline without notifying the instance
+func (t *virtualTimer) disarm() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.armed = false
+}
+
+// stageCounter is a journal that counts the stage changes of an operator's instances,
+// which is used to tell when the operator is done processing a step
+type stageCounter struct {
+	stages int64
+}
+
+var _ journal.Journal = (*stageCounter)(nil)
+
+// Record implements journal.Journal
+func (c *stageCounter) Record(e journal.Event) {
+	if e.Type == journal.EventStage {
+		atomic.AddInt64(&c.stages, 1)
+	}
+}
+
+// Close implements journal.Journal
+func (c *stageCounter) Close() error {
+	return nil
+}
+
+func (c *stageCounter) stagesCount() int64 {
+	return atomic.LoadInt64(&c.stages)
+}
@@ -0,0 +1,561 @@
+package spectest
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
+	"github.com/bloxapp/ssv/protocol/v1/message"
+	"github.com/bloxapp/ssv/protocol/v1/qbft"
+	"github.com/bloxapp/ssv/protocol/v1/utils/threshold"
+)
+
+// TestGenerateVectors writes the corpus to testdata, it runs only if SPECTEST_GENERATE is set:
+//	SPECTEST_GENERATE=1 go test ./protocol/v1/qbft/spectest -run TestGenerateVectors
+// the outputs of the vectors are recorded from the current implementation, and checked by the generator
+func TestGenerateVectors(t *testing.T) {
+	if len(os.Getenv("SPECTEST_GENERATE")) == 0 {
+		t.Skip("SPECTEST_GENERATE is not set")
+	}
+	g := newGenerator(t)
+	for file, cases := range g.corpus() {
+		vectors := make([]*Vector, 0, len(cases))
+		for _, c := range cases {
+			require.NoError(t, Record(zap.NewNop(), c.vector), c.vector.Name)
+			if c.check != nil {
+				c.check(t, &c.vector.Expected)
+			}
+			vectors = append(vectors, c.vector)
+		}
+		require.NoError(t, Save(filepath.Join("testdata", file), vectors...))
+	}
+}
+
+// generatedCase is a vector of the corpus, and a check of its recorded outputs
+type generatedCase struct {
+	vector *Vector
+	check  func(t *testing.T, o *Outputs)
+}
+
+// generator creates the messages of the corpus with deterministic keys
+type generator struct {
+	t           *testing.T
+	validatorSK *bls.SecretKey
+	sks         map[message.OperatorID]*bls.SecretKey
+	identifier  message.Identifier
+}
+
+func newGenerator(t *testing.T) *generator {
+	threshold.Init()
+	g := &generator{
+		t:           t,
+		validatorSK: newKey(t, "validator"),
+		sks:         make(map[message.OperatorID]*bls.SecretKey),
+	}
+	for id := message.OperatorID(1); id <= 4; id++ {
+		g.sks[id] = newKey(t, fmt.Sprintf("operator %d", id))
+	}
+	g.identifier = message.NewIdentifier(g.validatorSK.GetPublicKey().Serialize(), message.RoleTypeAttester)
+	return g
+}
+
+// newKey returns a deterministic key for the given seed
+func newKey(t *testing.T, seed string) *bls.SecretKey {
+	h := sha256.Sum256([]byte(seed))
+	sk := &bls.SecretKey{}
+	require.NoError(t, sk.SetLittleEndianMod(h[:]))
+	return sk
+}
+
+func (g *generator) share(id message.OperatorID) *Share {
+	committee := make(map[message.OperatorID][]byte)
+	for oid, sk := range g.sks {
+		committee[oid] = sk.GetPublicKey().Serialize()
+	}
+	return &Share{
+		OperatorID:  id,
+		ValidatorPK: g.validatorSK.GetPublicKey().Serialize(),
+		Committee:   committee,
+		SecretKey:   g.sks[id].Serialize(),
+	}
+}
+
+func (g *generator) state(height message.Height, round message.Round, stage qbft.RoundState, input []byte) *qbft.State {
+	s := &qbft.State{}
+	s.Stage.Store(int32(stage))
+	s.Identifier.Store(g.identifier)
+	s.Height.Store(height)
+	s.InputValue.Store(input)
+	s.Round.Store(round)
+	s.PreparedRound.Store(message.Round(0))
+	s.PreparedValue.Store([]byte(nil))
+	return s
+}
+
+// signed returns the message signed by the given signers, the signature is aggregated
+func (g *generator) signed(fork forksprotocol.ForkVersion, msg *message.ConsensusMessage, signers ...message.OperatorID) *message.SignedMessage {
+	var agg *bls.Sign
+	for _, id := range signers {
+		sig, err := msg.Sign(g.sks[id], fork.String())
+		require.NoError(g.t, err)
+		if agg == nil {
+			agg = sig
+		} else {
+			agg.Add(sig)
+		}
+	}
+	return &message.SignedMessage{
+		Message:   msg,
+		Signature: agg.Serialize(),
+		Signers:   signers,
+	}
+}
+
+func (g *generator) msg(msgType message.ConsensusMessageType, height message.Height, round message.Round, data []byte) *message.ConsensusMessage {
+	return &message.ConsensusMessage{
+		MsgType:    msgType,
+		Height:     height,
+		Round:      round,
+		Identifier: g.identifier,
+		Data:       data,
+	}
+}
+
+func (g *generator) proposal(height message.Height, round message.Round, value []byte) *message.ConsensusMessage {
+	data, err := (&message.ProposalData{Data: value}).Encode()
+	require.NoError(g.t, err)
+	return g.msg(message.ProposalMsgType, height, round, data)
+}
+
+func (g *generator) prepare(height message.Height, round message.Round, value []byte) *message.ConsensusMessage {
+	data, err := (&message.PrepareData{Data: value}).Encode()
+	require.NoError(g.t, err)
+	return g.msg(message.PrepareMsgType, height, round, data)
+}
+
+func (g *generator) commit(height message.Height, round message.Round, value []byte) *message.ConsensusMessage {
+	data, err := (&message.CommitData{Data: value}).Encode()
+	require.NoError(g.t, err)
+	return g.msg(message.CommitMsgType, height, round, data)
+}
+
+func (g *generator) roundChange(height message.Height, round message.Round, prepared *message.SignedMessage) *message.ConsensusMessage {
+	rcData := &message.RoundChangeData{}
+	if prepared != nil {
+		prepareData, err := prepared.Message.GetPrepareData()
+		require.NoError(g.t, err)
+		rcData.PreparedValue = prepareData.Data
+		rcData.Round = prepared.Message.Round
+		rcData.RoundChangeJustification = []*message.SignedMessage{prepared}
+	}
+	data, err := rcData.Encode()
+	require.NoError(g.t, err)
+	return g.msg(message.RoundChangeMsgType, height, round, data)
+}
+
+// each returns the message of each of the given signers
+func (g *generator) each(fork forksprotocol.ForkVersion, msg *message.ConsensusMessage, signers ...message.OperatorID) []*Message {
+	msgs := make([]*Message, 0, len(signers))
+	for _, id := range signers {
+		msgs = append(msgs, &Message{Message: g.signed(fork, msg, id)})
+	}
+	return msgs
+}
+
+func concat(msgs ...[]*Message) []*Message {
+	res := make([]*Message, 0)
+	for _, m := range msgs {
+		res = append(res, m...)
+	}
+	return res
+}
+
+func consensus(signed ...*message.SignedMessage) []*Message {
+	msgs := make([]*Message, 0, len(signed))
+	for _, s := range signed {
+		msgs = append(msgs, &Message{Message: s})
+	}
+	return msgs
+}
+
+func decided(signed ...*message.SignedMessage) []*Message {
+	msgs := make([]*Message, 0, len(signed))
+	for _, s := range signed {
+		msgs = append(msgs, &Message{MsgType: message.SSVDecidedMsgType, Message: s})
+	}
+	return msgs
+}
+
+// broadcastTypes returns the types of the broadcasted consensus messages
+func broadcastTypes(o *Outputs) []message.ConsensusMessageType {
+	var types []message.ConsensusMessageType
+	for _, m := range o.Broadcast {
+		types = append(types, m.Message.Message.MsgType)
+	}
+	return types
+}
+
+func (g *generator) corpus() map[string][]*generatedCase {
+	return map[string][]*generatedCase{
+		"roots.json":                      g.roots(),
+		"instance/happy_flow.json":        g.happyFlow(),
+		"instance/invalid_messages.json":  g.invalidMessages(),
+		"instance/unprepared_commit.json": g.unpreparedCommit(),
+		"instance/round_change.json":      g.roundChanges(),
+		"controller/decided.json":         g.controllerDecided(),
+	}
+}
+
+func (g *generator) roots() []*generatedCase {
+	value := []byte("value")
+	cases := make([]*generatedCase, 0)
+	for _, fork := range []forksprotocol.ForkVersion{forksprotocol.V0ForkVersion, forksprotocol.V1ForkVersion} {
+		prepared := g.signed(fork, g.prepare(1, 1, value), 1, 2, 3)
+		cases = append(cases, &generatedCase{
+			vector: &Vector{
+				Name: fmt.Sprintf("%s message roots", fork),
+				Type: RootType,
+				Fork: fork,
+				Messages: consensus(
+					g.signed(fork, g.proposal(1, 1, value), 1),
+					g.signed(fork, g.prepare(1, 1, value), 1),
+					g.signed(fork, g.commit(1, 1, value), 1),
+					g.signed(fork, g.roundChange(1, 2, nil), 1),
+					g.signed(fork, g.roundChange(1, 2, prepared), 1),
+				),
+			},
+			check: func(t *testing.T, o *Outputs) {
+				require.Len(t, o.Roots, 5)
+			},
+		})
+	}
+	return cases
+}
+
+func (g *generator) happyFlow() []*generatedCase {
+	fork := forksprotocol.V1ForkVersion
+	value := []byte("value")
+	return []*generatedCase{
+		{
+			vector: &Vector{
+				Name:       "leader proposes when started",
+				Type:       InstanceType,
+				Fork:       fork,
+				Identifier: g.identifier,
+				Share:      g.share(1),
+				Leader:     1,
+				State:      g.state(1, 0, qbft.RoundStateNotStarted, value),
+				Start:      true,
+			},
+			check: func(t *testing.T, o *Outputs) {
+				require.Equal(t, []message.ConsensusMessageType{message.ProposalMsgType}, broadcastTypes(o))
+			},
+		},
+		{
+			vector: &Vector{
+				Name:       "proposal, prepare and commit quorums decide",
+				Type:       InstanceType,
+				Fork:       fork,
+				Identifier: g.identifier,
+				Share:      g.share(1),
+				Leader:     2,
+				State:      g.state(1, 0, qbft.RoundStateNotStarted, []byte("input value")),
+				Start:      true,
+				Messages: concat(
+					consensus(g.signed(fork, g.proposal(1, 1, value), 2)),
+					g.each(fork, g.prepare(1, 1, value), 2, 3, 4),
+					g.each(fork, g.commit(1, 1, value), 2, 3, 4),
+				),
+			},
+			check: func(t *testing.T, o *Outputs) {
+				require.Equal(t, []message.ConsensusMessageType{message.PrepareMsgType, message.CommitMsgType}, broadcastTypes(o))
+				require.Equal(t, value, o.DecidedValue)
+			},
+		},
+		{
+			vector: &Vector{
+				Name:       "v0 proposal, prepare and commit quorums decide",
+				Type:       InstanceType,
+				Fork:       forksprotocol.V0ForkVersion,
+				Identifier: g.identifier,
+				Share:      g.share(1),
+				Leader:     2,
+				State:      g.state(1, 0, qbft.RoundStateNotStarted, []byte("input value")),
+				Start:      true,
+				Messages: concat(
+					consensus(g.signed(forksprotocol.V0ForkVersion, g.proposal(1, 1, value), 2)),
+					g.each(forksprotocol.V0ForkVersion, g.prepare(1, 1, value), 2, 3, 4),
+					g.each(forksprotocol.V0ForkVersion, g.commit(1, 1, value), 2, 3, 4),
+				),
+			},
+			check: func(t *testing.T, o *Outputs) {
+				require.Equal(t, value, o.DecidedValue)
+			},
+		},
+		{
+			vector: &Vector{
+				Name:       "aggregated commit decides",
+				Type:       InstanceType,
+				Fork:       fork,
+				Identifier: g.identifier,
+				Share:      g.share(1),
+				Leader:     2,
+				State:      g.state(1, 0, qbft.RoundStateNotStarted, []byte("input value")),
+				Start:      true,
+				Messages: concat(
+					consensus(g.signed(fork, g.proposal(1, 1, value), 2)),
+					g.each(fork, g.prepare(1, 1, value), 2, 3, 4),
+					consensus(g.signed(fork, g.commit(1, 1, value), 2, 3, 4)),
+				),
+			},
+			check: func(t *testing.T, o *Outputs) {
+				require.Equal(t, value, o.DecidedValue)
+			},
+		},
+	}
+}
+
+func (g *generator) invalidMessages() []*generatedCase {
+	fork := forksprotocol.V1ForkVersion
+	value := []byte("value")
+	invalidSig := g.signed(fork, g.prepare(1, 1, value), 3)
+	invalidSig.Signers = []message.OperatorID{2}
+	unknownSigner := g.signed(fork, g.prepare(1, 1, value), 3)
+	unknownSigner.Signers = []message.OperatorID{7}
+
+	vector := func(name string, check func(t *testing.T, o *Outputs), msgs ...*Message) *generatedCase {
+		return &generatedCase{
+			vector: &Vector{
+				Name:       name,
+				Type:       InstanceType,
+				Fork:       fork,
+				Identifier: g.identifier,
+				Share:      g.share(1),
+				Leader:     2,
+				State:      g.state(1, 0, qbft.RoundStateNotStarted, []byte("input value")),
+				Start:      true,
+				Messages:   msgs,
+			},
+			check: check,
+		}
+	}
+	// invalid checks that the last message fails
+	invalid := func(t *testing.T, o *Outputs) {
+		require.NotEmpty(t, o.Errors[len(o.Errors)-1])
+		require.Nil(t, o.DecidedValue)
+	}
+	// ignored checks that messages of future rounds are kept, but don't trigger anything
+	ignored := func(broadcast ...message.ConsensusMessageType) func(t *testing.T, o *Outputs) {
+		return func(t *testing.T, o *Outputs) {
+			require.Equal(t, broadcast, broadcastTypes(o))
+			require.Nil(t, o.DecidedValue)
+		}
+	}
+	return []*generatedCase{
+		vector("proposal of a non leader", invalid, consensus(g.signed(fork, g.proposal(1, 1, value), 3))...),
+		vector("proposal of a future round", ignored(), consensus(g.signed(fork, g.proposal(1, 2, value), 2))...),
+		vector("prepare with an invalid signature", invalid, consensus(invalidSig)...),
+		vector("prepare of an unknown signer", invalid, consensus(unknownSigner)...),
+		vector("prepare of another height", invalid, consensus(g.signed(fork, g.prepare(2, 1, value), 3))...),
+		vector("prepare of another identifier", invalid, consensus(g.signed(fork, &message.ConsensusMessage{
+			MsgType:    message.PrepareMsgType,
+			Height:     1,
+			Round:      1,
+			Identifier: message.NewIdentifier(g.validatorSK.GetPublicKey().Serialize(), message.RoleTypeProposer),
+			Data:       g.prepare(1, 1, value).Data,
+		}, 3))...),
+		vector("commit of a future round", ignored(message.PrepareMsgType), concat(
+			consensus(g.signed(fork, g.proposal(1, 1, value), 2)),
+			consensus(g.signed(fork, g.commit(1, 2, value), 3)),
+		)...),
+	}
+}
+
+// unpreparedCommit checks that commits of a value that was never prepared don't affect the decision
+func (g *generator) unpreparedCommit() []*generatedCase {
+	fork := forksprotocol.V1ForkVersion
+	value := []byte("value")
+	forged := []byte("forged value")
+	return []*generatedCase{
+		{
+			vector: &Vector{
+				Name:       "commit of an unprepared value is not counted",
+				Type:       InstanceType,
+				Fork:       fork,
+				Identifier: g.identifier,
+				Share:      g.share(1),
+				Leader:     2,
+				State:      g.state(1, 0, qbft.RoundStateNotStarted, []byte("input value")),
+				Start:      true,
+				Messages: concat(
+					consensus(g.signed(fork, g.proposal(1, 1, value), 2)),
+					g.each(fork, g.prepare(1, 1, value), 2, 3, 4),
+					consensus(g.signed(fork, g.commit(1, 1, forged), 4)),
+					g.each(fork, g.commit(1, 1, value), 2, 3),
+				),
+			},
+			check: func(t *testing.T, o *Outputs) {
+				require.Nil(t, o.DecidedValue, "2 commits of the prepared value are not a quorum")
+			},
+		},
+		{
+			vector: &Vector{
+				Name:       "decision ignores the commit of an unprepared value",
+				Type:       InstanceType,
+				Fork:       fork,
+				Identifier: g.identifier,
+				Share:      g.share(1),
+				Leader:     2,
+				State:      g.state(1, 0, qbft.RoundStateNotStarted, []byte("input value")),
+				Start:      true,
+				Messages: concat(
+					consensus(g.signed(fork, g.proposal(1, 1, value), 2)),
+					g.each(fork, g.prepare(1, 1, value), 2, 3, 4),
+					consensus(g.signed(fork, g.commit(1, 1, forged), 4)),
+					g.each(fork, g.commit(1, 1, value), 2, 3, 1),
+				),
+			},
+			check: func(t *testing.T, o *Outputs) {
+				require.Equal(t, value, o.DecidedValue)
+			},
+		},
+	}
+}
+
+func (g *generator) roundChanges() []*generatedCase {
+	fork := forksprotocol.V1ForkVersion
+	value := []byte("value")
+	forged := []byte("forged value")
+	prepared := g.signed(fork, g.prepare(1, 1, value), 2, 3, 4)
+	// the justification declares a quorum of signers, but is signed only by operator 4
+	forgedJustification := g.signed(fork, g.prepare(1, 1, forged), 4)
+	forgedJustification.Signers = []message.OperatorID{2, 3, 4}
+
+	return []*generatedCase{
+		{
+			vector: &Vector{
+				Name:       "partial quorum of round changes bumps the round",
+				Type:       InstanceType,
+				Fork:       fork,
+				Identifier: g.identifier,
+				Share:      g.share(1),
+				Leader:     3,
+				State:      g.state(1, 1, qbft.RoundStatePrePrepare, []byte("input value")),
+				Messages:   g.each(fork, g.roundChange(1, 2, nil), 2, 3),
+			},
+			check: func(t *testing.T, o *Outputs) {
+				require.Equal(t, message.Round(2), o.State.GetRound())
+			},
+		},
+		{
+			vector: &Vector{
+				Name:       "proposal justified by a round change quorum",
+				Type:       InstanceType,
+				Fork:       fork,
+				Identifier: g.identifier,
+				Share:      g.share(1),
+				Leader:     2,
+				State:      g.state(1, 2, qbft.RoundStateChangeRound, []byte("input value")),
+				Messages: concat(
+					g.each(fork, g.roundChange(1, 2, nil), 2, 3, 4),
+					consensus(g.signed(fork, g.proposal(1, 2, value), 2)),
+				),
+			},
+			check: func(t *testing.T, o *Outputs) {
+				require.Equal(t, []message.ConsensusMessageType{message.PrepareMsgType}, broadcastTypes(o))
+			},
+		},
+		{
+			vector: &Vector{
+				Name:       "proposal must propose the highest prepared value",
+				Type:       InstanceType,
+				Fork:       fork,
+				Identifier: g.identifier,
+				Share:      g.share(1),
+				Leader:     2,
+				State:      g.state(1, 2, qbft.RoundStateChangeRound, []byte("input value")),
+				Messages: concat(
+					g.each(fork, g.roundChange(1, 2, prepared), 2, 3, 4),
+					consensus(g.signed(fork, g.proposal(1, 2, forged), 2)),
+				),
+			},
+			check: func(t *testing.T, o *Outputs) {
+				require.NotEmpty(t, o.Errors[3])
+				require.Empty(t, o.Broadcast)
+			},
+		},
+		{
+			vector: &Vector{
+				Name:       "round change with a forged justification",
+				Type:       InstanceType,
+				Fork:       fork,
+				Identifier: g.identifier,
+				Share:      g.share(1),
+				Leader:     2,
+				State:      g.state(1, 2, qbft.RoundStateChangeRound, []byte("input value")),
+				Messages:   consensus(g.signed(fork, g.roundChange(1, 2, forgedJustification), 4)),
+			},
+			check: func(t *testing.T, o *Outputs) {
+				require.NotEmpty(t, o.Errors[0])
+			},
+		},
+	}
+}
+
+func (g *generator) controllerDecided() []*generatedCase {
+	fork := forksprotocol.V1ForkVersion
+	history := []*message.SignedMessage{
+		g.signed(fork, g.commit(0, 1, []byte("value 0")), 1, 2, 3),
+		g.signed(fork, g.commit(1, 1, []byte("value 1")), 1, 2, 3),
+		g.signed(fork, g.commit(2, 1, []byte("value 2")), 1, 2, 3),
+	}
+	next := g.signed(fork, g.commit(3, 1, []byte("value 3")), 2, 3, 4)
+	invalidSig := g.signed(fork, g.commit(3, 1, []byte("value 3")), 2, 3, 4)
+	invalidSig.Signers = []message.OperatorID{1, 3, 4}
+
+	vector := func(name string, msgs []*Message, lastDecided *message.SignedMessage) *generatedCase {
+		return &generatedCase{
+			vector: &Vector{
+				Name:       name,
+				Type:       ControllerType,
+				Fork:       fork,
+				Identifier: g.identifier,
+				Share:      g.share(1),
+				Decided:    history,
+				Messages:   msgs,
+			},
+			check: func(t *testing.T, o *Outputs) {
+				require.Equal(t, lastDecided.Message.Height, o.LastDecided.Message.Height)
+				require.Equal(t, lastDecided.Message.Data, o.LastDecided.Message.Data)
+			},
+		}
+	}
+	return []*generatedCase{
+		vector("decided of the next height", decided(next), next),
+		vector("decided with an invalid signature", decided(invalidSig), history[2]),
+		vector("decided without a quorum", decided(g.signed(fork, g.commit(3, 1, []byte("value 3")), 2, 3)), history[2]),
+		vector("decided of an old height", decided(g.signed(fork, g.commit(1, 1, []byte("other value")), 2, 3, 4)), history[2]),
+		{
+			vector: &Vector{
+				Name:       "decided of the last height with more signers",
+				Type:       ControllerType,
+				Fork:       fork,
+				Identifier: g.identifier,
+				Share:      g.share(1),
+				Decided:    history,
+				Messages:   decided(g.signed(fork, g.commit(2, 1, []byte("value 2")), 1, 2, 3, 4)),
+			},
+			check: func(t *testing.T, o *Outputs) {
+				require.ElementsMatch(t, []message.OperatorID{1, 2, 3, 4}, o.LastDecided.Signers)
+			},
+		},
+		vector("late commit completes the last decided", consensus(g.signed(fork, g.commit(2, 1, []byte("value 2")), 4)), history[2]),
+	}
+}
@@ -0,0 +1,146 @@
+package spectest
+
+import (
+	"sync"
+	"time"
+
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/pkg/errors"
+
+	"github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
+	"github.com/bloxapp/ssv/protocol/v1/message"
+	protcolp2p "github.com/bloxapp/ssv/protocol/v1/p2p"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/instance/roundtimer"
+)
+
+var errNotSupported = errors.New("not supported in spec tests")
+
+// recorder is a network that records the broadcasted messages, there are no peers to sync with
+type recorder struct {
+	lock      sync.Mutex
+	broadcast []*Message
+}
+
+var _ protcolp2p.Network = (*recorder)(nil)
+
+func newRecorder() *recorder {
+	return &recorder{}
+}
+
+func (r *recorder) Broadcast(msg message.SSVMessage) error {
+	signedMsg := &message.SignedMessage{}
+	if err := signedMsg.Decode(msg.Data); err != nil {
+		return errors.Wrap(err, "could not decode broadcasted message")
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.broadcast = append(r.broadcast, &Message{MsgType: msg.MsgType, Message: signedMsg})
+	return nil
+}
+
+// messages returns the broadcasted messages
+func (r *recorder) messages() []*Message {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return append([]*Message(nil), r.broadcast...)
+}
+
+// waitFor waits until the given number of messages were broadcasted
+func (r *recorder) waitFor(count int, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for len(r.messages()) < count {
+		if time.Now().After(deadline) {
+			return errors.New("timed out waiting for broadcasted messages")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return nil
+}
+
+func (r *recorder) Subscribe(pk message.ValidatorPK) error {
+	return nil
+}
+
+func (r *recorder) Unsubscribe(pk message.ValidatorPK) error {
+	return nil
+}
+
+func (r *recorder) Peers(pk message.ValidatorPK) ([]peer.ID, error) {
+	return nil, nil
+}
+
+func (r *recorder) RegisterHandlers(handlers ...*protcolp2p.SyncHandler) {}
+
+func (r *recorder) LastDecided(mid message.Identifier) ([]protcolp2p.SyncResult, error) {
+	return nil, errNotSupported
+}
+
+func (r *recorder) GetHistory(mid message.Identifier, from, to message.Height, targets ...string) ([]protcolp2p.SyncResult, message.Height, error) {
+	return nil, 0, errNotSupported
+}
+
+func (r *recorder) LastChangeRound(mid message.Identifier, height message.Height) ([]protcolp2p.SyncResult, error) {
+	return nil, errNotSupported
+}
+
+func (r *recorder) ReportValidation(message *message.SSVMessage, res protcolp2p.MsgValidationResult) {
+}
+
+// signer signs consensus messages with the share key of the operator
+type signer struct {
+	sk *bls.SecretKey
+}
+
+var _ beacon.Signer = (*signer)(nil)
+
+func (s *signer) SignIBFTMessage(msg *message.ConsensusMessage, pk []byte, forkVersion string) ([]byte, error) {
+	sig, err := msg.Sign(s.sk, forkVersion)
+	if err != nil {
+		return nil, err
+	}
+	return sig.Serialize(), nil
+}
+
+func (s *signer) SignAttestation(data *spec.AttestationData, duty *beacon.Duty, pk []byte) (*spec.Attestation, []byte, error) {
+	return nil, nil, errNotSupported
+}
+
+func (s *signer) SignValidatorRegistration(registration *beacon.ValidatorRegistration, pk []byte) ([]byte, []byte, error) {
+	return nil, nil, errNotSupported
+}
+
+// noopTimer is a round timer that never expires, vectors don't depend on time
+type noopTimer struct {
+	lock   sync.Mutex
+	killed bool
+	result chan bool
+}
+
+var _ roundtimer.Timer = (*noopTimer)(nil)
+
+func (t *noopTimer) ResultChan() <-chan bool {
+	return t.result
+}
+
+func (t *noopTimer) Reset(d time.Duration) {}
+
+// Kill pushes false so the timer loop of the instance will exit
+func (t *noopTimer) Kill() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.killed {
+		return
+	}
+	t.killed = true
+	select {
+	case t.result <- false:
+	default:
+	}
+}
+
+func (t *noopTimer) Stopped() bool {
+	return true
+}
@@ -0,0 +1,301 @@
+package spectest
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/protocol/v1/message"
+	"github.com/bloxapp/ssv/protocol/v1/qbft"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/controller"
+	forksfactory "github.com/bloxapp/ssv/protocol/v1/qbft/controller/forks/factory"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/instance"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/instance/leader"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/instance/leader/constant"
+	qbftstorage "github.com/bloxapp/ssv/protocol/v1/qbft/storage"
+	"github.com/bloxapp/ssv/protocol/v1/utils/threshold"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/storage/kv"
+)
+
+// proposalTimeout is the time to wait for the proposal of a leader that started an instance
+const proposalTimeout = 5 * time.Second
+
+// Run runs the given vector, and returns an error if the outputs don't match the expected outputs
+func Run(logger *zap.Logger, v *Vector) error {
+	outputs, err := run(logger, v)
+	if err != nil {
+		return errors.Wrap(err, "could not run vector")
+	}
+	return v.Expected.match(outputs)
+}
+
+// Record runs the given vector and sets the outputs as the expected outputs, it is used to create vectors
+func Record(logger *zap.Logger, v *Vector) error {
+	outputs, err := run(logger, v)
+	if err != nil {
+		return errors.Wrap(err, "could not run vector")
+	}
+	v.Expected = *outputs
+	return nil
+}
+
+func run(logger *zap.Logger, v *Vector) (*Outputs, error) {
+	threshold.Init()
+
+	switch v.Type {
+	case RootType:
+		return runRoots(v)
+	case InstanceType:
+		return runInstance(logger, v)
+	case ControllerType:
+		return runController(logger, v)
+	default:
+		return nil, errors.Errorf("unknown vector type: %s", v.Type)
+	}
+}
+
+// runRoots calculates the signing roots of the input messages
+func runRoots(v *Vector) (*Outputs, error) {
+	outputs := &Outputs{}
+	for _, msg := range v.Messages {
+		root, err := msg.Message.Message.GetRoot(v.Fork.String())
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get root")
+		}
+		outputs.Roots = append(outputs.Roots, hex.EncodeToString(root))
+	}
+	return outputs, nil
+}
+
+// runInstance processes the input messages with an instance
+func runInstance(logger *zap.Logger, v *Vector) (*Outputs, error) {
+	if v.Share == nil || v.State == nil {
+		return nil, errors.New("instance vectors must have a share and a state")
+	}
+	share, sk, err := v.Share.beaconShare()
+	if err != nil {
+		return nil, err
+	}
+	fork := forksfactory.NewFork(v.Fork)
+	height := v.State.GetHeight()
+	leaderSelector, err := v.leaderSelector(uint64(len(share.Committee)), height)
+	if err != nil {
+		return nil, err
+	}
+	net := newRecorder()
+	timer := &noopTimer{result: make(chan bool, 1)}
+	inst := instance.NewInstance(&instance.Options{
+		Logger:           logger,
+		ValidatorShare:   share,
+		Network:          net,
+		LeaderSelector:   leaderSelector,
+		Config:           v.config(),
+		Identifier:       v.Identifier,
+		Height:           height,
+		Fork:             fork.InstanceFork(),
+		Signer:           &signer{sk: sk},
+		ChangeRoundStore: qbftstorage.NewQBFTStore(newInMemDb(logger), logger, "spectest"),
+		RoundTimer:       timer,
+	}).(*instance.Instance)
+	defer inst.Stop()
+
+	if v.Start {
+		inst.Init()
+		if err := inst.Start(v.State.GetInputValue()); err != nil {
+			return nil, errors.Wrap(err, "could not start instance")
+		}
+		if inst.IsLeader() {
+			if err := net.waitFor(1, proposalTimeout); err != nil {
+				return nil, errors.Wrap(err, "leader didn't propose")
+			}
+		}
+	} else {
+		state := inst.State()
+		state.Stage.Store(v.State.Stage.Load())
+		state.InputValue.Store(v.State.GetInputValue())
+		state.Round.Store(v.State.GetRound())
+		state.PreparedRound.Store(v.State.GetPreparedRound())
+		state.PreparedValue.Store(v.State.GetPreparedValue())
+	}
+
+	outputs := &Outputs{}
+	for _, msg := range v.Messages {
+		var err error
+		switch msg.MsgType {
+		case message.SSVConsensusMsgType:
+			_, err = inst.ProcessMsg(msg.Message)
+		case message.SSVDecidedMsgType:
+			inst.ForceDecide(msg.Message)
+		default:
+			err = errors.Errorf("message type is not supported: %s", msg.MsgType)
+		}
+		outputs.Errors = append(outputs.Errors, errString(err))
+	}
+
+	outputs.Broadcast = net.messages()
+	if decided, err := inst.CommittedAggregatedMsg(); err == nil {
+		commitData, err := decided.Message.GetCommitData()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get commit data")
+		}
+		outputs.DecidedValue = commitData.Data
+	}
+	// a copy of the state, as the instance is stopped once done
+	raw, err := json.Marshal(inst.State())
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encode state")
+	}
+	outputs.State = &qbft.State{}
+	if err := json.Unmarshal(raw, outputs.State); err != nil {
+		return nil, errors.Wrap(err, "could not decode state")
+	}
+	return outputs, nil
+}
+
+// runController processes the input messages with a controller in read mode
+func runController(logger *zap.Logger, v *Vector) (*Outputs, error) {
+	if v.Share == nil {
+		return nil, errors.New("controller vectors must have a share")
+	}
+	share, sk, err := v.Share.beaconShare()
+	if err != nil {
+		return nil, err
+	}
+	store := qbftstorage.NewQBFTStore(newInMemDb(logger), logger, "spectest")
+	if len(v.Decided) > 0 {
+		if err := store.SaveDecided(v.Decided...); err != nil {
+			return nil, errors.Wrap(err, "could not save decided history")
+		}
+		if err := store.SaveLastDecided(v.Decided[len(v.Decided)-1]); err != nil {
+			return nil, errors.Wrap(err, "could not save last decided")
+		}
+	}
+	net := newRecorder()
+	ctrl := controller.New(controller.Options{
+		Context:        context.Background(),
+		Role:           v.Identifier.GetRoleType(),
+		Identifier:     v.Identifier,
+		Logger:         logger,
+		Storage:        store,
+		Network:        net,
+		InstanceConfig: v.config(),
+		ValidatorShare: share,
+		Version:        v.Fork,
+		Signer:         &signer{sk: sk},
+		ReadMode:       true,
+	})
+
+	outputs := &Outputs{}
+	for _, msg := range v.Messages {
+		data, err := msg.Message.Encode()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not encode message")
+		}
+		err = ctrl.ProcessMsg(&message.SSVMessage{
+			MsgType: msg.MsgType,
+			ID:      v.Identifier,
+			Data:    data,
+		})
+		outputs.Errors = append(outputs.Errors, errString(err))
+	}
+
+	outputs.Broadcast = net.messages()
+	outputs.LastDecided, err = store.GetLastDecided(v.Identifier)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get last decided")
+	}
+	return outputs, nil
+}
+
+// config returns the instance config of the vector, without the delay of the leader
+func (v *Vector) config() *qbft.InstanceConfig {
+	config := qbft.DefaultConsensusParams()
+	if v.Config != nil {
+		config = v.Config.WithDefaults()
+	}
+	config.LeaderPreprepareDelaySeconds = 0
+	return config
+}
+
+// leaderSelector returns the leader selector of the vector
+func (v *Vector) leaderSelector(committeeSize uint64, height message.Height) (leader.Selector, error) {
+	if v.Leader > 0 {
+		return &constant.Constant{LeaderIndex: uint64(v.Leader) - 1}, nil
+	}
+	fork := forksfactory.NewFork(v.Fork)
+	identifier := fork.Identifier(v.Identifier.GetValidatorPK(), v.Identifier.GetRoleType())
+	return fork.LeaderSelector(identifier, committeeSize, height, func(from, to message.Height) map[message.Height]message.Round {
+		return map[message.Height]message.Round{}
+	})
+}
+
+// match returns an error that describes the differences between the expected and the actual outputs
+func (o *Outputs) match(actual *Outputs) error {
+	var diffs []string
+	diff := func(field string, expected, actual interface{}) {
+		e, _ := json.Marshal(expected)
+		a, _ := json.Marshal(actual)
+		if !bytes.Equal(e, a) {
+			diffs = append(diffs, fmt.Sprintf("%s: expected %s, got %s", field, e, a))
+		}
+	}
+
+	if len(o.Roots) > 0 {
+		diff("roots", o.Roots, actual.Roots)
+	}
+	if len(o.Errors) > 0 {
+		if len(o.Errors) != len(actual.Errors) {
+			diff("errors", o.Errors, actual.Errors)
+		} else {
+			for i, expected := range o.Errors {
+				if (len(expected) == 0) != (len(actual.Errors[i]) == 0) || !strings.Contains(actual.Errors[i], expected) {
+					diffs = append(diffs, fmt.Sprintf("error of message %d: expected %q, got %q", i, expected, actual.Errors[i]))
+				}
+			}
+		}
+	}
+	diff("broadcast", o.Broadcast, actual.Broadcast)
+	diff("decided value", o.DecidedValue, actual.DecidedValue)
+	if o.State != nil {
+		diff("state", o.State, actual.State)
+	}
+	diff("last decided", o.LastDecided, actual.LastDecided)
+
+	if len(diffs) > 0 {
+		return errors.New(strings.Join(diffs, "\n"))
+	}
+	return nil
+}
+
+// errString returns the error as a string that survives json encoding,
+// as some errors contain raw bytes (e.g. identifiers) every invalid byte is replaced with utf8.RuneError
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	s := err.Error()
+	var b strings.Builder
+	for len(s) > 0 {
+		r, size := utf8.DecodeRuneInString(s)
+		b.WriteRune(r)
+		s = s[size:]
+	}
+	return b.String()
+}
+
+func newInMemDb(logger *zap.Logger) basedb.IDb {
+	db, _ := kv.New(basedb.Options{
+		Type:   "badger-memory",
+		Logger: logger,
+	})
+	return db
+}
@@ -0,0 +1,29 @@
+package spectest
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// TestVectors runs the vectors of testdata, and the vectors of the directory in SPECTEST_VECTORS (if set)
+func TestVectors(t *testing.T) {
+	dirs := []string{"testdata"}
+	if dir := os.Getenv("SPECTEST_VECTORS"); len(dir) > 0 {
+		dirs = append(dirs, dir)
+	}
+	for _, dir := range dirs {
+		files, err := LoadDir(dir)
+		require.NoError(t, err)
+		for file, vectors := range files {
+			for _, v := range vectors {
+				v := v
+				t.Run(file+"/"+v.Name, func(t *testing.T) {
+					require.NoError(t, Run(zap.NewNop(), v))
+				})
+			}
+		}
+	}
+}
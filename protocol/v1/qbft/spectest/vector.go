@@ -0,0 +1,178 @@
+package spectest
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
+
+	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
+	"github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
+	"github.com/bloxapp/ssv/protocol/v1/message"
+	"github.com/bloxapp/ssv/protocol/v1/qbft"
+)
+
+// Type is the component that a vector tests
+type Type string
+
+// Types of vectors
+const (
+	// RootType vectors check the signing roots of consensus messages
+	RootType Type = "root"
+	// InstanceType vectors drive an instance.Instance with consensus messages
+	InstanceType Type = "instance"
+	// ControllerType vectors drive a controller.Controller (in read mode) with decided and consensus messages
+	ControllerType Type = "controller"
+)
+
+// Vector is a test vector, it holds the inputs of a test and the expected outputs
+type Vector struct {
+	Name string
+	Type Type
+	// Fork is the fork version that is used to sign and process the messages
+	Fork forksprotocol.ForkVersion
+	// Identifier is the identifier of the instance or controller
+	Identifier message.Identifier `json:",omitempty"`
+	// Share is the share of the operator that runs the instance or controller
+	Share *Share `json:",omitempty"`
+	// Config is the config of the instance, the defaults are used if missing.
+	// NOTE: the leader proposes without a delay (LeaderPreprepareDelaySeconds is ignored)
+	Config *qbft.InstanceConfig `json:",omitempty"`
+	// Leader is the leader of all the rounds, if missing the leader is selected by the fork
+	Leader message.OperatorID `json:",omitempty"`
+	// State is the initial state of the instance
+	State *qbft.State `json:",omitempty"`
+	// Start starts the instance with the input value of the state, so it proposes if it's the leader
+	Start bool `json:",omitempty"`
+	// Decided is the decided history in the storage of the controller, the last message is the highest decided
+	Decided []*message.SignedMessage `json:",omitempty"`
+	// Messages are the input messages, which are processed in order
+	Messages []*Message
+
+	Expected Outputs
+}
+
+// Message is an input or output message
+type Message struct {
+	// MsgType is the type of the ssv message, consensus by default
+	MsgType message.MsgType
+	Message *message.SignedMessage
+}
+
+// Outputs are the expected outputs of a vector
+type Outputs struct {
+	// Roots are the signing roots (hex) of the input messages (root vectors)
+	Roots []string `json:",omitempty"`
+	// Errors are the errors of processing the input messages, an empty string if no error is expected.
+	// an error matches if it contains the expected error
+	Errors []string `json:",omitempty"`
+	// Broadcast are the messages that were broadcasted, in order
+	Broadcast []*Message `json:",omitempty"`
+	// DecidedValue is the decided value of the instance, if decided
+	DecidedValue []byte `json:",omitempty"`
+	// State is the state of the instance after processing the messages
+	State *qbft.State `json:",omitempty"`
+	// LastDecided is the highest decided message in the storage of the controller
+	LastDecided *message.SignedMessage `json:",omitempty"`
+}
+
+// Share is the share of an operator
+type Share struct {
+	OperatorID message.OperatorID
+	// ValidatorPK is the public key of the validator
+	ValidatorPK []byte
+	// Committee maps the operators to their share public keys
+	Committee map[message.OperatorID][]byte
+	// SecretKey is the share key of the operator, used to sign its messages
+	SecretKey []byte
+}
+
+// beaconShare returns the share and the secret key of the operator
+func (s *Share) beaconShare() (*beacon.Share, *bls.SecretKey, error) {
+	pk := &bls.PublicKey{}
+	if err := pk.Deserialize(s.ValidatorPK); err != nil {
+		return nil, nil, errors.Wrap(err, "could not decode validator public key")
+	}
+	sk := &bls.SecretKey{}
+	if err := sk.Deserialize(s.SecretKey); err != nil {
+		return nil, nil, errors.Wrap(err, "could not decode secret key")
+	}
+	committee := make(map[message.OperatorID]*beacon.Node)
+	for id, sharePK := range s.Committee {
+		committee[id] = &beacon.Node{
+			IbftID: uint64(id),
+			Pk:     sharePK,
+		}
+	}
+	return &beacon.Share{
+		NodeID:    s.OperatorID,
+		PublicKey: pk,
+		Committee: committee,
+	}, sk, nil
+}
+
+// Load reads the vectors of the given file, which contains a vector or a list of vectors
+func Load(path string) ([]*Vector, error) {
+	raw, err := ioutil.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read file")
+	}
+	var vectors []*Vector
+	if strings.HasPrefix(strings.TrimSpace(string(raw)), "[") {
+		err = json.Unmarshal(raw, &vectors)
+	} else {
+		v := &Vector{}
+		err = json.Unmarshal(raw, v)
+		vectors = append(vectors, v)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not decode vectors of %s", path)
+	}
+	return vectors, nil
+}
+
+// LoadDir reads the vectors of all the json files in the given directory and its sub-directories,
+// the vectors are mapped by their file
+func LoadDir(dir string) (map[string][]*Vector, error) {
+	files := make([]string, 0)
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() && filepath.Ext(path) == ".json" {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not walk directory")
+	}
+	sort.Strings(files)
+
+	vectors := make(map[string][]*Vector)
+	for _, f := range files {
+		v, err := Load(f)
+		if err != nil {
+			return nil, err
+		}
+		vectors[f] = v
+	}
+	return vectors, nil
+}
+
+// Save writes the given vectors to the given file
+func Save(path string, vectors ...*Vector) error {
+	raw, err := json.MarshalIndent(vectors, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "could not encode vectors")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return errors.Wrap(err, "could not create directory")
+	}
+	return ioutil.WriteFile(path, append(raw, '\n'), 0600)
+}
@@ -14,6 +14,10 @@ type DecidedMsgStore interface {
 	GetDecided(identifier message.Identifier, from message.Height, to message.Height) ([]*message.SignedMessage, error)
 	// SaveDecided saves historical decided messages
 	SaveDecided(signedMsg ...*message.SignedMessage) error
+	// GetLowestDecided returns the lowest historical decided message that is available for the given identifier
+	GetLowestDecided(identifier message.Identifier) (*message.SignedMessage, error)
+	// PruneDecided deletes historical decided messages in the given range, returns the amount of deleted messages
+	PruneDecided(identifier message.Identifier, from message.Height, to message.Height) (int, error)
 }
 
 // InstanceStore manages instance data
@@ -107,6 +107,47 @@ func (i *ibftStorage) SaveDecided(signedMsg ...*message.SignedMessage) error {
 	})
 }
 
+// GetLowestDecided returns the lowest decided message of the given identifier
+func (i *ibftStorage) GetLowestDecided(identifier message.Identifier) (*message.SignedMessage, error) {
+	prefix := make([]byte, len(i.prefix))
+	copy(prefix, i.prefix)
+	prefix = append(prefix, identifier...)
+	prefix = append(prefix, decidedKey...)
+
+	var lowest *message.SignedMessage
+	err := i.db.GetAll(prefix, func(_ int, obj basedb.Obj) error {
+		if len(obj.Key) != 8 {
+			return nil
+		}
+		msg := message.SignedMessage{}
+		if err := json.Unmarshal(obj.Value, &msg); err != nil {
+			return errors.Wrap(err, "un-marshaling error")
+		}
+		if lowest == nil || msg.Message.Height < lowest.Message.Height {
+			lowest = &msg
+		}
+		return nil
+	})
+	return lowest, err
+}
+
+// PruneDecided deletes the decided messages of the given identifier in the range [from, to]
+func (i *ibftStorage) PruneDecided(identifier message.Identifier, from message.Height, to message.Height) (int, error) {
+	count := 0
+	for seq := from; seq <= to; seq++ {
+		if _, found, err := i.get(decidedKey, identifier, uInt64ToByteSlice(uint64(seq))); err != nil {
+			return count, err
+		} else if !found {
+			continue
+		}
+		if err := i.delete(decidedKey, identifier, uInt64ToByteSlice(uint64(seq))); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, nil
+}
+
 func (i *ibftStorage) SaveCurrentInstance(identifier message.Identifier, state *qbft.State) error {
 	value, err := json.Marshal(state)
 	if err != nil {
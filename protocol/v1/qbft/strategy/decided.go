@@ -67,10 +67,15 @@ func UpdateLastDecided(logger *zap.Logger, store qbftstorage.DecidedMsgStore, si
 }
 
 // UpdateSigners will try to update signers list of the decided message, returns an indication if the msg was updated.
+// a height might be decided in more than one round, in that case the message of the lowest round is kept
+// so all nodes converge to the same decided round (which is used by the leader selection of some forks)
 func UpdateSigners(local, msg *message.SignedMessage) (*message.SignedMessage, bool) {
 	if local == nil {
 		return msg, true
 	}
+	if msg.Message.Height == local.Message.Height && msg.Message.Round != local.Message.Round {
+		return msg, msg.Message.Round < local.Message.Round
+	}
 	if msg.Message.Height == local.Message.Height {
 		origSize := len(local.Signers)
 		updatedSigners := message.AppendSigners(local.Signers, msg.Signers...)
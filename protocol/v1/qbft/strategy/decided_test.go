@@ -0,0 +1,37 @@
+package strategy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/protocol/v1/message"
+)
+
+func decidedMsg(height message.Height, round message.Round, signers ...message.OperatorID) *message.SignedMessage {
+	return &message.SignedMessage{
+		Signers: signers,
+		Message: &message.ConsensusMessage{Height: height, Round: round},
+	}
+}
+
+func TestUpdateSigners(t *testing.T) {
+	msg, ok := UpdateSigners(nil, decidedMsg(1, 1, 1, 2, 3))
+	require.True(t, ok)
+	require.Len(t, msg.Signers, 3)
+
+	msg, ok = UpdateSigners(decidedMsg(1, 1, 1, 2, 3), decidedMsg(1, 1, 4))
+	require.True(t, ok)
+	require.ElementsMatch(t, []message.OperatorID{1, 2, 3, 4}, msg.Signers)
+
+	_, ok = UpdateSigners(decidedMsg(1, 1, 1, 2, 3), decidedMsg(1, 1, 2, 3))
+	require.False(t, ok, "no new signers")
+
+	// the lowest decided round is kept
+	msg, ok = UpdateSigners(decidedMsg(1, 2, 1, 2, 3), decidedMsg(1, 1, 2, 3, 4))
+	require.True(t, ok)
+	require.Equal(t, message.Round(1), msg.Message.Round)
+	require.ElementsMatch(t, []message.OperatorID{2, 3, 4}, msg.Signers)
+	_, ok = UpdateSigners(decidedMsg(1, 1, 1, 2, 3), decidedMsg(1, 2, 1, 2, 3, 4))
+	require.False(t, ok)
+}
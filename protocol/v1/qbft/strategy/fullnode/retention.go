@@ -0,0 +1,226 @@
+package fullnode
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/async"
+	"go.uber.org/zap"
+
+	beaconprotocol "github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
+	"github.com/bloxapp/ssv/protocol/v1/message"
+	qbftstorage "github.com/bloxapp/ssv/protocol/v1/qbft/storage"
+)
+
+// RetentionOptions is the retention policy of the decided history that is saved by full nodes.
+// if both heights and epochs are set, only messages that satisfy both are kept
+type RetentionOptions struct {
+	Heights    uint64        `yaml:"Heights" env:"DECIDED_RETENTION_HEIGHTS" env-default:"0" env-description:"Number of most recent decided heights to keep per identifier, zero keeps all"`
+	Epochs     uint64        `yaml:"Epochs" env:"DECIDED_RETENTION_EPOCHS" env-default:"0" env-description:"Number of most recent epochs of decided messages to keep per identifier, zero keeps all"`
+	ArchiveDir string        `yaml:"ArchiveDir" env:"DECIDED_ARCHIVE_DIR" env-description:"Directory to archive pruned decided messages into as compressed files, pruned messages are dropped if empty"`
+	Interval   time.Duration `yaml:"Interval" env:"DECIDED_PRUNE_INTERVAL" env-default:"10m" env-description:"Interval of decided history pruning"`
+	BatchSize  int           `yaml:"BatchSize" env:"DECIDED_PRUNE_BATCH_SIZE" env-default:"1000" env-description:"Max number of decided messages to delete at once"`
+}
+
+// Enabled returns whether some retention policy was configured
+func (o RetentionOptions) Enabled() bool {
+	return o.Heights > 0 || o.Epochs > 0
+}
+
+// Pruner deletes (and optionally archives) decided messages that are out of the retention policy
+type Pruner struct {
+	logger      *zap.Logger
+	store       qbftstorage.DecidedMsgStore
+	network     beaconprotocol.Network
+	identifiers func() []message.Identifier
+	opts        RetentionOptions
+}
+
+// NewPruner creates a new instance of Pruner,
+// identifiers returns the identifiers whose decided history should be pruned
+func NewPruner(logger *zap.Logger, store qbftstorage.DecidedMsgStore, network beaconprotocol.Network,
+	identifiers func() []message.Identifier, opts RetentionOptions) *Pruner {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 1000
+	}
+	return &Pruner{
+		logger:      logger.With(zap.String("who", "DecidedPruner")),
+		store:       store,
+		network:     network,
+		identifiers: identifiers,
+		opts:        opts,
+	}
+}
+
+// Start runs the pruner in the background every interval until the context is done
+func (p *Pruner) Start(ctx context.Context) {
+	if !p.opts.Enabled() || p.opts.Interval <= 0 {
+		return
+	}
+	p.logger.Info("starting decided history pruner", zap.Uint64("heights", p.opts.Heights),
+		zap.Uint64("epochs", p.opts.Epochs), zap.String("archiveDir", p.opts.ArchiveDir))
+	async.RunEvery(ctx, p.opts.Interval, func() {
+		p.PruneAll(ctx)
+	})
+}
+
+// PruneAll prunes the decided history of all identifiers
+func (p *Pruner) PruneAll(ctx context.Context) {
+	total := 0
+	for _, identifier := range p.identifiers() {
+		if ctx.Err() != nil {
+			return
+		}
+		n, err := p.Prune(ctx, identifier)
+		if err != nil {
+			p.logger.Warn("could not prune decided history", zap.String("identifier", identifier.String()), zap.Error(err))
+		}
+		total += n
+	}
+	if total > 0 {
+		p.logger.Debug("decided history was pruned", zap.Int("count", total))
+	}
+}
+
+// Prune deletes the decided messages of the given identifier that are out of the retention policy,
+// in batches of up to BatchSize messages. returns the amount of pruned messages
+func (p *Pruner) Prune(ctx context.Context, identifier message.Identifier) (int, error) {
+	highest, err := p.store.GetLastDecided(identifier)
+	if err != nil || highest == nil {
+		return 0, errors.Wrap(err, "could not get last decided")
+	}
+	var minHeight message.Height
+	if p.opts.Heights > 0 && uint64(highest.Message.Height)+1 > p.opts.Heights {
+		minHeight = highest.Message.Height + 1 - message.Height(p.opts.Heights)
+	}
+	var minEpoch spec.Epoch
+	if current := uint64(p.network.EstimatedCurrentEpoch()); p.opts.Epochs > 0 && current > p.opts.Epochs {
+		minEpoch = spec.Epoch(current - p.opts.Epochs)
+	}
+
+	count := 0
+	for ctx.Err() == nil {
+		lowest, err := p.store.GetLowestDecided(identifier)
+		if err != nil {
+			return count, errors.Wrap(err, "could not get lowest decided")
+		}
+		if lowest == nil {
+			return count, nil
+		}
+		from := lowest.Message.Height
+		msgs, err := p.store.GetDecided(identifier, from, from+message.Height(p.opts.BatchSize-1))
+		if err != nil {
+			return count, errors.Wrap(err, "could not get decided")
+		}
+		// heights are increasing over time, therefore pruning stops at the first message that should be kept
+		var pruned []*message.SignedMessage
+		for _, msg := range msgs {
+			if msg.Message.Height >= highest.Message.Height || !p.outOfRetention(msg, minHeight, minEpoch) {
+				break
+			}
+			pruned = append(pruned, msg)
+		}
+		if len(pruned) == 0 {
+			return count, nil
+		}
+		to := pruned[len(pruned)-1].Message.Height
+		if err := p.archive(identifier, pruned); err != nil {
+			return count, errors.Wrap(err, "could not archive decided")
+		}
+		n, err := p.store.PruneDecided(identifier, from, to)
+		if err != nil {
+			return count, errors.Wrap(err, "could not prune decided")
+		}
+		count += n
+		if len(pruned) < len(msgs) {
+			return count, nil
+		}
+	}
+	return count, ctx.Err()
+}
+
+// outOfRetention returns whether the given message is older than the configured heights or epochs
+func (p *Pruner) outOfRetention(msg *message.SignedMessage, minHeight message.Height, minEpoch spec.Epoch) bool {
+	if p.opts.Heights > 0 && msg.Message.Height < minHeight {
+		return true
+	}
+	if p.opts.Epochs > 0 {
+		epoch, ok := decidedEpoch(msg)
+		return ok && epoch < minEpoch
+	}
+	return false
+}
+
+// decidedEpoch returns the target epoch of the attestation data that was decided in the given message
+func decidedEpoch(msg *message.SignedMessage) (spec.Epoch, bool) {
+	commitData, err := msg.Message.GetCommitData()
+	if err != nil {
+		return 0, false
+	}
+	attData := spec.AttestationData{}
+	if err := attData.UnmarshalSSZ(commitData.Data); err != nil || attData.Target == nil {
+		return 0, false
+	}
+	return attData.Target.Epoch, true
+}
+
+// archive writes the given messages into a gzip compressed json file in the archive dir,
+// named by the identifier and heights range. skipped if no archive dir was configured
+func (p *Pruner) archive(identifier message.Identifier, msgs []*message.SignedMessage) error {
+	if len(p.opts.ArchiveDir) == 0 {
+		return nil
+	}
+	dir := filepath.Join(p.opts.ArchiveDir, identifier.String())
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%d-%d.json.gz", msgs[0].Message.Height, msgs[len(msgs)-1].Message.Height)
+	tmp, err := ioutil.TempFile(dir, name+".tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+	zw := gzip.NewWriter(tmp)
+	if err := json.NewEncoder(zw).Encode(msgs); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filepath.Join(dir, name))
+}
+
+// ReadArchive reads the decided messages of the given archive file
+func ReadArchive(path string) ([]*message.SignedMessage, error) {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read gzip")
+	}
+	var msgs []*message.SignedMessage
+	if err := json.NewDecoder(zr).Decode(&msgs); err != nil {
+		return nil, errors.Wrap(err, "could not decode messages")
+	}
+	return msgs, nil
+}
@@ -0,0 +1,145 @@
+package fullnode
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/eth2-key-manager/core"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	ibftstorage "github.com/bloxapp/ssv/ibft/storage"
+	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
+	beaconprotocol "github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
+	"github.com/bloxapp/ssv/protocol/v1/message"
+	qbftstorage "github.com/bloxapp/ssv/protocol/v1/qbft/storage"
+	ssvstorage "github.com/bloxapp/ssv/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+func TestPruner_Prune(t *testing.T) {
+	network := beaconprotocol.NewNetwork(core.PraterNetwork)
+	currentEpoch := uint64(network.EstimatedCurrentEpoch())
+	identifier := message.NewIdentifier([]byte("pk"), message.RoleTypeAttester)
+
+	tests := []struct {
+		name    string
+		opts    RetentionOptions
+		archive bool
+		lowest  message.Height
+	}{
+		{"keep heights", RetentionOptions{Heights: 4, BatchSize: 3}, false, 7},
+		{"keep epochs", RetentionOptions{Epochs: 2, BatchSize: 100}, false, 8},
+		{"keep heights and epochs", RetentionOptions{Heights: 5, Epochs: 2, BatchSize: 100}, false, 8},
+		{"keep more than saved", RetentionOptions{Heights: 100, BatchSize: 100}, false, 1},
+		{"archive", RetentionOptions{Heights: 4, BatchSize: 3}, true, 7},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			logger := zaptest.NewLogger(t)
+			store := newTestStore(t)
+			// heights 1-10 are decided in consecutive epochs, the last one is the current epoch
+			var msgs []*message.SignedMessage
+			for h := message.Height(1); h <= 10; h++ {
+				msgs = append(msgs, decidedAt(t, identifier, h, spec.Epoch(currentEpoch-10+uint64(h))))
+			}
+			require.NoError(t, store.SaveDecided(msgs...))
+			require.NoError(t, store.SaveLastDecided(msgs[len(msgs)-1]))
+
+			opts := test.opts
+			if test.archive {
+				opts.ArchiveDir = t.TempDir()
+			}
+			pruner := NewPruner(logger, store, network, func() []message.Identifier {
+				return []message.Identifier{identifier}
+			}, opts)
+			n, err := pruner.Prune(context.Background(), identifier)
+			require.NoError(t, err)
+			require.Equal(t, int(test.lowest-1), n)
+
+			lowest, err := store.GetLowestDecided(identifier)
+			require.NoError(t, err)
+			require.Equal(t, test.lowest, lowest.Message.Height)
+			remaining, err := store.GetDecided(identifier, 0, 10)
+			require.NoError(t, err)
+			require.Len(t, remaining, int(11-test.lowest))
+			last, err := store.GetLastDecided(identifier)
+			require.NoError(t, err)
+			require.Equal(t, message.Height(10), last.Message.Height)
+
+			if test.archive {
+				files, err := filepath.Glob(filepath.Join(opts.ArchiveDir, identifier.String(), "*.json.gz"))
+				require.NoError(t, err)
+				// pruned in batches of 3: 1-3, 4-6
+				require.Len(t, files, 2)
+				archived, err := ReadArchive(filepath.Join(opts.ArchiveDir, identifier.String(), "4-6.json.gz"))
+				require.NoError(t, err)
+				require.Len(t, archived, 3)
+				require.Equal(t, message.Height(4), archived[0].Message.Height)
+				require.Equal(t, message.Height(6), archived[2].Message.Height)
+				tmpFiles, err := filepath.Glob(filepath.Join(opts.ArchiveDir, identifier.String(), "*.tmp*"))
+				require.NoError(t, err)
+				require.Empty(t, tmpFiles)
+			}
+
+			// pruning again has no effect
+			n, err = pruner.Prune(context.Background(), identifier)
+			require.NoError(t, err)
+			require.Equal(t, 0, n)
+		})
+	}
+}
+
+func TestPruner_PruneAllWithoutHistory(t *testing.T) {
+	store := newTestStore(t)
+	identifier := message.NewIdentifier([]byte("pk"), message.RoleTypeAttester)
+	archiveDir := t.TempDir()
+	pruner := NewPruner(zaptest.NewLogger(t), store, beaconprotocol.NewNetwork(core.PraterNetwork), func() []message.Identifier {
+		return []message.Identifier{identifier}
+	}, RetentionOptions{Heights: 1, ArchiveDir: archiveDir})
+	pruner.PruneAll(context.Background())
+
+	entries, err := ioutil.ReadDir(archiveDir)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func newTestStore(t *testing.T) qbftstorage.QBFTStore {
+	db, err := ssvstorage.GetStorageFactory(basedb.Options{
+		Type:   "badger-memory",
+		Logger: zaptest.NewLogger(t),
+		Path:   "",
+	})
+	require.NoError(t, err)
+	t.Cleanup(db.Close)
+	return ibftstorage.New(db, zaptest.NewLogger(t), "test", forksprotocol.V1ForkVersion)
+}
+
+// decidedAt returns a decided message of the given height, whose attestation data targets the given epoch
+func decidedAt(t *testing.T, identifier message.Identifier, height message.Height, epoch spec.Epoch) *message.SignedMessage {
+	attData := &spec.AttestationData{
+		Slot:            spec.Slot(uint64(epoch) * 32),
+		BeaconBlockRoot: spec.Root{},
+		Source:          &spec.Checkpoint{Epoch: epoch - 1, Root: spec.Root{}},
+		Target:          &spec.Checkpoint{Epoch: epoch, Root: spec.Root{}},
+	}
+	data, err := attData.MarshalSSZ()
+	require.NoError(t, err)
+	commitData, err := (&message.CommitData{Data: data}).Encode()
+	require.NoError(t, err)
+	return &message.SignedMessage{
+		Signature: []byte("sig"),
+		Signers:   []message.OperatorID{1, 2, 3},
+		Message: &message.ConsensusMessage{
+			MsgType:    message.CommitMsgType,
+			Height:     height,
+			Identifier: identifier,
+			Data:       commitData,
+		},
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/bloxapp/ssv/protocol/v1/message"
 	p2pprotocol "github.com/bloxapp/ssv/protocol/v1/p2p"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/instance/leader/reputation"
 	"github.com/bloxapp/ssv/protocol/v1/qbft/pipelines"
 	qbftstorage "github.com/bloxapp/ssv/protocol/v1/qbft/storage"
 	"github.com/bloxapp/ssv/protocol/v1/qbft/strategy"
@@ -12,6 +13,10 @@ import (
 	"go.uber.org/zap"
 )
 
+// recentHeights is the amount of recent heights whose decided messages are kept by light nodes,
+// as needed by the reputation based leader selection
+const recentHeights = reputation.Window
+
 // lightNode implements strategy.Decided
 type lightNode struct {
 	logger         *zap.Logger
@@ -46,14 +51,62 @@ func (ln *lightNode) Sync(ctx context.Context, identifier message.Identifier, fr
 }
 
 func (ln *lightNode) UpdateDecided(msg *message.SignedMessage) (*message.SignedMessage, error) {
-	return strategy.UpdateLastDecided(ln.logger, ln.store, msg)
+	updated, err := strategy.UpdateLastDecided(ln.logger, ln.store, msg)
+	if err != nil {
+		return nil, err
+	}
+	if err := ln.updateRecentDecided(msg); err != nil {
+		ln.logger.Debug("could not update recent decided", zap.Error(err))
+	}
+	return updated, nil
+}
+
+// updateRecentDecided saves the given message in the decided history if it is one of the recent heights,
+// and removes the heights that are not recent anymore
+func (ln *lightNode) updateRecentDecided(msg *message.SignedMessage) error {
+	identifier, height := msg.Message.Identifier, msg.Message.Height
+	ld, err := ln.store.GetLastDecided(identifier)
+	if err != nil {
+		return errors.Wrap(err, "could not read last decided")
+	}
+	if ld != nil && height+recentHeights <= ld.Message.Height {
+		return nil
+	}
+	localMsgs, err := ln.store.GetDecided(identifier, height, height)
+	if err != nil {
+		return errors.Wrap(err, "could not read decided")
+	}
+	if len(localMsgs) > 0 && localMsgs[0] != nil {
+		updated, ok := strategy.UpdateSigners(localMsgs[0], msg)
+		if !ok {
+			return nil
+		}
+		msg = updated
+	}
+	if err := ln.store.SaveDecided(msg); err != nil {
+		return errors.Wrap(err, "could not save decided")
+	}
+	if ld != nil && ld.Message.Height >= recentHeights {
+		if _, err := ln.store.PruneDecided(identifier, 0, ld.Message.Height-recentHeights); err != nil {
+			return errors.Wrap(err, "could not prune decided")
+		}
+	}
+	return nil
 }
 
-// GetDecided in light node will try to look for last decided and returns it if in the given range
+// GetDecided in light node returns the recent decided messages in the given range,
+// or the last decided if it is in the given range and there is no recent history (e.g. before the history was kept)
 func (ln *lightNode) GetDecided(identifier message.Identifier, heightRange ...message.Height) ([]*message.SignedMessage, error) {
 	if len(heightRange) < 2 {
 		return nil, errors.New("missing height range")
 	}
+	msgs, err := ln.store.GetDecided(identifier, heightRange[0], heightRange[1])
+	if err != nil {
+		return nil, err
+	}
+	if len(msgs) > 0 {
+		return msgs, nil
+	}
 	ld, err := ln.store.GetLastDecided(identifier)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,53 @@
+package lightnode
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/protocol/v1/message"
+	qbftstorage "github.com/bloxapp/ssv/protocol/v1/qbft/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/storage/kv"
+)
+
+func TestLightNode_RecentDecided(t *testing.T) {
+	db, err := kv.New(basedb.Options{Type: "badger-memory", Logger: zap.L()})
+	require.NoError(t, err)
+	defer db.Close()
+	store := qbftstorage.NewQBFTStore(db, zap.L(), "attestation")
+	ln := NewLightNodeStrategy(zap.L(), store, nil)
+	identifier := message.NewIdentifier([]byte("pk"), message.RoleTypeAttester)
+
+	decided := func(height message.Height, round message.Round) *message.SignedMessage {
+		return &message.SignedMessage{
+			Signers: []message.OperatorID{1, 2, 3},
+			Message: &message.ConsensusMessage{Identifier: identifier, Height: height, Round: round},
+		}
+	}
+
+	last := message.Height(recentHeights + 10)
+	for h := message.Height(0); h <= last; h++ {
+		_, err := ln.UpdateDecided(decided(h, 1))
+		require.NoError(t, err)
+	}
+	msgs, err := ln.GetDecided(identifier, 0, last)
+	require.NoError(t, err)
+	require.Len(t, msgs, recentHeights)
+	require.Equal(t, last-recentHeights+1, msgs[0].Message.Height)
+
+	// late messages of recent heights are saved, older heights are ignored
+	_, err = ln.UpdateDecided(decided(last-1, 3))
+	require.NoError(t, err)
+	_, err = ln.UpdateDecided(decided(last-recentHeights, 1))
+	require.NoError(t, err)
+	msgs, err = ln.GetDecided(identifier, 0, last-1)
+	require.NoError(t, err)
+	require.Len(t, msgs, recentHeights-1)
+	require.Equal(t, message.Round(1), msgs[len(msgs)-1].Message.Round, "the lowest decided round is kept")
+
+	highest, err := ln.GetLastDecided(identifier)
+	require.NoError(t, err)
+	require.Equal(t, last, highest.Message.Height)
+}
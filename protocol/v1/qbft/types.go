@@ -159,17 +159,3 @@ func NewByteValue(val []byte) atomic.Value {
 	value.Store(val)
 	return value
 }
-
-// InstanceConfig is the configuration of the instance
-type InstanceConfig struct {
-	RoundChangeDurationSeconds   float32
-	LeaderPreprepareDelaySeconds float32
-}
-
-//DefaultConsensusParams returns the default round change duration time
-func DefaultConsensusParams() *InstanceConfig {
-	return &InstanceConfig{
-		RoundChangeDurationSeconds:   3,
-		LeaderPreprepareDelaySeconds: 1,
-	}
-}
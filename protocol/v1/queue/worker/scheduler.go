@@ -0,0 +1,254 @@
+package worker
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Task is a unit of work that is processed repeatedly by the scheduler, e.g. the message queue of a qbft controller
+type Task interface {
+	// Run processes the next piece of work, returns false if there was nothing to process
+	Run() bool
+	// Deadline returns the deadline of the current work, tasks with earlier deadlines are processed first.
+	// zero time means no deadline, such tasks are processed after tasks that have a deadline
+	Deadline() time.Time
+}
+
+// states of scheduled tasks
+const (
+	taskIdle = iota
+	taskQueued
+	taskRunning
+)
+
+type scheduledTask struct {
+	id   string
+	task Task
+
+	state int
+	// dirty is set when the task was scheduled while running, so it will be queued again once done
+	dirty    bool
+	removed  bool
+	deadline time.Time
+	seq      uint64
+	index    int
+}
+
+// Scheduler multiplexes the work of many tasks onto a bounded pool of workers.
+// tasks are queued by Schedule, and processed in order of their deadlines (FIFO for equal deadlines).
+// a task is processed by a single worker at a time, which preserves the order of its work.
+// a task that processed some work is queued again, so work is interleaved with other tasks.
+type Scheduler struct {
+	ctx           context.Context
+	cancel        context.CancelFunc
+	logger        *zap.Logger
+	workersCount  int
+	metricsPrefix string
+
+	lock  sync.Mutex
+	cond  *sync.Cond
+	tasks map[string]*scheduledTask
+	ready taskHeap
+	seq   uint64
+}
+
+// NewScheduler creates a new scheduler and starts its workers, Config.Buffer is not used
+func NewScheduler(cfg *Config) *Scheduler {
+	ctx, cancel := context.WithCancel(cfg.Ctx)
+	s := &Scheduler{
+		ctx:           ctx,
+		cancel:        cancel,
+		logger:        cfg.Logger.With(zap.String("who", "scheduler")),
+		workersCount:  cfg.WorkersCount,
+		metricsPrefix: cfg.MetrixPrefix,
+		tasks:         make(map[string]*scheduledTask),
+	}
+	s.cond = sync.NewCond(&s.lock)
+
+	s.init()
+
+	return s
+}
+
+// init starts the workers, and wakes them up once the context is done so they will exit
+func (s *Scheduler) init() {
+	for i := 1; i <= s.workersCount; i++ {
+		go s.startWorker()
+	}
+	go func() {
+		<-s.ctx.Done()
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		s.cond.Broadcast()
+	}()
+}
+
+// Register adds a task with the given id, the task is processed once scheduled
+func (s *Scheduler) Register(id string, task Task) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if st, ok := s.tasks[id]; ok {
+		st.removed = true
+		s.dequeue(st)
+	}
+	s.tasks[id] = &scheduledTask{id: id, task: task, index: -1}
+}
+
+// Unregister removes the task with the given id, a running task completes its current work
+func (s *Scheduler) Unregister(id string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	st, ok := s.tasks[id]
+	if !ok {
+		return
+	}
+	st.removed = true
+	s.dequeue(st)
+	delete(s.tasks, id)
+}
+
+// Schedule queues the task with the given id, it should be called whenever the task might have new work.
+// it returns false if no such task was registered
+func (s *Scheduler) Schedule(id string) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	st, ok := s.tasks[id]
+	if !ok {
+		return false
+	}
+	switch st.state {
+	case taskIdle:
+		s.enqueue(st)
+	case taskRunning:
+		st.dirty = true
+	}
+	return true
+}
+
+// Size returns the number of tasks that are waiting for a worker
+func (s *Scheduler) Size() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.ready.Len()
+}
+
+// Close stops the workers
+func (s *Scheduler) Close() {
+	s.cancel()
+}
+
+// startWorker processes queued tasks until the context is done
+func (s *Scheduler) startWorker() {
+	for {
+		st := s.next()
+		if st == nil {
+			return
+		}
+		processed := st.task.Run()
+		if processed {
+			metricsMsgProcessing.WithLabelValues(s.metricsPrefix).Inc()
+		}
+		s.done(st, processed)
+	}
+}
+
+// next blocks until a task is queued, returns nil once the context is done
+func (s *Scheduler) next() *scheduledTask {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for s.ready.Len() == 0 {
+		if s.ctx.Err() != nil {
+			return nil
+		}
+		s.cond.Wait()
+	}
+	if s.ctx.Err() != nil {
+		return nil
+	}
+	st := heap.Pop(&s.ready).(*scheduledTask)
+	st.state = taskRunning
+	st.dirty = false
+	return st
+}
+
+// done queues the task again if it might have more work
+func (s *Scheduler) done(st *scheduledTask, processed bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	st.state = taskIdle
+	if st.removed {
+		return
+	}
+	if processed || st.dirty {
+		s.enqueue(st)
+	}
+}
+
+// enqueue adds the task to the ready queue, must be called with the lock held
+func (s *Scheduler) enqueue(st *scheduledTask) {
+	s.seq++
+	st.state = taskQueued
+	st.deadline = st.task.Deadline()
+	st.seq = s.seq
+	heap.Push(&s.ready, st)
+	s.cond.Signal()
+}
+
+// dequeue removes the task from the ready queue, must be called with the lock held
+func (s *Scheduler) dequeue(st *scheduledTask) {
+	if st.state == taskQueued && st.index >= 0 {
+		heap.Remove(&s.ready, st.index)
+		st.state = taskIdle
+	}
+}
+
+// taskHeap is a priority queue of tasks, ordered by deadline and then by the order of scheduling
+type taskHeap []*scheduledTask
+
+func (h taskHeap) Len() int { return len(h) }
+
+func (h taskHeap) Less(i, j int) bool {
+	a, b := h[i], h[j]
+	if !a.deadline.Equal(b.deadline) {
+		if a.deadline.IsZero() {
+			return false
+		}
+		if b.deadline.IsZero() {
+			return true
+		}
+		return a.deadline.Before(b.deadline)
+	}
+	return a.seq < b.seq
+}
+
+func (h taskHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *taskHeap) Push(x interface{}) {
+	st := x.(*scheduledTask)
+	st.index = len(*h)
+	*h = append(*h, st)
+}
+
+func (h *taskHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	st := old[n-1]
+	old[n-1] = nil
+	st.index = -1
+	*h = old[:n-1]
+	return st
+}
@@ -0,0 +1,171 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type testTask struct {
+	lock     sync.Mutex
+	work     int
+	deadline time.Time
+	running  int32
+	onRun    func()
+	// concurrent is set if the task was run by two workers at the same time
+	concurrent int32
+}
+
+func (t *testTask) add(n int) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.work += n
+}
+
+func (t *testTask) pending() int {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.work
+}
+
+func (t *testTask) Run() bool {
+	if atomic.AddInt32(&t.running, 1) > 1 {
+		atomic.StoreInt32(&t.concurrent, 1)
+	}
+	defer atomic.AddInt32(&t.running, -1)
+
+	t.lock.Lock()
+	if t.work == 0 {
+		t.lock.Unlock()
+		return false
+	}
+	t.work--
+	t.lock.Unlock()
+	if t.onRun != nil {
+		t.onRun()
+	}
+	return true
+}
+
+func (t *testTask) Deadline() time.Time {
+	return t.deadline
+}
+
+func newTestScheduler(t *testing.T, workers int) *Scheduler {
+	s := NewScheduler(&Config{
+		Ctx:          context.Background(),
+		Logger:       zap.L(),
+		WorkersCount: workers,
+	})
+	t.Cleanup(s.Close)
+	return s
+}
+
+func TestScheduler(t *testing.T) {
+	t.Run("process all work", func(t *testing.T) {
+		s := newTestScheduler(t, 4)
+		tasks := make([]*testTask, 100)
+		for i := range tasks {
+			tasks[i] = &testTask{}
+			s.Register(string(rune('a'+i)), tasks[i])
+		}
+		for round := 0; round < 5; round++ {
+			for i, task := range tasks {
+				task.add(2)
+				require.True(t, s.Schedule(string(rune('a'+i))))
+			}
+		}
+		require.Eventually(t, func() bool {
+			for _, task := range tasks {
+				if task.pending() > 0 {
+					return false
+				}
+			}
+			return true
+		}, 5*time.Second, 10*time.Millisecond)
+		for _, task := range tasks {
+			require.Equal(t, int32(0), atomic.LoadInt32(&task.concurrent))
+		}
+	})
+
+	t.Run("earliest deadline first", func(t *testing.T) {
+		s := newTestScheduler(t, 1)
+
+		// blocking the only worker so the other tasks are queued
+		release := make(chan struct{})
+		blocker := &testTask{work: 1, onRun: func() { <-release }}
+		s.Register("blocker", blocker)
+		s.Schedule("blocker")
+		require.Eventually(t, func() bool {
+			return blocker.pending() == 0
+		}, time.Second, time.Millisecond)
+
+		var order []string
+		var lock sync.Mutex
+		now := time.Now()
+		deadlines := map[string]time.Time{
+			"no-deadline": {},
+			"late":        now.Add(time.Minute),
+			"early":       now.Add(time.Second),
+			"passed":      now.Add(-time.Second),
+		}
+		for _, id := range []string{"no-deadline", "late", "early", "passed"} {
+			id := id
+			s.Register(id, &testTask{work: 1, deadline: deadlines[id], onRun: func() {
+				lock.Lock()
+				defer lock.Unlock()
+				order = append(order, id)
+			}})
+			s.Schedule(id)
+		}
+		close(release)
+
+		require.Eventually(t, func() bool {
+			lock.Lock()
+			defer lock.Unlock()
+			return len(order) == 4
+		}, time.Second, time.Millisecond)
+		require.Equal(t, []string{"passed", "early", "late", "no-deadline"}, order)
+	})
+
+	t.Run("schedule while running", func(t *testing.T) {
+		s := newTestScheduler(t, 2)
+		started := make(chan struct{})
+		release := make(chan struct{})
+		task := &testTask{work: 1}
+		task.onRun = func() {
+			if task.pending() == 0 {
+				close(started)
+				<-release
+			}
+		}
+		s.Register("task", task)
+		s.Schedule("task")
+		<-started
+		// new work arrives while the task is running
+		task.onRun = nil
+		task.add(1)
+		s.Schedule("task")
+		close(release)
+
+		require.Eventually(t, func() bool {
+			return task.pending() == 0
+		}, time.Second, time.Millisecond)
+	})
+
+	t.Run("unregister", func(t *testing.T) {
+		s := newTestScheduler(t, 1)
+		task := &testTask{}
+		s.Register("task", task)
+		s.Unregister("task")
+		task.add(1)
+		require.False(t, s.Schedule("task"))
+		time.Sleep(10 * time.Millisecond)
+		require.Equal(t, 1, task.pending())
+	})
+}
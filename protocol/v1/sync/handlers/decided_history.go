@@ -26,12 +26,24 @@ func HistoryHandler(plogger *zap.Logger, store qbftstorage.DecidedMsgStore, repo
 			// TODO: remove after v0
 			return nil, nil
 		} else {
-			items := int(sm.Params.Height[1] - sm.Params.Height[0])
-			if items > maxBatchSize {
-				sm.Params.Height[1] = sm.Params.Height[0] + message.Height(maxBatchSize)
+			// advertising the lowest available height in case the requested range was (partially) pruned
+			lowest, err := store.GetLowestDecided(msg.ID)
+			if err != nil {
+				logger.Debug("could not get lowest decided", zap.Error(err))
+			} else if lowest != nil && lowest.Message.Height > sm.Params.Height[0] {
+				sm.LowestHeight = lowest.Message.Height
+				sm.Params.Height[0] = lowest.Message.Height
+			}
+			if sm.Params.Height[0] > sm.Params.Height[1] {
+				sm.UpdateResults(nil)
+			} else {
+				items := int(sm.Params.Height[1] - sm.Params.Height[0])
+				if items > maxBatchSize {
+					sm.Params.Height[1] = sm.Params.Height[0] + message.Height(maxBatchSize)
+				}
+				results, err := store.GetDecided(msg.ID, sm.Params.Height[0], sm.Params.Height[1])
+				sm.UpdateResults(err, results...)
 			}
-			results, err := store.GetDecided(msg.ID, sm.Params.Height[0], sm.Params.Height[1])
-			sm.UpdateResults(err, results...)
 		}
 
 		data, err := sm.Encode()
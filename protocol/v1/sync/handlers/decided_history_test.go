@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/bloxapp/ssv/protocol/v1/message"
+	protocolp2p "github.com/bloxapp/ssv/protocol/v1/p2p"
+	testingprotocol "github.com/bloxapp/ssv/protocol/v1/testing"
+)
+
+type nopReporting struct{}
+
+func (nopReporting) ReportValidation(*message.SSVMessage, protocolp2p.MsgValidationResult) {}
+
+func TestHistoryHandler_LowestHeight(t *testing.T) {
+	sks, _ := testingprotocol.GenerateBLSKeys(1, 2, 3, 4)
+	store := testingprotocol.PopulatedStorage(t, sks, 1, 20)
+	identifier := message.Identifier("Identifier_11")
+	handler := HistoryHandler(zaptest.NewLogger(t), store, nopReporting{}, 25)
+
+	request := func(from, to message.Height) *message.SyncMessage {
+		data, err := (&message.SyncMessage{
+			Protocol: message.DecidedHistoryType,
+			Params: &message.SyncParams{
+				Height:     []message.Height{from, to},
+				Identifier: identifier,
+			},
+		}).Encode()
+		require.NoError(t, err)
+		res, err := handler(&message.SSVMessage{MsgType: message.SSVSyncMsgType, ID: identifier, Data: data})
+		require.NoError(t, err)
+		sm := &message.SyncMessage{}
+		require.NoError(t, sm.Decode(res.Data))
+		return sm
+	}
+
+	sm := request(0, 10)
+	require.Equal(t, message.StatusSuccess, sm.Status)
+	require.Equal(t, message.Height(0), sm.LowestHeight)
+	require.Len(t, sm.Data, 11)
+
+	_, err := store.PruneDecided(identifier, 0, 14)
+	require.NoError(t, err)
+
+	t.Run("partially pruned range", func(t *testing.T) {
+		sm := request(10, 20)
+		require.Equal(t, message.StatusSuccess, sm.Status)
+		require.Equal(t, message.Height(15), sm.LowestHeight)
+		require.Len(t, sm.Data, 6)
+		require.Equal(t, message.Height(15), sm.Data[0].Message.Height)
+	})
+
+	t.Run("pruned range", func(t *testing.T) {
+		sm := request(0, 10)
+		require.Equal(t, message.StatusNotFound, sm.Status)
+		require.Equal(t, message.Height(15), sm.LowestHeight)
+		require.Empty(t, sm.Data)
+	})
+
+	t.Run("available range", func(t *testing.T) {
+		sm := request(16, 18)
+		require.Equal(t, message.StatusSuccess, sm.Status)
+		require.Equal(t, message.Height(0), sm.LowestHeight)
+		require.Len(t, sm.Data, 3)
+	})
+}
@@ -51,7 +51,11 @@ func (s syncer) SyncRange(ctx context.Context, identifier message.Identifier, ha
 			if err != nil {
 				return err
 			}
-			s.processMessages(ctx, msgs, handler, visited)
+			// skipping ranges that were pruned by all the responding peers
+			if lowest := s.processMessages(ctx, msgs, handler, visited); lowest > lastBatch {
+				s.logger.Debug("skipping pruned history", zap.Int64("currentHighest", int64(lastBatch)), zap.Int64("lowest", int64(lowest)))
+				lastBatch = lowest
+			}
 			elapsed := time.Since(start)
 			s.logger.Debug("received and processed history batch", zap.Int64("currentHighest", int64(lastBatch)), zap.Int64("needToSync", int64(to)), zap.Float64("duration", elapsed.Seconds()))
 			return nil
@@ -73,7 +77,11 @@ func (s syncer) SyncRange(ctx context.Context, identifier message.Identifier, ha
 	return nil
 }
 
-func (s syncer) processMessages(ctx context.Context, msgs []p2pprotocol.SyncResult, handler DecidedHandler, visited map[message.Height]bool) {
+// processMessages handles the decided messages of the given results,
+// it returns the lowest height that is available on all the responding peers
+func (s syncer) processMessages(ctx context.Context, msgs []p2pprotocol.SyncResult, handler DecidedHandler, visited map[message.Height]bool) message.Height {
+	var lowest message.Height
+	first := true
 	for _, msg := range msgs {
 		if ctx.Err() != nil {
 			break
@@ -86,6 +94,10 @@ func (s syncer) processMessages(ctx context.Context, msgs []p2pprotocol.SyncResu
 		if sm == nil {
 			continue
 		}
+		if first || sm.LowestHeight < lowest {
+			lowest = sm.LowestHeight
+			first = false
+		}
 	signedMsgLoop:
 		for _, signedMsg := range sm.Data {
 			height := signedMsg.Message.Height
@@ -99,4 +111,5 @@ func (s syncer) processMessages(ctx context.Context, msgs []p2pprotocol.SyncResu
 			visited[height] = true
 		}
 	}
+	return lowest
 }
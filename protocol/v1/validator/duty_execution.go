@@ -1,19 +1,28 @@
 package validator
 
 import (
+	"context"
 	"encoding/hex"
+
+	"github.com/bloxapp/ssv/monitoring/tracing"
 	beaconprotocol "github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
 	"github.com/bloxapp/ssv/protocol/v1/message"
 	"github.com/bloxapp/ssv/protocol/v1/qbft/controller"
 	"github.com/bloxapp/ssv/protocol/v1/qbft/instance"
 	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	"go.opencensus.io/trace"
 
 	"go.uber.org/zap"
 )
 
-func (v *Validator) comeToConsensusOnInputValue(logger *zap.Logger, duty *beaconprotocol.Duty) (controller.IController, int, []byte, message.Height, error) {
+func (v *Validator) comeToConsensusOnInputValue(ctx context.Context, logger *zap.Logger, duty *beaconprotocol.Duty) (qbftCtrl controller.IController, signaturesCount int, decidedValue []byte, height message.Height, err error) {
+	ctx, span := tracing.StartSpan(ctx, "Validator.comeToConsensusOnInputValue")
+	defer func() {
+		tracing.EndWithError(span, err)
+	}()
+
 	var inputByts []byte
-	var err error
 
 	qbftCtrl, ok := v.ibfts[duty.Type]
 	if !ok {
@@ -22,7 +31,9 @@ func (v *Validator) comeToConsensusOnInputValue(logger *zap.Logger, duty *beacon
 
 	switch duty.Type {
 	case message.RoleTypeAttester:
+		_, dataSpan := tracing.StartSpan(ctx, "beacon.GetAttestationData")
 		attData, err := v.beacon.GetAttestationData(duty.Slot, duty.CommitteeIndex)
+		tracing.EndWithError(dataSpan, err)
 		if err != nil {
 			return nil, 0, nil, 0, errors.Wrap(err, "failed to get attestation data")
 		}
@@ -36,17 +47,20 @@ func (v *Validator) comeToConsensusOnInputValue(logger *zap.Logger, duty *beacon
 	}
 
 	// calculate next seq
-	height, err := qbftCtrl.NextSeqNumber()
+	height, err = qbftCtrl.NextSeqNumber()
 	if err != nil {
 		return nil, 0, nil, 0, errors.Wrap(err, "failed to calculate next sequence number")
 	}
+	span.AddAttributes(trace.Int64Attribute("height", int64(height)))
 
 	logger.Debug("start instance", zap.Int64("height", int64(height)))
 	result, err := qbftCtrl.StartInstance(instance.ControllerStartInstanceOptions{
+		Ctx:             ctx,
 		Logger:          logger,
 		SeqNumber:       height,
 		Value:           inputByts,
 		RequireMinPeers: true,
+		Deadline:        v.network.GetSlotStartTime(uint64(duty.Slot)),
 	})
 	if err != nil {
 		return nil, 0, nil, 0, errors.Wrap(err, "ibft instance failed")
@@ -67,7 +81,13 @@ func (v *Validator) comeToConsensusOnInputValue(logger *zap.Logger, duty *beacon
 }
 
 // ExecuteDuty executes the given duty
-func (v *Validator) ExecuteDuty(slot uint64, duty *beaconprotocol.Duty) {
+func (v *Validator) ExecuteDuty(ctx context.Context, slot uint64, duty *beaconprotocol.Duty) {
+	ctx, span := tracing.StartSpan(ctx, "Validator.ExecuteDuty",
+		trace.StringAttribute("role", duty.Type.String()),
+		trace.Int64Attribute("slot", int64(slot)),
+		trace.Int64Attribute("operator_id", int64(v.Share.NodeID)))
+	defer span.End()
+
 	logger := v.logger.With(zap.Time("start_time", v.network.GetSlotStartTime(slot)),
 		zap.Uint64("committee_index", uint64(duty.CommitteeIndex)),
 		zap.Uint64("slot", slot),
@@ -76,9 +96,17 @@ func (v *Validator) ExecuteDuty(slot uint64, duty *beaconprotocol.Duty) {
 	metricsCurrentSlot.WithLabelValues(v.Share.PublicKey.SerializeToHexStr()).Set(float64(duty.Slot))
 
 	logger.Debug("executing duty...")
-	qbftCtrl, signaturesCount, decidedValue, seqNumber, err := v.comeToConsensusOnInputValue(logger, duty)
+	if !duty.Type.HasConsensus() {
+		if err := v.executeDutyWithoutConsensus(ctx, logger, duty); err != nil {
+			logger.Error("could not execute duty", zap.Error(err))
+			tracing.SetError(span, err)
+		}
+		return
+	}
+	qbftCtrl, signaturesCount, decidedValue, seqNumber, err := v.comeToConsensusOnInputValue(ctx, logger, duty)
 	if err != nil {
 		logger.Error("could not come to consensus", zap.Error(err))
+		tracing.SetError(span, err)
 		return
 	}
 
@@ -86,8 +114,59 @@ func (v *Validator) ExecuteDuty(slot uint64, duty *beaconprotocol.Duty) {
 	logger.Info("GOT CONSENSUS", zap.Any("inputValueHex", hex.EncodeToString(decidedValue)))
 
 	// Sign, aggregate and broadcast signature
-	if err := qbftCtrl.PostConsensusDutyExecution(logger, seqNumber, decidedValue, signaturesCount, duty); err != nil {
+	if err := qbftCtrl.PostConsensusDutyExecution(ctx, logger, seqNumber, decidedValue, signaturesCount, duty); err != nil {
 		logger.Error("could not execute duty", zap.Error(err))
+		tracing.SetError(span, err)
 		return
 	}
 }
+
+// executeDutyWithoutConsensus signs and broadcasts duties that don't require consensus,
+// the duty data is derived deterministically by all operators so only partial signatures are exchanged
+func (v *Validator) executeDutyWithoutConsensus(ctx context.Context, logger *zap.Logger, duty *beaconprotocol.Duty) error {
+	qbftCtrl, ok := v.ibfts[duty.Type]
+	if !ok {
+		return errors.Errorf("no ibft for this role [%s]", duty.Type.String())
+	}
+
+	var value []byte
+	var height message.Height
+	switch duty.Type {
+	case message.RoleTypeValidatorRegistration:
+		registration, err := v.validatorRegistration(duty)
+		if err != nil {
+			return errors.Wrap(err, "failed to create validator registration")
+		}
+		value, err = registration.MarshalSSZ()
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal validator registration")
+		}
+		// registrations are submitted once per epoch
+		height = message.Height(v.network.EstimatedEpochAtSlot(types.Slot(duty.Slot)))
+	default:
+		return errors.Errorf("unknown role: %s", duty.Type.String())
+	}
+
+	return qbftCtrl.PostConsensusDutyExecution(ctx, logger, height, value, v.Share.ThresholdSize(), duty)
+}
+
+// validatorRegistration creates the registration of the given duty,
+// the timestamp is set to the start of the epoch so all operators sign the same message
+func (v *Validator) validatorRegistration(duty *beaconprotocol.Duty) (*beaconprotocol.ValidatorRegistration, error) {
+	feeRecipient, err := v.Share.FeeRecipientAddress()
+	if err != nil {
+		return nil, err
+	}
+	gasLimit := v.gasLimit
+	if gasLimit == 0 {
+		gasLimit = beaconprotocol.DefaultGasLimit
+	}
+	epoch := v.network.EstimatedEpochAtSlot(types.Slot(duty.Slot))
+	epochStart := v.network.GetSlotStartTime(uint64(epoch) * v.network.SlotsPerEpoch())
+	return &beaconprotocol.ValidatorRegistration{
+		FeeRecipient: feeRecipient,
+		GasLimit:     gasLimit,
+		Timestamp:    uint64(epochStart.Unix()),
+		Pubkey:       duty.PubKey,
+	}, nil
+}
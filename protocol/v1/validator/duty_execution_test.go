@@ -1,6 +1,7 @@
 package validator
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -89,7 +90,7 @@ func TestConsensusOnInputValue(t *testing.T) {
 				ValidatorCommitteeIndex: 0,
 			}
 
-			_, signaturesCount, decidedByts, _, err := node.comeToConsensusOnInputValue(node.logger, duty)
+			_, signaturesCount, decidedByts, _, err := node.comeToConsensusOnInputValue(context.Background(), node.logger, duty)
 			if !test.decided {
 				require.EqualError(t, err, test.expectedError)
 				return
@@ -196,7 +197,7 @@ func TestPostConsensusSignatureAndAggregation(t *testing.T) {
 
 			// TODO: do for all ibfts
 			for _, ibft := range validator.Ibfts() {
-				err := ibft.PostConsensusDutyExecution(validator.logger, 0, test.expectedAttestationDataByts, test.expectedSignaturesCount, duty)
+				err := ibft.PostConsensusDutyExecution(context.Background(), validator.logger, 0, test.expectedAttestationDataByts, test.expectedSignaturesCount, duty)
 				if len(test.expectedError) > 0 {
 					require.EqualError(t, err, test.expectedError)
 				} else {
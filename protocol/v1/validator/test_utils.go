@@ -1,12 +1,14 @@
 package validator
 
 import (
+	"context"
 	"encoding/hex"
 	"fmt"
 	"sync"
 	"testing"
 
 	api "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/bloxapp/eth2-key-manager/core"
 	"github.com/herumi/bls-eth-go-binary/bls"
@@ -133,7 +135,15 @@ func (t *testIBFT) OnFork(forkVersion forksprotocol.ForkVersion) error {
 	return nil
 }
 
-func (t *testIBFT) PostConsensusDutyExecution(logger *zap.Logger, height message.Height, decidedValue []byte, signaturesCount int, duty *beaconprotocol.Duty) error {
+func (t *testIBFT) State() uint32 {
+	return controller.Ready
+}
+
+func (t *testIBFT) ResyncDecided() error {
+	return nil
+}
+
+func (t *testIBFT) PostConsensusDutyExecution(ctx context.Context, logger *zap.Logger, height message.Height, decidedValue []byte, signaturesCount int, duty *beaconprotocol.Duty) error {
 	// get operator pk for sig
 	pk, err := t.share.OperatorSharePubKey()
 	if err != nil {
@@ -254,6 +264,18 @@ func (b *testBeacon) SubscribeToCommitteeSubnet(subscription []*api.BeaconCommit
 	panic("implement me")
 }
 
+func (b *testBeacon) SubmitProposalPreparation(feeRecipients map[spec.ValidatorIndex]bellatrix.ExecutionAddress) error {
+	panic("implement me")
+}
+
+func (b *testBeacon) SubmitValidatorRegistration(registration *beacon.SignedValidatorRegistration) error {
+	panic("implement me")
+}
+
+func (b *testBeacon) SignValidatorRegistration(registration *beacon.ValidatorRegistration, pk []byte) ([]byte, []byte, error) {
+	panic("implement me")
+}
+
 func (b *testBeacon) AddShare(shareKey *bls.SecretKey) error {
 	panic("implement me")
 }
@@ -14,13 +14,16 @@ import (
 	p2pprotocol "github.com/bloxapp/ssv/protocol/v1/p2p"
 	"github.com/bloxapp/ssv/protocol/v1/qbft"
 	"github.com/bloxapp/ssv/protocol/v1/qbft/controller"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/journal"
 	qbftstorage "github.com/bloxapp/ssv/protocol/v1/qbft/storage"
+	"github.com/bloxapp/ssv/protocol/v1/queue/worker"
 )
 
 // IValidator is the interface for validator
 type IValidator interface {
 	Start() error
-	ExecuteDuty(slot uint64, duty *beaconprotocol.Duty)
+	// ExecuteDuty executes the given duty, the context holds the trace span of the duty
+	ExecuteDuty(ctx context.Context, slot uint64, duty *beaconprotocol.Duty)
 	ProcessMsg(msg *message.SSVMessage) error // TODO need to be as separate interface?
 	GetShare() *beaconprotocol.Share
 
@@ -44,6 +47,12 @@ type Options struct {
 	ReadMode                   bool
 	FullNode                   bool
 	NewDecidedHandler          controller.NewDecidedHandler
+	GasLimit                   uint64
+	Journal                    journal.Journal
+	// Scheduler processes the msg queues of the qbft controllers on a shared pool of workers (optional)
+	Scheduler *worker.Scheduler
+	// InstanceConfigs are the qbft configs of the roles, qbft.DefaultRoleConsensusParams is used for missing roles
+	InstanceConfigs map[message.RoleType]*qbft.InstanceConfig
 }
 
 // Validator represents the validator
@@ -56,8 +65,10 @@ type Validator struct {
 	beacon     beaconprotocol.Beacon
 	Share      *beaconprotocol.Share // var is exported to validator ctrl tests reasons
 	signer     beaconprotocol.Signer
+	gasLimit   uint64
 
-	ibfts controller.Controllers
+	ibfts     controller.Controllers
+	scheduler *worker.Scheduler
 
 	// flags
 	readMode    bool
@@ -87,7 +98,9 @@ func NewValidator(opt *Options) IValidator {
 		beacon:      opt.Beacon,
 		Share:       opt.Share,
 		signer:      opt.Signer,
+		gasLimit:    opt.GasLimit,
 		ibfts:       ibfts,
+		scheduler:   opt.Scheduler,
 		readMode:    opt.ReadMode,
 		saveHistory: opt.FullNode,
 	}
@@ -96,6 +109,11 @@ func NewValidator(opt *Options) IValidator {
 // Close implements io.Closer
 func (v *Validator) Close() error {
 	v.cancelCtx()
+	if v.scheduler != nil {
+		for _, ib := range v.ibfts {
+			v.scheduler.Unregister(message.Identifier(ib.GetIdentifier()).String())
+		}
+	}
 	return nil
 }
 
@@ -130,6 +148,9 @@ func (v *Validator) GetShare() *beaconprotocol.Share {
 // ProcessMsg processes a new msg
 func (v *Validator) ProcessMsg(msg *message.SSVMessage) error {
 	ibftController := v.ibfts.ControllerForIdentifier(msg.GetIdentifier())
+	if ibftController == nil {
+		return errors.Errorf("no ibft for this role [%s]", msg.GetIdentifier().GetRoleType().String())
+	}
 	// synchronize process
 	return ibftController.ProcessMsg(msg)
 }
@@ -148,11 +169,16 @@ func (v *Validator) OnFork(forkVersion forksprotocol.ForkVersion) error {
 func setupIbfts(opt *Options, logger *zap.Logger) map[message.RoleType]controller.IController {
 	ibfts := make(map[message.RoleType]controller.IController)
 	ibfts[message.RoleTypeAttester] = setupIbftController(message.RoleTypeAttester, logger, opt)
+	ibfts[message.RoleTypeValidatorRegistration] = setupIbftController(message.RoleTypeValidatorRegistration, logger, opt)
 	return ibfts
 }
 
 func setupIbftController(role message.RoleType, logger *zap.Logger, opt *Options) controller.IController {
 	identifier := message.NewIdentifier(opt.Share.PublicKey.Serialize(), role)
+	instanceConfig, ok := opt.InstanceConfigs[role]
+	if !ok {
+		instanceConfig = qbft.DefaultRoleConsensusParams(role)
+	}
 	opts := controller.Options{
 		Context:           opt.Context,
 		Role:              role,
@@ -160,7 +186,7 @@ func setupIbftController(role message.RoleType, logger *zap.Logger, opt *Options
 		Logger:            logger,
 		Storage:           opt.IbftStorage,
 		Network:           opt.P2pNetwork,
-		InstanceConfig:    qbft.DefaultConsensusParams(),
+		InstanceConfig:    instanceConfig,
 		ValidatorShare:    opt.Share,
 		Version:           opt.ForkVersion,
 		Beacon:            opt.Beacon,
@@ -170,6 +196,8 @@ func setupIbftController(role message.RoleType, logger *zap.Logger, opt *Options
 		ReadMode:          opt.ReadMode,
 		FullNode:          opt.FullNode,
 		NewDecidedHandler: opt.NewDecidedHandler,
+		Journal:           opt.Journal,
+		Scheduler:         opt.Scheduler,
 	}
 	return controller.New(opts)
 }
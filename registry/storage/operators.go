@@ -2,9 +2,11 @@ package storage
 
 import (
 	"bytes"
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
-	"strconv"
+	"math"
+	"math/big"
 	"strings"
 	"sync"
 
@@ -15,7 +17,8 @@ import (
 )
 
 var (
-	operatorsPrefix = []byte("operators")
+	operatorsPrefix        = []byte("operators")
+	removedOperatorsPrefix = []byte("removed_operators")
 )
 
 // OperatorData the public data of an operator
@@ -24,6 +27,7 @@ type OperatorData struct {
 	PublicKey    string         `json:"publicKey"`
 	Name         string         `json:"name"`
 	OwnerAddress common.Address `json:"ownerAddress"`
+	Fee          *big.Int       `json:"fee,omitempty"`
 }
 
 // GetOperatorData is a function that returns the operator data
@@ -34,8 +38,14 @@ type OperatorsCollection interface {
 	GetOperatorDataByPubKey(operatorPubKey string) (*OperatorData, bool, error)
 	GetOperatorData(index uint64) (*OperatorData, bool, error)
 	SaveOperatorData(operatorData *OperatorData) error
+	UpdateOperatorFee(index uint64, fee *big.Int) error
+	DeleteOperatorData(index uint64) error
+	// IsOperatorRemoved returns whether the operator with the given public key was removed (see DeleteOperatorData)
+	IsOperatorRemoved(operatorPubKey string) (bool, error)
 	ListOperators(from uint64, to uint64) ([]OperatorData, error)
 	GetOperatorsPrefix() []byte
+	// GetRemovedOperatorsPrefix returns the prefix of the removed operators (see IsOperatorRemoved)
+	GetRemovedOperatorsPrefix() []byte
 }
 
 type operatorsStorage struct {
@@ -59,6 +69,11 @@ func (s *operatorsStorage) GetOperatorsPrefix() []byte {
 	return operatorsPrefix
 }
 
+// GetRemovedOperatorsPrefix returns the prefix of the removed operators
+func (s *operatorsStorage) GetRemovedOperatorsPrefix() []byte {
+	return removedOperatorsPrefix
+}
+
 // ListOperators returns data of the all known operators by index range (from, to)
 // when 'to' equals zero, all operators will be returned
 func (s *operatorsStorage) ListOperators(from, to uint64) ([]OperatorData, error) {
@@ -112,14 +127,16 @@ func (s *operatorsStorage) getOperatorData(index uint64) (*OperatorData, bool, e
 
 func (s *operatorsStorage) listOperators(from, to uint64) ([]OperatorData, error) {
 	var operators []OperatorData
-	err := s.db.GetAll(append(s.prefix, operatorsPrefix...), func(i int, obj basedb.Obj) error {
+	opts := basedb.RangeOptions{From: operatorIndexKey(from)}
+	if to > 0 && to < math.MaxUint64 {
+		opts.To = operatorIndexKey(to + 1)
+	}
+	err := s.db.Iterate(s.operatorsKeyPrefix(), opts, func(obj basedb.Obj) error {
 		var od OperatorData
 		if err := json.Unmarshal(obj.Value, &od); err != nil {
 			return err
 		}
-		if (od.Index >= from && od.Index <= to) || (to == 0) {
-			operators = append(operators, od)
-		}
+		operators = append(operators, od)
 		return nil
 	})
 
@@ -154,12 +171,83 @@ func (s *operatorsStorage) SaveOperatorData(operatorData *OperatorData) error {
 	if err != nil {
 		return errors.Wrap(err, "could not marshal operator information")
 	}
-	return s.db.Set(s.prefix, buildOperatorKey(operatorData.Index), raw)
+	if err := s.db.Set(s.prefix, buildOperatorKey(operatorData.Index), raw); err != nil {
+		return err
+	}
+	// the operator might be added again after it was removed
+	return s.db.Delete(s.prefix, buildRemovedOperatorKey(operatorData.PublicKey))
 }
 
-// buildOperatorKey builds operator key using operatorsPrefix & index, e.g. "operators/1"
+// UpdateOperatorFee updates the fee of an existing operator
+func (s *operatorsStorage) UpdateOperatorFee(index uint64, fee *big.Int) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	od, found, err := s.getOperatorData(index)
+	if err != nil {
+		return errors.Wrap(err, "could not get operator's data")
+	}
+	if !found {
+		return errors.Errorf("could not find operator %d", index)
+	}
+	od.Fee = fee
+	raw, err := json.Marshal(od)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal operator information")
+	}
+	return s.db.Set(s.prefix, buildOperatorKey(index), raw)
+}
+
+// DeleteOperatorData removes the data of the given operator, and marks its public key as removed
+func (s *operatorsStorage) DeleteOperatorData(index uint64) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	od, found, err := s.getOperatorData(index)
+	if err != nil {
+		return errors.Wrap(err, "could not get operator's data")
+	}
+	if err := s.db.Delete(s.prefix, buildOperatorKey(index)); err != nil {
+		return err
+	}
+	if !found {
+		return nil
+	}
+	return s.db.Set(s.prefix, buildRemovedOperatorKey(od.PublicKey), operatorIndexKey(index))
+}
+
+// IsOperatorRemoved returns whether the operator with the given public key was removed
+func (s *operatorsStorage) IsOperatorRemoved(operatorPubKey string) (bool, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	_, found, err := s.db.Get(s.prefix, buildRemovedOperatorKey(operatorPubKey))
+	return found, err
+}
+
+// buildOperatorKey builds operator key using operatorsPrefix & big endian index, e.g. "operators/\x00...\x01",
+// so operators are sorted by index
 func buildOperatorKey(index uint64) []byte {
-	return bytes.Join([][]byte{operatorsPrefix[:], []byte(strconv.FormatUint(index, 10))}, []byte("/"))
+	return bytes.Join([][]byte{operatorsPrefix[:], operatorIndexKey(index)}, []byte("/"))
+}
+
+// buildRemovedOperatorKey builds the key of a removed operator using removedOperatorsPrefix & public key,
+// public keys are compared case insensitively (see GetOperatorDataByPubKey)
+func buildRemovedOperatorKey(operatorPubKey string) []byte {
+	return bytes.Join([][]byte{removedOperatorsPrefix[:], []byte(strings.ToLower(operatorPubKey))}, []byte("/"))
+}
+
+func operatorIndexKey(index uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, index)
+	return b
+}
+
+// operatorsKeyPrefix returns the prefix of the operators keys, e.g. "operators/"
+func (s *operatorsStorage) operatorsKeyPrefix() []byte {
+	prefix := append([]byte{}, s.prefix...)
+	prefix = append(prefix, operatorsPrefix...)
+	return append(prefix, '/')
 }
 
 func (s *operatorsStorage) nextIndex() (int64, error) {
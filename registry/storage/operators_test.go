@@ -2,6 +2,7 @@ package storage
 
 import (
 	"fmt"
+	"math/big"
 	"strings"
 	"testing"
 
@@ -152,6 +153,90 @@ func TestStorage_ListOperators(t *testing.T) {
 	})
 }
 
+func TestStorage_ListOperatorsOrder(t *testing.T) {
+	storage, done := newStorageForTest()
+	require.NotNil(t, storage)
+	defer done()
+
+	for i := 1; i <= 25; i++ {
+		require.NoError(t, storage.SaveOperatorData(&OperatorData{
+			PublicKey: fmt.Sprintf("pk-%d", i),
+			Name:      fmt.Sprintf("operator-%d", i),
+			Index:     uint64(i),
+		}))
+	}
+
+	operators, err := storage.ListOperators(0, 0)
+	require.NoError(t, err)
+	require.Len(t, operators, 25)
+	for i, od := range operators {
+		require.Equal(t, uint64(i+1), od.Index)
+	}
+
+	operators, err = storage.ListOperators(9, 11)
+	require.NoError(t, err)
+	require.Len(t, operators, 3)
+	require.Equal(t, uint64(9), operators[0].Index)
+	require.Equal(t, uint64(11), operators[2].Index)
+}
+
+func TestStorage_UpdateAndDeleteOperator(t *testing.T) {
+	storage, done := newStorageForTest()
+	require.NotNil(t, storage)
+	defer done()
+
+	pk, _, err := rsaencryption.GenerateKeys()
+	require.NoError(t, err)
+	operatorData := OperatorData{
+		PublicKey: string(pk),
+		Name:      "my_operator",
+		Index:     1,
+		Fee:       big.NewInt(10),
+	}
+	require.NoError(t, storage.SaveOperatorData(&operatorData))
+
+	t.Run("update fee", func(t *testing.T) {
+		require.NoError(t, storage.UpdateOperatorFee(operatorData.Index, big.NewInt(20)))
+		od, found, err := storage.GetOperatorData(operatorData.Index)
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Equal(t, int64(20), od.Fee.Int64())
+		require.Equal(t, operatorData.Name, od.Name)
+	})
+
+	t.Run("update fee of unknown operator", func(t *testing.T) {
+		require.Error(t, storage.UpdateOperatorFee(2, big.NewInt(20)))
+	})
+
+	t.Run("operator that was not removed", func(t *testing.T) {
+		removed, err := storage.IsOperatorRemoved(operatorData.PublicKey)
+		require.NoError(t, err)
+		require.False(t, removed)
+	})
+
+	t.Run("delete operator", func(t *testing.T) {
+		require.NoError(t, storage.DeleteOperatorData(operatorData.Index))
+		_, found, err := storage.GetOperatorData(operatorData.Index)
+		require.NoError(t, err)
+		require.False(t, found)
+		_, found, err = storage.GetOperatorDataByPubKey(operatorData.PublicKey)
+		require.NoError(t, err)
+		require.False(t, found)
+		removed, err := storage.IsOperatorRemoved(operatorData.PublicKey)
+		require.NoError(t, err)
+		require.True(t, removed)
+	})
+
+	t.Run("add removed operator", func(t *testing.T) {
+		readded := operatorData
+		readded.Index = 2
+		require.NoError(t, storage.SaveOperatorData(&readded))
+		removed, err := storage.IsOperatorRemoved(operatorData.PublicKey)
+		require.NoError(t, err)
+		require.False(t, removed)
+	})
+}
+
 func newStorageForTest() (OperatorsCollection, func()) {
 	logger := zap.L()
 	db, err := ssvstorage.GetStorageFactory(basedb.Options{
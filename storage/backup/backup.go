@@ -0,0 +1,263 @@
+package backup
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"hash"
+	"io"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+// Version is the current version of the backup format.
+//
+// a backup is a gzip stream that contains a json header line, followed by records of
+// (uvarint key length, key, uvarint value length, value). records are terminated by a zero key length,
+// followed by a footer of the records count (uvarint) and a sha256 checksum of everything that precedes it
+const Version = 1
+
+// Header describes the content of a backup
+type Header struct {
+	Version     int
+	CreatedAt   time.Time
+	Collections []string
+}
+
+// Summary holds the amount of items per collection in a backup
+type Summary map[string]int
+
+// Total returns the total amount of items
+func (s Summary) Total() int {
+	total := 0
+	for _, n := range s {
+		total += n
+	}
+	return total
+}
+
+// Write writes a backup of the given collections into w.
+// the db is read in a single read-only transaction, so the backup is a consistent snapshot even while the node runs
+func Write(db basedb.IDb, w io.Writer, collections []string) (Summary, error) {
+	selected := make(map[string]bool, len(collections))
+	for _, c := range collections {
+		selected[c] = true
+	}
+	zw := gzip.NewWriter(w)
+	bw := newWriter(zw)
+
+	header, err := json.Marshal(Header{
+		Version:     Version,
+		CreatedAt:   time.Now().UTC(),
+		Collections: collections,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encode header")
+	}
+	if err := bw.write(append(header, '\n')); err != nil {
+		return nil, err
+	}
+
+	summary := make(Summary)
+	err = db.Iterate([]byte{}, basedb.RangeOptions{}, func(obj basedb.Obj) error {
+		c := CollectionOf(obj.Key)
+		if !selected[c] {
+			return nil
+		}
+		summary[c]++
+		return bw.writeRecord(obj.Key, obj.Value)
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not write records")
+	}
+	if err := bw.writeFooter(summary.Total()); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, errors.Wrap(err, "could not flush backup")
+	}
+	return summary, nil
+}
+
+// Read reads the given backup, the handler is called for every record.
+// the checksum is verified only once all records were read, therefore records should not be applied before
+// the backup was verified (see Verify)
+func Read(r io.Reader, handler func(Header, basedb.Obj) error) (Header, Summary, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return Header{}, nil, errors.Wrap(err, "could not read gzip stream")
+	}
+	br := newReader(bufio.NewReader(zr))
+
+	var header Header
+	line, err := br.readLine()
+	if err != nil {
+		return header, nil, errors.Wrap(err, "could not read header")
+	}
+	if err := json.Unmarshal(line, &header); err != nil {
+		return header, nil, errors.Wrap(err, "could not decode header")
+	}
+	if header.Version != Version {
+		return header, nil, errors.Errorf("unsupported backup version %d", header.Version)
+	}
+
+	summary := make(Summary)
+	for {
+		key, value, err := br.readRecord()
+		if err != nil {
+			return header, summary, errors.Wrap(err, "could not read record")
+		}
+		if key == nil {
+			break
+		}
+		summary[CollectionOf(key)]++
+		if handler != nil {
+			if err := handler(header, basedb.Obj{Key: key, Value: value}); err != nil {
+				return header, summary, err
+			}
+		}
+	}
+	if err := br.verifyFooter(summary.Total()); err != nil {
+		return header, summary, err
+	}
+	return header, summary, nil
+}
+
+// Verify reads the given backup and checks its integrity
+func Verify(r io.Reader) (Header, Summary, error) {
+	return Read(r, nil)
+}
+
+type writer struct {
+	w   io.Writer
+	sum hash.Hash
+	buf [binary.MaxVarintLen64]byte
+}
+
+func newWriter(w io.Writer) *writer {
+	sum := sha256.New()
+	return &writer{w: io.MultiWriter(w, sum), sum: sum}
+}
+
+func (w *writer) write(b []byte) error {
+	_, err := w.w.Write(b)
+	return err
+}
+
+func (w *writer) writeUvarint(n uint64) error {
+	l := binary.PutUvarint(w.buf[:], n)
+	return w.write(w.buf[:l])
+}
+
+func (w *writer) writeRecord(key, value []byte) error {
+	if err := w.writeUvarint(uint64(len(key))); err != nil {
+		return err
+	}
+	if err := w.write(key); err != nil {
+		return err
+	}
+	if err := w.writeUvarint(uint64(len(value))); err != nil {
+		return err
+	}
+	return w.write(value)
+}
+
+func (w *writer) writeFooter(count int) error {
+	if err := w.writeUvarint(0); err != nil {
+		return err
+	}
+	if err := w.writeUvarint(uint64(count)); err != nil {
+		return err
+	}
+	return w.write(w.sum.Sum(nil))
+}
+
+type reader struct {
+	r   *bufio.Reader
+	sum hash.Hash
+}
+
+func newReader(r *bufio.Reader) *reader {
+	return &reader{r: r, sum: sha256.New()}
+}
+
+func (r *reader) readLine() ([]byte, error) {
+	line, err := r.r.ReadBytes('\n')
+	if err != nil {
+		return nil, err
+	}
+	r.sum.Write(line)
+	return line, nil
+}
+
+func (r *reader) readUvarint() (uint64, error) {
+	n, err := binary.ReadUvarint(r.r)
+	if err != nil {
+		return 0, err
+	}
+	var buf [binary.MaxVarintLen64]byte
+	r.sum.Write(buf[:binary.PutUvarint(buf[:], n)])
+	return n, nil
+}
+
+func (r *reader) readBytes(n uint64) ([]byte, error) {
+	b := make([]byte, n)
+	if _, err := io.ReadFull(r.r, b); err != nil {
+		return nil, err
+	}
+	r.sum.Write(b)
+	return b, nil
+}
+
+// readRecord returns a nil key once all records were read
+func (r *reader) readRecord() ([]byte, []byte, error) {
+	l, err := r.readUvarint()
+	if err != nil || l == 0 {
+		return nil, nil, err
+	}
+	key, err := r.readBytes(l)
+	if err != nil {
+		return nil, nil, err
+	}
+	l, err = r.readUvarint()
+	if err != nil {
+		return nil, nil, err
+	}
+	value, err := r.readBytes(l)
+	if err != nil {
+		return nil, nil, err
+	}
+	return key, value, nil
+}
+
+func (r *reader) verifyFooter(count int) error {
+	n, err := r.readUvarint()
+	if err != nil {
+		return errors.Wrap(err, "could not read footer")
+	}
+	if n != uint64(count) {
+		return errors.Errorf("backup is corrupted: expected %d records, found %d", n, count)
+	}
+	expected := r.sum.Sum(nil)
+	checksum := make([]byte, len(expected))
+	if _, err := io.ReadFull(r.r, checksum); err != nil {
+		return errors.Wrap(err, "could not read checksum")
+	}
+	if !bytes.Equal(checksum, expected) {
+		return errors.New("backup is corrupted: checksum mismatch")
+	}
+	// reading until the end of the gzip stream, which also verifies its own checksum
+	if _, err := r.r.ReadByte(); err != io.EOF {
+		if err == nil {
+			return errors.New("backup is corrupted: unexpected data after footer")
+		}
+		return errors.Wrap(err, "backup is corrupted")
+	}
+	return nil
+}
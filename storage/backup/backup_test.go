@@ -0,0 +1,339 @@
+package backup
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/storage/kv"
+)
+
+func newTestDb(t *testing.T) basedb.IDb {
+	db, err := kv.New(basedb.Options{
+		Type:   "badger-memory",
+		Logger: zap.L(),
+	})
+	require.NoError(t, err)
+	t.Cleanup(db.Close)
+	return db
+}
+
+// populate saves n items into each of the given prefixes
+func populate(t *testing.T, db basedb.IDb, n int, prefixes ...string) {
+	for _, p := range prefixes {
+		require.NoError(t, db.SetMany(nil, n, func(i int) (basedb.Obj, error) {
+			return basedb.Obj{Key: []byte(fmt.Sprintf("%skey-%d", p, i)), Value: []byte(fmt.Sprintf("%s-value-%d", p, i))}, nil
+		}))
+	}
+}
+
+func count(t *testing.T, db basedb.IDb) Summary {
+	summary := make(Summary)
+	require.NoError(t, db.Iterate([]byte{}, basedb.RangeOptions{KeysOnly: true}, func(obj basedb.Obj) error {
+		summary[CollectionOf(obj.Key)]++
+		return nil
+	}))
+	return summary
+}
+
+// failingDb fails to set items after failAfter items were set
+type failingDb struct {
+	basedb.IDb
+	failAfter int
+}
+
+func (db *failingDb) Update(fn func(basedb.Txn) error) error {
+	return db.IDb.Update(func(txn basedb.Txn) error {
+		return fn(&failingTxn{Txn: txn, db: db})
+	})
+}
+
+type failingTxn struct {
+	basedb.Txn
+	db *failingDb
+}
+
+func (txn *failingTxn) Set(prefix []byte, key []byte, value []byte) error {
+	if txn.db.failAfter == 0 {
+		return errors.New("failed to set")
+	}
+	txn.db.failAfter--
+	return txn.Txn.Set(prefix, key, value)
+}
+
+func opener(b []byte) func() (io.ReadCloser, error) {
+	return func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(b)), nil
+	}
+}
+
+// testGuard refuses restored values that are lower (lexicographically) than the current ones
+type testGuard struct {
+	prefix []byte
+}
+
+func (g testGuard) Applies(key []byte) bool {
+	return bytes.HasPrefix(key, g.prefix)
+}
+
+func (g testGuard) Check(key, current, restored []byte) error {
+	if current != nil && bytes.Compare(restored, current) < 0 {
+		return errors.Errorf("older value for key %s", key)
+	}
+	return nil
+}
+
+func TestCollectionOf(t *testing.T) {
+	tests := []struct {
+		key        string
+		collection string
+	}{
+		{"operator-private-key", "operator"},
+		{"operator-operators/1", "operator"},
+		{"share-abcd", "shares"},
+		{"pratersigner_data-wallet", "ekm"},
+		{"pratersigner_data-highest_att-abcd", "ekm"},
+		{"ATTESTER_abcd", "decided"},
+		{"attestationsabcd", "decided"},
+		{"p2p-network-key", "p2p"},
+		{"migrations/migration_1", "migrations"},
+		{"unknown", OtherCollection},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.collection, CollectionOf([]byte(test.key)), test.key)
+	}
+}
+
+func TestSelectCollections(t *testing.T) {
+	all, err := SelectCollections(nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, collectionNames(), all)
+
+	selected, err := SelectCollections([]string{"shares", "ekm"}, []string{"ekm"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"shares"}, selected)
+
+	_, err = SelectCollections([]string{"xxx"}, nil)
+	require.Error(t, err)
+	_, err = SelectCollections([]string{"shares"}, []string{"shares"})
+	require.EqualError(t, err, "no collection was selected")
+}
+
+func TestWriteAndRead(t *testing.T) {
+	db := newTestDb(t)
+	populate(t, db, 10, "operator-", "share-", "pratersigner_data-", "ATTESTER_")
+
+	collections, err := SelectCollections(nil, []string{"decided"})
+	require.NoError(t, err)
+	buf := new(bytes.Buffer)
+	summary, err := Write(db, buf, collections)
+	require.NoError(t, err)
+	require.Equal(t, Summary{"operator": 10, "shares": 10, "ekm": 10}, summary)
+
+	items := make(map[string]string)
+	header, read, err := Read(bytes.NewReader(buf.Bytes()), func(h Header, obj basedb.Obj) error {
+		items[string(obj.Key)] = string(obj.Value)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, Version, header.Version)
+	require.Equal(t, collections, header.Collections)
+	require.Equal(t, summary, read)
+	require.Len(t, items, 30)
+	require.Equal(t, "share--value-3", items["share-key-3"])
+}
+
+func TestVerify_Corrupted(t *testing.T) {
+	db := newTestDb(t)
+	populate(t, db, 10, "share-")
+	buf := new(bytes.Buffer)
+	_, err := Write(db, buf, []string{"shares"})
+	require.NoError(t, err)
+
+	_, _, err = Verify(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+
+	// truncated
+	_, _, err = Verify(bytes.NewReader(buf.Bytes()[:buf.Len()/2]))
+	require.Error(t, err)
+
+	// a record that was changed (re-compressed, so only the checksum can detect it)
+	zr, err := gzip.NewReader(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	decompressed, err := ioutil.ReadAll(zr)
+	require.NoError(t, err)
+	corrupted := bytes.Replace(decompressed, []byte("value-3"), []byte("value-4"), 1)
+	require.NotEqual(t, decompressed, corrupted)
+	compressed := new(bytes.Buffer)
+	zw := gzip.NewWriter(compressed)
+	_, err = zw.Write(corrupted)
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	_, _, err = Verify(compressed)
+	require.EqualError(t, err, "backup is corrupted: checksum mismatch")
+}
+
+func TestRestore(t *testing.T) {
+	src := newTestDb(t)
+	populate(t, src, 10, "operator-", "share-", "ATTESTER_")
+	buf := new(bytes.Buffer)
+	_, err := Write(src, buf, collectionNames())
+	require.NoError(t, err)
+
+	t.Run("replace all", func(t *testing.T) {
+		db := newTestDb(t)
+		populate(t, db, 5, "share-other-", "p2p-")
+		summary, err := Restore(db, opener(buf.Bytes()), RestoreOptions{})
+		require.NoError(t, err)
+		require.Equal(t, Summary{"operator": 10, "shares": 10, "decided": 10}, summary)
+		// p2p was part of the backup (empty), so it was removed
+		require.Equal(t, Summary{"operator": 10, "shares": 10, "decided": 10}, count(t, db))
+	})
+
+	t.Run("replace selected collections", func(t *testing.T) {
+		db := newTestDb(t)
+		populate(t, db, 5, "share-other-", "p2p-", "ATTESTER_other_")
+		summary, err := Restore(db, opener(buf.Bytes()), RestoreOptions{Collections: []string{"shares"}})
+		require.NoError(t, err)
+		require.Equal(t, Summary{"shares": 10}, summary)
+		require.Equal(t, Summary{"shares": 10, "p2p": 5, "decided": 5}, count(t, db))
+		obj, found, err := db.Get([]byte("share-"), []byte("key-1"))
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Equal(t, "share--value-1", string(obj.Value))
+	})
+
+	t.Run("collection is not part of the backup", func(t *testing.T) {
+		partial := new(bytes.Buffer)
+		_, err := Write(src, partial, []string{"operator"})
+		require.NoError(t, err)
+		db := newTestDb(t)
+		_, err = Restore(db, opener(partial.Bytes()), RestoreOptions{Collections: []string{"shares"}})
+		require.EqualError(t, err, `collection "shares" is not part of the backup`)
+	})
+
+	t.Run("corrupted backup", func(t *testing.T) {
+		db := newTestDb(t)
+		populate(t, db, 5, "share-other-")
+		_, err := Restore(db, opener(buf.Bytes()[:buf.Len()-10]), RestoreOptions{})
+		require.Error(t, err)
+		require.Equal(t, Summary{"shares": 5}, count(t, db))
+	})
+
+	t.Run("failed write", func(t *testing.T) {
+		db := newTestDb(t)
+		populate(t, db, 5, "share-other-")
+		_, err := Restore(&failingDb{IDb: db, failAfter: 15}, opener(buf.Bytes()), RestoreOptions{})
+		require.EqualError(t, err, "could not restore items: failed to set")
+		// the restore can be run again
+		summary, err := Restore(db, opener(buf.Bytes()), RestoreOptions{})
+		require.NoError(t, err)
+		require.Equal(t, Summary{"operator": 10, "shares": 10, "decided": 10}, count(t, db))
+		require.Equal(t, count(t, db), summary)
+	})
+}
+
+func TestRestore_LargeBackup(t *testing.T) {
+	// the decided history of the backup and the current one are larger than a single badger transaction
+	n := 3 * restoreBatchSize
+	value := bytes.Repeat([]byte{1}, 4096)
+	src := newTestDb(t)
+	require.NoError(t, src.SetMany([]byte("ATTESTER_"), n, func(i int) (basedb.Obj, error) {
+		return basedb.Obj{Key: []byte(fmt.Sprintf("key-%d", i)), Value: value}, nil
+	}))
+	buf := new(bytes.Buffer)
+	_, err := Write(src, buf, []string{"decided"})
+	require.NoError(t, err)
+
+	db := newTestDb(t)
+	require.NoError(t, db.SetMany([]byte("ATTESTER_other_"), n, func(i int) (basedb.Obj, error) {
+		return basedb.Obj{Key: []byte(fmt.Sprintf("key-%d", i)), Value: value}, nil
+	}))
+	// a single transaction can't hold the restored items
+	err = db.Update(func(txn basedb.Txn) error {
+		_, _, err := Read(bytes.NewReader(buf.Bytes()), func(h Header, obj basedb.Obj) error {
+			return txn.Set(nil, obj.Key, obj.Value)
+		})
+		return err
+	})
+	require.Error(t, err)
+
+	summary, err := Restore(db, opener(buf.Bytes()), RestoreOptions{})
+	require.NoError(t, err)
+	require.Equal(t, Summary{"decided": n}, summary)
+	require.Equal(t, Summary{"decided": n}, count(t, db))
+	obj, found, err := db.Get([]byte("ATTESTER_"), []byte(fmt.Sprintf("key-%d", n-1)))
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, value, obj.Value)
+}
+
+func TestRestore_Guards(t *testing.T) {
+	src := newTestDb(t)
+	require.NoError(t, src.Set([]byte("share-"), []byte("a"), []byte("2")))
+	require.NoError(t, src.Set([]byte("share-"), []byte("b"), []byte("2")))
+	buf := new(bytes.Buffer)
+	_, err := Write(src, buf, []string{"shares"})
+	require.NoError(t, err)
+	guards := []Guard{testGuard{prefix: []byte("share-")}}
+
+	t.Run("older value", func(t *testing.T) {
+		db := newTestDb(t)
+		require.NoError(t, db.Set([]byte("share-"), []byte("a"), []byte("3")))
+		require.NoError(t, db.Set([]byte("share-"), []byte("c"), []byte("1")))
+		_, err := Restore(db, opener(buf.Bytes()), RestoreOptions{Guards: guards})
+		require.EqualError(t, err, "older value for key share-a")
+		// nothing was changed
+		obj, found, err := db.Get([]byte("share-"), []byte("a"))
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Equal(t, "3", string(obj.Value))
+		require.Equal(t, Summary{"shares": 2}, count(t, db))
+	})
+
+	t.Run("missing value", func(t *testing.T) {
+		db := newTestDb(t)
+		require.NoError(t, db.Set([]byte("share-"), []byte("c"), []byte("1")))
+		_, err := Restore(db, opener(buf.Bytes()), RestoreOptions{Guards: guards})
+		require.EqualError(t, err, "older value for key share-c")
+	})
+
+	t.Run("newer value", func(t *testing.T) {
+		db := newTestDb(t)
+		require.NoError(t, db.Set([]byte("share-"), []byte("a"), []byte("1")))
+		summary, err := Restore(db, opener(buf.Bytes()), RestoreOptions{Guards: guards})
+		require.NoError(t, err)
+		require.Equal(t, Summary{"shares": 2}, summary)
+	})
+}
+
+func TestHandler(t *testing.T) {
+	db := newTestDb(t)
+	populate(t, db, 10, "share-", "ATTESTER_")
+	server := httptest.NewServer(Handler(zap.L(), db))
+	defer server.Close()
+
+	res, err := http.Get(server.URL + "?exclude=decided")
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	header, summary, err := Verify(res.Body)
+	require.NoError(t, err)
+	require.NotContains(t, header.Collections, "decided")
+	require.Equal(t, Summary{"shares": 10}, summary)
+
+	res, err = http.Get(server.URL + "?collections=xxx")
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusBadRequest, res.StatusCode)
+}
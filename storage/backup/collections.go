@@ -0,0 +1,97 @@
+package backup
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/bloxapp/ssv/protocol/v1/message"
+)
+
+// Collection is a named set of keys in the node db that can be selected in backup and restore
+type Collection struct {
+	Name        string
+	Description string
+	match       func(key []byte) bool
+}
+
+// OtherCollection holds the keys that don't belong to any known collection
+const OtherCollection = "other"
+
+// Collections are the known collections of the node db, keys are matched in this order
+var Collections = []Collection{
+	{Name: "operator", Description: "operator key, registry sync offset and operators", match: hasPrefix("operator-")},
+	{Name: "shares", Description: "validators shares", match: hasPrefix("share-")},
+	{Name: "ekm", Description: "key manager wallet, accounts and slashing protection data", match: contains("signer_data-")},
+	{Name: "decided", Description: "decided history and qbft instances state", match: hasPrefix(message.RoleTypeAttester.String(), "attestations")},
+	{Name: "p2p", Description: "network key", match: hasPrefix("p2p-")},
+	{Name: "migrations", Description: "completed migrations", match: hasPrefix("migrations/")},
+	{Name: OtherCollection, Description: "keys that don't belong to any other collection", match: func([]byte) bool { return true }},
+}
+
+// CollectionOf returns the name of the collection of the given key
+func CollectionOf(key []byte) string {
+	for _, c := range Collections {
+		if c.match(key) {
+			return c.Name
+		}
+	}
+	return OtherCollection
+}
+
+// SelectCollections returns the names of the selected collections,
+// all collections are selected if include is empty. returns an error for unknown names
+func SelectCollections(include, exclude []string) ([]string, error) {
+	known := make(map[string]bool, len(Collections))
+	for _, c := range Collections {
+		known[c.Name] = true
+	}
+	excluded := make(map[string]bool, len(exclude))
+	for _, name := range append(include, exclude...) {
+		if !known[name] {
+			return nil, errors.Errorf("unknown collection %q, known collections are: %s", name, strings.Join(collectionNames(), ", "))
+		}
+	}
+	for _, name := range exclude {
+		excluded[name] = true
+	}
+	if len(include) == 0 {
+		include = collectionNames()
+	}
+	var selected []string
+	for _, name := range include {
+		if !excluded[name] {
+			selected = append(selected, name)
+		}
+	}
+	if len(selected) == 0 {
+		return nil, errors.New("no collection was selected")
+	}
+	return selected, nil
+}
+
+func collectionNames() []string {
+	names := make([]string, 0, len(Collections))
+	for _, c := range Collections {
+		names = append(names, c.Name)
+	}
+	return names
+}
+
+func hasPrefix(prefixes ...string) func([]byte) bool {
+	return func(key []byte) bool {
+		for _, p := range prefixes {
+			if bytes.HasPrefix(key, []byte(p)) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func contains(sub string) func([]byte) bool {
+	return func(key []byte) bool {
+		return bytes.Contains(key, []byte(sub))
+	}
+}
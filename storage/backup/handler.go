@@ -0,0 +1,53 @@
+package backup
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+// Handler returns an http handler that streams a backup of the given db,
+// collections are selected by the optional "collections" and "exclude" (comma separated) query params
+func Handler(logger *zap.Logger, db basedb.IDb) http.HandlerFunc {
+	logger = logger.With(zap.String("who", "BackupHandler"))
+	return func(res http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			http.Error(res, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		query := req.URL.Query()
+		collections, err := SelectCollections(ParseList(query.Get("collections")), ParseList(query.Get("exclude")))
+		if err != nil {
+			http.Error(res, err.Error(), http.StatusBadRequest)
+			return
+		}
+		res.Header().Set("Content-Type", "application/gzip")
+		res.Header().Set("Content-Disposition",
+			fmt.Sprintf("attachment; filename=ssv-backup-%s.gz", time.Now().UTC().Format("20060102T150405Z")))
+		start := time.Now()
+		summary, err := Write(db, res, collections)
+		if err != nil {
+			// the response was already started, the client is expected to fail on verification
+			logger.Error("could not write backup", zap.Error(err))
+			return
+		}
+		logger.Info("db backup was written", zap.Strings("collections", collections),
+			zap.Int("items", summary.Total()), zap.Duration("took", time.Since(start)))
+	}
+}
+
+// ParseList parses a comma separated list of collections
+func ParseList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); len(item) > 0 {
+			items = append(items, item)
+		}
+	}
+	return items
+}
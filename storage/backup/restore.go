@@ -0,0 +1,211 @@
+package backup
+
+import (
+	"io"
+
+	"github.com/pkg/errors"
+
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+// restoreBatchSize is the max amount of items that are deleted or written at once
+const restoreBatchSize = 1000
+
+// Guard checks whether the restored value of a key may replace the current one, e.g. slashing protection data
+type Guard interface {
+	// Applies returns whether the given key is checked by the guard
+	Applies(key []byte) bool
+	// Check returns an error if the restored value must not replace the current value,
+	// current is nil if the key doesn't exist in the db and restored is nil if the key is missing in the backup
+	Check(key, current, restored []byte) error
+}
+
+// RestoreOptions are the options of Restore
+type RestoreOptions struct {
+	// Collections to restore, all the collections of the backup are restored if empty
+	Collections []string
+	Guards      []Guard
+}
+
+// Restore replaces the selected collections in the db with the content of the given backup.
+// open is called once in order to verify the backup and check the guards, and once again to restore it,
+// nothing is changed in the db unless the backup is valid and all guards passed.
+// the items are deleted and written in batches of restoreBatchSize, so a restore that failed while writing
+// leaves the selected collections partially restored and should be run again.
+// the node must be stopped while restoring
+func Restore(db basedb.IDb, open func() (io.ReadCloser, error), opts RestoreOptions) (Summary, error) {
+	current, err := guardedItems(db, opts.Guards)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read guarded items")
+	}
+	var selected map[string]bool
+	var guardErr error
+	header, _, err := readBackup(open, func(header Header, obj basedb.Obj) error {
+		if selected == nil {
+			selected, guardErr = selectedCollections(header, opts.Collections)
+		}
+		if guardErr != nil || !selected[CollectionOf(obj.Key)] {
+			return nil
+		}
+		guardErr = checkGuards(opts.Guards, obj.Key, current[string(obj.Key)], obj.Value)
+		delete(current, string(obj.Key))
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not verify backup")
+	}
+	if selected == nil {
+		// empty backup
+		selected, guardErr = selectedCollections(header, opts.Collections)
+	}
+	if guardErr != nil {
+		return nil, guardErr
+	}
+	// current items that are missing in the backup are going to be deleted
+	for k, v := range current {
+		if !selected[CollectionOf([]byte(k))] {
+			continue
+		}
+		if err := checkGuards(opts.Guards, []byte(k), v, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := deleteCollections(db, selected); err != nil {
+		return nil, errors.Wrap(err, "could not delete current items")
+	}
+
+	summary := make(Summary)
+	var batch []basedb.Obj
+	flush := func() error {
+		err := setBatch(db, batch)
+		batch = batch[:0]
+		return err
+	}
+	_, _, err = readBackup(open, func(header Header, obj basedb.Obj) error {
+		c := CollectionOf(obj.Key)
+		if !selected[c] {
+			return nil
+		}
+		summary[c]++
+		batch = append(batch, obj)
+		if len(batch) < restoreBatchSize {
+			return nil
+		}
+		return flush()
+	})
+	if err != nil {
+		return summary, errors.Wrap(err, "could not restore items")
+	}
+	if len(batch) > 0 {
+		if err := flush(); err != nil {
+			return summary, errors.Wrap(err, "could not restore items")
+		}
+	}
+	return summary, nil
+}
+
+func readBackup(open func() (io.ReadCloser, error), handler func(Header, basedb.Obj) error) (Header, Summary, error) {
+	r, err := open()
+	if err != nil {
+		return Header{}, nil, err
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+	return Read(r, handler)
+}
+
+// selectedCollections returns the collections to restore, which must be part of the backup
+func selectedCollections(header Header, collections []string) (map[string]bool, error) {
+	inBackup := make(map[string]bool, len(header.Collections))
+	for _, c := range header.Collections {
+		inBackup[c] = true
+	}
+	if len(collections) == 0 {
+		return inBackup, nil
+	}
+	selected := make(map[string]bool, len(collections))
+	for _, c := range collections {
+		if !inBackup[c] {
+			return nil, errors.Errorf("collection %q is not part of the backup", c)
+		}
+		selected[c] = true
+	}
+	return selected, nil
+}
+
+// guardedItems returns the current items that are checked by the given guards
+func guardedItems(db basedb.IDb, guards []Guard) (map[string][]byte, error) {
+	items := make(map[string][]byte)
+	if len(guards) == 0 {
+		return items, nil
+	}
+	err := db.Iterate([]byte{}, basedb.RangeOptions{}, func(obj basedb.Obj) error {
+		for _, g := range guards {
+			if g.Applies(obj.Key) {
+				items[string(obj.Key)] = obj.Value
+				break
+			}
+		}
+		return nil
+	})
+	return items, err
+}
+
+func checkGuards(guards []Guard, key, current, restored []byte) error {
+	for _, g := range guards {
+		if !g.Applies(key) {
+			continue
+		}
+		if err := g.Check(key, current, restored); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteCollections deletes all the items of the given collections, in batches of restoreBatchSize
+func deleteCollections(db basedb.IDb, collections map[string]bool) error {
+	var keys [][]byte
+	err := db.Iterate([]byte{}, basedb.RangeOptions{KeysOnly: true}, func(obj basedb.Obj) error {
+		if collections[CollectionOf(obj.Key)] {
+			keys = append(keys, obj.Key)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for len(keys) > 0 {
+		n := restoreBatchSize
+		if len(keys) < n {
+			n = len(keys)
+		}
+		err := db.Update(func(txn basedb.Txn) error {
+			for _, k := range keys[:n] {
+				if err := txn.Delete(nil, k); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		keys = keys[n:]
+	}
+	return nil
+}
+
+// setBatch saves the given items in a single transaction
+func setBatch(db basedb.IDb, batch []basedb.Obj) error {
+	return db.Update(func(txn basedb.Txn) error {
+		for _, obj := range batch {
+			if err := txn.Set(nil, obj.Key, obj.Value); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
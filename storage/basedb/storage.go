@@ -2,17 +2,44 @@ package basedb
 
 import (
 	"context"
+	"time"
 
 	"go.uber.org/zap"
 )
 
 // Options for creating all db type
 type Options struct {
-	Type      string `yaml:"Type" env:"DB_TYPE" env-default:"badger-db" env-description:"Type of db badger-db or badger-memory"`
+	Type      string `yaml:"Type" env:"DB_TYPE" env-default:"badger-db" env-description:"Type of db badger-db, badger-memory or bbolt-db"`
 	Path      string `yaml:"Path" env:"DB_PATH" env-default:"./data/db" env-description:"Path for storage"`
 	Reporting bool   `yaml:"Reporting" env:"DB_REPORTING" env-default:"false" env-description:"Flag to run on-off db size reporting"`
-	Logger    *zap.Logger
-	Ctx       context.Context
+	// maintenance
+	GCInterval         time.Duration `yaml:"GCInterval" env:"DB_GC_INTERVAL" env-default:"10m" env-description:"Interval of value log garbage collection, zero disables it"`
+	GCDiscardRatio     float64       `yaml:"GCDiscardRatio" env:"DB_GC_DISCARD_RATIO" env-default:"0.5" env-description:"Min ratio of stale data in a value log file for it to be rewritten by garbage collection"`
+	ValueLogFileSize   int64         `yaml:"ValueLogFileSize" env:"DB_VALUE_LOG_FILE_SIZE" env-default:"104857600" env-description:"Max size of a value log file in bytes"`
+	DiskUsageThreshold float64       `yaml:"DiskUsageThreshold" env:"DB_DISK_USAGE_THRESHOLD" env-default:"0.95" env-description:"Ratio of used disk space above which the node is reported as unhealthy, zero disables the check"`
+	Logger             *zap.Logger
+	Ctx                context.Context
+}
+
+// Compactor is implemented by dbs that can reclaim unused disk space on demand,
+// it should not be used while the db is in use by other components
+type Compactor interface {
+	Compact() error
+}
+
+// RangeOptions bounds and orders the iteration over the items of a prefix,
+// keys are relative to the prefix and are compared lexicographically
+type RangeOptions struct {
+	// From is the (inclusive) lower bound, iteration starts from the first item if empty
+	From []byte
+	// To is the (exclusive) upper bound, iteration ends with the last item if empty
+	To []byte
+	// Reverse iterates from the upper bound down to the lower bound
+	Reverse bool
+	// Limit is the max number of items to iterate, zero means no limit
+	Limit int
+	// KeysOnly skips reading the values, the returned objects contain only keys
+	KeysOnly bool
 }
 
 // Txn interface for badger transaction like functions
@@ -20,7 +47,7 @@ type Txn interface {
 	Set(prefix []byte, key []byte, value []byte) error
 	Get(prefix []byte, key []byte) (Obj, bool, error)
 	Delete(prefix []byte, key []byte) error
-	// TODO: add iterator
+	Iterate(prefix []byte, opts RangeOptions, handler func(Obj) error) error
 }
 
 // IDb interface for all db kind
@@ -31,6 +58,7 @@ type IDb interface {
 	GetMany(prefix []byte, keys [][]byte, iterator func(Obj) error) error
 	Delete(prefix []byte, key []byte) error
 	GetAll(prefix []byte, handler func(int, Obj) error) error
+	Iterate(prefix []byte, opts RangeOptions, handler func(Obj) error) error
 	CountByCollection(prefix []byte) (int64, error)
 	RemoveAllByCollection(prefix []byte) error
 	Update(fn func(Txn) error) error
@@ -0,0 +1,49 @@
+package storage
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+// copyBatchSize is the number of items that are written to the target db in a single transaction
+const copyBatchSize = 10000
+
+// Copy copies all the items of the source db into the (empty) target db, returns the number of copied items
+func Copy(src, dst basedb.IDb) (int, error) {
+	n, err := dst.CountByCollection(nil)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not count target items")
+	}
+	if n > 0 {
+		return 0, errors.New("target db is not empty")
+	}
+
+	count := 0
+	batch := make([]basedb.Obj, 0, copyBatchSize)
+	flush := func() error {
+		err := dst.SetMany(nil, len(batch), func(i int) (basedb.Obj, error) {
+			return batch[i], nil
+		})
+		if err != nil {
+			return errors.Wrap(err, "could not write items")
+		}
+		count += len(batch)
+		batch = batch[:0]
+		return nil
+	}
+	err = src.GetAll(nil, func(i int, obj basedb.Obj) error {
+		batch = append(batch, obj)
+		if len(batch) < copyBatchSize {
+			return nil
+		}
+		return flush()
+	})
+	if err != nil {
+		return count, err
+	}
+	if len(batch) > 0 {
+		return count, flush()
+	}
+	return count, nil
+}
@@ -0,0 +1,50 @@
+package storage
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+func TestCopy(t *testing.T) {
+	src, err := GetStorageFactory(basedb.Options{
+		Type:   "badger-memory",
+		Logger: zap.L(),
+		Path:   "",
+	})
+	require.NoError(t, err)
+	defer src.Close()
+	dst, err := GetStorageFactory(basedb.Options{
+		Type:   "bbolt-db",
+		Logger: zap.L(),
+		Path:   t.TempDir(),
+	})
+	require.NoError(t, err)
+	defer dst.Close()
+
+	n := copyBatchSize + 10
+	require.NoError(t, src.SetMany([]byte("prefix1/"), n, func(i int) (basedb.Obj, error) {
+		return basedb.Obj{Key: []byte(fmt.Sprintf("key-%d", i)), Value: []byte(fmt.Sprintf("value-%d", i))}, nil
+	}))
+	require.NoError(t, src.Set([]byte("prefix2/"), []byte("key"), []byte("value")))
+
+	copied, err := Copy(src, dst)
+	require.NoError(t, err)
+	require.Equal(t, n+1, copied)
+
+	count, err := dst.CountByCollection([]byte("prefix1/"))
+	require.NoError(t, err)
+	require.Equal(t, int64(n), count)
+	obj, found, err := dst.Get([]byte("prefix2/"), []byte("key"))
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, []byte("value"), obj.Value)
+
+	// target db must be empty
+	_, err = Copy(src, dst)
+	require.EqualError(t, err, "target db is not empty")
+}
@@ -2,6 +2,7 @@ package kv
 
 import (
 	"bytes"
+	"runtime"
 	"time"
 
 	"github.com/bloxapp/ssv/storage/basedb"
@@ -14,12 +15,20 @@ import (
 const (
 	// EntryNotFoundError is an error for a storage entry not found
 	EntryNotFoundError = "EntryNotFoundError"
+	// defaultValueLogFileSize is used when the value log file size is not configured
+	defaultValueLogFileSize = 1024 * 1024 * 100
+	// defaultGCDiscardRatio is used when the configured discard ratio is not within (0, 1)
+	defaultGCDiscardRatio = 0.5
 )
 
 // BadgerDb struct
 type BadgerDb struct {
 	db     *badger.DB
 	logger *zap.Logger
+
+	path               string
+	gcDiscardRatio     float64
+	diskUsageThreshold float64
 }
 
 // New create new instance of Badger db
@@ -38,20 +47,33 @@ func New(options basedb.Options) (basedb.IDb, error) {
 		opt.Logger = newLogger(options.Logger)
 	}
 
-	opt.ValueLogFileSize = 1024 * 1024 * 100 // TODO:need to set the vlog proper (max) size
+	opt.ValueLogFileSize = options.ValueLogFileSize
+	if opt.ValueLogFileSize <= 0 {
+		opt.ValueLogFileSize = defaultValueLogFileSize
+	}
 
 	db, err := badger.Open(opt)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to open badger")
 	}
+	gcDiscardRatio := options.GCDiscardRatio
+	if gcDiscardRatio <= 0 || gcDiscardRatio >= 1 {
+		gcDiscardRatio = defaultGCDiscardRatio
+	}
 	_db := BadgerDb{
-		db:     db,
-		logger: options.Logger,
+		db:                 db,
+		logger:             options.Logger,
+		path:               options.Path,
+		gcDiscardRatio:     gcDiscardRatio,
+		diskUsageThreshold: options.DiskUsageThreshold,
 	}
 
 	if options.Reporting && options.Ctx != nil {
 		async.RunEvery(options.Ctx, 1*time.Minute, _db.report)
 	}
+	if !opt.InMemory && options.GCInterval > 0 && options.Ctx != nil {
+		async.RunEvery(options.Ctx, options.GCInterval, _db.maintain)
+	}
 
 	options.Logger.Info("Badger db initialized")
 	return &_db, nil
@@ -163,6 +185,14 @@ func (b *BadgerDb) GetAll(prefix []byte, handler func(int, basedb.Obj) error) er
 	return err
 }
 
+// Iterate iterates over the items of the given prefix in key order, within the bounds of the given options.
+// the keys of the returned objects are trimmed from the prefix
+func (b *BadgerDb) Iterate(prefix []byte, opts basedb.RangeOptions, handler func(basedb.Obj) error) error {
+	return b.db.View(func(txn *badger.Txn) error {
+		return badgerTxn{txn}.Iterate(prefix, opts, handler)
+	})
+}
+
 // CountByCollection return the object count for all keys under specified prefix(bucket)
 func (b *BadgerDb) CountByCollection(prefix []byte) (int64, error) {
 	var res int64
@@ -191,16 +221,67 @@ func (b *BadgerDb) Close() {
 	}
 }
 
-// report the db size and metrics
+// report the db size and cache metrics
 func (b *BadgerDb) report() {
-	logger := b.logger.With(zap.String("who", "BadgerDBReporting"))
 	lsm, vlog := b.db.Size()
-	blockCache := b.db.BlockCacheMetrics()
-	indexCache := b.db.IndexCacheMetrics()
+	metricsDBSize.WithLabelValues("lsm").Set(float64(lsm))
+	metricsDBSize.WithLabelValues("vlog").Set(float64(vlog))
+	metricsDBCacheHitRatio.WithLabelValues("block").Set(b.db.BlockCacheMetrics().Ratio())
+	metricsDBCacheHitRatio.WithLabelValues("index").Set(b.db.IndexCacheMetrics().Ratio())
+}
 
-	logger.Debug("BadgerDBReport", zap.Int64("lsm", lsm), zap.Int64("vlog", vlog),
-		zap.String("BlockCacheMetrics", blockCache.String()),
-		zap.String("IndexCacheMetrics", indexCache.String()))
+// maintain runs value log garbage collection and reports the db metrics
+func (b *BadgerDb) maintain() {
+	n, err := b.runValueLogGC()
+	if err != nil {
+		b.logger.Warn("failed to run value log gc", zap.Error(err))
+	} else if n > 0 {
+		b.logger.Debug("value log gc rewrote files", zap.Int("count", n))
+	}
+	b.report()
+}
+
+// runValueLogGC rewrites value log files until there is nothing left to rewrite,
+// returns the amount of files that were rewritten
+func (b *BadgerDb) runValueLogGC() (int, error) {
+	n := 0
+	if b.db.Opts().InMemory {
+		return n, nil
+	}
+	for {
+		err := b.db.RunValueLogGC(b.gcDiscardRatio)
+		switch err {
+		case nil:
+			n++
+			metricsDBValueLogGC.Inc()
+		case badger.ErrNoRewrite, badger.ErrRejected:
+			return n, nil
+		default:
+			return n, err
+		}
+	}
+}
+
+// Compact flattens the LSM tree and reclaims value log space
+func (b *BadgerDb) Compact() error {
+	if err := b.db.Flatten(runtime.NumCPU()); err != nil {
+		return errors.Wrap(err, "could not flatten lsm tree")
+	}
+	n, err := b.runValueLogGC()
+	if err != nil {
+		return errors.Wrap(err, "could not run value log gc")
+	}
+	b.logger.Debug("db was compacted", zap.Int("rewrittenValueLogs", n))
+	b.report()
+	return nil
+}
+
+// HealthCheck returns a list of issues regards the disk of the db
+func (b *BadgerDb) HealthCheck() []string {
+	if len(b.path) == 0 || b.db.Opts().InMemory {
+		return nil
+	}
+	return checkDiskUsage(b.path, b.diskUsageThreshold)
 }
 
 func (b *BadgerDb) listRawKeys(prefix []byte, txn *badger.Txn) [][]byte {
@@ -260,3 +341,83 @@ func (t badgerTxn) Get(prefix []byte, key []byte) (obj basedb.Obj, found bool, e
 func (t badgerTxn) Delete(prefix []byte, key []byte) error {
 	return t.txn.Delete(append(prefix, key...))
 }
+
+func (t badgerTxn) Iterate(prefix []byte, opts basedb.RangeOptions, handler func(basedb.Obj) error) error {
+	lower := append(append([]byte{}, prefix...), opts.From...)
+	var upper []byte
+	if len(opts.To) > 0 {
+		upper = append(append([]byte{}, prefix...), opts.To...)
+	}
+
+	itOpts := badger.DefaultIteratorOptions
+	itOpts.PrefetchValues = !opts.KeysOnly
+	itOpts.Reverse = opts.Reverse
+	if !opts.Reverse {
+		// in reverse mode the seek key might not have the prefix, therefore the prefix is checked manually
+		itOpts.Prefix = prefix
+	}
+	it := t.txn.NewIterator(itOpts)
+	defer it.Close()
+
+	if opts.Reverse {
+		// seeking in reverse lands on the largest key that is smaller or equal to the seek key
+		seekKey := upper
+		if seekKey == nil {
+			seekKey = prefixUpperBound(prefix)
+		}
+		if seekKey == nil {
+			it.Rewind()
+		} else {
+			it.Seek(seekKey)
+		}
+	} else {
+		it.Seek(lower)
+	}
+
+	count := 0
+	for ; it.Valid(); it.Next() {
+		if opts.Limit > 0 && count >= opts.Limit {
+			break
+		}
+		item := it.Item()
+		k := item.Key()
+		if opts.Reverse {
+			if upper != nil && bytes.Compare(k, upper) >= 0 {
+				continue
+			}
+			if !bytes.HasPrefix(k, prefix) || bytes.Compare(k, lower) < 0 {
+				break
+			}
+		} else if upper != nil && bytes.Compare(k, upper) >= 0 {
+			break
+		}
+		obj := basedb.Obj{
+			Key: item.KeyCopy(nil)[len(prefix):],
+		}
+		if !opts.KeysOnly {
+			val, err := item.ValueCopy(nil)
+			if err != nil {
+				return errors.Wrap(err, "failed to copy value")
+			}
+			obj.Value = val
+		}
+		if err := handler(obj); err != nil {
+			return err
+		}
+		count++
+	}
+	return nil
+}
+
+// prefixUpperBound returns the smallest key that is greater than all the keys with the given prefix,
+// or nil if there is no such key (the prefix is empty or contains only 0xff bytes)
+func prefixUpperBound(prefix []byte) []byte {
+	bound := append([]byte{}, prefix...)
+	for i := len(bound) - 1; i >= 0; i-- {
+		if bound[i] < 0xff {
+			bound[i]++
+			return bound[:i+1]
+		}
+	}
+	return nil
+}
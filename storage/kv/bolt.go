@@ -0,0 +1,356 @@
+package kv
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/async"
+	bolt "go.etcd.io/bbolt"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+const (
+	// BoltDbType is the db type of bbolt storage
+	BoltDbType = "bbolt-db"
+	// boltFileName is the name of the db file, placed in the db path
+	boltFileName = "ssv.db"
+	// boltCompactTxSize is the max size of a transaction while compacting the db
+	boltCompactTxSize = 64 * 1024 * 1024
+)
+
+// boltBucket is the single bucket of the db,
+// prefixes are part of the keys in order to support arbitrary prefixes as in badger
+var boltBucket = []byte("ssv")
+
+// BoltDb struct
+type BoltDb struct {
+	db     *bolt.DB
+	logger *zap.Logger
+
+	path               string
+	diskUsageThreshold float64
+}
+
+// NewBoltDb creates a new instance of bbolt db, the db file is located in the given path (directory)
+func NewBoltDb(options basedb.Options) (basedb.IDb, error) {
+	if err := os.MkdirAll(options.Path, 0700); err != nil {
+		return nil, errors.Wrap(err, "failed to create db directory")
+	}
+	db, err := openBolt(filepath.Join(options.Path, boltFileName))
+	if err != nil {
+		return nil, err
+	}
+	_db := BoltDb{
+		db:                 db,
+		logger:             options.Logger,
+		path:               options.Path,
+		diskUsageThreshold: options.DiskUsageThreshold,
+	}
+
+	if options.Reporting && options.Ctx != nil {
+		async.RunEvery(options.Ctx, 1*time.Minute, _db.report)
+	}
+
+	options.Logger.Info("Bolt db initialized")
+	return &_db, nil
+}
+
+func openBolt(file string) (*bolt.DB, error) {
+	db, err := bolt.Open(file, 0600, &bolt.Options{
+		Timeout: 1 * time.Second,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open bbolt")
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(boltBucket)
+		return err
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, errors.Wrap(err, "failed to create bucket")
+	}
+	return db, nil
+}
+
+// Set save value with key to storage
+func (b *BoltDb) Set(prefix []byte, key []byte, value []byte) error {
+	return b.Update(func(txn basedb.Txn) error {
+		return txn.Set(prefix, key, value)
+	})
+}
+
+// SetMany save many values with the given keys in a single transaction
+func (b *BoltDb) SetMany(prefix []byte, n int, next func(int) (basedb.Obj, error)) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(boltBucket)
+		for i := 0; i < n; i++ {
+			item, err := next(i)
+			if err != nil {
+				return err
+			}
+			if err := bucket.Put(boltKey(prefix, item.Key), item.Value); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Get return value for specified key
+func (b *BoltDb) Get(prefix []byte, key []byte) (obj basedb.Obj, found bool, err error) {
+	err = b.db.View(func(tx *bolt.Tx) error {
+		obj, found, err = boltTxn{tx}.Get(prefix, key)
+		return err
+	})
+	return obj, found, err
+}
+
+// GetMany return values for the given keys
+func (b *BoltDb) GetMany(prefix []byte, keys [][]byte, iterator func(basedb.Obj) error) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	return b.db.View(func(tx *bolt.Tx) error {
+		txn := boltTxn{tx}
+		for _, k := range keys {
+			obj, found, err := txn.Get(prefix, k)
+			if err != nil {
+				return err
+			}
+			if !found {
+				b.logger.Debug("item not found", zap.String("key", string(k)))
+				continue
+			}
+			if err := iterator(obj); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Delete key in specific prefix
+func (b *BoltDb) Delete(prefix []byte, key []byte) error {
+	return b.Update(func(txn basedb.Txn) error {
+		return txn.Delete(prefix, key)
+	})
+}
+
+// GetAll returns all the items of a given collection
+func (b *BoltDb) GetAll(prefix []byte, handler func(int, basedb.Obj) error) error {
+	i := 0
+	return b.Iterate(prefix, basedb.RangeOptions{}, func(obj basedb.Obj) error {
+		err := handler(i, obj)
+		i++
+		return err
+	})
+}
+
+// Iterate iterates over the items of the given prefix in key order, within the bounds of the given options.
+// the keys of the returned objects are trimmed from the prefix
+func (b *BoltDb) Iterate(prefix []byte, opts basedb.RangeOptions, handler func(basedb.Obj) error) error {
+	return b.db.View(func(tx *bolt.Tx) error {
+		return boltTxn{tx}.Iterate(prefix, opts, handler)
+	})
+}
+
+// CountByCollection return the object count for all keys under specified prefix(bucket)
+func (b *BoltDb) CountByCollection(prefix []byte) (int64, error) {
+	var res int64
+	err := b.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(boltBucket).Cursor()
+		for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+			res++
+		}
+		return nil
+	})
+	return res, err
+}
+
+// RemoveAllByCollection cleans all items in a collection
+func (b *BoltDb) RemoveAllByCollection(prefix []byte) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(boltBucket)
+		c := bucket.Cursor()
+		// seeking again after every delete, as deleting while moving the cursor might skip items
+		for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Seek(prefix) {
+			if err := bucket.Delete(k); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Update is a gateway to bbolt db Update function
+// creating and managing a read-write transaction
+func (b *BoltDb) Update(fn func(basedb.Txn) error) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		return fn(boltTxn{tx})
+	})
+}
+
+// Close close db
+func (b *BoltDb) Close() {
+	if err := b.db.Close(); err != nil {
+		b.logger.Fatal("failed to close db", zap.Error(err))
+	}
+}
+
+// report the db size
+func (b *BoltDb) report() {
+	err := b.db.View(func(tx *bolt.Tx) error {
+		metricsDBSize.WithLabelValues("file").Set(float64(tx.Size()))
+		return nil
+	})
+	if err != nil {
+		b.logger.Warn("could not get db size", zap.Error(err))
+	}
+}
+
+// Compact rewrites the db file without its free pages, the db is reopened once done
+func (b *BoltDb) Compact() error {
+	file := b.db.Path()
+	tmpFile := file + ".compact"
+	dst, err := bolt.Open(tmpFile, 0600, &bolt.Options{Timeout: 1 * time.Second})
+	if err != nil {
+		return errors.Wrap(err, "failed to open compacted db")
+	}
+	if err := bolt.Compact(dst, b.db, boltCompactTxSize); err != nil {
+		_ = dst.Close()
+		_ = os.Remove(tmpFile)
+		return errors.Wrap(err, "failed to compact db")
+	}
+	if err := dst.Close(); err != nil {
+		return errors.Wrap(err, "failed to close compacted db")
+	}
+	if err := b.db.Close(); err != nil {
+		return errors.Wrap(err, "failed to close db")
+	}
+	renameErr := os.Rename(tmpFile, file)
+	// reopening the original file in case it was not replaced
+	db, err := openBolt(file)
+	if err != nil {
+		return err
+	}
+	b.db = db
+	if renameErr != nil {
+		return errors.Wrap(renameErr, "failed to replace db file")
+	}
+	b.report()
+	return nil
+}
+
+// HealthCheck returns a list of issues regards the disk of the db
+func (b *BoltDb) HealthCheck() []string {
+	return checkDiskUsage(b.path, b.diskUsageThreshold)
+}
+
+func boltKey(prefix []byte, key []byte) []byte {
+	k := make([]byte, 0, len(prefix)+len(key))
+	k = append(k, prefix...)
+	return append(k, key...)
+}
+
+type boltTxn struct {
+	tx *bolt.Tx
+}
+
+func (t boltTxn) Set(prefix []byte, key []byte, value []byte) error {
+	return t.tx.Bucket(boltBucket).Put(boltKey(prefix, key), value)
+}
+
+func (t boltTxn) Get(prefix []byte, key []byte) (basedb.Obj, bool, error) {
+	value := t.tx.Bucket(boltBucket).Get(boltKey(prefix, key))
+	if value == nil {
+		return basedb.Obj{}, false, nil
+	}
+	// values are valid only during the transaction
+	return basedb.Obj{
+		Key:   key,
+		Value: append([]byte{}, value...),
+	}, true, nil
+}
+
+func (t boltTxn) Delete(prefix []byte, key []byte) error {
+	return t.tx.Bucket(boltBucket).Delete(boltKey(prefix, key))
+}
+
+func (t boltTxn) Iterate(prefix []byte, opts basedb.RangeOptions, handler func(basedb.Obj) error) error {
+	lower := boltKey(prefix, opts.From)
+	var upper []byte
+	if len(opts.To) > 0 {
+		upper = boltKey(prefix, opts.To)
+	}
+
+	// the handler might change the bucket in writable transactions,
+	// which is not safe while moving the cursor, therefore the items are collected first
+	var collected []basedb.Obj
+	emit := handler
+	if t.tx.Writable() {
+		emit = func(obj basedb.Obj) error {
+			collected = append(collected, obj)
+			return nil
+		}
+	}
+
+	c := t.tx.Bucket(boltBucket).Cursor()
+	var k, v []byte
+	next := c.Next
+	if opts.Reverse {
+		next = c.Prev
+		if upper != nil {
+			k, v = c.Seek(upper)
+		} else if bound := prefixUpperBound(prefix); bound != nil {
+			k, v = c.Seek(bound)
+		}
+		// seek lands on the first key that is greater or equal to the seek key
+		if k == nil {
+			k, v = c.Last()
+		} else {
+			k, v = c.Prev()
+		}
+	} else {
+		k, v = c.Seek(lower)
+	}
+
+	count := 0
+	for ; k != nil; k, v = next() {
+		if opts.Limit > 0 && count >= opts.Limit {
+			break
+		}
+		if !bytes.HasPrefix(k, prefix) {
+			break
+		}
+		if opts.Reverse {
+			if bytes.Compare(k, lower) < 0 {
+				break
+			}
+		} else if upper != nil && bytes.Compare(k, upper) >= 0 {
+			break
+		}
+		obj := basedb.Obj{
+			Key: append([]byte{}, k[len(prefix):]...),
+		}
+		if !opts.KeysOnly {
+			obj.Value = append([]byte{}, v...)
+		}
+		if err := emit(obj); err != nil {
+			return err
+		}
+		count++
+	}
+
+	for _, obj := range collected {
+		if err := handler(obj); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,478 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/bloxapp/ssv/monitoring/metrics"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
+	"testing"
+	"time"
+)
+
+// dbConstructor creates a db of some backend with the given options
+type dbConstructor func(t *testing.T, options basedb.Options) (basedb.IDb, error)
+
+// reporter is implemented by all backends
+type reporter interface {
+	report()
+}
+
+// backends are the implementations of basedb.IDb, the tests in this file are a conformance suite that runs against each of them
+var backends = []struct {
+	name  string
+	newDb dbConstructor
+}{
+	{"badger", func(t *testing.T, options basedb.Options) (basedb.IDb, error) {
+		return New(options)
+	}},
+	{"bbolt", func(t *testing.T, options basedb.Options) (basedb.IDb, error) {
+		options.Path = t.TempDir()
+		db, err := NewBoltDb(options)
+		if err != nil {
+			return nil, err
+		}
+		// syncing the file on every commit slows down the tests that populate many items one by one
+		db.(*BoltDb).db.NoSync = true
+		return db, nil
+	}},
+}
+
+func forEachBackend(t *testing.T, test func(t *testing.T, newDb dbConstructor)) {
+	for _, b := range backends {
+		b := b
+		t.Run(b.name, func(t *testing.T) {
+			test(t, b.newDb)
+		})
+	}
+}
+
+func TestDbEndToEnd(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, newDb dbConstructor) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		options := basedb.Options{
+			Type:      "badger-memory",
+			Logger:    zap.L(),
+			Path:      "",
+			Reporting: true,
+			Ctx:       ctx,
+		}
+
+		db, err := newDb(t, options)
+		require.NoError(t, err)
+
+		toSave := []struct {
+			prefix []byte
+			key    []byte
+			value  []byte
+		}{
+			{
+				[]byte("prefix1"),
+				[]byte("key1"),
+				[]byte("value"),
+			},
+			{
+				[]byte("prefix1"),
+				[]byte("key2"),
+				[]byte("value"),
+			},
+			{
+				[]byte("prefix2"),
+				[]byte("key1"),
+				[]byte("value"),
+			},
+		}
+
+		for _, save := range toSave {
+			require.NoError(t, db.Set(save.prefix, save.key, save.value))
+		}
+
+		obj, found, err := db.Get(toSave[0].prefix, toSave[0].key)
+		require.True(t, found)
+		require.NoError(t, err)
+		require.EqualValues(t, toSave[0].key, obj.Key)
+		require.EqualValues(t, toSave[0].value, obj.Value)
+
+		count := 0
+		err = db.GetAll(toSave[0].prefix, func(i int, obj basedb.Obj) error {
+			count++
+			return nil
+		})
+		require.NoError(t, err)
+		require.EqualValues(t, 2, count)
+
+		obj, found, err = db.Get(toSave[2].prefix, toSave[2].key)
+		require.True(t, found)
+		require.NoError(t, err)
+		require.EqualValues(t, toSave[2].key, obj.Key)
+		require.EqualValues(t, toSave[2].value, obj.Value)
+
+		db.(reporter).report()
+
+		require.NoError(t, db.Delete(toSave[0].prefix, toSave[0].key))
+		obj, found, err = db.Get(toSave[0].prefix, toSave[0].key)
+		require.NoError(t, err)
+		require.False(t, found)
+
+		require.NoError(t, db.RemoveAllByCollection([]byte("prefix1")))
+		require.NoError(t, db.RemoveAllByCollection([]byte("prefix2")))
+	})
+}
+
+func TestDb_GetAll(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, newDb dbConstructor) {
+		options := basedb.Options{
+			Type:   "badger-memory",
+			Logger: zap.L(),
+			Path:   "",
+		}
+
+		t.Run("100_items", func(t *testing.T) {
+			db, err := newDb(t, options)
+			require.NoError(t, err)
+			defer db.Close()
+
+			getAllTest(t, 100, db)
+		})
+
+		t.Run("10K_items", func(t *testing.T) {
+			db, err := newDb(t, options)
+			require.NoError(t, err)
+			defer db.Close()
+
+			getAllTest(t, 10000, db)
+		})
+
+		t.Run("100K_items", func(t *testing.T) {
+			db, err := newDb(t, options)
+			require.NoError(t, err)
+			defer db.Close()
+
+			getAllTest(t, 100000, db)
+		})
+	})
+}
+
+func TestDb_GetMany(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, newDb dbConstructor) {
+		options := basedb.Options{
+			Type:   "badger-memory",
+			Logger: zap.L(),
+			Path:   "",
+		}
+		db, err := newDb(t, options)
+		require.NoError(t, err)
+		defer db.Close()
+
+		prefix := []byte("prefix")
+		var i uint64
+		for i = 0; i < 100; i++ {
+			require.NoError(t, db.Set(prefix, uInt64ToByteSlice(i+1), uInt64ToByteSlice(i+1)))
+		}
+
+		results := make([]basedb.Obj, 0)
+		err = db.GetMany(prefix, [][]byte{uInt64ToByteSlice(1), uInt64ToByteSlice(2),
+			uInt64ToByteSlice(5), uInt64ToByteSlice(10)}, func(obj basedb.Obj) error {
+			require.True(t, bytes.Equal(obj.Key, obj.Value))
+			results = append(results, obj)
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, 4, len(results))
+	})
+}
+
+func TestDb_SetMany(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, newDb dbConstructor) {
+		options := basedb.Options{
+			Type:   "badger-memory",
+			Logger: zaptest.NewLogger(t),
+			Path:   "",
+		}
+		db, err := newDb(t, options)
+		require.NoError(t, err)
+		defer db.Close()
+
+		prefix := []byte("prefix")
+		var values [][]byte
+		err = db.SetMany(prefix, 10, func(i int) (basedb.Obj, error) {
+			seq := uint64(i + 1)
+			values = append(values, uInt64ToByteSlice(seq))
+			return basedb.Obj{Key: uInt64ToByteSlice(seq), Value: uInt64ToByteSlice(seq)}, nil
+		})
+		require.NoError(t, err)
+
+		for i := 0; i < 10; i++ {
+			seq := uint64(i + 1)
+			obj, found, err := db.Get(prefix, uInt64ToByteSlice(seq))
+			require.NoError(t, err, "should find item %d", i)
+			require.True(t, found, "should find item %d", i)
+			require.True(t, bytes.Equal(obj.Value, values[i]), "item %d wrong value", i)
+		}
+	})
+}
+
+func TestDb_Iterate(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, newDb dbConstructor) {
+		options := basedb.Options{
+			Type:   "badger-memory",
+			Logger: zap.L(),
+			Path:   "",
+		}
+		db, err := newDb(t, options)
+		require.NoError(t, err)
+		defer db.Close()
+
+		prefix := []byte("prefix")
+		for i := uint64(0); i < 100; i++ {
+			require.NoError(t, db.Set(prefix, bigEndianKey(i), bigEndianKey(i)))
+		}
+		// neighbouring prefixes that must not be iterated
+		require.NoError(t, db.Set([]byte("prefi"), []byte("w"), []byte("value")))
+		require.NoError(t, db.Set([]byte("prefiy"), []byte("key"), []byte("value")))
+		require.NoError(t, db.Set([]byte("prefix"), []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, []byte("value")))
+
+		iterate := func(opts basedb.RangeOptions) []basedb.Obj {
+			var res []basedb.Obj
+			require.NoError(t, db.Iterate(prefix, opts, func(obj basedb.Obj) error {
+				res = append(res, obj)
+				return nil
+			}))
+			return res
+		}
+
+		tests := []struct {
+			name     string
+			opts     basedb.RangeOptions
+			expected []uint64
+		}{
+			{"range", basedb.RangeOptions{From: bigEndianKey(10), To: bigEndianKey(15)}, []uint64{10, 11, 12, 13, 14}},
+			{"reverse range", basedb.RangeOptions{From: bigEndianKey(10), To: bigEndianKey(15), Reverse: true}, []uint64{14, 13, 12, 11, 10}},
+			{"limit", basedb.RangeOptions{From: bigEndianKey(50), Limit: 3}, []uint64{50, 51, 52}},
+			{"reverse limit", basedb.RangeOptions{To: bigEndianKey(50), Reverse: true, Limit: 3}, []uint64{49, 48, 47}},
+			{"from start", basedb.RangeOptions{To: bigEndianKey(3)}, []uint64{0, 1, 2}},
+			{"empty range", basedb.RangeOptions{From: bigEndianKey(15), To: bigEndianKey(10)}, nil},
+		}
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				res := iterate(test.opts)
+				require.Len(t, res, len(test.expected))
+				for i, obj := range res {
+					require.Equal(t, bigEndianKey(test.expected[i]), obj.Key)
+					require.Equal(t, bigEndianKey(test.expected[i]), obj.Value)
+				}
+			})
+		}
+
+		t.Run("all items", func(t *testing.T) {
+			res := iterate(basedb.RangeOptions{})
+			require.Len(t, res, 101)
+			require.Equal(t, bigEndianKey(0), res[0].Key)
+			res = iterate(basedb.RangeOptions{Reverse: true})
+			require.Len(t, res, 101)
+			require.Equal(t, []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, res[0].Key)
+			require.Equal(t, bigEndianKey(0), res[100].Key)
+		})
+
+		t.Run("keys only", func(t *testing.T) {
+			res := iterate(basedb.RangeOptions{From: bigEndianKey(10), Limit: 2, KeysOnly: true})
+			require.Len(t, res, 2)
+			require.Equal(t, bigEndianKey(11), res[1].Key)
+			require.Nil(t, res[1].Value)
+		})
+
+		t.Run("handler error", func(t *testing.T) {
+			err := db.Iterate(prefix, basedb.RangeOptions{}, func(obj basedb.Obj) error {
+				return errors.New("test")
+			})
+			require.EqualError(t, err, "test")
+		})
+
+		t.Run("txn", func(t *testing.T) {
+			require.NoError(t, db.Update(func(txn basedb.Txn) error {
+				count := 0
+				err := txn.Iterate(prefix, basedb.RangeOptions{From: bigEndianKey(98), To: bigEndianKey(100)}, func(obj basedb.Obj) error {
+					count++
+					return txn.Delete(prefix, obj.Key)
+				})
+				require.Equal(t, 2, count)
+				return err
+			}))
+			res := iterate(basedb.RangeOptions{From: bigEndianKey(97), To: bigEndianKey(100)})
+			require.Len(t, res, 1)
+		})
+	})
+}
+
+func TestDb_Update(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, newDb dbConstructor) {
+		options := basedb.Options{
+			Type:   "badger-memory",
+			Logger: zap.L(),
+			Path:   "",
+		}
+		db, err := newDb(t, options)
+		require.NoError(t, err)
+		defer db.Close()
+
+		prefix := []byte("prefix")
+		require.NoError(t, db.Set(prefix, []byte("key1"), []byte("value1")))
+
+		t.Run("commit", func(t *testing.T) {
+			require.NoError(t, db.Update(func(txn basedb.Txn) error {
+				if err := txn.Set(prefix, []byte("key2"), []byte("value2")); err != nil {
+					return err
+				}
+				// reads within the transaction see its writes
+				obj, found, err := txn.Get(prefix, []byte("key2"))
+				require.NoError(t, err)
+				require.True(t, found)
+				require.Equal(t, []byte("value2"), obj.Value)
+				return txn.Delete(prefix, []byte("key1"))
+			}))
+			_, found, err := db.Get(prefix, []byte("key1"))
+			require.NoError(t, err)
+			require.False(t, found)
+			_, found, err = db.Get(prefix, []byte("key2"))
+			require.NoError(t, err)
+			require.True(t, found)
+		})
+
+		t.Run("rollback", func(t *testing.T) {
+			err := db.Update(func(txn basedb.Txn) error {
+				if err := txn.Set(prefix, []byte("key3"), []byte("value3")); err != nil {
+					return err
+				}
+				if err := txn.Delete(prefix, []byte("key2")); err != nil {
+					return err
+				}
+				return errors.New("test")
+			})
+			require.EqualError(t, err, "test")
+			_, found, err := db.Get(prefix, []byte("key3"))
+			require.NoError(t, err)
+			require.False(t, found)
+			_, found, err = db.Get(prefix, []byte("key2"))
+			require.NoError(t, err)
+			require.True(t, found)
+		})
+	})
+}
+
+func TestDb_Compact(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, newDb dbConstructor) {
+		db, err := newDb(t, basedb.Options{
+			Type:   "badger-db",
+			Logger: zaptest.NewLogger(t),
+			Path:   t.TempDir(),
+		})
+		require.NoError(t, err)
+		defer db.Close()
+
+		prefix := []byte("compact")
+		n := 1000
+		require.NoError(t, db.SetMany(prefix, n, func(i int) (basedb.Obj, error) {
+			return basedb.Obj{Key: bigEndianKey(uint64(i)), Value: bytes.Repeat([]byte{1}, 1024)}, nil
+		}))
+		require.NoError(t, db.Update(func(txn basedb.Txn) error {
+			for i := 0; i < n; i += 2 {
+				if err := txn.Delete(prefix, bigEndianKey(uint64(i))); err != nil {
+					return err
+				}
+			}
+			return nil
+		}))
+
+		compactor, ok := db.(basedb.Compactor)
+		require.True(t, ok)
+		require.NoError(t, compactor.Compact())
+
+		count, err := db.CountByCollection(prefix)
+		require.NoError(t, err)
+		require.Equal(t, int64(n/2), count)
+		obj, found, err := db.Get(prefix, bigEndianKey(1))
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Len(t, obj.Value, 1024)
+		_, found, err = db.Get(prefix, bigEndianKey(2))
+		require.NoError(t, err)
+		require.False(t, found)
+	})
+}
+
+func TestDb_HealthCheck(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, newDb dbConstructor) {
+		tests := []struct {
+			name      string
+			threshold float64
+			healthy   bool
+		}{
+			{"disabled", 0, true},
+			{"below threshold", 1.01, true},
+			{"above threshold", 0.000001, false},
+		}
+		for _, test := range tests {
+			test := test
+			t.Run(test.name, func(t *testing.T) {
+				db, err := newDb(t, basedb.Options{
+					Type:               "badger-db",
+					Logger:             zaptest.NewLogger(t),
+					Path:               t.TempDir(),
+					DiskUsageThreshold: test.threshold,
+				})
+				require.NoError(t, err)
+				defer db.Close()
+
+				agent, ok := db.(metrics.HealthCheckAgent)
+				require.True(t, ok)
+				if test.healthy {
+					require.Empty(t, agent.HealthCheck())
+				} else {
+					require.Len(t, agent.HealthCheck(), 1)
+				}
+			})
+		}
+	})
+}
+
+func bigEndianKey(n uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, n)
+	return b
+}
+
+func uInt64ToByteSlice(n uint64) []byte {
+	b := make([]byte, 8)
+	binary.LittleEndian.PutUint64(b, n)
+	return b
+}
+
+func getAllTest(t *testing.T, n int, db basedb.IDb) {
+	// populating DB
+	prefix := []byte("test")
+	for i := 0; i < n; i++ {
+		id := fmt.Sprintf("test-%d", i)
+		db.Set(prefix, []byte(id), []byte(id+"-data"))
+	}
+	time.Sleep(1 * time.Millisecond)
+
+	var all []basedb.Obj
+	err := db.GetAll(prefix, func(i int, obj basedb.Obj) error {
+		all = append(all, obj)
+		return nil
+	})
+	require.Equal(t, n, len(all))
+	require.NoError(t, err)
+	visited := map[string][]byte{}
+	for _, item := range all {
+		visited[string(item.Key[:])] = item.Value[:]
+	}
+	require.Equal(t, n, len(visited))
+	require.NoError(t, db.RemoveAllByCollection(prefix))
+}
@@ -0,0 +1,39 @@
+package kv
+
+import (
+	"fmt"
+	"syscall"
+
+	"github.com/pkg/errors"
+)
+
+// diskUsage returns the ratio of used space on the file system of the given path
+func diskUsage(path string) (float64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, errors.Wrap(err, "could not stat file system")
+	}
+	total := uint64(stat.Blocks) * uint64(stat.Bsize)
+	if total == 0 {
+		return 0, nil
+	}
+	free := uint64(stat.Bavail) * uint64(stat.Bsize)
+	return float64(total-free) / float64(total), nil
+}
+
+// checkDiskUsage returns health check errors in case the disk of the given path is nearly full,
+// a zero threshold disables the check
+func checkDiskUsage(path string, threshold float64) []string {
+	if threshold <= 0 {
+		return nil
+	}
+	usage, err := diskUsage(path)
+	if err != nil {
+		return []string{err.Error()}
+	}
+	metricsDBDiskUsage.Set(usage)
+	if usage >= threshold {
+		return []string{fmt.Sprintf("db disk is nearly full: %.2f%% used (threshold %.2f%%)", usage*100, threshold*100)}
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package kv
+
+import (
+	"log"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	metricsDBSize = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ssv:storage:size_bytes",
+		Help: "Size of the db on disk by component (lsm, vlog, file)",
+	}, []string{"component"})
+	metricsDBCacheHitRatio = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ssv:storage:cache_hit_ratio",
+		Help: "Hit ratio of the db caches (block, index)",
+	}, []string{"cache"})
+	metricsDBValueLogGC = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "ssv:storage:vlog_gc:count",
+		Help: "Count value log files that were rewritten by garbage collection",
+	})
+	metricsDBDiskUsage = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "ssv:storage:disk_usage_ratio",
+		Help: "Ratio of used space on the disk of the db",
+	})
+)
+
+func init() {
+	if err := prometheus.Register(metricsDBSize); err != nil {
+		log.Println("could not register prometheus collector")
+	}
+	if err := prometheus.Register(metricsDBCacheHitRatio); err != nil {
+		log.Println("could not register prometheus collector")
+	}
+	if err := prometheus.Register(metricsDBValueLogGC); err != nil {
+		log.Println("could not register prometheus collector")
+	}
+	if err := prometheus.Register(metricsDBDiskUsage); err != nil {
+		log.Println("could not register prometheus collector")
+	}
+}
@@ -33,3 +33,17 @@ func AddPersistentIntFlag(c *cobra.Command, flag string, value uint64, descripti
 		_ = c.MarkPersistentFlagRequired(flag)
 	}
 }
+
+// AddPersistentBoolFlag adds a bool flag to the command
+func AddPersistentBoolFlag(c *cobra.Command, flag string, value bool, description string, isRequired bool) {
+	req := ""
+	if isRequired {
+		req = " (required)"
+	}
+
+	c.PersistentFlags().Bool(flag, value, fmt.Sprintf("%s%s", description, req))
+
+	if isRequired {
+		_ = c.MarkPersistentFlagRequired(flag)
+	}
+}
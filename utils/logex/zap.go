@@ -13,6 +13,9 @@ import (
 var once sync.Once
 var logger *zap.Logger
 
+// atomicLevel is the level of the global logger, it can be changed at runtime
+var atomicLevel = zap.NewAtomicLevel()
+
 // GetLogger returns an instance with some context, expressed as fields
 func GetLogger(fields ...zap.Field) *zap.Logger {
 	return logger.With(fields...)
@@ -54,7 +57,7 @@ func Build(appName string, level zapcore.Level, ec *EncodingConfig) *zap.Logger
 	ec = defaultEncodingConfig(ec)
 	cfg := zap.Config{
 		Encoding:    ec.Format,
-		Level:       zap.NewAtomicLevelAt(level),
+		Level:       atomicLevel,
 		OutputPaths: []string{"stdout"},
 		EncoderConfig: zapcore.EncoderConfig{
 			MessageKey:  "message",
@@ -71,6 +74,7 @@ func Build(appName string, level zapcore.Level, ec *EncodingConfig) *zap.Logger
 	}
 
 	once.Do(func() {
+		atomicLevel.SetLevel(level)
 		var err error
 		logger, err = cfg.Build()
 		if err != nil {
@@ -83,6 +87,16 @@ func Build(appName string, level zapcore.Level, ec *EncodingConfig) *zap.Logger
 	return logger
 }
 
+// GetLevel returns the current level of the global logger
+func GetLevel() zapcore.Level {
+	return atomicLevel.Level()
+}
+
+// SetLevel changes the level of the global logger at runtime
+func SetLevel(level zapcore.Level) {
+	atomicLevel.SetLevel(level)
+}
+
 // GetLoggerLevelValue resolves logger level to zap level
 func GetLoggerLevelValue(loggerLevel string) (zapcore.Level, error) {
 	switch loggerLevel {
@@ -51,6 +51,15 @@ func DecodeKey(sk *rsa.PrivateKey, hashBase64 string) (string, error) {
 	return string(decryptedKey), nil
 }
 
+// EncodeKey encrypts the given key with the public key, returns the encrypted key as base64 (the reverse of DecodeKey)
+func EncodeKey(pk *rsa.PublicKey, key string) (string, error) {
+	encryptedKey, err := rsa.EncryptPKCS1v15(rand.Reader, pk, []byte(key))
+	if err != nil {
+		return "", errors.Wrap(err, "Failed to encrypt key")
+	}
+	return base64.StdEncoding.EncodeToString(encryptedKey), nil
+}
+
 // ConvertPemToPrivateKey return rsa private key from secret key
 func ConvertPemToPrivateKey(skPem string) (*rsa.PrivateKey, error) {
 	block, _ := pem.Decode([]byte(skPem))